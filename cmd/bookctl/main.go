@@ -0,0 +1,20 @@
+// Command bookctl administers the catalogue through the REST API.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go-boilerplate-rest-api-chi/internal/cli"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	code := cli.NewApp(os.Stdin, os.Stdout, os.Stderr, os.Getenv).Run(ctx, os.Args[1:])
+
+	stop()
+	os.Exit(code)
+}
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+
 	_ "go-boilerplate-rest-api-chi/docs"
 	"go-boilerplate-rest-api-chi/internal/api"
 	"go-boilerplate-rest-api-chi/internal/config"
@@ -44,7 +47,13 @@ func main() {
 		log.Fatal("failed to init connection with database", err)
 	}
 
-	handler := api.CreateApi(config, logger, database.Gorm)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	handler, grpcServer, err := api.CreateApi(ctx, config, logger, database.Gorm)
+	if err != nil {
+		log.Fatal("failed to create api", err)
+	}
 
 	addr := fmt.Sprintf("%s:%d", config.Api.Host, config.Api.Port)
 	srv := &http.Server{
@@ -53,6 +62,13 @@ func main() {
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
+	if grpcServer != nil && config.Grpc.SharePort {
+		// gRPC clients speak HTTP/2 without TLS (h2c)
+		srv.Protocols = new(http.Protocols)
+		srv.Protocols.SetHTTP1(true)
+		srv.Protocols.SetUnencryptedHTTP2(true)
+	}
+
 	go func() {
 		logger.Info().Msgf("Server listening on http://%s", addr)
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -60,8 +76,21 @@ func main() {
 		}
 	}()
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer stop()
+	if grpcServer != nil && !config.Grpc.SharePort {
+		grpcAddr := fmt.Sprintf("%s:%d", config.Api.Host, config.Grpc.Port)
+
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatal("failed to listen for grpc", err)
+		}
+
+		go func() {
+			logger.Info().Msgf("gRPC server listening on %s", grpcAddr)
+			if err := grpcServer.Serve(listener); err != nil {
+				logger.Error().Err(err).Msg("gRPC listen error")
+			}
+		}()
+	}
 
 	<-ctx.Done()
 	logger.Info().Msg("Shutting down server...")
@@ -73,9 +102,30 @@ func main() {
 		logger.Error().Err(err).Msg("Forced shutdown")
 	}
 
+	if grpcServer != nil {
+		stopGrpc(ctxShutdown, grpcServer)
+	}
+
 	if err := database.Close(); err != nil {
 		logger.Error().Err(err).Msg("Failed to close database")
 	}
 
 	logger.Info().Msg("Server and database shutdown cleanly")
 }
+
+// stopGrpc waits for the running RPCs to end, they are cancelled once ctx is
+// done.
+func stopGrpc(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
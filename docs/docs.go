@@ -16,8 +16,58 @@ const docTemplate = `{
     "basePath": "{{.BasePath}}",
     "paths": {
         "/authors": {
+            "get": {
+                "description": "Get a page of the authors ordered by name. The next_cursor of the response fetches the next page, it is missing on the last page. With a name, resolve the name or an alias to the matching authors instead, several authors may share a name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authors"
+                ],
+                "summary": "Get all authors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name or alias of the author",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of authors of the page (default 50, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_author.AuthorsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Create a new author with the provided data",
+                "description": "Create a new author with the provided data, names are not unique",
                 "consumes": [
                     "application/json"
                 ],
@@ -37,6 +87,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.CreateAuthorRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -58,6 +120,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -69,7 +149,7 @@ const docTemplate = `{
         },
         "/authors/{author_id}": {
             "get": {
-                "description": "Get a single author by its ID",
+                "description": "Get a single author by its ID, with its aliases ordered by name",
                 "produces": [
                     "application/json"
                 ],
@@ -112,23 +192,54 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/books": {
-            "get": {
-                "description": "Get a list of all books",
+            },
+            "put": {
+                "description": "Update the provided fields of an author, an empty value clears an optional field",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
+                ],
+                "summary": "Update an author",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Author ID",
+                        "name": "author_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Author data",
+                        "name": "author",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.UpdateAuthorRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get all books",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_book.BooksSuccessResponse"
+                            "$ref": "#/definitions/internal_author.AuthorSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -137,6 +248,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -144,9 +273,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/authors/{author_id}/aliases": {
             "post": {
-                "description": "Create a new book with the provided data",
+                "description": "Add a pen name or a variant spelling resolving to the author",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,25 +285,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Create a new book",
+                "summary": "Add an alias to an author",
                 "parameters": [
                     {
-                        "description": "Book data",
-                        "name": "book",
+                        "type": "string",
+                        "description": "Author ID",
+                        "name": "author_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alias data",
+                        "name": "alias",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.CreateBookRequest"
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.CreateAliasRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                            "$ref": "#/definitions/internal_author.AliasSuccessResponse"
                         }
                     },
                     "400": {
@@ -181,12 +331,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
                         }
                     },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -196,21 +364,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{book_id}": {
-            "get": {
-                "description": "Get a single book by its ID",
+        "/authors/{author_id}/aliases/{alias_id}": {
+            "delete": {
+                "description": "Remove an alias, it no longer resolves to the author",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "authors"
                 ],
-                "summary": "Get book by id",
+                "summary": "Remove an alias from an author",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
-                        "name": "book_id",
+                        "description": "Author ID",
+                        "name": "author_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias ID",
+                        "name": "alias_id",
                         "in": "path",
                         "required": true
                     }
@@ -219,7 +394,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
                         }
                     },
                     "400": {
@@ -241,42 +416,77 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a book with the provided data",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/books": {
+            "get": {
+                "description": "Get a page of the books, optionally filtered. The next_cursor of the response fetches the next page, it is missing on the last page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "books"
                 ],
-                "summary": "Update a book",
+                "summary": "Get all books",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Book ID",
-                        "name": "book_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only books of this author",
+                        "name": "author_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Book data",
-                        "name": "book",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.UpdateBookRequest"
-                        }
+                        "type": "string",
+                        "description": "Only books whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre or of its descendants",
+                        "name": "genre_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "title",
+                            "rating"
+                        ],
+                        "type": "string",
+                        "description": "Order of the books, the best rated first for rating",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of books of the page (default 50, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                            "$ref": "#/definitions/internal_book.BooksSuccessResponse"
                         }
                     },
                     "400": {
@@ -299,29 +509,52 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "Delete a book by its ID",
+            "post": {
+                "description": "Create a new book with the provided data",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "books"
                 ],
-                "summary": "Delete a book",
+                "summary": "Create a new book",
                 "parameters": [
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.CreateBookRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Book ID",
-                        "name": "book_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -330,127 +563,6048 @@ const docTemplate = `{
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/secure": {
-            "get": {
-                "security": [
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/export": {
+            "get": {
+                "description": "Stream every book matching the filters as a downloadable CSV, NDJSON or JSON file. Rows are read from the database in batches.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Export books",
+                "parameters": [
                     {
-                        "ApiKeyAuth": []
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre or of its descendants",
+                        "name": "genre_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookExportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/facets": {
+            "get": {
+                "description": "Count the books matching the filters in each genre, the books of the descendants included, the largest genres first. The genres without books are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get genre facets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only books of this author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books whose title contains this text",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books of this genre or of its descendants",
+                        "name": "genre_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only books with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_book.GenreFacetsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{book_id}": {
+            "get": {
+                "description": "Get a single book by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get book by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a book with the provided data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Update a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Book data",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.UpdateBookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_book.BookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a book by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "book_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/copies": {
+            "get": {
+                "description": "Get the copies matching the filters, ordered by branch and shelf location",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Get copies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the copies of this book",
+                        "name": "book_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the copy with this barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the copies of this branch",
+                        "name": "branch",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "available",
+                            "on_loan",
+                            "on_hold",
+                            "lost",
+                            "in_repair"
+                        ],
+                        "type": "string",
+                        "description": "Only the copies with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.CopiesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a physical copy of a book, available for loan. The barcode must not be used by another copy. Restricted to the librarians.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Create a new copy",
+                "parameters": [
+                    {
+                        "description": "Copy data",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_inventory_dto.CreateCopyRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.CopySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/copies/{copy_id}": {
+            "get": {
+                "description": "Get a single copy by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Get copy by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Copy ID",
+                        "name": "copy_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.CopySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update the condition, location or status of a copy. The status of a copy on loan or on hold cannot be changed. Restricted to the librarians.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Update a copy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Copy ID",
+                        "name": "copy_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Copy data",
+                        "name": "copy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_inventory_dto.UpdateCopyRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_inventory.CopySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a copy registered by mistake. Copies which were ever lent are kept with the loans, mark them as lost instead. Restricted to the librarians.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "copies"
+                ],
+                "summary": "Delete a copy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Copy ID",
+                        "name": "copy_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/stream": {
+            "get": {
+                "description": "Push the book and author change events as Server-Sent Events. A client reconnecting with the ID of the last event it received gets the events it missed while they are buffered, or a stream.reset event when they are not. A comment is sent as heartbeat.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream the change events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated event types to receive, every type when empty",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, for clients unable to set the Last-Event-ID header",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fines": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the fines ledger of a member, the charges, payments and waivers oldest first, with the balance owed. Amounts are decimal strings. A member only gets their own ledger, whose ID is the subject of the access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Get the fines of a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "member_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_fine.FineAccountSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fines/payments": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Record a payment of fines by a member, up to the balance owed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Record a payment",
+                "parameters": [
+                    {
+                        "description": "Payment data",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_fine_dto.PaymentRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_fine.FineEntrySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/fines/waivers": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Forgive a member part of the balance owed, optionally for the fine of one of their loans. The note gives the reason of the waiver.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "fines"
+                ],
+                "summary": "Waive fines",
+                "parameters": [
+                    {
+                        "description": "Waiver data",
+                        "name": "waiver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_fine_dto.WaiverRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_fine.FineEntrySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Get every genre ordered by name, the tree is rebuilt from their parent",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get all genres",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_genre.GenresSuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a genre or subject under its parent, or at the root of the tree without one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Create a genre",
+                "parameters": [
+                    {
+                        "description": "Genre data",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.CreateGenreRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_genre.GenreSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/genres/{genre_id}": {
+            "get": {
+                "description": "Get a single genre by its ID, with its ancestors from the root and its children",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Get genre by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Genre ID",
+                        "name": "genre_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_genre.GenreSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a genre and move it with its descendants under its parent, or to the root of the tree without one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Update a genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Genre ID",
+                        "name": "genre_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre data",
+                        "name": "genre",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.UpdateGenreRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_genre.GenreSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a genre without children, it is removed from the books assigned to it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "genres"
+                ],
+                "summary": "Delete a genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Genre ID",
+                        "name": "genre_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Run a GraphQL query or mutation over the books and authors. The response is always 200 once the request is decoded, the errors are listed with a code in their extensions. Queries deeper or more complex than the configured limits are rejected before being executed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "graphql"
+                ],
+                "summary": "Execute a GraphQL request",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_gql_dto.Request"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_gql_dto.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the holds matching the filters in the order they were placed, with the queue position of the waiting ones. A member only gets their own holds, the librarians get the holds of every member.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Get holds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the holds of this member, the authenticated member by default",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the holds of this book",
+                        "name": "book_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "waiting",
+                            "ready",
+                            "fulfilled",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Only the holds with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_hold.HoldsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reserve a book for a member. The hold waits in the queue of the book until a copy is set aside for the member, at once when one is available. A member only places holds for themselves, whose ID is the subject of the access token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Place a hold",
+                "parameters": [
+                    {
+                        "description": "Hold data",
+                        "name": "hold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_hold_dto.PlaceHoldRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_hold.HoldSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single hold by its ID, with its queue position while waiting. A member only gets their own holds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Get hold by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_hold.HoldSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/holds/{hold_id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel a waiting or ready hold, the copy set aside for it goes to the next hold of the queue. A member only cancels their own holds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "holds"
+                ],
+                "summary": "Cancel a hold",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hold ID",
+                        "name": "hold_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_hold.HoldSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "post": {
+                "description": "Import books from a CSV file with a header line (title, description, author) or from NDJSON objects with the same fields. Authors are matched by name and created when missing. The body is streamed row by row.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Bulk import books",
+                "parameters": [
+                    {
+                        "enum": [
+                            "best_effort",
+                            "all_or_nothing"
+                        ],
+                        "type": "string",
+                        "description": "best_effort (default) commits valid rows, all_or_nothing commits only when every row is valid",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_importer.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/internal_importer.ImportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/live": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Upgrade to a WebSocket exchanging JSON messages. Send {\"type\":\"subscribe\",\"topics\":[\"book:\u003cid\u003e\",\"author:\u003cid\u003e\"]} to receive the changes of these books and authors as \"event\" messages, and the users subscribed to the same topics as \"presence\" messages. A connection too slow to read its messages is closed with status 1013.",
+                "tags": [
+                    "live"
+                ],
+                "summary": "Live collaboration",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token, for clients unable to set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the loans matching the filters, the latest first. Filter on a member to get their active and past loans. A member only gets their own loans, whose ID is the subject of the access token, the librarians get the loans of every member.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Get loans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the loans of this member, the authenticated member by default",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the loans of this book",
+                        "name": "book_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "returned"
+                        ],
+                        "type": "string",
+                        "description": "Only the loans with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_loan.LoansSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lend a copy of a book to a member for a loan period: the copy set aside for a hold of the member, or else either the copy with the barcode or the first available one left by the waiting holds. Members owing fines above the checkout limit cannot borrow. Restricted to the librarians.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Check out a book",
+                "parameters": [
+                    {
+                        "description": "Loan data",
+                        "name": "loan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_loan_dto.CheckoutRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_loan.LoanSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{loan_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single loan by its ID, a member only gets their own loans",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Get loan by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_loan.LoanSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{loan_id}/renew": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Extend the due date of an active loan by a loan period, up to the renewal limit. Overdue loans cannot be renewed. A member only renews their own loans.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Renew a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_loan.LoanSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{loan_id}/return": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Return the book of an active loan, its copy goes to the next hold or back on the shelf. A late return is charged its fine. Restricted to the librarians.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Return a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "loan_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_loan.LoanSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Register a member allowed to borrow books, the email must not be used by another member. Restricted to the librarians.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Create a new member",
+                "parameters": [
+                    {
+                        "description": "Member data",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_member_dto.CreateMemberRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_member.MemberSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/members/{member_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single member by its ID. A member only gets their own account, whose ID is the subject of the access token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Get member by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "member_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_member.MemberSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers": {
+            "post": {
+                "description": "Create a new publisher with the provided data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Create a new publisher",
+                "parameters": [
+                    {
+                        "description": "Publisher data",
+                        "name": "publisher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.CreatePublisherRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_publisher.PublisherSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{publisher_id}": {
+            "get": {
+                "description": "Get a single publisher by its ID, with its imprints ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get publisher by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "publisher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_publisher.PublisherSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{publisher_id}/books": {
+            "get": {
+                "description": "Get the books released under any imprint of the publisher, ordered by title",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get the books of a publisher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "publisher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_publisher.PublisherBooksSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{publisher_id}/imprints": {
+            "post": {
+                "description": "Add an imprint to the publisher, books are released under an imprint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Create an imprint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "publisher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Imprint data",
+                        "name": "imprint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.CreateImprintRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_publisher.ImprintSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews": {
+            "get": {
+                "description": "Get the reviews matching the filters, the most recent first. The status filter is only honoured for the librarians and for the members listing their own reviews, the other callers get the approved reviews.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the reviews of this book",
+                        "name": "book_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the reviews of this member",
+                        "name": "member_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Only the reviews with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewsSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Review a book as the authenticated member, whose ID is the subject of the access token, with a rating of 1 to 5 stars and an optional text. A member reviews a book at most once, the review waits for moderation before it counts in the rating of the book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Submit a review",
+                "parameters": [
+                    {
+                        "description": "Review data",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_review_dto.SubmitReviewRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{review_id}": {
+            "get": {
+                "description": "Get a single review by its ID. A review which is not approved is only found by the librarians and by its author.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Get review by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the rating and the text of a review of the authenticated member, it waits for moderation again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Update a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review data",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_review_dto.UpdateReviewRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a review of the authenticated member by its ID, the rating of the book no longer counts it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{review_id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Publish a pending or rejected review, its rating counts in the rating of the book",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Approve a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reviews/{review_id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Withdraw a pending or approved review with the reason given to the member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Reject a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection data",
+                        "name": "rejection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_review_dto.RejectReviewRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_review.ReviewSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text search across book titles, descriptions and author names, ranked by relevance. Query terms match by prefix and tolerate typos.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Search books and authors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_search.SearchSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/secure": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Authenticated test route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Authenticated test route",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "get": {
+                "description": "Get every series ordered by name, without their books",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get all series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_series.SeriesListSuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a series, books join it with their volume number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Create a series",
+                "parameters": [
+                    {
+                        "description": "Series data",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.CreateSeriesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_series.SeriesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{series_id}": {
+            "get": {
+                "description": "Get a single series by its ID, with its books ordered by volume",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get series by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_series.SeriesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Update a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Series data",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.UpdateSeriesRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_series.SeriesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a series, its books are kept outside of any series",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Delete a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Get every tag ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Get all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_tag.TagsSuccessResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a free-form tag, its name is stored in lowercase. Tags are also created on the fly when assigned to a book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Create a tag",
+                "parameters": [
+                    {
+                        "description": "Tag data",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_tag_dto.CreateTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unique key making retries of this request safe, the first response is replayed",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_tag.TagSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{tag_id}": {
+            "delete": {
+                "description": "Delete a tag, it is removed from every book",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Delete a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a list of all webhooks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get all webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.WebhooksSuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to events. The deliveries are signed with the secret, generated when not provided and only returned here. URLs targeting a loopback, private or link-local address are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.CreateWebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.WebhookCreatedSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single webhook by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook by id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.WebhookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update a webhook with the provided data, a disabled webhook keeps its pending deliveries until enabled again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.UpdateWebhookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.WebhookSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a webhook and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the latest deliveries of a webhook, optionally filtered by status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get the deliveries of a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Only deliveries with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language of the validation messages (en, fr)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.DeliveriesSuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/deliveries/{delivery_id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Schedule a dead delivery again, with a fresh number of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry a dead delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "delivery_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.DeliverySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{webhook_id}/test": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deliver a webhook.test event to the webhook right away, even when disabled, and return the outcome of the attempt",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send a test event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_webhook.DeliverySuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "go-boilerplate-rest-api-chi_internal_author_dto.AliasResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "pen_name"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AliasResponse"
+                    }
+                },
+                "biography": {
+                    "type": "string"
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1802-02-26"
+                },
+                "death_date": {
+                    "type": "string",
+                    "example": "1885-05-22"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isni": {
+                    "type": "string",
+                    "example": "0000000121200982"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string",
+                    "example": "FR"
+                },
+                "viaf_id": {
+                    "type": "string",
+                    "example": "9847974"
+                },
+                "wikidata_id": {
+                    "type": "string",
+                    "example": "Q535"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_author_dto.CreateAliasRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "pen_name",
+                        "variant"
+                    ],
+                    "example": "pen_name"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Richard Bachman"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_author_dto.CreateAuthorRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "biography": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1802-02-26"
+                },
+                "death_date": {
+                    "type": "string",
+                    "example": "1885-05-22"
+                },
+                "isni": {
+                    "description": "ISNI accepts the 16 characters of the identifier grouped by spaces or\nhyphens.",
+                    "type": "string",
+                    "example": "0000 0001 2120 0982"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nationality": {
+                    "type": "string",
+                    "example": "FR"
+                },
+                "viaf_id": {
+                    "type": "string",
+                    "example": "9847974"
+                },
+                "wikidata_id": {
+                    "type": "string",
+                    "example": "Q535"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_author_dto.UpdateAuthorRequest": {
+            "type": "object",
+            "properties": {
+                "biography": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "birth_date": {
+                    "type": "string",
+                    "example": "1802-02-26"
+                },
+                "death_date": {
+                    "type": "string",
+                    "example": "1885-05-22"
+                },
+                "isni": {
+                    "type": "string",
+                    "example": "0000 0001 2120 0982"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "nationality": {
+                    "type": "string",
+                    "example": "FR"
+                },
+                "viaf_id": {
+                    "type": "string",
+                    "example": "9847974"
+                },
+                "wikidata_id": {
+                    "type": "string",
+                    "example": "Q535"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.BookExportResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.BookResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse"
+                },
+                "copies": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.CopyCountsResponse"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imprint": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.ImprintResponse"
+                },
+                "rating": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.RatingResponse"
+                },
+                "series": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookSeriesResponse"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.BookSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.SeriesBookResponse"
+                },
+                "previous": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.SeriesBookResponse"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 2.5
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.CopyCountsResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.CreateBookRequest": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "description",
+                "tags",
+                "title"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imprint_id": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "series_volume": {
+                    "description": "SeriesVolume places the book in the series, fractional volumes such as\n2.5 sit between two others.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2.5
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.GenreFacetResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.RatingResponse": {
+            "type": "object",
+            "properties": {
+                "average": {
+                    "type": "number",
+                    "example": 4.25
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_book_dto.UpdateBookRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "tags"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "genre_ids": {
+                    "description": "GenreIDs and Tags replace the genres and the tags of the book, an empty\nlist removes them all.",
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "imprint_id": {
+                    "description": "ImprintID moves the book to the imprint, an empty one removes it.",
+                    "type": "string"
+                },
+                "series_id": {
+                    "description": "SeriesID moves the book to the series, an empty one takes it out of\nits series. A new series needs a volume.",
+                    "type": "string"
+                },
+                "series_volume": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2.5
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_fine_dto.FineAccountResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string",
+                    "example": "7.25"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_fine_dto.FineEntryResponse"
+                    }
+                },
+                "member_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_fine_dto.FineEntryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "charge",
+                        "payment",
+                        "waiver"
+                    ],
+                    "example": "charge"
+                },
+                "loan_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_fine_dto.PaymentRequest": {
+            "type": "object",
+            "required": [
+                "member_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_fine_dto.WaiverRequest": {
+            "type": "object",
+            "required": [
+                "member_id",
+                "note"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "loan_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_genre_dto.CreateGenreRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse": {
+            "type": "object",
+            "properties": {
+                "ancestors": {
+                    "description": "Ancestors is the path from the root down to the parent of the genre.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse"
+                    }
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_genre_dto.UpdateGenreRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_gql_dto.Request": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "description": "Extensions are accepted for compatibility with the clients sending\nthem, none is supported.",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_gql_dto.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gqlerrors.FormattedError"
+                    }
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_hold_dto.HoldResponse": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "copy_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "placed_at": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position is the rank of a waiting hold in the queue of its book.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "waiting",
+                        "ready",
+                        "fulfilled",
+                        "cancelled",
+                        "expired"
+                    ],
+                    "example": "waiting"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_hold_dto.PlaceHoldRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "member_id"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_importer_dto.ImportReport": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "best_effort"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_importer_dto.ImportRowResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_importer_dto.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "author_created": {
+                    "type": "boolean"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorDetail"
+                    }
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "status": {
+                    "type": "string",
+                    "example": "created"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_inventory_dto.CopyResponse": {
+            "type": "object",
+            "properties": {
+                "acquired_on": {
+                    "type": "string"
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "branch": {
+                    "type": "string"
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor"
+                    ],
+                    "example": "good"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_type": {
+                    "type": "string",
+                    "example": "standard"
+                },
+                "shelf_location": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "on_loan",
+                        "on_hold",
+                        "lost",
+                        "in_repair"
+                    ],
+                    "example": "available"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_inventory_dto.CreateCopyRequest": {
+            "type": "object",
+            "required": [
+                "barcode",
+                "book_id",
+                "branch",
+                "condition",
+                "shelf_location"
+            ],
+            "properties": {
+                "acquired_on": {
+                    "type": "string"
+                },
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "branch": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor"
+                    ]
+                },
+                "item_type": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "standard"
+                },
+                "shelf_location": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_inventory_dto.UpdateCopyRequest": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "condition": {
+                    "type": "string",
+                    "enum": [
+                        "new",
+                        "good",
+                        "fair",
+                        "poor"
+                    ]
+                },
+                "item_type": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1
+                },
+                "shelf_location": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "available",
+                        "lost",
+                        "in_repair"
+                    ]
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_loan_dto.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "member_id"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_loan_dto.LoanResponse": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "book": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "borrowed_at": {
+                    "type": "string"
+                },
+                "copy_id": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "type": "boolean"
+                },
+                "renewals": {
+                    "type": "integer"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "returned"
+                    ],
+                    "example": "active"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_member_dto.CreateMemberRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 254
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_member_dto.MemberResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_publisher_dto.CreateImprintRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_publisher_dto.CreatePublisherRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_publisher_dto.ImprintResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "description": "Publisher is only returned with the imprint of a book.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.PublisherResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_publisher_dto.PublisherResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "imprints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.ImprintResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "An error occurred"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_response.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Operation completed successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_response.ValidationErrorDetail": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "type": "string",
+                    "example": "string"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Email is required"
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorDetail"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Validation failed"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "error"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_review_dto.RejectReviewRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_review_dto.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "reason": {
+                    "description": "Reason explains the rejection of the review.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "pending"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_review_dto.SubmitReviewRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "rating"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_review_dto.UpdateReviewRequest": {
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 4
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 5000
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_series_dto.CreateSeriesRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_series_dto.SeriesBookResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "volume": {
+                    "type": "number",
+                    "example": 2.5
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_series_dto.SeriesResponse": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "description": "Books is the reading order of the series, it is only returned for a\nsingle series.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.SeriesBookResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_series_dto.UpdateSeriesRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_tag_dto.CreateTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_tag_dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "award winner"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_webhook_dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_webhook_dto.DeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_attempt_at": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "dead"
+                    ],
+                    "example": "pending"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_webhook_dto.UpdateWebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_webhook_dto.WebhookCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-boilerplate-rest-api-chi_internal_webhook_dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gqlerrors.FormattedError": {
+            "type": "object",
+            "properties": {
+                "extensions": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "locations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/location.SourceLocation"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "internal_author.AliasSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AliasResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Alias created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_author.AuthorSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Author retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_author.AuthorsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Authors retrieved successfully"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page, it is missing on the last page.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_book.BookSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Book retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_book.BooksSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Books retrieved successfully"
+                },
+                "next_cursor": {
+                    "description": "NextCursor fetches the next page, it is missing on the last page.",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_book.GenreFacetsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.GenreFacetResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Genre facets retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_fine.FineAccountSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "account": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_fine_dto.FineAccountResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Fines retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_fine.FineEntrySuccessResponse": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_fine_dto.FineEntryResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Payment recorded successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_genre.GenreSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "genre": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Genre retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_genre.GenresSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_genre_dto.GenreResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Genres retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_hold.HoldSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "hold": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_hold_dto.HoldResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Hold retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_hold.HoldsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "holds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_hold_dto.HoldResponse"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Holds retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_importer.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Import completed"
+                },
+                "report": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_importer_dto.ImportReport"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_inventory.CopiesSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "copies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_inventory_dto.CopyResponse"
                     }
-                ],
-                "description": "Authenticated test route",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "books"
-                ],
-                "summary": "Authenticated test route",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.SuccessResponse"
-                        }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Copies retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_inventory.CopySuccessResponse": {
+            "type": "object",
+            "properties": {
+                "copy": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_inventory_dto.CopyResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Copy retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_loan.LoanSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "loan": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_loan_dto.LoanResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Loan retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_loan.LoansSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "loans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_loan_dto.LoanResponse"
                     }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Loans retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
-        }
-    },
-    "definitions": {
-        "go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse": {
+        },
+        "internal_member.MemberSuccessResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "member": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_member_dto.MemberResponse"
                 },
-                "name": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Member retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_author_dto.CreateAuthorRequest": {
+        "internal_publisher.ImprintSuccessResponse": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "name": {
-                    "type": "string"
+                "imprint": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.ImprintResponse"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Imprint created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_book_dto.BookResponse": {
+        "internal_publisher.PublisherBooksSuccessResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse"
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
+                    }
                 },
-                "id": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Books retrieved successfully"
                 },
-                "title": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_book_dto.CreateBookRequest": {
+        "internal_publisher.PublisherSuccessResponse": {
             "type": "object",
-            "required": [
-                "author_id",
-                "description",
-                "title"
-            ],
             "properties": {
-                "author_id": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Publisher retrieved successfully"
                 },
-                "description": {
+                "publisher": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_publisher_dto.PublisherResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_review.ReviewSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Review retrieved successfully"
+                },
+                "review": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_review_dto.ReviewResponse"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_review.ReviewsSuccessResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Reviews retrieved successfully"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_review_dto.ReviewResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                }
+            }
+        },
+        "internal_search.SearchResultResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string"
                 },
+                "score": {
+                    "type": "number"
+                },
                 "title": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "book"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_book_dto.UpdateBookRequest": {
+        "internal_search.SearchSuccessResponse": {
             "type": "object",
-            "required": [
-                "description"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "message": {
+                    "type": "string",
+                    "example": "Search completed successfully"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_search.SearchResultResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_response.ErrorResponse": {
+        "internal_series.SeriesListSuccessResponse": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "An error occurred"
+                    "example": "Series retrieved successfully"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.SeriesResponse"
+                    }
                 },
                 "status": {
                     "type": "string",
-                    "example": "error"
+                    "example": "success"
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_response.SuccessResponse": {
+        "internal_series.SeriesSuccessResponse": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Operation completed successfully"
+                    "example": "Series retrieved successfully"
+                },
+                "series": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_series_dto.SeriesResponse"
                 },
                 "status": {
                     "type": "string",
@@ -458,47 +6612,69 @@ const docTemplate = `{
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_response.ValidationErrorDetail": {
+        "internal_tag.TagSuccessResponse": {
             "type": "object",
             "properties": {
-                "field": {
+                "message": {
                     "type": "string",
-                    "example": "email"
+                    "example": "Tag created successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 },
+                "tag": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_tag_dto.TagResponse"
+                }
+            }
+        },
+        "internal_tag.TagsSuccessResponse": {
+            "type": "object",
+            "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Email is required"
+                    "example": "Tags retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_tag_dto.TagResponse"
+                    }
                 }
             }
         },
-        "go-boilerplate-rest-api-chi_internal_response.ValidationErrorResponse": {
+        "internal_webhook.DeliveriesSuccessResponse": {
             "type": "object",
             "properties": {
-                "errors": {
+                "deliveries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_response.ValidationErrorDetail"
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.DeliveryResponse"
                     }
                 },
                 "message": {
                     "type": "string",
-                    "example": "Validation failed"
+                    "example": "Deliveries retrieved successfully"
                 },
                 "status": {
                     "type": "string",
-                    "example": "error"
+                    "example": "success"
                 }
             }
         },
-        "internal_author.AuthorSuccessResponse": {
+        "internal_webhook.DeliverySuccessResponse": {
             "type": "object",
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_author_dto.AuthorResponse"
+                "delivery": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.DeliveryResponse"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Author retrieved successfully"
+                    "example": "Test event sent"
                 },
                 "status": {
                     "type": "string",
@@ -506,38 +6682,65 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_book.BookSuccessResponse": {
+        "internal_webhook.WebhookCreatedSuccessResponse": {
             "type": "object",
             "properties": {
-                "book": {
-                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
-                },
                 "message": {
                     "type": "string",
-                    "example": "Book retrieved successfully"
+                    "example": "Webhook created successfully"
                 },
                 "status": {
                     "type": "string",
                     "example": "success"
+                },
+                "webhook": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.WebhookCreatedResponse"
                 }
             }
         },
-        "internal_book.BooksSuccessResponse": {
+        "internal_webhook.WebhookSuccessResponse": {
             "type": "object",
             "properties": {
-                "books": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_book_dto.BookResponse"
-                    }
+                "message": {
+                    "type": "string",
+                    "example": "Webhook retrieved successfully"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
                 },
+                "webhook": {
+                    "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.WebhookResponse"
+                }
+            }
+        },
+        "internal_webhook.WebhooksSuccessResponse": {
+            "type": "object",
+            "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Books retrieved successfully"
+                    "example": "Webhooks retrieved successfully"
                 },
                 "status": {
                     "type": "string",
                     "example": "success"
+                },
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-boilerplate-rest-api-chi_internal_webhook_dto.WebhookResponse"
+                    }
+                }
+            }
+        },
+        "location.SourceLocation": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
                 }
             }
         }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -10,15 +11,49 @@ import (
 	"github.com/go-chi/httprate"
 	"github.com/rs/zerolog"
 	httpSwagger "github.com/swaggo/http-swagger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 	"gorm.io/gorm"
 
+	"go-boilerplate-rest-api-chi/internal/auth"
 	"go-boilerplate-rest-api-chi/internal/author"
 	"go-boilerplate-rest-api-chi/internal/book"
 	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/gql"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/idempotency"
+	"go-boilerplate-rest-api-chi/internal/importer"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/live"
+	"go-boilerplate-rest-api-chi/internal/loan"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	"go-boilerplate-rest-api-chi/internal/review"
+	"go-boilerplate-rest-api-chi/internal/rpc"
+	"go-boilerplate-rest-api-chi/internal/search"
+	"go-boilerplate-rest-api-chi/internal/series"
+	"go-boilerplate-rest-api-chi/internal/stream"
+	"go-boilerplate-rest-api-chi/internal/tag"
+	"go-boilerplate-rest-api-chi/internal/transaction"
 	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+	"go-boilerplate-rest-api-chi/internal/webhook"
 )
 
-func CreateApi(cfg config.Config, logger zerolog.Logger, db *gorm.DB) http.Handler {
+const (
+	// requestTimeout bounds the handling of every regular request.
+	requestTimeout = 10 * time.Second
+	// maxConcurrentRequests limits the number of regular requests handled at
+	// once, streams are limited by their own settings.
+	maxConcurrentRequests = 100
+)
+
+// CreateApi builds the API handler and the gRPC server sharing its services,
+// the gRPC server is nil when disabled. Its background workers, such as the
+// outbox relay, run until ctx is done.
+func CreateApi(ctx context.Context, cfg config.Config, logger zerolog.Logger, db *gorm.DB) (http.Handler, *grpc.Server, error) {
 	r := chi.NewRouter()
 
 	r.Use(
@@ -29,15 +64,14 @@ func CreateApi(cfg config.Config, logger zerolog.Logger, db *gorm.DB) http.Handl
 		middleware.CleanPath,
 		middleware.StripSlashes,
 		middleware.GetHead,
-		middleware.Timeout(10*time.Second),
-		middleware.Throttle(100), // limit the number of request globaly for all the api
 		httprate.LimitByRealIP(100, 1*time.Minute),
 	)
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
-		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "Last-Event-ID", idempotency.HeaderKey},
+		ExposedHeaders:   []string{idempotency.HeaderReplayed},
 		AllowCredentials: false,
 		MaxAge:           12 * int(time.Hour),
 	}))
@@ -46,27 +80,214 @@ func CreateApi(cfg config.Config, logger zerolog.Logger, db *gorm.DB) http.Handl
 
 	api.Use(middleware.Heartbeat("/api/alive"))
 
+	// the callers sending a valid bearer token are identified, the routes
+	// requiring an identity reject the others
+	var verifier *auth.Verifier
+	if cfg.Auth.JWTSecret != "" {
+		verifier = auth.NewVerifier(cfg.Auth.JWTSecret)
+		api.Use(auth.Identify(verifier))
+	}
+
 	validator := internalValidator.New()
 
+	if err := author.RegisterValidations(validator); err != nil {
+		return nil, nil, err
+	}
+
+	if err := book.RegisterValidations(validator); err != nil {
+		return nil, nil, err
+	}
+
+	if err := webhook.RegisterValidations(validator, cfg.Webhook); err != nil {
+		return nil, nil, err
+	}
+
+	if err := stream.RegisterValidations(validator); err != nil {
+		return nil, nil, err
+	}
+
 	// -------- Repos / Services / Handlers --------
 
+	searchIndex, err := search.NewIndex(ctx, cfg.Search, db, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idempotencyStore, err := idempotency.NewStore(cfg.Idempotency, db, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions := transaction.NewManager(db, logger)
+
+	if err := outbox.Migrate(db); err != nil {
+		logger.Error().Err(err).Msg("failed to migrate outbox")
+		return nil, nil, err
+	}
+
+	events := outbox.NewOutbox(db, logger)
+	bus := outbox.NewBus()
+
+	sink, err := outbox.NewSink(cfg.Outbox, bus)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	bookRepo := book.NewBookRepository(db, logger)
 	authorRepo := author.NewAuthorRepository(db, logger)
+	webhookRepo := webhook.NewWebhookRepository(db, logger)
+	memberRepo := member.NewMemberRepository(db, logger)
+	loanRepo := loan.NewLoanRepository(db, logger)
+	copyRepo := inventory.NewCopyRepository(db, logger)
+	holdRepo := hold.NewHoldRepository(db, logger)
+	fineRepo := fine.NewFineRepository(db, logger)
+	reviewRepo := review.NewReviewRepository(db, logger)
+	genreRepo := genre.NewGenreRepository(db, logger)
+	tagRepo := tag.NewTagRepository(db, logger)
+	seriesRepo := series.NewSeriesRepository(db, logger)
+	publisherRepo := publisher.NewPublisherRepository(db, logger)
 
-	bookService := book.NewBookService(bookRepo, authorRepo, logger)
-	authorService := author.NewAuthorService(authorRepo, logger)
+	dispatcher := webhook.NewDispatcher(webhookRepo, cfg.Webhook, logger)
+	if cfg.Webhook.DispatcherEnabled {
+		go dispatcher.Run(ctx)
+	}
+
+	bookService := book.NewBookService(bookRepo, authorRepo, genreRepo, tagRepo, seriesRepo, publisherRepo, transactions, events, searchIndex, logger)
+	authorService := author.NewAuthorService(authorRepo, bookRepo, transactions, events, searchIndex, logger)
+	searchService := search.NewSearchService(searchIndex, logger)
+	importService := importer.NewImportService(transactions, bookRepo, authorRepo, events, validator, searchIndex, logger)
+	webhookService := webhook.NewWebhookService(webhookRepo, dispatcher, logger)
+	memberService := member.NewMemberService(memberRepo, logger)
+	copyService := inventory.NewCopyService(copyRepo, bookRepo, transactions, logger)
+	holdQueue := hold.NewQueue(holdRepo, copyRepo, events, cfg.Hold)
+	holdService := hold.NewHoldService(holdRepo, bookRepo, memberRepo, copyRepo, holdQueue, transactions, events, cfg.Hold, logger)
+	fineLedger := fine.NewLedger(fineRepo, events, cfg.Fine)
+	fineService := fine.NewFineService(fineRepo, memberRepo, fineLedger, transactions, events, cfg.Fine, logger)
+	reviewService := review.NewReviewService(reviewRepo, bookRepo, memberRepo, transactions, events, logger)
+	genreService := genre.NewGenreService(genreRepo, transactions, logger)
+	tagService := tag.NewTagService(tagRepo, logger)
+	seriesService := series.NewSeriesService(seriesRepo, transactions, logger)
+	publisherService := publisher.NewPublisherService(publisherRepo, transactions, events, logger)
+	loanService := loan.NewLoanService(loanRepo, bookRepo, copyRepo, memberRepo, holdQueue, fineLedger, transactions, events, cfg.Loan, logger)
+
+	if cfg.Hold.SweeperEnabled {
+		go hold.NewSweeper(holdService, cfg.Hold, logger).Run(ctx)
+	}
+
+	if cfg.Fine.AccrualEnabled {
+		go fine.NewAccrualJob(fineService, cfg.Fine, logger).Run(ctx)
+	}
+
+	// the relay hands every event to the bus, the webhooks enqueue their
+	// deliveries from there and the broker pushes them to the streams
+	bus.Subscribe("webhooks", webhookService.HandleEvent)
+
+	broker := stream.NewBroker(cfg.Stream)
+	bus.Subscribe("stream", broker.Publish)
+
+	hub := live.NewHub(cfg.Live)
+	bus.Subscribe("live", hub.Publish)
+
+	var grpcServer *grpc.Server
+	var grpcHealth *health.Server
+	if cfg.Grpc.Enabled {
+		grpcServer, grpcHealth = rpc.NewServer(bookService, authorService, validator, logger)
+	}
+
+	// end the streams on shutdown, the server waits for them otherwise, and
+	// let the gRPC clients know that the services are going away
+	go func() {
+		<-ctx.Done()
+		broker.Close()
+		hub.Close()
+
+		if grpcHealth != nil {
+			grpcHealth.Shutdown()
+		}
+	}()
+
+	// the relay starts once the bus has all its subscribers, the events it
+	// publishes are not handed to the late ones
+	if cfg.Outbox.RelayEnabled {
+		go outbox.NewRelay(db, sink, cfg.Outbox, logger).Run(ctx)
+	}
 
 	bookHandler := book.NewBookHandler(bookService, validator, logger)
 	authorHandler := author.NewAuthorHandler(authorService, validator, logger)
+	searchHandler := search.NewSearchHandler(searchService, logger)
+	importHandler := importer.NewImportHandler(importService, logger)
+	webhookHandler := webhook.NewWebhookHandler(webhookService, validator, logger)
+	memberHandler := member.NewMemberHandler(memberService, validator, logger)
+	copyHandler := inventory.NewCopyHandler(copyService, validator, logger)
+	loanHandler := loan.NewLoanHandler(loanService, validator, logger)
+	holdHandler := hold.NewHoldHandler(holdService, validator, logger)
+	fineHandler := fine.NewFineHandler(fineService, validator, logger)
+	reviewHandler := review.NewReviewHandler(reviewService, validator, logger)
+	genreHandler := genre.NewGenreHandler(genreService, validator, logger)
+	tagHandler := tag.NewTagHandler(tagService, validator, logger)
+	seriesHandler := series.NewSeriesHandler(seriesService, validator, logger)
+	publisherHandler := publisher.NewPublisherHandler(publisherService, validator, logger)
+	streamHandler := stream.NewStreamHandler(broker, cfg.Stream.HeartbeatInterval, validator, logger)
 
-	api.Mount("/books", bookHandler.Routes())
-	api.Mount("/authors", authorHandler.Routes())
+	schema, err := gql.NewSchema(bookService, authorService, validator, cfg.GraphQL, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	graphqlHandler := gql.NewGraphQLHandler(schema, logger)
+
+	throttle := middleware.Throttle(maxConcurrentRequests)
+
+	// streams may legitimately outlive the request timeout, the event streams
+	// and the live connections are long-lived and would hold the throttle
+	// slots as well
+	api.With(throttle).Get("/books/export", bookHandler.ExportBooks)
+	// an import of thousands of rows has its own deadline
+	api.With(throttle, middleware.Timeout(cfg.Import.Timeout)).Mount("/import", importHandler.Routes())
+	api.Mount("/events", streamHandler.Routes())
+
+	if verifier != nil {
+		liveHandler := live.NewLiveHandler(hub, verifier, bookService, authorService, cfg.Live, logger)
+
+		api.Mount("/live", liveHandler.Routes())
+	} else {
+		logger.Warn().Msg("AUTH_JWT_SECRET is not set, live collaboration and the routes requiring an identity are disabled")
+	}
+
+	api.Group(func(r chi.Router) {
+		r.Use(throttle, middleware.Timeout(requestTimeout))
+
+		// creations may be retried safely with an Idempotency-Key header
+		idempotent := idempotency.Middleware(idempotencyStore, cfg.Idempotency.TTL, logger)
+
+		r.With(idempotent).Mount("/books", bookHandler.Routes())
+		r.With(idempotent).Mount("/authors", authorHandler.Routes())
+		r.With(idempotent).Mount("/copies", copyHandler.Routes())
+		r.With(idempotent).Mount("/members", memberHandler.Routes())
+		r.With(idempotent).Mount("/loans", loanHandler.Routes())
+		r.With(idempotent).Mount("/holds", holdHandler.Routes())
+		r.With(idempotent).Mount("/fines", fineHandler.Routes())
+		r.With(idempotent).Mount("/reviews", reviewHandler.Routes())
+		r.With(idempotent).Mount("/genres", genreHandler.Routes())
+		r.With(idempotent).Mount("/tags", tagHandler.Routes())
+		r.With(idempotent).Mount("/series", seriesHandler.Routes())
+		r.With(idempotent).Mount("/publishers", publisherHandler.Routes())
+		r.Mount("/search", searchHandler.Routes())
+		// the webhooks receive every event and are managed by the staff
+		r.With(auth.RequireRole(auth.RoleLibrarian)).Mount("/webhooks", webhookHandler.Routes())
+		r.Mount("/graphql", graphqlHandler.Routes())
+	})
 
 	if cfg.Api.Environement == "development" {
-		api.Get("/doc/*", httpSwagger.WrapHandler)
+		api.With(throttle).Get("/doc/*", httpSwagger.WrapHandler)
+		api.With(throttle).Get("/graphiql", gql.Playground("/api/graphql"))
 	}
 
 	r.Mount("/api", api)
 
-	return r
+	if grpcServer != nil && cfg.Grpc.SharePort {
+		return rpc.Handler(grpcServer, r), grpcServer, nil
+	}
+
+	return r, grpcServer, nil
 }
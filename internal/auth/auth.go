@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// QueryToken is the query parameter carrying the access token of the clients
+// unable to set the Authorization header, such as browser WebSockets.
+const QueryToken = "access_token"
+
+// RoleLibrarian is the role of the library staff, managing the catalogue
+// integrations and the members' accounts.
+const RoleLibrarian = "librarian"
+
+// Identity is the authenticated caller.
+type Identity struct {
+	Subject string
+	Name    string
+	Roles   []string
+}
+
+// HasRole reports whether the caller was granted role.
+func (i *Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Verifier checks the HS256 access tokens signed with the shared secret. A
+// token must carry a subject and an expiration.
+type Verifier struct {
+	secret []byte
+	parser *jwt.Parser
+}
+
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{
+		secret: []byte(secret),
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+		),
+	}
+}
+
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	var c claims
+	_, err := v.parser.ParseWithClaims(token, &c, func(*jwt.Token) (any, error) {
+		return v.secret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return &Identity{
+		Subject: c.Subject,
+		Name:    c.Name,
+		Roles:   c.Roles,
+	}, nil
+}
+
+// TokenFromRequest returns the bearer token of the Authorization header, or
+// else of the QueryToken parameter.
+func TokenFromRequest(r *http.Request) string {
+	if r.Header.Get("Authorization") != "" {
+		return BearerToken(r)
+	}
+
+	return r.URL.Query().Get(QueryToken)
+}
+
+// BearerToken returns the bearer token of the Authorization header.
+func BearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
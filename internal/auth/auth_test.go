@@ -0,0 +1,138 @@
+package auth_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+)
+
+const secret = "test-secret"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+
+	return token
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name             string
+		token            string
+		expectedIdentity *auth.Identity
+		expectedErr      error
+	}{
+		{
+			name:             "valid token",
+			token:            sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"sub": "u-1", "name": "Ada", "exp": expiresAt}),
+			expectedIdentity: &auth.Identity{Subject: "u-1", Name: "Ada"},
+		},
+		{
+			name:             "valid token with roles",
+			token:            sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"sub": "u-1", "roles": []string{"librarian"}, "exp": expiresAt}),
+			expectedIdentity: &auth.Identity{Subject: "u-1", Roles: []string{"librarian"}},
+		},
+		{
+			name:        "missing token",
+			expectedErr: auth.ErrMissingToken,
+		},
+		{
+			name:        "wrong secret",
+			token:       sign(t, jwt.SigningMethodHS256, []byte("another secret"), jwt.MapClaims{"sub": "u-1", "exp": expiresAt}),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "unexpected algorithm",
+			token:       sign(t, jwt.SigningMethodHS512, []byte(secret), jwt.MapClaims{"sub": "u-1", "exp": expiresAt}),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "expired token",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"sub": "u-1", "exp": time.Now().Add(-time.Minute).Unix()}),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "no expiration",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"sub": "u-1"}),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "no subject",
+			token:       sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"exp": expiresAt}),
+			expectedErr: auth.ErrInvalidToken,
+		},
+		{
+			name:        "malformed token",
+			token:       "not.a.token",
+			expectedErr: auth.ErrInvalidToken,
+		},
+	}
+
+	verifier := auth.NewVerifier(secret)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity, err := verifier.Verify(test.token)
+
+			assert.ErrorIs(t, err, test.expectedErr)
+			assert.Equal(t, test.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestTokenFromRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		authorization string
+		expectedToken string
+	}{
+		{
+			name:          "bearer header",
+			url:           "/live",
+			authorization: "Bearer abc",
+			expectedToken: "abc",
+		},
+		{
+			name:          "header wins over the query",
+			url:           "/live?access_token=def",
+			authorization: "bearer abc",
+			expectedToken: "abc",
+		},
+		{
+			name:          "other scheme",
+			url:           "/live?access_token=def",
+			authorization: "Basic abc",
+		},
+		{
+			name:          "query parameter",
+			url:           "/live?access_token=def",
+			expectedToken: "def",
+		},
+		{
+			name: "no token",
+			url:  "/live",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", test.url, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			assert.Equal(t, test.expectedToken, auth.TokenFromRequest(req))
+		})
+	}
+}
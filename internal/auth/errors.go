@@ -0,0 +1,9 @@
+package auth
+
+import "errors"
+
+var (
+	ErrMissingToken = errors.New("missing access token")
+	ErrInvalidToken = errors.New("invalid access token")
+	ErrForbidden    = errors.New("forbidden")
+)
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the identity.
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, identity)
+}
+
+// FromContext returns the identity of the authenticated caller, if any.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(*Identity)
+	return identity, ok
+}
+
+// Identify verifies the bearer token of the requests carrying one and stores
+// the identity in their context. Requests without token go through
+// anonymously, the ones with an invalid token are rejected with 401.
+func Identify(verifier *Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := BearerToken(r)
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			identity, err := verifier.Verify(token)
+			if err != nil {
+				unauthorized(w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), identity)))
+		})
+	}
+}
+
+// Require rejects the anonymous requests with 401.
+func Require(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := FromContext(r.Context()); !ok {
+			unauthorized(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole rejects the anonymous requests with 401 and the callers lacking
+// role with 403.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, ok := FromContext(r.Context())
+			if !ok {
+				unauthorized(w)
+				return
+			}
+
+			if !identity.HasRole(role) {
+				response.Error(w, http.StatusForbidden, "Forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ActFor returns ErrForbidden unless the caller identified in ctx may act on
+// behalf of subject: a member, whose ID is the subject of their token, acts
+// for themselves and the librarians for everyone.
+func ActFor(ctx context.Context, subject string) error {
+	identity, ok := FromContext(ctx)
+	if !ok || (identity.Subject != subject && !identity.HasRole(RoleLibrarian)) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// ScopeSubject returns the subject a listing is narrowed to. An empty subject
+// lists everything for a librarian and only their own items for the others,
+// any other subject must be one the caller may act for.
+func ScopeSubject(ctx context.Context, subject string) (string, error) {
+	identity, ok := FromContext(ctx)
+	if !ok {
+		return "", ErrForbidden
+	}
+
+	if subject == "" && !identity.HasRole(RoleLibrarian) {
+		return identity.Subject, nil
+	}
+
+	if subject != "" {
+		if err := ActFor(ctx, subject); err != nil {
+			return "", err
+		}
+	}
+
+	return subject, nil
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	response.Error(w, http.StatusUnauthorized, "Unauthorized")
+}
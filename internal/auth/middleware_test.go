@@ -0,0 +1,195 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+)
+
+func TestIdentify(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name               string
+		authorization      string
+		expectedStatusCode int
+		expectedSubject    string
+	}{
+		{
+			name:               "valid token",
+			authorization:      "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(secret), jwt.MapClaims{"sub": "u-1", "exp": expiresAt}),
+			expectedStatusCode: http.StatusOK,
+			expectedSubject:    "u-1",
+		},
+		{
+			name:               "anonymous",
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "invalid token",
+			authorization:      "Bearer not.a.token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var subject string
+			handler := auth.Identify(auth.NewVerifier(secret))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if identity, ok := auth.FromContext(r.Context()); ok {
+					subject = identity.Subject
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/books", nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatusCode, rec.Code)
+			assert.Equal(t, test.expectedSubject, subject)
+		})
+	}
+}
+
+func TestRequire(t *testing.T) {
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		expectedStatusCode int
+	}{
+		{
+			name:               "authenticated",
+			identity:           &auth.Identity{Subject: "u-1"},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "anonymous",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := auth.Require(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodPost, "/reviews", nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatusCode, rec.Code)
+			if test.expectedStatusCode == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		expectedStatusCode int
+	}{
+		{
+			name:               "librarian",
+			identity:           &auth.Identity{Subject: "u-1", Roles: []string{auth.RoleLibrarian}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "missing role",
+			identity:           &auth.Identity{Subject: "u-1"},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "anonymous",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := auth.RequireRole(auth.RoleLibrarian)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatusCode, rec.Code)
+		})
+	}
+}
+
+func TestScopeSubject(t *testing.T) {
+	tests := []struct {
+		name            string
+		identity        *auth.Identity
+		subject         string
+		expectedSubject string
+		expectedError   error
+	}{
+		{
+			name:            "member lists their own items",
+			identity:        &auth.Identity{Subject: "u-1"},
+			expectedSubject: "u-1",
+		},
+		{
+			name:            "member asks for their own items",
+			identity:        &auth.Identity{Subject: "u-1"},
+			subject:         "u-1",
+			expectedSubject: "u-1",
+		},
+		{
+			name:          "member asks for the items of another member",
+			identity:      &auth.Identity{Subject: "u-1"},
+			subject:       "u-2",
+			expectedError: auth.ErrForbidden,
+		},
+		{
+			name:     "librarian lists every item",
+			identity: &auth.Identity{Subject: "u-1", Roles: []string{auth.RoleLibrarian}},
+		},
+		{
+			name:            "librarian asks for the items of a member",
+			identity:        &auth.Identity{Subject: "u-1", Roles: []string{auth.RoleLibrarian}},
+			subject:         "u-2",
+			expectedSubject: "u-2",
+		},
+		{
+			name:          "anonymous",
+			expectedError: auth.ErrForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.identity != nil {
+				ctx = auth.NewContext(ctx, test.identity)
+			}
+
+			subject, err := auth.ScopeSubject(ctx, test.subject)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expectedSubject, subject)
+		})
+	}
+}
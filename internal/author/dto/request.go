@@ -1,5 +1,32 @@
 package dto
 
 type CreateAuthorRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name        string `json:"name" validate:"required,trimmed,max=255"`
+	Biography   string `json:"biography,omitempty" validate:"omitempty,trimmed,max=10000"`
+	BirthDate   string `json:"birth_date,omitempty" validate:"omitempty,iso_date,not_future" example:"1802-02-26"`
+	DeathDate   string `json:"death_date,omitempty" validate:"omitempty,iso_date,not_future" example:"1885-05-22"`
+	Nationality string `json:"nationality,omitempty" validate:"omitempty,iso3166_1_alpha2" example:"FR"`
+	// ISNI accepts the 16 characters of the identifier grouped by spaces or
+	// hyphens.
+	ISNI       string `json:"isni,omitempty" validate:"omitempty,isni" example:"0000 0001 2120 0982"`
+	VIAFID     string `json:"viaf_id,omitempty" validate:"omitempty,viaf_id" example:"9847974"`
+	WikidataID string `json:"wikidata_id,omitempty" validate:"omitempty,wikidata_id" example:"Q535"`
+}
+
+// UpdateAuthorRequest only updates the provided fields, at least one of them
+// is required. An empty value clears the optional fields.
+type UpdateAuthorRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitnil,min=1,trimmed,max=255"`
+	Biography   *string `json:"biography,omitempty" validate:"omitnil,trimmed,max=10000"`
+	BirthDate   *string `json:"birth_date,omitempty" validate:"omitnil,omitzero,iso_date,not_future" example:"1802-02-26"`
+	DeathDate   *string `json:"death_date,omitempty" validate:"omitnil,omitzero,iso_date,not_future" example:"1885-05-22"`
+	Nationality *string `json:"nationality,omitempty" validate:"omitnil,omitzero,iso3166_1_alpha2" example:"FR"`
+	ISNI        *string `json:"isni,omitempty" validate:"omitnil,omitzero,isni" example:"0000 0001 2120 0982"`
+	VIAFID      *string `json:"viaf_id,omitempty" validate:"omitnil,omitzero,viaf_id" example:"9847974"`
+	WikidataID  *string `json:"wikidata_id,omitempty" validate:"omitnil,omitzero,wikidata_id" example:"Q535"`
+}
+
+type CreateAliasRequest struct {
+	Name string `json:"name" validate:"required,trimmed,max=255" example:"Richard Bachman"`
+	Kind string `json:"kind" validate:"required,oneof=pen_name variant" example:"pen_name"`
 }
@@ -1,15 +1,79 @@
 package dto
 
-import "go-boilerplate-rest-api-chi/internal/entity"
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
 
 type AuthorResponse struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Biography   string          `json:"biography,omitempty"`
+	BirthDate   string          `json:"birth_date,omitempty" example:"1802-02-26"`
+	DeathDate   string          `json:"death_date,omitempty" example:"1885-05-22"`
+	Nationality string          `json:"nationality,omitempty" example:"FR"`
+	ISNI        string          `json:"isni,omitempty" example:"0000000121200982"`
+	VIAFID      string          `json:"viaf_id,omitempty" example:"9847974"`
+	WikidataID  string          `json:"wikidata_id,omitempty" example:"Q535"`
+	Aliases     []AliasResponse `json:"aliases,omitempty"`
+}
+
+type AliasResponse struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
+	Kind string `json:"kind" example:"pen_name"`
 }
 
 func ToAuthorResponse(author *entity.Author) *AuthorResponse {
-	return &AuthorResponse{
-		ID:   author.ID.String(),
-		Name: author.Name,
+	response := &AuthorResponse{
+		ID:          author.ID.String(),
+		Name:        author.Name,
+		Biography:   author.Biography,
+		Nationality: author.Nationality,
+	}
+
+	if author.BirthDate != nil {
+		response.BirthDate = author.BirthDate.Format(internalValidator.DateLayout)
+	}
+
+	if author.DeathDate != nil {
+		response.DeathDate = author.DeathDate.Format(internalValidator.DateLayout)
+	}
+
+	if author.ISNI != nil {
+		response.ISNI = *author.ISNI
+	}
+
+	if author.VIAFID != nil {
+		response.VIAFID = *author.VIAFID
+	}
+
+	if author.WikidataID != nil {
+		response.WikidataID = *author.WikidataID
+	}
+
+	if len(author.Aliases) > 0 {
+		response.Aliases = make([]AliasResponse, len(author.Aliases))
+		for i, alias := range author.Aliases {
+			response.Aliases[i] = *ToAliasResponse(alias)
+		}
+	}
+
+	return response
+}
+
+func ToAuthorsResponse(authors []*entity.Author) []AuthorResponse {
+	responses := make([]AuthorResponse, len(authors))
+	for i, author := range authors {
+		responses[i] = *ToAuthorResponse(author)
+	}
+	return responses
+}
+
+func ToAliasResponse(alias *entity.AuthorAlias) *AliasResponse {
+	return &AliasResponse{
+		ID:   alias.ID.String(),
+		Name: alias.Name,
+		Kind: alias.Kind,
 	}
 }
@@ -2,6 +2,7 @@ package dto_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -26,4 +27,34 @@ func TestToAuthorResponse(t *testing.T) {
 
 		assert.Equal(t, &expectedrResponse, response)
 	})
+	t.Run("with profile and aliases", func(t *testing.T) {
+		birthDate := time.Date(1947, time.September, 21, 0, 0, 0, 0, time.UTC)
+		wikidataID := "Q39829"
+
+		entity := entity.Author{
+			ID:          uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"),
+			Name:        "Stephen King",
+			BirthDate:   &birthDate,
+			Nationality: "US",
+			WikidataID:  &wikidataID,
+			Aliases: []*entity.AuthorAlias{{
+				ID:   uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+				Name: "Richard Bachman",
+				Kind: entity.AliasKindPenName,
+			}},
+		}
+
+		response := dto.ToAuthorResponse(&entity)
+
+		assert.Equal(t, &dto.AuthorResponse{
+			ID:          "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			Name:        "Stephen King",
+			BirthDate:   "1947-09-21",
+			Nationality: "US",
+			WikidataID:  "Q39829",
+			Aliases: []dto.AliasResponse{
+				{ID: "eb21d07a-7ab3-40db-bfd3-448093bc5626", Name: "Richard Bachman", Kind: "pen_name"},
+			},
+		}, response)
+	})
 }
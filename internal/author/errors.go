@@ -3,6 +3,14 @@ package author
 import "errors"
 
 var (
-	ErrNotFound  = errors.New("author not found")
+	ErrNotFound = errors.New("author not found")
+	// ErrDuplicate is returned when an external identifier already belongs
+	// to another author, names are not unique.
 	ErrDuplicate = errors.New("author already exists")
+	// ErrAmbiguousName is returned when a name or an alias resolves to
+	// several authors.
+	ErrAmbiguousName    = errors.New("author name is ambiguous")
+	ErrDeathBeforeBirth = errors.New("author death date is before birth date")
+	ErrAliasNotFound    = errors.New("author alias not found")
+	ErrDuplicateAlias   = errors.New("author alias already exists")
 )
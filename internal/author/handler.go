@@ -1,15 +1,18 @@
 package author
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"go-boilerplate-rest-api-chi/internal/author/dto"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/request"
 	"go-boilerplate-rest-api-chi/internal/response"
 	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
 )
@@ -20,6 +23,20 @@ type AuthorSuccessResponse struct {
 	Author  *dto.AuthorResponse `json:"author"`
 }
 
+type AuthorsSuccessResponse struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Authors retrieved successfully"`
+	Authors []dto.AuthorResponse `json:"authors"`
+	// NextCursor fetches the next page, it is missing on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type AliasSuccessResponse struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Alias created successfully"`
+	Alias   *dto.AliasResponse `json:"alias"`
+}
+
 type AuthorHandler struct {
 	service   AuthorService
 	validator *internalValidator.Validator
@@ -39,7 +56,11 @@ func (h *AuthorHandler) Routes() http.Handler {
 
 	// routes
 	r.Post("/", h.CreateAuthor)
+	r.Get("/", h.GetAllAuthors)
 	r.Get("/{author_id}", h.GetAuthorByID)
+	r.Put("/{author_id}", h.UpdateAuthor)
+	r.Post("/{author_id}/aliases", h.AddAlias)
+	r.Delete("/{author_id}/aliases/{alias_id}", h.RemoveAlias)
 
 	return r
 }
@@ -47,26 +68,31 @@ func (h *AuthorHandler) Routes() http.Handler {
 // CreateAuthor godoc
 //
 //	@Summary		Create a new author
-//	@Description	Create a new author with the provided data
+//	@Description	Create a new author with the provided data, names are not unique
 //	@Tags			authors
 //	@Accept			json
 //	@Produce		json
-//	@Param			author	body		dto.CreateAuthorRequest	true	"Author data"
-//	@Success		201		{object}	AuthorSuccessResponse
-//	@Failure		400		{object}	response.ValidationErrorResponse
-//	@Failure		409		{object}	response.ErrorResponse
-//	@Failure		500		{object}	response.ErrorResponse
+//	@Param			author			body		dto.CreateAuthorRequest	true	"Author data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	AuthorSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		422				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
 //	@Router			/authors [post]
 func (h *AuthorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateAuthorRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "Invalid request body")
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
 		return
 	}
 
 	if err := h.validator.Struct(&req); err != nil {
-		validationErrors := h.validator.FormatErrors(err)
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
 		response.ValidationError(w, validationErrors)
 		return
 	}
@@ -87,7 +113,7 @@ func (h *AuthorHandler) CreateAuthor(w http.ResponseWriter, r *http.Request) {
 // GetAuthorByID godoc
 //
 //	@Summary		Get author by id
-//	@Description	Get a single author by its ID
+//	@Description	Get a single author by its ID, with its aliases ordered by name
 //	@Tags			authors
 //	@Produce		json
 //	@Param			author_id	path		string	true	"Author ID"
@@ -116,12 +142,214 @@ func (h *AuthorHandler) GetAuthorByID(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetAllAuthors godoc
+//
+//	@Summary		Get all authors
+//	@Description	Get a page of the authors ordered by name. The next_cursor of the response fetches the next page, it is missing on the last page. With a name, resolve the name or an alias to the matching authors instead, several authors may share a name.
+//	@Tags			authors
+//	@Produce		json
+//	@Param			name	query		string	false	"Name or alias of the author"
+//	@Param			limit	query		int		false	"Maximum number of authors of the page (default 50, max 100)"
+//	@Param			cursor	query		string	false	"Cursor of the page, the next_cursor of the previous page"
+//	@Success		200		{object}	AuthorsSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/authors [get]
+func (h *AuthorHandler) GetAllAuthors(w http.ResponseWriter, r *http.Request) {
+	if name := strings.TrimSpace(r.URL.Query().Get("name")); name != "" {
+		h.findAuthorsByName(w, r, name)
+		return
+	}
+
+	page, err := pagination.NewPage(r.URL.Query())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	authors, next, err := h.service.GetAllAuthors(r.Context(), page)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, AuthorsSuccessResponse{
+		Status:     "success",
+		Message:    "Authors retrieved successfully",
+		Authors:    dto.ToAuthorsResponse(authors),
+		NextCursor: next,
+	})
+}
+
+// findAuthorsByName answers with every author matching the name, the few
+// authors sharing a name are not paged.
+func (h *AuthorHandler) findAuthorsByName(w http.ResponseWriter, r *http.Request, name string) {
+	authors, err := h.service.FindAuthorsByName(r.Context(), name)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, AuthorsSuccessResponse{
+		Status:  "success",
+		Message: "Authors retrieved successfully",
+		Authors: dto.ToAuthorsResponse(authors),
+	})
+}
+
+// UpdateAuthor godoc
+//
+//	@Summary		Update an author
+//	@Description	Update the provided fields of an author, an empty value clears an optional field
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			author_id		path		string					true	"Author ID"
+//	@Param			author			body		dto.UpdateAuthorRequest	true	"Author data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	AuthorSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/authors/{author_id} [put]
+func (h *AuthorHandler) UpdateAuthor(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(chi.URLParam(r, "author_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.UpdateAuthorRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	author, err := h.service.UpdateAuthor(r.Context(), &req, authorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, AuthorSuccessResponse{
+		Status:  "success",
+		Message: "Author updated successfully",
+		Author:  dto.ToAuthorResponse(author),
+	})
+}
+
+// AddAlias godoc
+//
+//	@Summary		Add an alias to an author
+//	@Description	Add a pen name or a variant spelling resolving to the author
+//	@Tags			authors
+//	@Accept			json
+//	@Produce		json
+//	@Param			author_id		path		string					true	"Author ID"
+//	@Param			alias			body		dto.CreateAliasRequest	true	"Alias data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	AliasSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/authors/{author_id}/aliases [post]
+func (h *AuthorHandler) AddAlias(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(chi.URLParam(r, "author_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.CreateAliasRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	alias, err := h.service.AddAlias(r.Context(), &req, authorID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, AliasSuccessResponse{
+		Status:  "success",
+		Message: "Alias created successfully",
+		Alias:   dto.ToAliasResponse(alias),
+	})
+}
+
+// RemoveAlias godoc
+//
+//	@Summary		Remove an alias from an author
+//	@Description	Remove an alias, it no longer resolves to the author
+//	@Tags			authors
+//	@Produce		json
+//	@Param			author_id	path		string	true	"Author ID"
+//	@Param			alias_id	path		string	true	"Alias ID"
+//	@Success		200			{object}	response.SuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/authors/{author_id}/aliases/{alias_id} [delete]
+func (h *AuthorHandler) RemoveAlias(w http.ResponseWriter, r *http.Request) {
+	authorID, err := uuid.Parse(chi.URLParam(r, "author_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	aliasID, err := uuid.Parse(chi.URLParam(r, "alias_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.RemoveAlias(r.Context(), authorID, aliasID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Alias deleted successfully")
+}
+
 func (h *AuthorHandler) handleError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, ErrNotFound):
 		response.Error(w, http.StatusNotFound, "Author not found")
 	case errors.Is(err, ErrDuplicate):
-		response.Error(w, http.StatusConflict, "Author with this name already exists")
+		response.Error(w, http.StatusConflict, "Author with this identifier already exists")
+	case errors.Is(err, ErrDeathBeforeBirth):
+		response.Error(w, http.StatusBadRequest, "death_date must not be before birth_date")
+	case errors.Is(err, ErrAliasNotFound):
+		response.Error(w, http.StatusNotFound, "Alias not found")
+	case errors.Is(err, ErrDuplicateAlias):
+		response.Error(w, http.StatusConflict, "Author already has this alias")
+	case errors.Is(err, pagination.ErrInvalidLimit):
+		response.Error(w, http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", pagination.MaxLimit))
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		response.Error(w, http.StatusBadRequest, "Invalid cursor")
 	default:
 		h.logger.Error().Err(err).Msg("unexpected error")
 		response.Error(w, http.StatusInternalServerError, "Internal server error")
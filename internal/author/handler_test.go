@@ -20,6 +20,7 @@ import (
 	"go-boilerplate-rest-api-chi/internal/author/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
 	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/pagination"
 	"go-boilerplate-rest-api-chi/internal/response"
 	"go-boilerplate-rest-api-chi/internal/validator"
 )
@@ -60,13 +61,30 @@ func TestAuthorHandler_CreateAuthor(t *testing.T) {
 			},
 		},
 		{
-			name:               "error invalid JSON",
+			name:               "error empty body",
 			requestBody:        nil,
 			configureMock:      func(mockService *mocks.MockAuthorService) {},
 			expectedStatusCode: http.StatusBadRequest,
 			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Request body must not be empty",
+			},
+		},
+		{
+			name: "error unknown field",
+			requestBody: map[string]string{
+				"name":     "George R.R. Martin",
+				"nickname": "GRRM",
+			},
+			configureMock:      func(mockService *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
 				Status:  "error",
 				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "nickname",
+					Message: `Unknown field "nickname"`,
+				}},
 			},
 		},
 		{
@@ -80,8 +98,8 @@ func TestAuthorHandler_CreateAuthor(t *testing.T) {
 				Status:  "error",
 				Message: "Validation failed",
 				Errors: []response.ValidationErrorDetail{{
-					Field:   "Name",
-					Message: "Name is required",
+					Field:   "name",
+					Message: "name is required",
 				}},
 			},
 		},
@@ -102,7 +120,7 @@ func TestAuthorHandler_CreateAuthor(t *testing.T) {
 			expectedStatusCode: http.StatusConflict,
 			expectedResponse: &response.ErrorResponse{
 				Status:  "error",
-				Message: "Author with this name already exists",
+				Message: "Author with this identifier already exists",
 			},
 		},
 		{
@@ -136,6 +154,7 @@ func TestAuthorHandler_CreateAuthor(t *testing.T) {
 			test.configureMock(mockService)
 
 			v := validator.New()
+			require.NoError(t, author.RegisterValidations(v))
 			handler := author.NewAuthorHandler(mockService, v, zerolog.Nop())
 
 			var body *bytes.Buffer
@@ -245,6 +264,7 @@ func TestAuthorHandler_GetAuthorByID(t *testing.T) {
 			test.configureMock(mockService)
 
 			v := validator.New()
+			require.NoError(t, author.RegisterValidations(v))
 			handler := author.NewAuthorHandler(mockService, v, zerolog.Nop())
 
 			url := fmt.Sprintf("/authors/%s", test.idInUrlParam)
@@ -265,3 +285,209 @@ func TestAuthorHandler_GetAuthorByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorHandler_GetAllAuthors(t *testing.T) {
+	kingID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	aliasID := uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+	tests := []struct {
+		name               string
+		query              string
+		configureMock      func(*mocks.MockAuthorService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:  "success get a page of authors",
+			query: "limit=1&cursor=abc",
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					GetAllAuthors(gomock.Any(), pagination.Page{Limit: 1, Cursor: "abc"}).
+					Return([]*entity.Author{{ID: kingID, Name: "Stephen King"}}, "def", nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: author.AuthorsSuccessResponse{
+				Status:     "success",
+				Message:    "Authors retrieved successfully",
+				Authors:    []dto.AuthorResponse{{ID: kingID.String(), Name: "Stephen King"}},
+				NextCursor: "def",
+			},
+		},
+		{
+			name:  "success get the last page with the default limit",
+			query: "",
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					GetAllAuthors(gomock.Any(), pagination.Page{Limit: pagination.DefaultLimit}).
+					Return([]*entity.Author{{ID: kingID, Name: "Stephen King"}}, "", nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: author.AuthorsSuccessResponse{
+				Status:  "success",
+				Message: "Authors retrieved successfully",
+				Authors: []dto.AuthorResponse{{ID: kingID.String(), Name: "Stephen King"}},
+			},
+		},
+		{
+			name:  "success resolves an alias",
+			query: "name=Richard+Bachman",
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					FindAuthorsByName(gomock.Any(), "Richard Bachman").
+					Return([]*entity.Author{{
+						ID:      kingID,
+						Name:    "Stephen King",
+						Aliases: []*entity.AuthorAlias{{ID: aliasID, Name: "Richard Bachman", Kind: entity.AliasKindPenName}},
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: author.AuthorsSuccessResponse{
+				Status:  "success",
+				Message: "Authors retrieved successfully",
+				Authors: []dto.AuthorResponse{{
+					ID:      kingID.String(),
+					Name:    "Stephen King",
+					Aliases: []dto.AliasResponse{{ID: aliasID.String(), Name: "Richard Bachman", Kind: "pen_name"}},
+				}},
+			},
+		},
+		{
+			name:               "error invalid limit",
+			query:              "limit=101",
+			configureMock:      func(mockService *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Limit must be between 1 and 100",
+			},
+		},
+		{
+			name:  "error invalid cursor",
+			query: "cursor=abc",
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					GetAllAuthors(gomock.Any(), pagination.Page{Limit: pagination.DefaultLimit, Cursor: "abc"}).
+					Return(nil, "", pagination.ErrInvalidCursor)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid cursor",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockAuthorService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, author.RegisterValidations(v))
+			handler := author.NewAuthorHandler(mockService, v, zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/authors?"+test.query, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/authors", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestAuthorHandler_Errors(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	aliasID := uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		requestBody        string
+		configureMock      func(*mocks.MockAuthorService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "error death before birth",
+			method:      http.MethodPut,
+			path:        "/authors/" + authorID.String(),
+			requestBody: `{"death_date":"1802-02-26"}`,
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					UpdateAuthor(gomock.Any(), gomock.Any(), authorID).
+					Return(nil, author.ErrDeathBeforeBirth)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "death_date must not be before birth_date",
+		},
+		{
+			name:        "error duplicate alias",
+			method:      http.MethodPost,
+			path:        "/authors/" + authorID.String() + "/aliases",
+			requestBody: `{"name":"Richard Bachman","kind":"pen_name"}`,
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					AddAlias(gomock.Any(), &dto.CreateAliasRequest{Name: "Richard Bachman", Kind: "pen_name"}, authorID).
+					Return(nil, author.ErrDuplicateAlias)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Author already has this alias",
+		},
+		{
+			name:   "error alias not found",
+			method: http.MethodDelete,
+			path:   "/authors/" + authorID.String() + "/aliases/" + aliasID.String(),
+			configureMock: func(mockService *mocks.MockAuthorService) {
+				mockService.EXPECT().
+					RemoveAlias(gomock.Any(), authorID, aliasID).
+					Return(author.ErrAliasNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Alias not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockAuthorService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, author.RegisterValidations(v))
+			handler := author.NewAuthorHandler(mockService, v, zerolog.Nop())
+
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/authors", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
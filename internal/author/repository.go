@@ -7,14 +7,32 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/transaction"
 )
 
 //go:generate mockgen -destination=../mocks/mock_author_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/author AuthorRepository
 type AuthorRepository interface {
 	Create(ctx context.Context, newAuthor *entity.Author) (*entity.Author, error)
+	// GetByID returns the author with its aliases ordered by name.
 	GetByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error)
+	GetByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error)
+	LockByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error)
+	// GetByName resolves a name or an alias to its author. It returns
+	// ErrAmbiguousName when several authors match.
+	GetByName(ctx context.Context, name string) (*entity.Author, error)
+	// FindByName returns every author named so or having such an alias, with
+	// their aliases, ordered by name.
+	FindByName(ctx context.Context, name string) ([]*entity.Author, error)
+	// GetAll returns a page of the authors with their aliases, ordered by
+	// name, and the cursor of the next page, empty on the last page.
+	GetAll(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error)
+	Update(ctx context.Context, author *entity.Author) (*entity.Author, error)
+	CreateAlias(ctx context.Context, newAlias *entity.AuthorAlias) (*entity.AuthorAlias, error)
+	DeleteAlias(ctx context.Context, authorID, aliasID uuid.UUID) error
 }
 
 type authorRepository struct {
@@ -30,7 +48,7 @@ func NewAuthorRepository(db *gorm.DB, logger zerolog.Logger) AuthorRepository {
 }
 
 func (r *authorRepository) Create(ctx context.Context, newAuthor *entity.Author) (*entity.Author, error) {
-	if err := r.db.WithContext(ctx).Create(newAuthor).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Create(newAuthor).Error; err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			return nil, ErrDuplicate
 		}
@@ -45,7 +63,10 @@ func (r *authorRepository) Create(ctx context.Context, newAuthor *entity.Author)
 func (r *authorRepository) GetByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
 	var author *entity.Author
 
-	if err := r.db.WithContext(ctx).First(&author, "id = ?", authorID).Error; err != nil {
+	err := transaction.DB(ctx, r.db).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		First(&author, "id = ?", authorID).Error
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrNotFound
 		}
@@ -56,3 +77,145 @@ func (r *authorRepository) GetByID(ctx context.Context, authorID uuid.UUID) (*en
 
 	return author, nil
 }
+
+// GetByIDs reads the authors of the given ids in a single query. Unknown ids
+// are skipped.
+func (r *authorRepository) GetByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error) {
+	var authors []*entity.Author
+
+	if len(authorIDs) == 0 {
+		return authors, nil
+	}
+
+	if err := transaction.DB(ctx, r.db).Find(&authors, "id IN ?", authorIDs).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// LockByID reads the author and locks its row until the end of the
+// transaction carried by ctx, so that it cannot be changed or deleted
+// meanwhile.
+func (r *authorRepository) LockByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
+	var author *entity.Author
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&author, "id = ?", authorID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (r *authorRepository) GetByName(ctx context.Context, name string) (*entity.Author, error) {
+	var authors []*entity.Author
+
+	// two rows are enough to tell an ambiguous name
+	if err := r.byName(ctx, name).Limit(2).Find(&authors).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	switch len(authors) {
+	case 0:
+		return nil, ErrNotFound
+	case 1:
+		return authors[0], nil
+	default:
+		return nil, ErrAmbiguousName
+	}
+}
+
+func (r *authorRepository) FindByName(ctx context.Context, name string) ([]*entity.Author, error) {
+	var authors []*entity.Author
+
+	err := r.byName(ctx, name).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		Order("name, id").
+		Find(&authors).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// authorOrder orders the list by name, the id tells apart the authors
+// sharing a name.
+var authorOrder = pagination.Order{{Column: "name"}, {Column: "id"}}
+
+func (r *authorRepository) GetAll(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error) {
+	query := transaction.DB(ctx, r.db).
+		Preload("Aliases", func(db *gorm.DB) *gorm.DB { return db.Order("name") })
+
+	authors, next, err := pagination.Find(query, authorOrder, page, func(author *entity.Author) []any {
+		return []any{author.Name, author.ID}
+	})
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			return nil, "", err
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, "", err
+	}
+
+	return authors, next, nil
+}
+
+// byName matches the authors named so or having such an alias.
+func (r *authorRepository) byName(ctx context.Context, name string) *gorm.DB {
+	aliases := transaction.DB(ctx, r.db).Model(&entity.AuthorAlias{}).Select("author_id").Where("name = ?", name)
+
+	return transaction.DB(ctx, r.db).Where("name = ? OR id IN (?)", name, aliases)
+}
+
+func (r *authorRepository) Update(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(author).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func (r *authorRepository) CreateAlias(ctx context.Context, newAlias *entity.AuthorAlias) (*entity.AuthorAlias, error) {
+	if err := transaction.DB(ctx, r.db).Create(newAlias).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicateAlias
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newAlias, nil
+}
+
+func (r *authorRepository) DeleteAlias(ctx context.Context, authorID, aliasID uuid.UUID) error {
+	result := transaction.DB(ctx, r.db).Delete(&entity.AuthorAlias{}, "id = ? AND author_id = ?", aliasID, authorID)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Msg("database error")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrAliasNotFound
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"go-boilerplate-rest-api-chi/internal/author"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/pagination"
 	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
 )
 
@@ -35,6 +36,13 @@ func TestAuthorRepository_Create(t *testing.T) {
 					WithArgs(
 						sqlmock.AnyArg(), // ID généré
 						input.Name,
+						input.Biography,
+						input.BirthDate,
+						input.DeathDate,
+						input.Nationality,
+						input.ISNI,
+						input.VIAFID,
+						input.WikidataID,
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
 					).
@@ -55,6 +63,13 @@ func TestAuthorRepository_Create(t *testing.T) {
 					WithArgs(
 						sqlmock.AnyArg(), // ID généré
 						input.Name,
+						input.Biography,
+						input.BirthDate,
+						input.DeathDate,
+						input.Nationality,
+						input.ISNI,
+						input.VIAFID,
+						input.WikidataID,
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
 					).
@@ -73,6 +88,13 @@ func TestAuthorRepository_Create(t *testing.T) {
 					WithArgs(
 						sqlmock.AnyArg(), // ID généré
 						input.Name,
+						input.Biography,
+						input.BirthDate,
+						input.DeathDate,
+						input.Nationality,
+						input.ISNI,
+						input.VIAFID,
+						input.WikidataID,
 						sqlmock.AnyArg(), // created_at
 						sqlmock.AnyArg(), // updated_at
 					).
@@ -132,6 +154,10 @@ func TestAuthorRepository_GetByID(t *testing.T) {
 				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id = \? ORDER BY .authors.\..id. LIMIT \?`).
 					WithArgs(id, 1).
 					WillReturnRows(rows)
+
+				mock.ExpectQuery(`SELECT \* FROM .author_aliases. WHERE .author_aliases.\..author_id. = \? ORDER BY name`).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "name", "kind"}))
 			},
 			expectedError: nil,
 			expectedResponse: &entity.Author{
@@ -191,3 +217,542 @@ func TestAuthorRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorRepository_GetByIDs(t *testing.T) {
+	firstID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	secondID := uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+	tests := []struct {
+		name          string
+		authorIDs     []uuid.UUID
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedNames []string
+	}{
+		{
+			name:      "success get authors by ids",
+			authorIDs: []uuid.UUID{firstID, secondID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(firstID, "Victor Hugo", now, now).
+					AddRow(secondID, "George R.R. Martin", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id IN \(\?,\?\)`).
+					WithArgs(firstID, secondID).
+					WillReturnRows(rows)
+			},
+			expectedNames: []string{"Victor Hugo", "George R.R. Martin"},
+		},
+		{
+			name:          "success no ids does not query",
+			authorIDs:     nil,
+			configureMock: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "error database connection failed",
+			authorIDs: []uuid.UUID{firstID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id IN \(\?\)`).
+					WithArgs(firstID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			authors, err := repo.GetByIDs(context.Background(), test.authorIDs)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, authors)
+			} else {
+				require.NoError(t, err)
+
+				var names []string
+				for _, a := range authors {
+					names = append(names, a.Name)
+				}
+				assert.Equal(t, test.expectedNames, names)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_LockByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		authorID         uuid.UUID
+		configureMock    func(sqlmock.Sqlmock, uuid.UUID)
+		expectedError    error
+		expectedResponse *entity.Author
+	}{
+		{
+			name:     "success lock author by id",
+			authorID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(id, "Victor Hugo", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id = \? ORDER BY .authors.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnRows(rows)
+			},
+			expectedError: nil,
+			expectedResponse: &entity.Author{
+				ID:   uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+				Name: "Victor Hugo",
+			},
+		},
+		{
+			name:     "error author not found",
+			authorID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id = \? ORDER BY .authors.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError:    author.ErrNotFound,
+			expectedResponse: nil,
+		},
+		{
+			name:     "error database connection failed",
+			authorID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE id = \? ORDER BY .authors.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError:    gorm.ErrInvalidDB,
+			expectedResponse: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.authorID)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			author, err := repo.LockByID(context.Background(), test.authorID)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if test.expectedResponse != nil {
+				assert.NotNil(t, author)
+				assert.Equal(t, test.expectedResponse.ID, author.ID)
+				assert.Equal(t, test.expectedResponse.Name, author.Name)
+			} else {
+				assert.Nil(t, author)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_GetByName(t *testing.T) {
+	const byName = `SELECT \* FROM .authors. WHERE name = \? OR id IN \(SELECT .author_id. FROM .author_aliases. WHERE name = \?\) LIMIT \?`
+
+	tests := []struct {
+		name             string
+		authorName       string
+		configureMock    func(sqlmock.Sqlmock, string)
+		expectedError    error
+		expectedResponse *entity.Author
+	}{
+		{
+			name:       "success get author by name",
+			authorName: "Victor Hugo",
+			configureMock: func(mock sqlmock.Sqlmock, name string) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"), name, now, now)
+
+				mock.ExpectQuery(byName).
+					WithArgs(name, name, 2).
+					WillReturnRows(rows)
+			},
+			expectedError: nil,
+			expectedResponse: &entity.Author{
+				ID:   uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+				Name: "Victor Hugo",
+			},
+		},
+		{
+			name:       "error author not found",
+			authorName: "Victor Hugo",
+			configureMock: func(mock sqlmock.Sqlmock, name string) {
+				mock.ExpectQuery(byName).
+					WithArgs(name, name, 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}))
+			},
+			expectedError:    author.ErrNotFound,
+			expectedResponse: nil,
+		},
+		{
+			name:       "error ambiguous name",
+			authorName: "John Smith",
+			configureMock: func(mock sqlmock.Sqlmock, name string) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"), name, now, now).
+					AddRow(uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"), name, now, now)
+
+				mock.ExpectQuery(byName).
+					WithArgs(name, name, 2).
+					WillReturnRows(rows)
+			},
+			expectedError:    author.ErrAmbiguousName,
+			expectedResponse: nil,
+		},
+		{
+			name:       "error database connection failed",
+			authorName: "Victor Hugo",
+			configureMock: func(mock sqlmock.Sqlmock, name string) {
+				mock.ExpectQuery(byName).
+					WithArgs(name, name, 2).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError:    gorm.ErrInvalidDB,
+			expectedResponse: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.authorName)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			author, err := repo.GetByName(context.Background(), test.authorName)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if test.expectedResponse != nil {
+				assert.NotNil(t, author)
+				assert.Equal(t, test.expectedResponse.ID, author.ID)
+				assert.Equal(t, test.expectedResponse.Name, author.Name)
+			} else {
+				assert.Nil(t, author)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_CreateAlias(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+
+	tests := []struct {
+		name             string
+		input            *entity.AuthorAlias
+		configureMock    func(sqlmock.Sqlmock, *entity.AuthorAlias)
+		expectedError    error
+		expectedResponse *entity.AuthorAlias
+	}{
+		{
+			name:  "success create alias",
+			input: &entity.AuthorAlias{AuthorID: authorID, Name: "Richard Bachman", Kind: entity.AliasKindPenName},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.AuthorAlias) {
+				mock.ExpectExec(`INSERT INTO .author_aliases.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.AuthorID,
+						input.Name,
+						input.Kind,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.AuthorAlias{AuthorID: authorID, Name: "Richard Bachman", Kind: entity.AliasKindPenName},
+		},
+		{
+			name:  "error duplicate alias",
+			input: &entity.AuthorAlias{AuthorID: authorID, Name: "Richard Bachman", Kind: entity.AliasKindVariant},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.AuthorAlias) {
+				mock.ExpectExec(`INSERT INTO .author_aliases.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: author.ErrDuplicateAlias,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			newAlias, err := repo.CreateAlias(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newAlias)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Name, newAlias.Name)
+				assert.Equal(t, test.expectedResponse.Kind, newAlias.Kind)
+				assert.NotEqual(t, uuid.Nil, newAlias.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_FindByName(t *testing.T) {
+	kingID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	namesakeID := uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedIDs   []uuid.UUID
+	}{
+		{
+			name: "success the namesakes and the authors with such an alias",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(namesakeID, "Richard Bachman", now, now).
+					AddRow(kingID, "Stephen King", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE name = \? OR id IN \(SELECT .author_id. FROM .author_aliases. WHERE name = \?\) ORDER BY name, id`).
+					WithArgs("Richard Bachman", "Richard Bachman").
+					WillReturnRows(rows)
+
+				mock.ExpectQuery(`SELECT \* FROM .author_aliases. WHERE .author_aliases.\..author_id. IN \(\?,\?\) ORDER BY name`).
+					WithArgs(namesakeID, kingID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "name", "kind"}).
+						AddRow(uuid.New(), kingID, "Richard Bachman", entity.AliasKindPenName))
+			},
+			expectedIDs: []uuid.UUID{namesakeID, kingID},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE name = \? OR id IN`).
+					WithArgs("Richard Bachman", "Richard Bachman").
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			authors, err := repo.FindByName(context.Background(), "Richard Bachman")
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, authors)
+			} else {
+				require.NoError(t, err)
+
+				ids := make([]uuid.UUID, len(authors))
+				for i, a := range authors {
+					ids[i] = a.ID
+				}
+				assert.Equal(t, test.expectedIDs, ids)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_DeleteAlias(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	aliasID := uuid.MustParse("3f6b2c1d-8e4a-4f7b-9c2d-1a0e5b6c7d8e")
+
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "success delete alias",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM .author_aliases. WHERE id = \? AND author_id = \?`).
+					WithArgs(aliasID, authorID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error alias of another author",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM .author_aliases. WHERE id = \? AND author_id = \?`).
+					WithArgs(aliasID, authorID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: author.ErrAliasNotFound,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`DELETE FROM .author_aliases. WHERE id = \? AND author_id = \?`).
+					WithArgs(aliasID, authorID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			err := repo.DeleteAlias(context.Background(), authorID, aliasID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_Update(t *testing.T) {
+	isni := "0000000121200982"
+
+	tests := []struct {
+		name          string
+		input         *entity.Author
+		configureMock func(sqlmock.Sqlmock, *entity.Author)
+		expectedError error
+	}{
+		{
+			name:  "success update author",
+			input: &entity.Author{ID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"), Name: "Victor Hugo", Nationality: "FR", ISNI: &isni},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Author) {
+				mock.ExpectExec(`UPDATE .authors. SET .name.=\?,.biography.=\?,.birth_date.=\?,.death_date.=\?,.nationality.=\?,.isni.=\?,.viaf_id.=\?,.wikidata_id.=\?,.created_at.=\?,.updated_at.=\? WHERE .id. = \?`).
+					WithArgs(
+						input.Name,
+						input.Biography,
+						input.BirthDate,
+						input.DeathDate,
+						input.Nationality,
+						input.ISNI,
+						input.VIAFID,
+						input.WikidataID,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+						input.ID,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "error identifier of another author",
+			input: &entity.Author{ID: uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"), Name: "Victor Hugo", ISNI: &isni},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Author) {
+				mock.ExpectExec(`UPDATE .authors.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: author.ErrDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := author.NewAuthorRepository(db, zerolog.Nop())
+
+			updated, err := repo.Update(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, updated)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.input, updated)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuthorRepository_GetAll(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.AuthorAlias{})
+	repo := author.NewAuthorRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	// the namesakes are told apart by their id, none is skipped between pages
+	for _, name := range []string{"Victor Hugo", "Alexandre Dumas", "Alexandre Dumas", "Émile Zola", "Alexandre Dumas"} {
+		_, err := repo.Create(ctx, &entity.Author{Name: name})
+		require.NoError(t, err)
+	}
+
+	var (
+		names []string
+		ids   = map[uuid.UUID]bool{}
+		page  = pagination.Page{Limit: 2}
+	)
+
+	for {
+		authors, next, err := repo.GetAll(ctx, page)
+		require.NoError(t, err)
+
+		for _, a := range authors {
+			names = append(names, a.Name)
+			ids[a.ID] = true
+		}
+
+		if next == "" {
+			break
+		}
+		page.Cursor = next
+	}
+
+	assert.Equal(t, []string{"Alexandre Dumas", "Alexandre Dumas", "Alexandre Dumas", "Victor Hugo", "Émile Zola"}, names)
+	assert.Len(t, ids, 5)
+
+	_, _, err := repo.GetAll(ctx, pagination.Page{Limit: 2, Cursor: "bm90IGEgY3Vyc29y"})
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
@@ -2,38 +2,87 @@ package author
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 
 	"go-boilerplate-rest-api-chi/internal/author/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/search"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
 )
 
 //go:generate mockgen -destination=../mocks/mock_author_service.go -package=mocks go-boilerplate-rest-api-chi/internal/author AuthorService
 type AuthorService interface {
 	CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*entity.Author, error)
 	GetAuthorByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error)
+	GetAuthorsByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error)
+	// FindAuthorsByName resolves a name or an alias to the matching authors,
+	// several authors may share a name.
+	FindAuthorsByName(ctx context.Context, name string) ([]*entity.Author, error)
+	// GetAllAuthors returns a page of the authors ordered by name and the
+	// cursor of the next page, empty on the last page.
+	GetAllAuthors(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error)
+	UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorRequest, authorID uuid.UUID) (*entity.Author, error)
+	AddAlias(ctx context.Context, req *dto.CreateAliasRequest, authorID uuid.UUID) (*entity.AuthorAlias, error)
+	RemoveAlias(ctx context.Context, authorID, aliasID uuid.UUID) error
+}
+
+// BookLister lists the books of authors, with their author. The book
+// repository implements it, the book package depends on this one.
+//
+//go:generate mockgen -destination=../mocks/mock_author_book_lister.go -package=mocks go-boilerplate-rest-api-chi/internal/author BookLister
+type BookLister interface {
+	GetByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error)
 }
 
 type authorService struct {
-	repository AuthorRepository
-	logger     zerolog.Logger
+	repository   AuthorRepository
+	books        BookLister
+	transactions transaction.Manager
+	outbox       outbox.Outbox
+	index        search.Index
+	logger       zerolog.Logger
 }
 
-func NewAuthorService(repository AuthorRepository, logger zerolog.Logger) AuthorService {
+func NewAuthorService(repository AuthorRepository, books BookLister, transactions transaction.Manager, outbox outbox.Outbox, index search.Index, logger zerolog.Logger) AuthorService {
 	return &authorService{
-		repository: repository,
-		logger:     logger,
+		repository:   repository,
+		books:        books,
+		transactions: transactions,
+		outbox:       outbox,
+		index:        index,
+		logger:       logger,
 	}
 }
 
 func (s *authorService) CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*entity.Author, error) {
-	author := &entity.Author{
-		Name: req.Name,
+	author, err := NewAuthor(req)
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repository.Create(ctx, author)
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		author, err = s.repository.Create(ctx, author)
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewAuthorCreated(author))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.indexAuthor(ctx, author)
+
+	return author, nil
 }
 
 func (s *authorService) GetAuthorByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
@@ -44,3 +93,247 @@ func (s *authorService) GetAuthorByID(ctx context.Context, authorID uuid.UUID) (
 
 	return author, nil
 }
+
+// GetAuthorsByIDs returns the authors of the given ids, in no particular
+// order. Unknown ids are not an error, they are simply missing from the result.
+func (s *authorService) GetAuthorsByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error) {
+	return s.repository.GetByIDs(ctx, authorIDs)
+}
+
+func (s *authorService) FindAuthorsByName(ctx context.Context, name string) ([]*entity.Author, error) {
+	return s.repository.FindByName(ctx, name)
+}
+
+func (s *authorService) GetAllAuthors(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error) {
+	return s.repository.GetAll(ctx, page)
+}
+
+func (s *authorService) UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorRequest, authorID uuid.UUID) (*entity.Author, error) {
+	var (
+		author  *entity.Author
+		renamed bool
+	)
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		author, err = s.repository.LockByID(ctx, authorID)
+		if err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			renamed = *req.Name != author.Name
+			author.Name = *req.Name
+		}
+
+		if req.Biography != nil {
+			author.Biography = *req.Biography
+		}
+
+		if req.BirthDate != nil {
+			if author.BirthDate, err = parseDate(*req.BirthDate); err != nil {
+				return err
+			}
+		}
+
+		if req.DeathDate != nil {
+			if author.DeathDate, err = parseDate(*req.DeathDate); err != nil {
+				return err
+			}
+		}
+
+		if req.Nationality != nil {
+			author.Nationality = *req.Nationality
+		}
+
+		if req.ISNI != nil {
+			author.ISNI = optionalString(NormalizeISNI(*req.ISNI))
+		}
+
+		if req.VIAFID != nil {
+			author.VIAFID = optionalString(*req.VIAFID)
+		}
+
+		if req.WikidataID != nil {
+			author.WikidataID = optionalString(*req.WikidataID)
+		}
+
+		if err := checkLifespan(author); err != nil {
+			return err
+		}
+
+		if _, err := s.repository.Update(ctx, author); err != nil {
+			return err
+		}
+
+		// reloaded for the aliases, the lock does not read them
+		author, err = s.repository.GetByID(ctx, authorID)
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewAuthorUpdated(author))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.indexAuthor(ctx, author)
+
+	// the documents of the books hold the name of their author
+	if renamed {
+		s.indexBooks(ctx, author)
+	}
+
+	return author, nil
+}
+
+func (s *authorService) AddAlias(ctx context.Context, req *dto.CreateAliasRequest, authorID uuid.UUID) (*entity.AuthorAlias, error) {
+	var alias *entity.AuthorAlias
+	var author *entity.Author
+
+	// the author stays locked until the alias is committed, it cannot be
+	// deleted in between
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, authorID); err != nil {
+			return err
+		}
+
+		var err error
+		alias, err = s.repository.CreateAlias(ctx, &entity.AuthorAlias{
+			AuthorID: authorID,
+			Name:     req.Name,
+			Kind:     req.Kind,
+		})
+		if err != nil {
+			return err
+		}
+
+		author, err = s.repository.GetByID(ctx, authorID)
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewAuthorUpdated(author))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.indexAuthor(ctx, author)
+
+	return alias, nil
+}
+
+func (s *authorService) RemoveAlias(ctx context.Context, authorID, aliasID uuid.UUID) error {
+	var author *entity.Author
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, authorID); err != nil {
+			return err
+		}
+
+		if err := s.repository.DeleteAlias(ctx, authorID, aliasID); err != nil {
+			return err
+		}
+
+		var err error
+		author, err = s.repository.GetByID(ctx, authorID)
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewAuthorUpdated(author))
+	})
+	if err != nil {
+		return err
+	}
+
+	s.indexAuthor(ctx, author)
+
+	return nil
+}
+
+// indexAuthor updates the search index after a committed write. The database
+// stays the source of truth, an indexing failure must not fail the write.
+func (s *authorService) indexAuthor(ctx context.Context, author *entity.Author) {
+	if err := s.index.IndexAuthor(ctx, author); err != nil {
+		s.logger.Error().Err(err).Str("author_id", author.ID.String()).Msg("failed to index author")
+	}
+}
+
+// NewAuthor builds the author described by a creation request. The request
+// is expected to be validated.
+// indexBooks reindexes the books of the author after a committed rename, see
+// indexAuthor.
+func (s *authorService) indexBooks(ctx context.Context, author *entity.Author) {
+	books, err := s.books.GetByAuthorIDs(ctx, []uuid.UUID{author.ID})
+	if err != nil {
+		s.logger.Error().Err(err).Str("author_id", author.ID.String()).Msg("failed to reindex the books of the author")
+		return
+	}
+
+	for _, book := range books {
+		if err := s.index.IndexBook(ctx, book); err != nil {
+			s.logger.Error().Err(err).Str("book_id", book.ID.String()).Msg("failed to index book")
+		}
+	}
+}
+
+func NewAuthor(req *dto.CreateAuthorRequest) (*entity.Author, error) {
+	author := &entity.Author{
+		Name:        req.Name,
+		Biography:   req.Biography,
+		Nationality: req.Nationality,
+		ISNI:        optionalString(NormalizeISNI(req.ISNI)),
+		VIAFID:      optionalString(req.VIAFID),
+		WikidataID:  optionalString(req.WikidataID),
+	}
+
+	var err error
+	if author.BirthDate, err = parseDate(req.BirthDate); err != nil {
+		return nil, err
+	}
+
+	if author.DeathDate, err = parseDate(req.DeathDate); err != nil {
+		return nil, err
+	}
+
+	if err := checkLifespan(author); err != nil {
+		return nil, err
+	}
+
+	return author, nil
+}
+
+func checkLifespan(author *entity.Author) error {
+	if author.BirthDate != nil && author.DeathDate != nil && author.DeathDate.Before(*author.BirthDate) {
+		return ErrDeathBeforeBirth
+	}
+
+	return nil
+}
+
+// parseDate reads an optional YYYY-MM-DD date, an empty value is no date.
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(internalValidator.DateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+// optionalString maps an empty value to NULL, so that unique columns accept
+// any number of authors without the value.
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
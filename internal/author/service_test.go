@@ -4,23 +4,27 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"go-boilerplate-rest-api-chi/internal/author"
 	"go-boilerplate-rest-api-chi/internal/author/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
 	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
 )
 
 func TestAuthorService_CreateAuthor(t *testing.T) {
 	tests := []struct {
 		name             string
 		input            *dto.CreateAuthorRequest
-		configureMock    func(*mocks.MockAuthorRepository)
+		configureMock    func(*mocks.MockAuthorRepository, *mocks.MockOutbox, *mocks.MockIndex)
 		expectedResponse *entity.Author
 		expectedError    error
 	}{
@@ -29,7 +33,7 @@ func TestAuthorService_CreateAuthor(t *testing.T) {
 			input: &dto.CreateAuthorRequest{
 				Name: "J.K. Rowling",
 			},
-			configureMock: func(mockRepo *mocks.MockAuthorRepository) {
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
 				sampleAuthor := &entity.Author{
 					Name: "J.K. Rowling",
 				}
@@ -40,17 +44,73 @@ func TestAuthorService_CreateAuthor(t *testing.T) {
 						ID:   uuid.New(),
 						Name: "J.K. Rowling",
 					}, nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.AuthorCreated, events[0].Type)
+						return nil
+					})
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedResponse: &entity.Author{
+				Name: "J.K. Rowling",
+			},
+		},
+		{
+			name: "success create author when indexing fails",
+			input: &dto.CreateAuthorRequest{
+				Name: "J.K. Rowling",
+			},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&entity.Author{
+						ID:   uuid.New(),
+						Name: "J.K. Rowling",
+					}, nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), gomock.Any()).
+					Return(errors.New("index unavailable"))
 			},
 			expectedResponse: &entity.Author{
 				Name: "J.K. Rowling",
 			},
 		},
+		{
+			name: "error recording event",
+			input: &dto.CreateAuthorRequest{
+				Name: "J.K. Rowling",
+			},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&entity.Author{
+						ID:   uuid.New(),
+						Name: "J.K. Rowling",
+					}, nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(errors.New("database connection failed"))
+			},
+			expectedError: errors.New("database connection failed"),
+		},
 		{
 			name: "error duplicate author",
 			input: &dto.CreateAuthorRequest{
 				Name: "Duplicate Author",
 			},
-			configureMock: func(mockRepo *mocks.MockAuthorRepository) {
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
 				expectedEntity := &entity.Author{
 					Name: "Duplicate Author",
 				}
@@ -66,7 +126,7 @@ func TestAuthorService_CreateAuthor(t *testing.T) {
 			input: &dto.CreateAuthorRequest{
 				Name: "Test Author",
 			},
-			configureMock: func(mockRepo *mocks.MockAuthorRepository) {
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
 				expectedEntity := &entity.Author{
 					Name: "Test Author",
 				}
@@ -85,9 +145,11 @@ func TestAuthorService_CreateAuthor(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			t.Cleanup(ctrl.Finish)
 			authorRepoMock := mocks.NewMockAuthorRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+			indexMock := mocks.NewMockIndex(ctrl)
 
-			test.configureMock(authorRepoMock)
-			service := author.NewAuthorService(authorRepoMock, zerolog.Nop())
+			test.configureMock(authorRepoMock, outboxMock, indexMock)
+			service := author.NewAuthorService(authorRepoMock, mocks.NewMockBookLister(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, indexMock, zerolog.Nop())
 
 			result, err := service.CreateAuthor(context.Background(), test.input)
 
@@ -165,7 +227,7 @@ func TestAuthorService_GetAuthorByID(t *testing.T) {
 			authorRepoMock := mocks.NewMockAuthorRepository(ctrl)
 
 			test.configureMock(authorRepoMock)
-			service := author.NewAuthorService(authorRepoMock, zerolog.Nop())
+			service := author.NewAuthorService(authorRepoMock, mocks.NewMockBookLister(ctrl), mocks.NewMockManager(ctrl), mocks.NewMockOutbox(ctrl), mocks.NewMockIndex(ctrl), zerolog.Nop())
 
 			result, err := service.GetAuthorByID(context.Background(), test.authorID)
 
@@ -186,3 +248,282 @@ func TestAuthorService_GetAuthorByID(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorService_CreateAuthor_Profile(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.CreateAuthorRequest
+		configureMock func(*mocks.MockAuthorRepository, *mocks.MockOutbox, *mocks.MockIndex)
+		expectedError error
+	}{
+		{
+			name: "success normalizes the identifiers",
+			input: &dto.CreateAuthorRequest{
+				Name:      "Victor Hugo",
+				BirthDate: "1802-02-26",
+				ISNI:      "0000 0001 2120 0982",
+			},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, a *entity.Author) (*entity.Author, error) {
+						require.NotNil(t, a.ISNI)
+						assert.Equal(t, "0000000121200982", *a.ISNI)
+						assert.Nil(t, a.VIAFID)
+						assert.Equal(t, "1802-02-26", a.BirthDate.Format("2006-01-02"))
+						assert.Nil(t, a.DeathDate)
+						return a, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "error death before birth",
+			input: &dto.CreateAuthorRequest{
+				Name:      "Victor Hugo",
+				BirthDate: "1885-05-22",
+				DeathDate: "1802-02-26",
+			},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {},
+			expectedError: author.ErrDeathBeforeBirth,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			authorRepoMock := mocks.NewMockAuthorRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+			indexMock := mocks.NewMockIndex(ctrl)
+
+			test.configureMock(authorRepoMock, outboxMock, indexMock)
+			service := author.NewAuthorService(authorRepoMock, mocks.NewMockBookLister(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, indexMock, zerolog.Nop())
+
+			_, err := service.CreateAuthor(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthorService_UpdateAuthor(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	wikidataID := "Q535"
+	empty := ""
+	nationality := "FR"
+	name := "Victor Marie Hugo"
+	deathDate := "1802-02-26"
+
+	tests := []struct {
+		name             string
+		input            *dto.UpdateAuthorRequest
+		configureMock    func(*mocks.MockAuthorRepository, *mocks.MockBookLister, *mocks.MockOutbox, *mocks.MockIndex)
+		expectedResponse *entity.Author
+		expectedError    error
+	}{
+		{
+			name:  "success clears an identifier",
+			input: &dto.UpdateAuthorRequest{WikidataID: &empty, Nationality: &nationality},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockBooks *mocks.MockBookLister, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), authorID).
+					Return(&entity.Author{ID: authorID, Name: "Victor Hugo", WikidataID: &wikidataID}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &entity.Author{ID: authorID, Name: "Victor Hugo", Nationality: "FR"}).
+					DoAndReturn(func(_ context.Context, a *entity.Author) (*entity.Author, error) { return a, nil })
+
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), authorID).
+					Return(&entity.Author{ID: authorID, Name: "Victor Hugo", Nationality: "FR"}, nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.AuthorUpdated, events[0].Type)
+						return nil
+					})
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedResponse: &entity.Author{ID: authorID, Name: "Victor Hugo", Nationality: "FR"},
+		},
+		{
+			name:  "success reindexes the books after a rename",
+			input: &dto.UpdateAuthorRequest{Name: &name},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockBooks *mocks.MockBookLister, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				renamed := &entity.Author{ID: authorID, Name: name}
+				miserables := &entity.Book{ID: uuid.MustParse("1d3f5b7a-9c2e-4f6a-8b1d-3e5f7a9c2b4d"), Title: "Les Misérables", AuthorID: &authorID, Author: renamed}
+				contemplations := &entity.Book{ID: uuid.MustParse("6a8c0e2b-4d6f-4a1c-9e3b-5d7f9a1c3e5b"), Title: "Les Contemplations", AuthorID: &authorID, Author: renamed}
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), authorID).
+					Return(&entity.Author{ID: authorID, Name: "Victor Hugo"}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), renamed).
+					Return(renamed, nil)
+
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), authorID).
+					Return(renamed, nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), renamed).
+					Return(nil)
+
+				mockBooks.EXPECT().
+					GetByAuthorIDs(gomock.Any(), []uuid.UUID{authorID}).
+					Return([]*entity.Book{contemplations, miserables}, nil)
+
+				mockIndex.EXPECT().
+					IndexBook(gomock.Any(), contemplations).
+					Return(nil)
+
+				// an indexing failure does not fail the rename
+				mockIndex.EXPECT().
+					IndexBook(gomock.Any(), miserables).
+					Return(errors.New("index unavailable"))
+			},
+			expectedResponse: &entity.Author{ID: authorID, Name: name},
+		},
+		{
+			name:  "error death before the stored birth",
+			input: &dto.UpdateAuthorRequest{DeathDate: &deathDate},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockBooks *mocks.MockBookLister, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				birthDate := time.Date(1885, time.May, 22, 0, 0, 0, 0, time.UTC)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), authorID).
+					Return(&entity.Author{ID: authorID, Name: "Victor Hugo", BirthDate: &birthDate}, nil)
+			},
+			expectedError: author.ErrDeathBeforeBirth,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			authorRepoMock := mocks.NewMockAuthorRepository(ctrl)
+			bookListerMock := mocks.NewMockBookLister(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+			indexMock := mocks.NewMockIndex(ctrl)
+
+			test.configureMock(authorRepoMock, bookListerMock, outboxMock, indexMock)
+			service := author.NewAuthorService(authorRepoMock, bookListerMock, testutils.NewTransactionManager(ctrl), outboxMock, indexMock, zerolog.Nop())
+
+			result, err := service.UpdateAuthor(context.Background(), test.input, authorID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse.Name, result.Name)
+			assert.Equal(t, test.expectedResponse.Nationality, result.Nationality)
+		})
+	}
+}
+
+func TestAuthorService_AddAlias(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	alias := &entity.AuthorAlias{AuthorID: authorID, Name: "Richard Bachman", Kind: entity.AliasKindPenName}
+
+	tests := []struct {
+		name             string
+		input            *dto.CreateAliasRequest
+		configureMock    func(*mocks.MockAuthorRepository, *mocks.MockOutbox, *mocks.MockIndex)
+		expectedResponse *entity.AuthorAlias
+		expectedError    error
+	}{
+		{
+			name:  "success reindexes the author",
+			input: &dto.CreateAliasRequest{Name: "Richard Bachman", Kind: entity.AliasKindPenName},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				withAlias := &entity.Author{ID: authorID, Name: "Stephen King", Aliases: []*entity.AuthorAlias{alias}}
+
+				gomock.InOrder(
+					mockRepo.EXPECT().
+						LockByID(gomock.Any(), authorID).
+						Return(&entity.Author{ID: authorID, Name: "Stephen King"}, nil),
+					mockRepo.EXPECT().
+						CreateAlias(gomock.Any(), alias).
+						Return(alias, nil),
+					mockRepo.EXPECT().
+						GetByID(gomock.Any(), authorID).
+						Return(withAlias, nil),
+				)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockIndex.EXPECT().
+					IndexAuthor(gomock.Any(), withAlias).
+					Return(nil)
+			},
+			expectedResponse: alias,
+		},
+		{
+			name:  "error duplicate alias",
+			input: &dto.CreateAliasRequest{Name: "Richard Bachman", Kind: entity.AliasKindPenName},
+			configureMock: func(mockRepo *mocks.MockAuthorRepository, mockOutbox *mocks.MockOutbox, mockIndex *mocks.MockIndex) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), authorID).
+					Return(&entity.Author{ID: authorID}, nil)
+
+				mockRepo.EXPECT().
+					CreateAlias(gomock.Any(), alias).
+					Return(nil, author.ErrDuplicateAlias)
+			},
+			expectedError: author.ErrDuplicateAlias,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			authorRepoMock := mocks.NewMockAuthorRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+			indexMock := mocks.NewMockIndex(ctrl)
+
+			test.configureMock(authorRepoMock, outboxMock, indexMock)
+			service := author.NewAuthorService(authorRepoMock, mocks.NewMockBookLister(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, indexMock, zerolog.Nop())
+
+			result, err := service.AddAlias(context.Background(), test.input, authorID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package author
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"go-boilerplate-rest-api-chi/internal/author/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+var (
+	viafIDRegex     = regexp.MustCompile(`^[1-9][0-9]{0,21}$`)
+	wikidataIDRegex = regexp.MustCompile(`^Q[1-9][0-9]{0,18}$`)
+)
+
+// RegisterValidations registers the validation rules of the author module.
+func RegisterValidations(v *internalValidator.Validator) error {
+	if err := v.RegisterRules(
+		internalValidator.Rule{
+			Tag:  "isni",
+			Func: isISNI,
+			Messages: map[string]string{
+				"en": "{0} must be a valid ISNI",
+				"fr": "{0} doit être un ISNI valide",
+			},
+		},
+		internalValidator.Rule{
+			Tag:  "viaf_id",
+			Func: isVIAFID,
+			Messages: map[string]string{
+				"en": "{0} must be a VIAF identifier made of digits",
+				"fr": "{0} doit être un identifiant VIAF composé de chiffres",
+			},
+		},
+		internalValidator.Rule{
+			Tag:  "wikidata_id",
+			Func: isWikidataID,
+			Messages: map[string]string{
+				"en": "{0} must be a Wikidata item identifier such as Q535",
+				"fr": "{0} doit être un identifiant d'élément Wikidata tel que Q535",
+			},
+		},
+		internalValidator.Rule{
+			Tag: "iso3166_1_alpha2",
+			Messages: map[string]string{
+				"en": "{0} must be an ISO 3166-1 alpha-2 country code",
+				"fr": "{0} doit être un code pays ISO 3166-1 alpha-2",
+			},
+		},
+		internalValidator.Rule{
+			Tag: "author_update_not_empty",
+			Messages: map[string]string{
+				"en": "at least one field must be provided",
+				"fr": "au moins un champ doit être renseigné",
+			},
+		},
+	); err != nil {
+		return err
+	}
+
+	v.RegisterStructRules(internalValidator.StructRule{
+		Func:  validateUpdateAuthorRequest,
+		Types: []any{dto.UpdateAuthorRequest{}},
+	})
+
+	return nil
+}
+
+// NormalizeISNI strips the separators of an ISNI and upper cases its check
+// character.
+func NormalizeISNI(isni string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isni))
+}
+
+// isISNI accepts the 16 characters of an ISNI, the last one being an ISO 7064
+// MOD 11-2 check character, optionally separated by hyphens or spaces.
+func isISNI(fl validator.FieldLevel) bool {
+	isni := NormalizeISNI(fl.Field().String())
+	if len(isni) != 16 {
+		return false
+	}
+
+	total := 0
+	for _, c := range isni[:15] {
+		if c < '0' || c > '9' {
+			return false
+		}
+		total = (total + int(c-'0')) * 2
+	}
+
+	check := (12 - total%11) % 11
+	if check == 10 {
+		return isni[15] == 'X'
+	}
+
+	return int(isni[15]-'0') == check
+}
+
+func isVIAFID(fl validator.FieldLevel) bool {
+	return viafIDRegex.MatchString(fl.Field().String())
+}
+
+func isWikidataID(fl validator.FieldLevel) bool {
+	return wikidataIDRegex.MatchString(fl.Field().String())
+}
+
+func validateUpdateAuthorRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(dto.UpdateAuthorRequest)
+
+	if req.Name == nil && req.Biography == nil && req.BirthDate == nil && req.DeathDate == nil &&
+		req.Nationality == nil && req.ISNI == nil && req.VIAFID == nil && req.WikidataID == nil {
+		sl.ReportError(req, "", "", "author_update_not_empty", "")
+	}
+}
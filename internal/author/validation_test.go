@@ -0,0 +1,88 @@
+package author_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/author/dto"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestRegisterValidations(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    any
+		expected []response.ValidationErrorDetail
+	}{
+		{
+			name: "success create with a full profile",
+			input: &dto.CreateAuthorRequest{
+				Name:        "Victor Hugo",
+				BirthDate:   "1802-02-26",
+				DeathDate:   "1885-05-22",
+				Nationality: "FR",
+				ISNI:        "0000 0001 2120 0982",
+				VIAFID:      "9847974",
+				WikidataID:  "Q535",
+			},
+		},
+		{
+			name:  "success isni with a check character",
+			input: &dto.CreateAuthorRequest{Name: "Victor Hugo", ISNI: "0000-0001-2146-438X"},
+		},
+		{
+			name: "error invalid identifiers",
+			input: &dto.CreateAuthorRequest{
+				Name:        "Victor Hugo",
+				Nationality: "FRA",
+				ISNI:        "0000 0001 2120 0983",
+				VIAFID:      "VIAF9847974",
+				WikidataID:  "q535",
+			},
+			expected: []response.ValidationErrorDetail{
+				{Field: "nationality", Message: "nationality must be an ISO 3166-1 alpha-2 country code"},
+				{Field: "isni", Message: "isni must be a valid ISNI"},
+				{Field: "viaf_id", Message: "viaf_id must be a VIAF identifier made of digits"},
+				{Field: "wikidata_id", Message: "wikidata_id must be a Wikidata item identifier such as Q535"},
+			},
+		},
+		{
+			name:  "success update clears an identifier",
+			input: &dto.UpdateAuthorRequest{ISNI: new(string)},
+		},
+		{
+			name:  "error update without field",
+			input: &dto.UpdateAuthorRequest{},
+			expected: []response.ValidationErrorDetail{
+				{Field: "", Message: "at least one field must be provided"},
+			},
+		},
+		{
+			name:  "error unknown alias kind",
+			input: &dto.CreateAliasRequest{Name: "Richard Bachman", Kind: "nickname"},
+			expected: []response.ValidationErrorDetail{
+				{Field: "kind", Message: "kind must be one of [pen_name variant]"},
+			},
+		},
+	}
+
+	v := validator.New()
+	require.NoError(t, author.RegisterValidations(v))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.Struct(test.input)
+
+			if test.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, test.expected, v.FormatErrors(err, "en"))
+		})
+	}
+}
@@ -1,11 +1,66 @@
 package dto
 
+import (
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
 type CreateBookRequest struct {
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	AuthorID    string `json:"author_id" validate:"required"`
+	Title       string    `json:"title" validate:"required,trimmed"`
+	Description string    `json:"description" validate:"required,trimmed"`
+	AuthorID    uuid.UUID `json:"author_id" validate:"required" swaggertype:"string" format:"uuid"`
+	ImprintID   string    `json:"imprint_id,omitempty" validate:"omitempty,uuid_strict"`
+	GenreIDs    []string  `json:"genre_ids,omitempty" validate:"omitempty,unique,dive,uuid_strict"`
+	Tags        []string  `json:"tags,omitempty" validate:"omitempty,dive,required,trimmed,max=50"`
+	SeriesID    string    `json:"series_id,omitempty" validate:"omitempty,uuid_strict"`
+	// SeriesVolume places the book in the series, fractional volumes such as
+	// 2.5 sit between two others.
+	SeriesVolume *float64 `json:"series_volume,omitempty" validate:"required_with=SeriesID,omitnil,gte=0" example:"2.5"`
 }
 
+// UpdateBookRequest replaces the description of the book, the other fields
+// are only updated when provided.
 type UpdateBookRequest struct {
-	Description string `json:"description" validate:"required"`
+	Description string `json:"description" validate:"required,trimmed"`
+	// ImprintID moves the book to the imprint, an empty one removes it.
+	ImprintID *string `json:"imprint_id,omitempty" validate:"omitnil,omitzero,uuid_strict"`
+	// GenreIDs and Tags replace the genres and the tags of the book, an empty
+	// list removes them all.
+	GenreIDs *[]string `json:"genre_ids,omitempty" validate:"omitnil,unique,dive,uuid_strict"`
+	Tags     *[]string `json:"tags,omitempty" validate:"omitnil,dive,required,trimmed,max=50"`
+	// SeriesID moves the book to the series, an empty one takes it out of
+	// its series. A new series needs a volume.
+	SeriesID     *string  `json:"series_id,omitempty" validate:"omitnil,omitzero,uuid_strict"`
+	SeriesVolume *float64 `json:"series_volume,omitempty" validate:"omitnil,gte=0" example:"2.5"`
+}
+
+// The orders of the book list.
+const (
+	SortTitle  = "title"
+	SortRating = "rating"
+)
+
+// BookFilter narrows the books returned by the list and the export. Empty
+// fields are ignored.
+type BookFilter struct {
+	AuthorID string `json:"author_id" validate:"omitempty,uuid_strict"`
+	Title    string `json:"title"`
+	// GenreID also matches the books of the descendants of the genre.
+	GenreID string `json:"genre_id" validate:"omitempty,uuid_strict"`
+	Tag     string `json:"tag"`
+	// Sort orders the list, the export is always ordered by id.
+	Sort string `json:"sort" validate:"omitempty,oneof=title rating"`
+}
+
+// NewBookFilter reads the filter from the query string.
+func NewBookFilter(query url.Values) BookFilter {
+	return BookFilter{
+		AuthorID: strings.TrimSpace(query.Get("author_id")),
+		Title:    strings.TrimSpace(query.Get("title")),
+		GenreID:  strings.TrimSpace(query.Get("genre_id")),
+		Tag:      strings.TrimSpace(query.Get("tag")),
+		Sort:     strings.TrimSpace(query.Get("sort")),
+	}
 }
@@ -1,14 +1,49 @@
 package dto
 
 import (
+	"time"
+
 	"go-boilerplate-rest-api-chi/internal/author/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	genreDto "go-boilerplate-rest-api-chi/internal/genre/dto"
+	publisherDto "go-boilerplate-rest-api-chi/internal/publisher/dto"
+	seriesDto "go-boilerplate-rest-api-chi/internal/series/dto"
 )
 
 type BookResponse struct {
-	ID     string              `json:"id"`
-	Title  string              `json:"title"`
-	Author *dto.AuthorResponse `json:"author,omitempty"`
+	ID      string                        `json:"id"`
+	Title   string                        `json:"title"`
+	Author  *dto.AuthorResponse           `json:"author,omitempty"`
+	Imprint *publisherDto.ImprintResponse `json:"imprint,omitempty"`
+	Genres  []genreDto.GenreResponse      `json:"genres,omitempty"`
+	Tags    []string                      `json:"tags,omitempty"`
+	Series  *BookSeriesResponse           `json:"series,omitempty"`
+	Copies  *CopyCountsResponse           `json:"copies,omitempty"`
+	Rating  RatingResponse                `json:"rating"`
+}
+
+// CopyCountsResponse tells how many copies of the book the library owns and
+// how many of them can be checked out right now.
+type CopyCountsResponse struct {
+	Total     int64 `json:"total" example:"3"`
+	Available int64 `json:"available" example:"1"`
+}
+
+// BookSeriesResponse places the book in its series, with the previous and the
+// next books in reading order.
+type BookSeriesResponse struct {
+	ID       string                        `json:"id"`
+	Name     string                        `json:"name,omitempty"`
+	Volume   float64                       `json:"volume" example:"2.5"`
+	Previous *seriesDto.SeriesBookResponse `json:"previous,omitempty"`
+	Next     *seriesDto.SeriesBookResponse `json:"next,omitempty"`
+}
+
+// RatingResponse sums up the approved reviews of the book, the average is 0
+// until the first one.
+type RatingResponse struct {
+	Average float64 `json:"average" example:"4.25"`
+	Count   int64   `json:"count" example:"4"`
 }
 
 func ToBookResponse(book *entity.Book) *BookResponse {
@@ -21,11 +56,64 @@ func ToBookResponse(book *entity.Book) *BookResponse {
 		}
 	}
 
-	return &BookResponse{
+	response := &BookResponse{
 		ID:     book.ID.String(),
 		Title:  book.Title,
 		Author: author,
+		Rating: RatingResponse{
+			Average: book.RatingAverage,
+			Count:   book.RatingCount,
+		},
+	}
+
+	if book.Imprint != nil {
+		response.Imprint = publisherDto.ToImprintResponse(book.Imprint)
+	}
+
+	if len(book.Genres) > 0 {
+		response.Genres = genreDto.ToGenresResponse(book.Genres)
+	}
+
+	for _, tag := range book.Tags {
+		response.Tags = append(response.Tags, tag.Name)
+	}
+
+	if book.SeriesID != nil {
+		response.Series = toBookSeriesResponse(book)
+	}
+
+	if book.Availability != nil {
+		response.Copies = &CopyCountsResponse{
+			Total:     book.Availability.Total,
+			Available: book.Availability.Available,
+		}
+	}
+
+	return response
+}
+
+func toBookSeriesResponse(book *entity.Book) *BookSeriesResponse {
+	response := &BookSeriesResponse{
+		ID: book.SeriesID.String(),
+	}
+
+	if book.Series != nil {
+		response.Name = book.Series.Name
+	}
+
+	if book.SeriesVolume != nil {
+		response.Volume = *book.SeriesVolume
+	}
+
+	if book.Previous != nil {
+		response.Previous = seriesDto.ToSeriesBookResponse(book.Previous)
+	}
+
+	if book.Next != nil {
+		response.Next = seriesDto.ToSeriesBookResponse(book.Next)
 	}
+
+	return response
 }
 
 func ToBooksResponse(books []*entity.Book) []BookResponse {
@@ -35,3 +123,68 @@ func ToBooksResponse(books []*entity.Book) []BookResponse {
 	}
 	return responses
 }
+
+// GenreFacetResponse is the number of books matching the filters in the
+// genre, the books of its descendants included.
+type GenreFacetResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+	Count    int64  `json:"count" example:"12"`
+}
+
+func ToGenreFacetsResponse(facets []*entity.GenreFacet) []GenreFacetResponse {
+	responses := make([]GenreFacetResponse, len(facets))
+	for i, facet := range facets {
+		responses[i] = GenreFacetResponse{
+			ID:    facet.Genre.ID.String(),
+			Name:  facet.Genre.Name,
+			Count: facet.Count,
+		}
+
+		if facet.Genre.ParentID != nil {
+			responses[i].ParentID = facet.Genre.ParentID.String()
+		}
+	}
+	return responses
+}
+
+// BookExportResponse is the flat representation of a book written by the
+// export, every field is always present.
+type BookExportResponse struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AuthorID    string `json:"author_id"`
+	Author      string `json:"author"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func ToBookExportResponse(book *entity.Book) *BookExportResponse {
+	response := &BookExportResponse{
+		ID:          book.ID.String(),
+		Title:       book.Title,
+		Description: book.Description,
+		CreatedAt:   book.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   book.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+
+	if book.AuthorID != nil {
+		response.AuthorID = book.AuthorID.String()
+	}
+
+	if book.Author != nil {
+		response.Author = book.Author.Name
+	}
+
+	return response
+}
+
+// CSVHeader lists the export columns in the order of CSVRecord.
+var CSVHeader = []string{"id", "title", "description", "author_id", "author", "created_at", "updated_at"}
+
+// CSVRecord returns the export fields in the order of CSVHeader.
+func (b *BookExportResponse) CSVRecord() []string {
+	return []string{b.ID, b.Title, b.Description, b.AuthorID, b.Author, b.CreatedAt, b.UpdatedAt}
+}
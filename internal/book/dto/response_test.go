@@ -0,0 +1,44 @@
+package dto_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	seriesDto "go-boilerplate-rest-api-chi/internal/series/dto"
+)
+
+func TestToBookResponse_Series(t *testing.T) {
+	seriesID := uuid.MustParse("2b4d6f8a-1c3e-4a5b-8d7f-9e1a3c5b7d9f")
+	nextID := uuid.MustParse("0f1e2d3c-4b5a-4968-8776-a5b4c3d2e1f0")
+	one, twoAndAHalf := 1.0, 2.5
+
+	t.Run("first of its series", func(t *testing.T) {
+		book := &entity.Book{
+			ID:           uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+			Title:        "The Colour of Magic",
+			SeriesID:     &seriesID,
+			Series:       &entity.Series{ID: seriesID, Name: "Discworld"},
+			SeriesVolume: &one,
+			Next:         &entity.Book{ID: nextID, Title: "Troll Bridge", SeriesVolume: &twoAndAHalf},
+		}
+
+		response := dto.ToBookResponse(book)
+
+		assert.Equal(t, &dto.BookSeriesResponse{
+			ID:     seriesID.String(),
+			Name:   "Discworld",
+			Volume: 1,
+			Next:   &seriesDto.SeriesBookResponse{ID: nextID.String(), Title: "Troll Bridge", Volume: 2.5},
+		}, response.Series)
+	})
+
+	t.Run("outside of any series", func(t *testing.T) {
+		response := dto.ToBookResponse(&entity.Book{Title: "Les Misérables"})
+
+		assert.Nil(t, response.Series)
+	})
+}
@@ -3,7 +3,14 @@ package book
 import "errors"
 
 var (
-	ErrNotFound        = errors.New("book not found")
-	ErrDuplicate       = errors.New("book already exists")
-	ErrInvalidAuthorId = errors.New("invalid author ID")
+	ErrNotFound            = errors.New("book not found")
+	ErrDuplicate           = errors.New("book already exists")
+	ErrInvalidExportFormat = errors.New("invalid export format")
+	ErrHasLoans            = errors.New("book has loans")
+	ErrInvalidGenreID      = errors.New("invalid genre ID")
+	ErrInvalidSeriesID     = errors.New("invalid series ID")
+	ErrInvalidImprintID    = errors.New("invalid imprint ID")
+	ErrVolumeRequired      = errors.New("series volume is required")
+	ErrNotInSeries         = errors.New("book is not part of a series")
+	ErrVolumeTaken         = errors.New("volume is already taken in the series")
 )
@@ -0,0 +1,139 @@
+package book
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+)
+
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportJSON   = "json"
+)
+
+// exportContentTypes maps each export format to its media type.
+var exportContentTypes = map[string]string{
+	ExportCSV:    "text/csv; charset=utf-8",
+	ExportNDJSON: "application/x-ndjson",
+	ExportJSON:   "application/json",
+}
+
+// exportWriter encodes the exported books one at a time. Flush pushes the
+// buffered output to the underlying writer and Close terminates the document.
+type exportWriter interface {
+	Write(book *dto.BookExportResponse) error
+	Flush() error
+	Close() error
+}
+
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportCSV:
+		return &csvExportWriter{writer: csv.NewWriter(w)}, nil
+	case ExportNDJSON:
+		return &ndjsonExportWriter{encoder: json.NewEncoder(w)}, nil
+	case ExportJSON:
+		return &jsonExportWriter{w: w}, nil
+	default:
+		return nil, ErrInvalidExportFormat
+	}
+}
+
+// csvExportWriter writes the CSVHeader line before the first record, so an
+// empty export still describes its columns.
+type csvExportWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvExportWriter) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	return e.writer.Write(dto.CSVHeader)
+}
+
+func (e *csvExportWriter) Write(book *dto.BookExportResponse) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	return e.writer.Write(book.CSVRecord())
+}
+
+func (e *csvExportWriter) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvExportWriter) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	return e.Flush()
+}
+
+type ndjsonExportWriter struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonExportWriter) Write(book *dto.BookExportResponse) error {
+	return e.encoder.Encode(book)
+}
+
+func (e *ndjsonExportWriter) Flush() error {
+	return nil
+}
+
+func (e *ndjsonExportWriter) Close() error {
+	return nil
+}
+
+// jsonExportWriter writes a single JSON array, element by element.
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (e *jsonExportWriter) Write(book *dto.BookExportResponse) error {
+	separator := ","
+	if e.count == 0 {
+		separator = "["
+	}
+
+	data, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+
+	e.count++
+	return nil
+}
+
+func (e *jsonExportWriter) Flush() error {
+	return nil
+}
+
+func (e *jsonExportWriter) Close() error {
+	closing := "]\n"
+	if e.count == 0 {
+		closing = "[]\n"
+	}
+
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
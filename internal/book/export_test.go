@@ -0,0 +1,203 @@
+package book_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestBookHandler_ExportBooks(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+
+	batches := [][]*entity.Book{
+		{
+			{
+				ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+				Title:       "Les Misérables",
+				Description: "Jean Valjean, \"24601\"",
+				AuthorID:    &authorID,
+				Author:      &entity.Author{ID: authorID, Name: "Victor Hugo"},
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			},
+		},
+		{
+			{
+				ID:          uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1"),
+				Title:       "Untitled",
+				Description: "No author",
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			},
+		},
+	}
+
+	streamBatches := func(batches [][]*entity.Book) func(any, dto.BookFilter, func([]*entity.Book) error) error {
+		return func(_ any, _ dto.BookFilter, fn func([]*entity.Book) error) error {
+			for _, batch := range batches {
+				if err := fn(batch); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		configureMock       func(*mocks.MockBookService)
+		expectedStatusCode  int
+		expectedContentType string
+		expectedBody        string
+		expectedJSON        bool
+	}{
+		{
+			name:  "success export csv",
+			query: "?format=csv",
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), dto.BookFilter{}, gomock.Any()).
+					DoAndReturn(streamBatches(batches))
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "id,title,description,author_id,author,created_at,updated_at\n" +
+				"a1b2c3d4-e5f6-7890-1234-56789abcdef0,Les Misérables,\"Jean Valjean, \"\"24601\"\"\",eb21d07a-7ab3-40db-bfd3-448093bc5626,Victor Hugo,2024-03-01T10:00:00Z,2024-03-01T10:00:00Z\n" +
+				"b1c2d3e4-f5a6-7890-1234-56789abcdef1,Untitled,No author,,,2024-03-01T10:00:00Z,2024-03-01T10:00:00Z\n",
+		},
+		{
+			name:  "success export ndjson with filter",
+			query: "?format=ndjson&author_id=eb21d07a-7ab3-40db-bfd3-448093bc5626",
+			configureMock: func(mockService *mocks.MockBookService) {
+				filter := dto.BookFilter{
+					AuthorID: "eb21d07a-7ab3-40db-bfd3-448093bc5626",
+				}
+
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), filter, gomock.Any()).
+					DoAndReturn(streamBatches(batches[:1]))
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody:        `{"id":"a1b2c3d4-e5f6-7890-1234-56789abcdef0","title":"Les Misérables","description":"Jean Valjean, \"24601\"","author_id":"eb21d07a-7ab3-40db-bfd3-448093bc5626","author":"Victor Hugo","created_at":"2024-03-01T10:00:00Z","updated_at":"2024-03-01T10:00:00Z"}` + "\n",
+		},
+		{
+			name:  "success export json by default",
+			query: "",
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), dto.BookFilter{}, gomock.Any()).
+					DoAndReturn(streamBatches(batches))
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody: `[
+				{"id":"a1b2c3d4-e5f6-7890-1234-56789abcdef0","title":"Les Misérables","description":"Jean Valjean, \"24601\"","author_id":"eb21d07a-7ab3-40db-bfd3-448093bc5626","author":"Victor Hugo","created_at":"2024-03-01T10:00:00Z","updated_at":"2024-03-01T10:00:00Z"},
+				{"id":"b1c2d3e4-f5a6-7890-1234-56789abcdef1","title":"Untitled","description":"No author","author_id":"","author":"","created_at":"2024-03-01T10:00:00Z","updated_at":"2024-03-01T10:00:00Z"}
+			]`,
+			expectedJSON: true,
+		},
+		{
+			name:  "success export empty json",
+			query: "?format=json&title=nothing",
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), dto.BookFilter{Title: "nothing"}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "application/json",
+			expectedBody:        "[]\n",
+		},
+		{
+			name:  "success export empty csv",
+			query: "?format=csv",
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), dto.BookFilter{}, gomock.Any()).
+					Return(nil)
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "id,title,description,author_id,author,created_at,updated_at\n",
+		},
+		{
+			name:               "error invalid format",
+			query:              "?format=xml",
+			configureMock:      func(mockService *mocks.MockBookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"status":"error","message":"Invalid export format, expected csv, ndjson or json"}`,
+			expectedJSON:       true,
+		},
+		{
+			name:               "error invalid filter",
+			query:              "?format=csv&author_id=not-a-uuid",
+			configureMock:      func(mockService *mocks.MockBookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"status":"error","message":"Validation failed","errors":[{"field":"author_id","message":"author_id must be a lowercase UUID"}]}`,
+			expectedJSON:       true,
+		},
+		{
+			name:  "error database failure before the first batch",
+			query: "?format=csv",
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					ExportBooks(gomock.Any(), dto.BookFilter{}, gomock.Any()).
+					Return(errors.New("database connection failed"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"status":"error","message":"Internal server error"}`,
+			expectedJSON:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			mockService := mocks.NewMockBookService(ctrl)
+			test.configureMock(mockService)
+
+			handler := book.NewBookHandler(mockService, validator.New(), zerolog.Nop())
+
+			// the export is routed outside of the book routes, away from the
+			// request timeout
+			r := chi.NewRouter()
+			r.Get("/books/export", handler.ExportBooks)
+
+			req := httptest.NewRequest(http.MethodGet, "/books/export"+test.query, nil)
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatusCode, rec.Code)
+
+			if test.expectedJSON {
+				assert.JSONEq(t, test.expectedBody, rec.Body.String())
+			} else {
+				assert.Equal(t, test.expectedBody, rec.Body.String())
+			}
+
+			if test.expectedContentType != "" {
+				assert.Equal(t, test.expectedContentType, rec.Header().Get("Content-Type"))
+				assert.Regexp(t, `^attachment; filename=books-\d{8}T\d{6}Z\.`, rec.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
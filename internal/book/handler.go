@@ -1,9 +1,11 @@
 package book
 
 import (
-	"encoding/json"
 	"errors"
+	"fmt"
+	"mime"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -11,7 +13,13 @@ import (
 
 	"go-boilerplate-rest-api-chi/internal/author"
 	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	"go-boilerplate-rest-api-chi/internal/request"
 	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/series"
 	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
 )
 
@@ -25,6 +33,14 @@ type BooksSuccessResponse struct {
 	Status  string             `json:"status" example:"success"`
 	Message string             `json:"message" example:"Books retrieved successfully"`
 	Books   []dto.BookResponse `json:"books"`
+	// NextCursor fetches the next page, it is missing on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type GenreFacetsSuccessResponse struct {
+	Status  string                   `json:"status" example:"success"`
+	Message string                   `json:"message" example:"Genre facets retrieved successfully"`
+	Facets  []dto.GenreFacetResponse `json:"facets"`
 }
 
 type BookHandler struct {
@@ -47,8 +63,10 @@ func (h *BookHandler) Routes() http.Handler {
 	// routes
 	r.Post("/", h.CreateBook)
 	r.Get("/", h.GetAllBooks)
+	r.Get("/facets", h.GetGenreFacets)
 	r.Get("/{book_id}", h.GetBookByID)
 	r.Put("/{book_id}", h.UpdateBook)
+	r.Delete("/{book_id}", h.DeleteBook)
 	r.Get("/secure", h.AuthTestRoute)
 
 	return r
@@ -61,22 +79,28 @@ func (h *BookHandler) Routes() http.Handler {
 //	@Tags			books
 //	@Accept			json
 //	@Produce		json
-//	@Param			book	body		dto.CreateBookRequest	true	"Book data"
-//	@Success		201		{object}	BookSuccessResponse
-//	@Failure		400		{object}	response.ValidationErrorResponse
-//	@Failure		409		{object}	response.ErrorResponse
-//	@Failure		500		{object}	response.ErrorResponse
+//	@Param			book			body		dto.CreateBookRequest	true	"Book data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	BookSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		422				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
 //	@Router			/books [post]
 func (h *BookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateBookRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "Invalid request body")
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
 		return
 	}
 
 	if err := h.validator.Struct(&req); err != nil {
-		validationErrors := h.validator.FormatErrors(err)
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
 		response.ValidationError(w, validationErrors)
 		return
 	}
@@ -97,24 +121,175 @@ func (h *BookHandler) CreateBook(w http.ResponseWriter, r *http.Request) {
 // GetAllBooks godoc
 //
 //	@Summary		Get all books
-//	@Description	Get a list of all books
+//	@Description	Get a page of the books, optionally filtered. The next_cursor of the response fetches the next page, it is missing on the last page.
 //	@Tags			books
 //	@Produce		json
-//	@Success		200	{object}	BooksSuccessResponse
-//	@Failure		404	{object}	response.ErrorResponse
-//	@Failure		500	{object}	response.ErrorResponse
+//	@Param			author_id		query		string	false	"Only books of this author"
+//	@Param			title			query		string	false	"Only books whose title contains this text"
+//	@Param			genre_id		query		string	false	"Only books of this genre or of its descendants"
+//	@Param			tag				query		string	false	"Only books with this tag"
+//	@Param			sort			query		string	false	"Order of the books, the best rated first for rating"	Enums(title, rating)
+//	@Param			limit			query		int		false	"Maximum number of books of the page (default 50, max 100)"
+//	@Param			cursor			query		string	false	"Cursor of the page, the next_cursor of the previous page"
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	BooksSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
 //	@Router			/books [get]
 func (h *BookHandler) GetAllBooks(w http.ResponseWriter, r *http.Request) {
-	books, err := h.service.GetAllBooks(r.Context())
+	filter := dto.NewBookFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	page, err := pagination.NewPage(r.URL.Query())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	books, next, err := h.service.GetAllBooks(r.Context(), filter, page)
 	if err != nil {
 		h.handleError(w, err)
 		return
 	}
 
 	response.JSON(w, http.StatusOK, BooksSuccessResponse{
+		Status:     "success",
+		Message:    "Books retrieved successfully",
+		Books:      dto.ToBooksResponse(books),
+		NextCursor: next,
+	})
+}
+
+// ExportBooks godoc
+//
+//	@Summary		Export books
+//	@Description	Stream every book matching the filters as a downloadable CSV, NDJSON or JSON file. Rows are read from the database in batches.
+//	@Tags			books
+//	@Produce		text/csv
+//	@Produce		application/x-ndjson
+//	@Produce		json
+//	@Param			format			query		string	false	"Export format"	Enums(csv, ndjson, json)	default(json)
+//	@Param			author_id		query		string	false	"Only books of this author"
+//	@Param			title			query		string	false	"Only books whose title contains this text"
+//	@Param			genre_id		query		string	false	"Only books of this genre or of its descendants"
+//	@Param			tag				query		string	false	"Only books with this tag"
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{array}		dto.BookExportResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/books/export [get]
+func (h *BookHandler) ExportBooks(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ExportJSON
+	}
+
+	writer, err := newExportWriter(format, w)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	filter := dto.NewBookFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	// The status line is only sent with the first batch, so a failure before
+	// any row was read can still be reported as a regular error response.
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+
+		filename := fmt.Sprintf("books-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+		w.Header().Set("Content-Type", exportContentTypes[format])
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	controller := http.NewResponseController(w)
+
+	err = h.service.ExportBooks(r.Context(), filter, func(books []*entity.Book) error {
+		start()
+
+		for _, book := range books {
+			if err := writer.Write(dto.ToBookExportResponse(book)); err != nil {
+				return err
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		if err := controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		if !started {
+			h.handleError(w, err)
+			return
+		}
+
+		// The response is already committed, the client sees a truncated file.
+		h.logger.Error().Err(err).Msg("export interrupted")
+		return
+	}
+
+	start()
+
+	if err := writer.Close(); err != nil {
+		h.logger.Error().Err(err).Msg("failed to terminate export")
+	}
+}
+
+// GetGenreFacets godoc
+//
+//	@Summary		Get genre facets
+//	@Description	Count the books matching the filters in each genre, the books of the descendants included, the largest genres first. The genres without books are left out.
+//	@Tags			books
+//	@Produce		json
+//	@Param			author_id		query		string	false	"Only books of this author"
+//	@Param			title			query		string	false	"Only books whose title contains this text"
+//	@Param			genre_id		query		string	false	"Only books of this genre or of its descendants"
+//	@Param			tag				query		string	false	"Only books with this tag"
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	GenreFacetsSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/books/facets [get]
+func (h *BookHandler) GetGenreFacets(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewBookFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	facets, err := h.service.GetGenreFacets(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, GenreFacetsSuccessResponse{
 		Status:  "success",
-		Message: "Books retrieved successfully",
-		Books:   dto.ToBooksResponse(books),
+		Message: "Genre facets retrieved successfully",
+		Facets:  dto.ToGenreFacetsResponse(facets),
 	})
 }
 
@@ -140,6 +315,7 @@ func (h *BookHandler) GetBookByID(w http.ResponseWriter, r *http.Request) {
 	book, err := h.service.GetBookByID(r.Context(), bookID)
 	if err != nil {
 		h.handleError(w, err)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, BookSuccessResponse{
@@ -156,12 +332,16 @@ func (h *BookHandler) GetBookByID(w http.ResponseWriter, r *http.Request) {
 //	@Tags			books
 //	@Accept			json
 //	@Produce		json
-//	@Param			book_id	path		string					true	"Book ID"
-//	@Param			book	body		dto.UpdateBookRequest	true	"Book data"
-//	@Success		200		{object}	BookSuccessResponse
-//	@Failure		400		{object}	response.ValidationErrorResponse
-//	@Failure		404		{object}	response.ErrorResponse
-//	@Failure		500		{object}	response.ErrorResponse
+//	@Param			book_id			path		string					true	"Book ID"
+//	@Param			book			body		dto.UpdateBookRequest	true	"Book data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	BookSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
 //	@Router			/books/{book_id} [put]
 func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 	bookID, err := uuid.Parse(chi.URLParam(r, "book_id"))
@@ -171,13 +351,13 @@ func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.UpdateBookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.Error(w, http.StatusBadRequest, "Invalid request body")
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
 		return
 	}
 
 	if err := h.validator.Struct(&req); err != nil {
-		validationErrors := h.validator.FormatErrors(err)
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
 		response.ValidationError(w, validationErrors)
 		return
 	}
@@ -203,7 +383,9 @@ func (h *BookHandler) UpdateBook(w http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			book_id	path		string	true	"Book ID"
 //	@Success		200		{object}	response.SuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
 //	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
 //	@Failure		500		{object}	response.ErrorResponse
 //	@Router			/books/{book_id} [delete]
 func (h *BookHandler) DeleteBook(w http.ResponseWriter, r *http.Request) {
@@ -241,10 +423,34 @@ func (h *BookHandler) handleError(w http.ResponseWriter, err error) {
 		response.Error(w, http.StatusNotFound, "Book not found")
 	case errors.Is(err, ErrDuplicate):
 		response.Error(w, http.StatusConflict, "Book with this name already exists")
-	case errors.Is(err, ErrInvalidAuthorId):
-		response.Error(w, http.StatusBadRequest, "invalid author ID")
 	case errors.Is(err, author.ErrNotFound):
 		response.Error(w, http.StatusNotFound, "Author not found")
+	case errors.Is(err, ErrInvalidGenreID):
+		response.Error(w, http.StatusBadRequest, "invalid genre ID")
+	case errors.Is(err, genre.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Genre not found")
+	case errors.Is(err, ErrInvalidImprintID):
+		response.Error(w, http.StatusBadRequest, "invalid imprint ID")
+	case errors.Is(err, publisher.ErrImprintNotFound):
+		response.Error(w, http.StatusNotFound, "Imprint not found")
+	case errors.Is(err, ErrInvalidSeriesID):
+		response.Error(w, http.StatusBadRequest, "invalid series ID")
+	case errors.Is(err, series.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Series not found")
+	case errors.Is(err, ErrVolumeRequired):
+		response.Error(w, http.StatusBadRequest, "Series volume is required")
+	case errors.Is(err, ErrNotInSeries):
+		response.Error(w, http.StatusBadRequest, "Book is not part of a series")
+	case errors.Is(err, ErrVolumeTaken):
+		response.Error(w, http.StatusConflict, "Volume is already taken in this series")
+	case errors.Is(err, ErrHasLoans):
+		response.Error(w, http.StatusConflict, "Book has loans and cannot be deleted")
+	case errors.Is(err, ErrInvalidExportFormat):
+		response.Error(w, http.StatusBadRequest, "Invalid export format, expected csv, ndjson or json")
+	case errors.Is(err, pagination.ErrInvalidLimit):
+		response.Error(w, http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", pagination.MaxLimit))
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		response.Error(w, http.StatusBadRequest, "Invalid cursor")
 	default:
 		h.logger.Error().Err(err).Msg("unexpected error")
 		response.Error(w, http.StatusInternalServerError, "Internal server error")
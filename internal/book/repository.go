@@ -3,19 +3,38 @@ package book
 import (
 	"context"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"go-boilerplate-rest-api-chi/internal/book/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/transaction"
 )
 
 //go:generate mockgen -destination=../mocks/mock_book_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/book BookRepository
 type BookRepository interface {
 	Create(ctx context.Context, book *entity.Book) (*entity.Book, error)
-	GetAll(ctx context.Context) ([]*entity.Book, error)
+	// GetAll returns a page of the filtered books and the cursor of the next
+	// page, empty on the last page.
+	GetAll(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error)
+	Stream(ctx context.Context, filter dto.BookFilter, batchSize int, fn func(books []*entity.Book) error) error
 	GetByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error)
+	GetByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error)
+	LockByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error)
+	// CountCopies sums up the copies of each book in a single query, the
+	// books without copies are missing from the result.
+	CountCopies(ctx context.Context, bookIDs []uuid.UUID) (map[uuid.UUID]entity.CopyCounts, error)
+	// CountByGenre counts the books matching the filter in each genre, the
+	// books of the descendants included. The genres without books are
+	// missing from the result.
+	CountByGenre(ctx context.Context, filter dto.BookFilter) (map[uuid.UUID]int64, error)
+	ReplaceGenres(ctx context.Context, book *entity.Book, genres []*entity.Genre) error
+	ReplaceTags(ctx context.Context, book *entity.Book, tags []*entity.Tag) error
 	Update(ctx context.Context, book *entity.Book) (*entity.Book, error)
 	Delete(ctx context.Context, bookID uuid.UUID) error
 }
@@ -33,7 +52,7 @@ func NewBookRepository(db *gorm.DB, logger zerolog.Logger) BookRepository {
 }
 
 func (r *bookRepository) Create(ctx context.Context, newBook *entity.Book) (*entity.Book, error) {
-	if err := r.db.WithContext(ctx).Create(newBook).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Create(newBook).Error; err != nil {
 		if errors.Is(err, gorm.ErrDuplicatedKey) {
 			r.logger.Error().Err(err).Msg("record already exist in database")
 			return nil, ErrDuplicate
@@ -46,21 +65,109 @@ func (r *bookRepository) Create(ctx context.Context, newBook *entity.Book) (*ent
 	return newBook, nil
 }
 
-func (r *bookRepository) GetAll(ctx context.Context) ([]*entity.Book, error) {
-	var books []*entity.Book
+// bookOrders are the orders of the list by sort, each ends with the id so
+// that the books can be paged through.
+var bookOrders = map[string]pagination.Order{
+	"":            {{Column: "books.id"}},
+	dto.SortTitle: {{Column: "books.title"}, {Column: "books.id"}},
+	// the best rated first, the most reviewed first among equals
+	dto.SortRating: {
+		{Column: "books.rating_average", Desc: true},
+		{Column: "books.rating_count", Desc: true},
+		{Column: "books.title"},
+		{Column: "books.id"},
+	},
+}
+
+// bookPosition returns the values of book for each key of the order of sort.
+func bookPosition(sort string) func(book *entity.Book) []any {
+	return func(book *entity.Book) []any {
+		switch sort {
+		case dto.SortTitle:
+			return []any{book.Title, book.ID}
+		case dto.SortRating:
+			return []any{book.RatingAverage, book.RatingCount, book.Title, book.ID}
+		default:
+			return []any{book.ID}
+		}
+	}
+}
+
+func (r *bookRepository) GetAll(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error) {
+	query := r.withTaxonomy(r.filtered(ctx, filter).Preload("Author").Preload("Imprint.Publisher"))
+
+	books, next, err := pagination.Find(query, bookOrders[filter.Sort], page, bookPosition(filter.Sort))
+	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			return nil, "", err
+		}
 
-	if err := r.db.WithContext(ctx).Preload("Author").Find(&books).Error; err != nil {
 		r.logger.Error().Err(err).Msg("error when retreive books on database ")
+		return nil, "", err
+	}
+
+	return books, next, nil
+}
+
+// Stream reads the filtered books in batches of batchSize, ordered by id, and
+// hands each batch to fn. Only one batch is held in memory at a time.
+func (r *bookRepository) Stream(ctx context.Context, filter dto.BookFilter, batchSize int, fn func(books []*entity.Book) error) error {
+	var books []*entity.Book
+
+	result := r.filtered(ctx, filter).Preload("Author").FindInBatches(&books, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(books)
+	})
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Msg("error when streaming books from database")
+		return result.Error
+	}
+
+	return nil
+}
+
+func (r *bookRepository) GetByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error) {
+	var book *entity.Book
+
+	if err := r.withTaxonomy(transaction.DB(ctx, r.db).Preload("Author").Preload("Imprint.Publisher")).First(&book, "id = ?", bookID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// GetByAuthorIDs reads the books of all the given authors in a single query.
+func (r *bookRepository) GetByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error) {
+	var books []*entity.Book
+
+	if len(authorIDs) == 0 {
+		return books, nil
+	}
+
+	err := transaction.DB(ctx, r.db).
+		Preload("Author").
+		Order("title").
+		Find(&books, "author_id IN ?", authorIDs).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("error when retreive books on database")
 		return nil, err
 	}
 
 	return books, nil
 }
 
-func (r *bookRepository) GetByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error) {
+// LockByID reads the book and locks its row until the end of the transaction
+// carried by ctx.
+func (r *bookRepository) LockByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error) {
 	var book *entity.Book
 
-	if err := r.db.WithContext(ctx).Preload("Author").First(&book, "id = ?", bookID).Error; err != nil {
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Preload("Author").
+		First(&book, "id = ?", bookID).Error
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrNotFound
 		}
@@ -70,8 +177,90 @@ func (r *bookRepository) GetByID(ctx context.Context, bookID uuid.UUID) (*entity
 	return book, nil
 }
 
+func (r *bookRepository) CountCopies(ctx context.Context, bookIDs []uuid.UUID) (map[uuid.UUID]entity.CopyCounts, error) {
+	counts := make(map[uuid.UUID]entity.CopyCounts, len(bookIDs))
+
+	if len(bookIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		BookID    uuid.UUID
+		Total     int64
+		Available int64
+	}
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Copy{}).
+		Select("book_id, COUNT(*) AS total, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS available", entity.CopyStatusAvailable).
+		Where("book_id IN ?", bookIDs).
+		Group("book_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("error when counting copies on database")
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.BookID] = entity.CopyCounts{Total: row.Total, Available: row.Available}
+	}
+
+	return counts, nil
+}
+
+func (r *bookRepository) CountByGenre(ctx context.Context, filter dto.BookFilter) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		GenreID uuid.UUID
+		Count   int64
+	}
+
+	err := r.filtered(ctx, filter).
+		Select("genre_closures.ancestor_id AS genre_id, COUNT(DISTINCT books.id) AS count").
+		Joins("JOIN book_genres ON book_genres.book_id = books.id").
+		Joins("JOIN genre_closures ON genre_closures.descendant_id = book_genres.genre_id").
+		Group("genre_closures.ancestor_id").
+		Scan(&rows).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("error when counting books by genre on database")
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.GenreID] = row.Count
+	}
+
+	return counts, nil
+}
+
+// ReplaceGenres assigns the genres to the book in place of its current ones,
+// the genres themselves are left untouched.
+func (r *bookRepository) ReplaceGenres(ctx context.Context, book *entity.Book, genres []*entity.Genre) error {
+	if err := transaction.DB(ctx, r.db).Model(book).Omit("Genres.*").Association("Genres").Replace(genres); err != nil {
+		r.logger.Error().Err(err).Msg("error when assigning genres on database")
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceTags assigns the tags to the book in place of its current ones, the
+// tags themselves are left untouched.
+func (r *bookRepository) ReplaceTags(ctx context.Context, book *entity.Book, tags []*entity.Tag) error {
+	if err := transaction.DB(ctx, r.db).Model(book).Omit("Tags.*").Association("Tags").Replace(tags); err != nil {
+		r.logger.Error().Err(err).Msg("error when assigning tags on database")
+		return err
+	}
+
+	return nil
+}
+
 func (r *bookRepository) Update(ctx context.Context, book *entity.Book) (*entity.Book, error) {
-	if err := r.db.WithContext(ctx).Save(book).Error; err != nil {
+	if err := transaction.DB(ctx, r.db).Save(book).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
 		return nil, err
 	}
 
@@ -79,11 +268,65 @@ func (r *bookRepository) Update(ctx context.Context, book *entity.Book) (*entity
 }
 
 func (r *bookRepository) Delete(ctx context.Context, bookID uuid.UUID) error {
-	result := r.db.WithContext(ctx).Where("id = ?", bookID).Delete(&entity.Book{})
+	result := transaction.DB(ctx, r.db).Where("id = ?", bookID).Delete(&entity.Book{})
 
 	if result.Error != nil {
+		// the loans keep the borrowing history, they are never deleted with
+		// their book
+		if errors.Is(result.Error, gorm.ErrForeignKeyViolated) {
+			return ErrHasLoans
+		}
+
 		return result.Error
 	}
 
 	return nil
 }
+
+// filtered returns a books query restricted by the filter.
+func (r *bookRepository) filtered(ctx context.Context, filter dto.BookFilter) *gorm.DB {
+	query := transaction.DB(ctx, r.db).Model(&entity.Book{})
+
+	if filter.AuthorID != "" {
+		query = query.Where("author_id = ?", filter.AuthorID)
+	}
+
+	if filter.Title != "" {
+		query = query.Where("title LIKE ? ESCAPE '!'", "%"+likeEscaper.Replace(filter.Title)+"%")
+	}
+
+	if filter.GenreID != "" {
+		// the genre closure holds the genre itself and all its descendants
+		inGenre := transaction.DB(ctx, r.db).
+			Table("book_genres").
+			Select("book_genres.book_id").
+			Joins("JOIN genre_closures ON genre_closures.descendant_id = book_genres.genre_id").
+			Where("genre_closures.ancestor_id = ?", filter.GenreID)
+
+		query = query.Where("books.id IN (?)", inGenre)
+	}
+
+	if filter.Tag != "" {
+		tagged := transaction.DB(ctx, r.db).
+			Table("book_tags").
+			Select("book_tags.book_id").
+			Joins("JOIN tags ON tags.id = book_tags.tag_id").
+			Where("tags.name = ?", strings.ToLower(filter.Tag))
+
+		query = query.Where("books.id IN (?)", tagged)
+	}
+
+	return query
+}
+
+// withTaxonomy preloads the genres and the tags of the books, ordered by
+// name.
+func (r *bookRepository) withTaxonomy(query *gorm.DB) *gorm.DB {
+	return query.
+		Preload("Genres", func(db *gorm.DB) *gorm.DB { return db.Order("genres.name") }).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB { return db.Order("tags.name") })
+}
+
+// likeEscaper escapes the LIKE wildcards of a user provided value, '!' is
+// used as escape character since it needs no quoting in any SQL dialect.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
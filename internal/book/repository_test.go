@@ -2,6 +2,8 @@ package book_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,7 +15,11 @@ import (
 	"gorm.io/gorm"
 
 	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/book/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/tag"
 	"go-boilerplate-rest-api-chi/internal/test-utils"
 )
 
@@ -39,6 +45,11 @@ func TestBookRepository_Create(t *testing.T) {
 						input.Title,
 						input.Description,
 						input.AuthorID,
+						input.ImprintID,
+						input.SeriesID,
+						input.SeriesVolume,
+						input.RatingAverage,
+						input.RatingCount,
 						sqlmock.AnyArg(), // CreatedAt
 						sqlmock.AnyArg(), // UpdatedAt
 					).WillReturnResult(sqlmock.NewResult(1, 1))
@@ -64,6 +75,11 @@ func TestBookRepository_Create(t *testing.T) {
 						input.Title,
 						input.Description,
 						input.AuthorID,
+						input.ImprintID,
+						input.SeriesID,
+						input.SeriesVolume,
+						input.RatingAverage,
+						input.RatingCount,
 						sqlmock.AnyArg(), // CreatedAt
 						sqlmock.AnyArg(), // UpdatedAt
 					).WillReturnError(gorm.ErrDuplicatedKey)
@@ -85,6 +101,11 @@ func TestBookRepository_Create(t *testing.T) {
 						input.Title,
 						input.Description,
 						input.AuthorID,
+						input.ImprintID,
+						input.SeriesID,
+						input.SeriesVolume,
+						input.RatingAverage,
+						input.RatingCount,
 						sqlmock.AnyArg(), // CreatedAt
 						sqlmock.AnyArg(), // UpdatedAt
 					).WillReturnError(gorm.ErrInvalidDB)
@@ -125,9 +146,20 @@ func TestBookRepository_Create(t *testing.T) {
 	}
 }
 
+// expectNoTaxonomy expects the preloads of the genres and the tags, which
+// find no assignment.
+func expectNoTaxonomy(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(`SELECT \* FROM .book_genres.`).
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "genre_id"}))
+	mock.ExpectQuery(`SELECT \* FROM .book_tags.`).
+		WillReturnRows(sqlmock.NewRows([]string{"book_id", "tag_id"}))
+}
+
 func TestBookRepository_GetAll(t *testing.T) {
 	tests := []struct {
 		name             string
+		filter           dto.BookFilter
+		page             pagination.Page
 		configureMock    func(sqlmock.Sqlmock)
 		expectedError    error
 		expectedResponse []*entity.Book
@@ -152,6 +184,8 @@ func TestBookRepository_GetAll(t *testing.T) {
 				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE .authors.\..id. = \?`).
 					WithArgs(authorID).
 					WillReturnRows(authorRows)
+
+				expectNoTaxonomy(mock)
 			},
 			expectedError: nil,
 			expectedResponse: []*entity.Book{
@@ -184,6 +218,87 @@ func TestBookRepository_GetAll(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "success get books with filter",
+			filter: dto.BookFilter{
+				AuthorID: "eb21d07a-7ab3-40db-bfd3-448093bc5626",
+				Title:    "100%_sure",
+			},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "author_id", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"), "Book One", "Description One", nil, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE author_id = \? AND title LIKE \? ESCAPE '!'`).
+					WithArgs("eb21d07a-7ab3-40db-bfd3-448093bc5626", "%100!%!_sure%").
+					WillReturnRows(rows)
+
+				expectNoTaxonomy(mock)
+			},
+			expectedError: nil,
+			expectedResponse: []*entity.Book{
+				{
+					ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+					Title:       "Book One",
+					Description: "Description One",
+				},
+			},
+		},
+		{
+			name:   "success get books sorted by rating",
+			filter: dto.BookFilter{Sort: dto.SortRating},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "rating_average", "rating_count", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"), "Book One", "Description One", 4.5, 2, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .books. ORDER BY books.rating_average DESC, books.rating_count DESC, books.title, books.id`).
+					WillReturnRows(rows)
+
+				expectNoTaxonomy(mock)
+			},
+			expectedError: nil,
+			expectedResponse: []*entity.Book{
+				{
+					ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+					Title:       "Book One",
+					Description: "Description One",
+				},
+			},
+		},
+		{
+			name: "success get a page of books",
+			page: pagination.Page{Limit: 1},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"), "Book One", "Description One", now, now).
+					AddRow(uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1"), "Book Two", "Description Two", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .books. ORDER BY books.id LIMIT \?`).
+					WithArgs(2).
+					WillReturnRows(rows)
+
+				expectNoTaxonomy(mock)
+			},
+			expectedError: nil,
+			expectedResponse: []*entity.Book{
+				{
+					ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+					Title:       "Book One",
+					Description: "Description One",
+				},
+			},
+		},
+		{
+			name:          "error invalid cursor",
+			page:          pagination.Page{Limit: 1, Cursor: "not-a-cursor"},
+			configureMock: func(mock sqlmock.Sqlmock) {},
+			expectedError: pagination.ErrInvalidCursor,
+		},
 		{
 			name: "error database connection failed",
 			configureMock: func(mock sqlmock.Sqlmock) {
@@ -202,7 +317,7 @@ func TestBookRepository_GetAll(t *testing.T) {
 
 			repo := book.NewBookRepository(db, zerolog.Nop())
 
-			books, err := repo.GetAll(context.Background())
+			books, _, err := repo.GetAll(context.Background(), test.filter, test.page)
 
 			if test.expectedError != nil {
 				assert.Error(t, err)
@@ -266,6 +381,7 @@ func TestBookRepository_GetByID(t *testing.T) {
 					WithArgs(authorID).
 					WillReturnRows(authorRows)
 
+				expectNoTaxonomy(mock)
 			},
 			expectedError: nil,
 			expectedResponse: &entity.Book{
@@ -336,3 +452,454 @@ func TestBookRepository_GetByID(t *testing.T) {
 		})
 	}
 }
+
+func TestBookRepository_GetByAuthorIDs(t *testing.T) {
+	authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+
+	tests := []struct {
+		name           string
+		authorIDs      []uuid.UUID
+		configureMock  func(sqlmock.Sqlmock)
+		expectedError  error
+		expectedTitles []string
+	}{
+		{
+			name:      "success get books by author ids",
+			authorIDs: []uuid.UUID{authorID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				booksRows := sqlmock.NewRows([]string{"id", "title", "description", "author_id", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"), "Book One", "Description One", authorID, now, now).
+					AddRow(uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1"), "Book Two", "Description Two", authorID, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE author_id IN \(\?\) ORDER BY title`).
+					WithArgs(authorID).
+					WillReturnRows(booksRows)
+
+				authorRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(authorID, "Victor Hugo", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE .authors.\..id. = \?`).
+					WithArgs(authorID).
+					WillReturnRows(authorRows)
+			},
+			expectedTitles: []string{"Book One", "Book Two"},
+		},
+		{
+			name:          "success no ids does not query",
+			authorIDs:     nil,
+			configureMock: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "error database connection failed",
+			authorIDs: []uuid.UUID{authorID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE author_id IN \(\?\)`).
+					WithArgs(authorID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := book.NewBookRepository(db, zerolog.Nop())
+
+			books, err := repo.GetByAuthorIDs(context.Background(), test.authorIDs)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, books)
+			} else {
+				require.NoError(t, err)
+
+				var titles []string
+				for _, b := range books {
+					titles = append(titles, b.Title)
+					assert.Equal(t, "Victor Hugo", b.Author.Name)
+				}
+				assert.Equal(t, test.expectedTitles, titles)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBookRepository_LockByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		bookId           uuid.UUID
+		configureMock    func(sqlmock.Sqlmock, uuid.UUID)
+		expectedError    error
+		expectedResponse *entity.Book
+	}{
+		{
+			name:   "success lock book by id",
+			bookId: uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				now := time.Now()
+
+				authorID := uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+
+				booksRows := sqlmock.NewRows([]string{"id", "title", "description", "author_id", "created_at", "updated_at"}).
+					AddRow(uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"), "Book One", "Description One", authorID, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE id = \? ORDER BY .books.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnRows(booksRows)
+
+				authorRows := sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+					AddRow(authorID, "Victor Hugo", now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .authors. WHERE .authors.\..id. = \?`).
+					WithArgs(authorID).
+					WillReturnRows(authorRows)
+
+			},
+			expectedError: nil,
+			expectedResponse: &entity.Book{
+				ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+				Title:       "Book One",
+				Description: "Description One",
+				AuthorID:    &[]uuid.UUID{uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")}[0],
+				Author: &entity.Author{
+					ID:   uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+					Name: "Victor Hugo",
+				},
+			},
+		},
+		{
+			name:   "error book not found",
+			bookId: uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE id = \? ORDER BY .books.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+
+			},
+			expectedError:    book.ErrNotFound,
+			expectedResponse: nil,
+		},
+		{
+			name:   "error database connection failed",
+			bookId: uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+			configureMock: func(mock sqlmock.Sqlmock, id uuid.UUID) {
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE id = \? ORDER BY .books.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(id, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError:    gorm.ErrInvalidDB,
+			expectedResponse: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.bookId)
+
+			repo := book.NewBookRepository(db, zerolog.Nop())
+
+			book, err := repo.LockByID(context.Background(), test.bookId)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if test.expectedResponse != nil {
+				assert.NotNil(t, book)
+				assert.Equal(t, test.expectedResponse.ID, book.ID)
+				assert.Equal(t, test.expectedResponse.Title, book.Title)
+				assert.Equal(t, test.expectedResponse.Description, book.Description)
+				assert.Equal(t, test.expectedResponse.AuthorID, book.AuthorID)
+				assert.Equal(t, test.expectedResponse.Author.ID, book.Author.ID)
+				assert.Equal(t, test.expectedResponse.Author.Name, book.Author.Name)
+			} else {
+				assert.Nil(t, book)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBookRepository_Stream(t *testing.T) {
+	tests := []struct {
+		name            string
+		batchSize       int
+		configureMock   func(sqlmock.Sqlmock)
+		fnError         error
+		expectedError   error
+		expectedBatches [][]string
+	}{
+		{
+			name:      "success stream books in batches",
+			batchSize: 2,
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .books. ORDER BY .books.\..id. LIMIT \?`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).
+						AddRow("a1b2c3d4-e5f6-7890-1234-56789abcdef0", "Book One", "Description One", now, now).
+						AddRow("b1c2d3e4-f5a6-7890-1234-56789abcdef1", "Book Two", "Description Two", now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE .books.\..id. > \? ORDER BY .books.\..id. LIMIT \?`).
+					WithArgs("b1c2d3e4-f5a6-7890-1234-56789abcdef1", 2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).
+						AddRow("c1d2e3f4-a5b6-7890-1234-56789abcdef2", "Book Three", "Description Three", now, now))
+			},
+			expectedBatches: [][]string{
+				{"Book One", "Book Two"},
+				{"Book Three"},
+			},
+		},
+		{
+			name:      "error returned by the batch callback",
+			batchSize: 2,
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .books. ORDER BY .books.\..id. LIMIT \?`).
+					WithArgs(2).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}).
+						AddRow("a1b2c3d4-e5f6-7890-1234-56789abcdef0", "Book One", "Description One", now, now).
+						AddRow("b1c2d3e4-f5a6-7890-1234-56789abcdef1", "Book Two", "Description Two", now, now))
+			},
+			fnError:       errors.New("client disconnected"),
+			expectedError: errors.New("client disconnected"),
+			expectedBatches: [][]string{
+				{"Book One", "Book Two"},
+			},
+		},
+		{
+			name:      "error database connection failed",
+			batchSize: 2,
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .books.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := book.NewBookRepository(db, zerolog.Nop())
+
+			var batches [][]string
+			err := repo.Stream(context.Background(), dto.BookFilter{}, test.batchSize, func(books []*entity.Book) error {
+				titles := make([]string, len(books))
+				for i, b := range books {
+					titles[i] = b.Title
+				}
+				batches = append(batches, titles)
+				return test.fnError
+			})
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedBatches, batches)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBookRepository_GetAllPages(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.Book{}, &entity.Genre{}, &entity.Tag{})
+	ctx := context.Background()
+
+	for _, b := range []*entity.Book{
+		{Title: "Les Misérables", Description: "Jean Valjean", RatingAverage: 4.5, RatingCount: 2},
+		{Title: "Les Contemplations", Description: "Poems", RatingAverage: 4.5, RatingCount: 2},
+		{Title: "Notre-Dame de Paris", Description: "Quasimodo", RatingAverage: 4.5, RatingCount: 7},
+		{Title: "Quatrevingt-treize", Description: "Revolution"},
+		{Title: "Hernani", Description: "Drama", RatingAverage: 3},
+	} {
+		require.NoError(t, db.Create(b).Error)
+	}
+
+	repo := book.NewBookRepository(db, zerolog.Nop())
+
+	tests := []struct {
+		name           string
+		filter         dto.BookFilter
+		expectedTitles []string
+	}{
+		{
+			name:   "by title",
+			filter: dto.BookFilter{Sort: dto.SortTitle},
+			expectedTitles: []string{
+				"Hernani", "Les Contemplations", "Les Misérables", "Notre-Dame de Paris", "Quatrevingt-treize",
+			},
+		},
+		{
+			name:   "by rating",
+			filter: dto.BookFilter{Sort: dto.SortRating},
+			expectedTitles: []string{
+				"Notre-Dame de Paris", "Les Contemplations", "Les Misérables", "Hernani", "Quatrevingt-treize",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				titles []string
+				page   = pagination.Page{Limit: 2}
+			)
+
+			for {
+				books, next, err := repo.GetAll(ctx, test.filter, page)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(books), 2)
+
+				for _, b := range books {
+					titles = append(titles, b.Title)
+				}
+
+				if next == "" {
+					break
+				}
+				page.Cursor = next
+			}
+
+			assert.Equal(t, test.expectedTitles, titles)
+		})
+	}
+
+	t.Run("refuses a cursor of another order", func(t *testing.T) {
+		_, next, err := repo.GetAll(ctx, dto.BookFilter{Sort: dto.SortTitle}, pagination.Page{Limit: 1})
+		require.NoError(t, err)
+
+		_, _, err = repo.GetAll(ctx, dto.BookFilter{Sort: dto.SortRating}, pagination.Page{Limit: 1, Cursor: next})
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
+
+func TestBookRepository_CountCopies(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.Book{}, &entity.Copy{})
+
+	lent := &entity.Book{Title: "Les Misérables", Description: "Jean Valjean"}
+	unowned := &entity.Book{Title: "Notre-Dame de Paris", Description: "Quasimodo"}
+	require.NoError(t, db.Create(lent).Error)
+	require.NoError(t, db.Create(unowned).Error)
+
+	for i, status := range []string{entity.CopyStatusAvailable, entity.CopyStatusOnLoan, entity.CopyStatusAvailable, entity.CopyStatusLost} {
+		require.NoError(t, db.Create(&entity.Copy{
+			BookID:    lent.ID,
+			Barcode:   fmt.Sprintf("LIB-%04d", i),
+			Condition: entity.CopyConditionGood,
+			Status:    status,
+		}).Error)
+	}
+
+	repo := book.NewBookRepository(db, zerolog.Nop())
+
+	counts, err := repo.CountCopies(context.Background(), []uuid.UUID{lent.ID, unowned.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]entity.CopyCounts{lent.ID: {Total: 4, Available: 2}}, counts)
+}
+
+func TestBookRepository_Taxonomy(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.Book{}, &entity.Genre{}, &entity.GenreClosure{}, &entity.Tag{})
+	ctx := context.Background()
+
+	genres := genre.NewGenreRepository(db, zerolog.Nop())
+	fiction, err := genres.Create(ctx, &entity.Genre{Name: "Fiction"})
+	require.NoError(t, err)
+	crime, err := genres.Create(ctx, &entity.Genre{Name: "Crime", ParentID: &fiction.ID})
+	require.NoError(t, err)
+	poetry, err := genres.Create(ctx, &entity.Genre{Name: "Poetry"})
+	require.NoError(t, err)
+
+	tags := tag.NewTagRepository(db, zerolog.Nop())
+	found, err := tags.FindOrCreate(ctx, []string{"classic", "paris"})
+	require.NoError(t, err)
+
+	repo := book.NewBookRepository(db, zerolog.Nop())
+
+	miserables := &entity.Book{Title: "Les Misérables", Description: "Jean Valjean"}
+	contemplations := &entity.Book{Title: "Les Contemplations", Description: "Poems"}
+	require.NoError(t, db.Create(miserables).Error)
+	require.NoError(t, db.Create(contemplations).Error)
+
+	require.NoError(t, repo.ReplaceGenres(ctx, miserables, []*entity.Genre{fiction, crime}))
+	require.NoError(t, repo.ReplaceGenres(ctx, contemplations, []*entity.Genre{poetry}))
+	require.NoError(t, repo.ReplaceTags(ctx, miserables, found))
+
+	t.Run("replace keeps the genres untouched", func(t *testing.T) {
+		var stored entity.Genre
+		require.NoError(t, db.First(&stored, "id = ?", crime.ID).Error)
+		assert.Equal(t, "Crime", stored.Name)
+		assert.Equal(t, &fiction.ID, stored.ParentID)
+	})
+
+	t.Run("preloads the genres and the tags", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, miserables.ID)
+		require.NoError(t, err)
+
+		require.Len(t, got.Genres, 2)
+		assert.Equal(t, "Crime", got.Genres[0].Name)
+		assert.Equal(t, "Fiction", got.Genres[1].Name)
+		require.Len(t, got.Tags, 2)
+		assert.Equal(t, "classic", got.Tags[0].Name)
+	})
+
+	t.Run("filters on the descendants of the genre", func(t *testing.T) {
+		got, _, err := repo.GetAll(ctx, dto.BookFilter{GenreID: fiction.ID.String()}, pagination.Page{})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, miserables.ID, got[0].ID)
+	})
+
+	t.Run("filters on the tag whatever its case", func(t *testing.T) {
+		got, _, err := repo.GetAll(ctx, dto.BookFilter{Tag: "Paris"}, pagination.Page{})
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, miserables.ID, got[0].ID)
+	})
+
+	t.Run("counts the books once per genre", func(t *testing.T) {
+		counts, err := repo.CountByGenre(ctx, dto.BookFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int64{fiction.ID: 1, crime.ID: 1, poetry.ID: 1}, counts)
+
+		counts, err = repo.CountByGenre(ctx, dto.BookFilter{Title: "Contemplations"})
+		require.NoError(t, err)
+		assert.Equal(t, map[uuid.UUID]int64{poetry.ID: 1}, counts)
+	})
+
+	t.Run("replace with nothing removes them all", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceGenres(ctx, contemplations, []*entity.Genre{}))
+		require.NoError(t, repo.ReplaceTags(ctx, miserables, nil))
+
+		got, err := repo.GetByID(ctx, contemplations.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Genres)
+
+		got, err = repo.GetByID(ctx, miserables.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Tags)
+		assert.Len(t, got.Genres, 2)
+	})
+}
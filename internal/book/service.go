@@ -2,6 +2,8 @@ package book
 
 import (
 	"context"
+	"slices"
+	"sort"
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
@@ -9,61 +11,156 @@ import (
 	"go-boilerplate-rest-api-chi/internal/author"
 	"go-boilerplate-rest-api-chi/internal/book/dto"
 	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	"go-boilerplate-rest-api-chi/internal/search"
+	"go-boilerplate-rest-api-chi/internal/series"
+	"go-boilerplate-rest-api-chi/internal/tag"
+	"go-boilerplate-rest-api-chi/internal/transaction"
 )
 
+// ExportBatchSize is the number of books read from the database at once
+// during an export.
+const ExportBatchSize = 500
+
 //go:generate mockgen -destination=../mocks/mock_book_service.go -package=mocks go-boilerplate-rest-api-chi/internal/book BookService
 type BookService interface {
 	CreateBook(ctx context.Context, req *dto.CreateBookRequest) (*entity.Book, error)
-	GetAllBooks(ctx context.Context) ([]*entity.Book, error)
+	// GetAllBooks returns a page of the filtered books and the cursor of the
+	// next page, empty on the last page. A zero page returns every book.
+	GetAllBooks(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error)
+	ExportBooks(ctx context.Context, filter dto.BookFilter, fn func(books []*entity.Book) error) error
 	GetBookByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error)
+	GetBooksByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error)
 	UpdateBook(ctx context.Context, req *dto.UpdateBookRequest, bookID uuid.UUID) (*entity.Book, error)
 	DeleteBook(ctx context.Context, bookID uuid.UUID) error
+	// GetGenreFacets counts the books matching the filter in each genre, the
+	// books of the descendants included, the largest genres first.
+	GetGenreFacets(ctx context.Context, filter dto.BookFilter) ([]*entity.GenreFacet, error)
 }
 
 type bookService struct {
-	repository       BookRepository
-	authorRepository author.AuthorRepository
-	logger           zerolog.Logger
+	repository          BookRepository
+	authorRepository    author.AuthorRepository
+	genreRepository     genre.GenreRepository
+	tagRepository       tag.TagRepository
+	seriesRepository    series.SeriesRepository
+	publisherRepository publisher.PublisherRepository
+	transactions        transaction.Manager
+	outbox              outbox.Outbox
+	index               search.Index
+	logger              zerolog.Logger
 }
 
-func NewBookService(repository BookRepository, authorRepository author.AuthorRepository, logger zerolog.Logger) BookService {
+func NewBookService(repository BookRepository, authorRepository author.AuthorRepository, genreRepository genre.GenreRepository, tagRepository tag.TagRepository, seriesRepository series.SeriesRepository, publisherRepository publisher.PublisherRepository, transactions transaction.Manager, outbox outbox.Outbox, index search.Index, logger zerolog.Logger) BookService {
 	return &bookService{
-		repository:       repository,
-		authorRepository: authorRepository,
-		logger:           logger,
+		repository:          repository,
+		authorRepository:    authorRepository,
+		genreRepository:     genreRepository,
+		tagRepository:       tagRepository,
+		seriesRepository:    seriesRepository,
+		publisherRepository: publisherRepository,
+		transactions:        transactions,
+		outbox:              outbox,
+		index:               index,
+		logger:              logger,
 	}
 }
 
 func (s *bookService) CreateBook(ctx context.Context, req *dto.CreateBookRequest) (*entity.Book, error) {
-	authorID, err := uuid.Parse(req.AuthorID)
-	if err != nil {
-		return nil, ErrInvalidAuthorId
-	}
+	var book *entity.Book
+
+	// the author stays locked until the book is committed, it cannot be
+	// deleted in between
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		bookAuthor, err := s.authorRepository.LockByID(ctx, req.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		newBook := NewBook(req, bookAuthor.ID)
+
+		var imprint *entity.Imprint
+		if req.ImprintID != "" {
+			imprint, err = s.lockImprint(ctx, req.ImprintID)
+			if err != nil {
+				return err
+			}
+			newBook.ImprintID = &imprint.ID
+		}
+
+		seriesID, err := parseSeriesID(req.SeriesID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.placeInSeries(ctx, newBook, seriesID, req.SeriesVolume); err != nil {
+			return err
+		}
+
+		book, err = s.repository.Create(ctx, newBook)
+		if err != nil {
+			return err
+		}
+
+		if len(req.GenreIDs) > 0 {
+			if err := s.setGenres(ctx, book, req.GenreIDs); err != nil {
+				return err
+			}
+		}
 
-	authorExist, err := s.authorRepository.GetByID(ctx, authorID)
+		if len(req.Tags) > 0 {
+			if err := s.setTags(ctx, book, req.Tags); err != nil {
+				return err
+			}
+		}
+
+		if err := s.linkSeries(ctx, book); err != nil {
+			return err
+		}
+
+		book.Author = bookAuthor
+		book.Imprint = imprint
+		book.Availability = &entity.CopyCounts{}
+		return s.outbox.Record(ctx, event.NewBookCreated(book))
+	})
 	if err != nil {
-		return nil, author.ErrNotFound
+		return nil, err
 	}
 
-	book := &entity.Book{
-		Title:       req.Title,
-		Description: req.Description,
-		AuthorID:    &authorExist.ID,
-	}
+	s.indexBook(ctx, book)
 
-	return s.repository.Create(ctx, book)
+	return book, nil
 }
 
-func (s *bookService) GetAllBooks(ctx context.Context) ([]*entity.Book, error) {
-	books, err := s.repository.GetAll(ctx)
+func (s *bookService) GetAllBooks(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error) {
+	books, next, err := s.repository.GetAll(ctx, filter, page)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if len(books) == 0 {
-		return nil, ErrNotFound
+		return nil, "", ErrNotFound
+	}
+
+	if err := s.countCopies(ctx, books...); err != nil {
+		return nil, "", err
 	}
-	return books, nil
+
+	if err := s.linkSeries(ctx, books...); err != nil {
+		return nil, "", err
+	}
+
+	return books, next, nil
+}
+
+// ExportBooks streams every book matching the filter to fn, batch by batch. An
+// empty catalogue is not an error, fn is simply never called.
+func (s *bookService) ExportBooks(ctx context.Context, filter dto.BookFilter, fn func(books []*entity.Book) error) error {
+	return s.repository.Stream(ctx, filter, ExportBatchSize, fn)
 }
 
 func (s *bookService) GetBookByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error) {
@@ -72,25 +169,364 @@ func (s *bookService) GetBookByID(ctx context.Context, bookID uuid.UUID) (*entit
 		return nil, err
 	}
 
+	if err := s.countCopies(ctx, book); err != nil {
+		return nil, err
+	}
+
+	if err := s.linkSeries(ctx, book); err != nil {
+		return nil, err
+	}
+
 	return book, nil
 }
 
+// GetBooksByAuthorIDs returns the books written by any of the given authors,
+// ordered by title. An author without books is not an error.
+func (s *bookService) GetBooksByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error) {
+	return s.repository.GetByAuthorIDs(ctx, authorIDs)
+}
+
 func (s *bookService) UpdateBook(ctx context.Context, req *dto.UpdateBookRequest, bookID uuid.UUID) (*entity.Book, error) {
-	book, err := s.repository.GetByID(ctx, bookID)
+	var book *entity.Book
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		book, err = s.repository.LockByID(ctx, bookID)
+		if err != nil {
+			return err
+		}
+
+		book.Description = req.Description
+
+		if req.ImprintID != nil {
+			book.ImprintID = nil
+
+			if *req.ImprintID != "" {
+				imprint, err := s.lockImprint(ctx, *req.ImprintID)
+				if err != nil {
+					return err
+				}
+				book.ImprintID = &imprint.ID
+			}
+		}
+
+		if req.SeriesID != nil || req.SeriesVolume != nil {
+			seriesID, volume := book.SeriesID, book.SeriesVolume
+
+			if req.SeriesID != nil {
+				newSeriesID, err := parseSeriesID(*req.SeriesID)
+				if err != nil {
+					return err
+				}
+
+				// the volume of the former series means nothing in the new one
+				if !sameSeries(seriesID, newSeriesID) {
+					volume = nil
+				}
+				seriesID = newSeriesID
+			}
+
+			if req.SeriesVolume != nil {
+				volume = req.SeriesVolume
+			}
+
+			if err := s.placeInSeries(ctx, book, seriesID, volume); err != nil {
+				return err
+			}
+		}
+
+		book, err = s.repository.Update(ctx, book)
+		if err != nil {
+			return err
+		}
+
+		if req.GenreIDs != nil {
+			if err := s.setGenres(ctx, book, *req.GenreIDs); err != nil {
+				return err
+			}
+		}
+
+		if req.Tags != nil {
+			if err := s.setTags(ctx, book, *req.Tags); err != nil {
+				return err
+			}
+		}
+
+		// reloaded for the genres and the tags, the lock does not read them
+		book, err = s.repository.GetByID(ctx, bookID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.countCopies(ctx, book); err != nil {
+			return err
+		}
+
+		if err := s.linkSeries(ctx, book); err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewBookUpdated(book))
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	book.Title = req.Description
+	s.indexBook(ctx, book)
 
-	return s.repository.Update(ctx, book)
+	return book, nil
 }
 
 func (s *bookService) DeleteBook(ctx context.Context, bookID uuid.UUID) error {
-	_, err := s.repository.GetByID(ctx, bookID)
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, bookID); err != nil {
+			return err
+		}
+
+		if err := s.repository.Delete(ctx, bookID); err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewBookDeleted(bookID))
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.index.RemoveBook(ctx, bookID); err != nil {
+		s.logger.Error().Err(err).Str("book_id", bookID.String()).Msg("failed to remove book from search index")
+	}
+
+	return nil
+}
+
+func (s *bookService) GetGenreFacets(ctx context.Context, filter dto.BookFilter) ([]*entity.GenreFacet, error) {
+	counts, err := s.repository.CountByGenre(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	genreIDs := make([]uuid.UUID, 0, len(counts))
+	for genreID := range counts {
+		genreIDs = append(genreIDs, genreID)
+	}
+
+	genres, err := s.genreRepository.GetByIDs(ctx, genreIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := make([]*entity.GenreFacet, len(genres))
+	for i, g := range genres {
+		facets[i] = &entity.GenreFacet{Genre: g, Count: counts[g.ID]}
+	}
+
+	// the genres come ordered by name, which breaks the ties
+	sort.SliceStable(facets, func(i, j int) bool {
+		return facets[i].Count > facets[j].Count
+	})
+
+	return facets, nil
+}
+
+// NewBook builds the book described by a validated creation request.
+func NewBook(req *dto.CreateBookRequest, authorID uuid.UUID) *entity.Book {
+	return &entity.Book{
+		Title:       req.Title,
+		Description: req.Description,
+		AuthorID:    &authorID,
+	}
+}
+
+// setGenres assigns the genres to the book in place of its current ones, they
+// must all exist.
+func (s *bookService) setGenres(ctx context.Context, book *entity.Book, values []string) error {
+	genreIDs := make([]uuid.UUID, len(values))
+	for i, value := range values {
+		genreID, err := uuid.Parse(value)
+		if err != nil {
+			return ErrInvalidGenreID
+		}
+		genreIDs[i] = genreID
+	}
+
+	genres, err := s.genreRepository.GetByIDs(ctx, genreIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(genres) != len(genreIDs) {
+		return genre.ErrNotFound
+	}
+
+	if err := s.repository.ReplaceGenres(ctx, book, genres); err != nil {
+		return err
+	}
+
+	book.Genres = genres
+	return nil
+}
+
+// setTags assigns the tags to the book in place of its current ones, the
+// unknown tags are created on the fly.
+func (s *bookService) setTags(ctx context.Context, book *entity.Book, names []string) error {
+	tags, err := s.tagRepository.FindOrCreate(ctx, tag.NormalizeAll(names))
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.ReplaceTags(ctx, book, tags); err != nil {
+		return err
+	}
+
+	book.Tags = tags
+	return nil
+}
+
+// lockImprint reads the imprint, which cannot be deleted until the book is
+// committed.
+func (s *bookService) lockImprint(ctx context.Context, value string) (*entity.Imprint, error) {
+	imprintID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, ErrInvalidImprintID
+	}
+
+	return s.publisherRepository.LockImprintByID(ctx, imprintID)
+}
+
+// placeInSeries sets the series and the volume of the book, or takes it out
+// of its series without one. The volume must be free in the series.
+func (s *bookService) placeInSeries(ctx context.Context, book *entity.Book, seriesID *uuid.UUID, volume *float64) error {
+	if seriesID == nil {
+		if volume != nil {
+			return ErrNotInSeries
+		}
+
+		book.SeriesID = nil
+		book.SeriesVolume = nil
+		return nil
+	}
+
+	if volume == nil {
+		return ErrVolumeRequired
+	}
+
+	// the series stays locked until the book is committed, no other book can
+	// take the volume in between
+	if _, err := s.seriesRepository.LockByID(ctx, *seriesID); err != nil {
+		return err
+	}
+
+	seriesList, err := s.seriesRepository.GetByIDs(ctx, []uuid.UUID{*seriesID})
 	if err != nil {
 		return err
 	}
 
-	return s.repository.Delete(ctx, bookID)
+	for _, bookSeries := range seriesList {
+		for _, other := range bookSeries.Books {
+			if other.ID != book.ID && other.SeriesVolume != nil && *other.SeriesVolume == *volume {
+				return ErrVolumeTaken
+			}
+		}
+	}
+
+	book.SeriesID = seriesID
+	book.SeriesVolume = volume
+	return nil
+}
+
+// linkSeries sets the series of the books, with their previous and next books
+// in reading order.
+func (s *bookService) linkSeries(ctx context.Context, books ...*entity.Book) error {
+	var seriesIDs []uuid.UUID
+	for _, book := range books {
+		if book.SeriesID != nil && !slices.Contains(seriesIDs, *book.SeriesID) {
+			seriesIDs = append(seriesIDs, *book.SeriesID)
+		}
+	}
+
+	if len(seriesIDs) == 0 {
+		return nil
+	}
+
+	seriesList, err := s.seriesRepository.GetByIDs(ctx, seriesIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*entity.Series, len(seriesList))
+	for _, bookSeries := range seriesList {
+		byID[bookSeries.ID] = bookSeries
+	}
+
+	for _, book := range books {
+		if book.SeriesID == nil {
+			continue
+		}
+
+		bookSeries, found := byID[*book.SeriesID]
+		if !found {
+			continue
+		}
+
+		book.Series = bookSeries
+
+		i := slices.IndexFunc(bookSeries.Books, func(b *entity.Book) bool { return b.ID == book.ID })
+		if i > 0 {
+			book.Previous = bookSeries.Books[i-1]
+		}
+		if i >= 0 && i < len(bookSeries.Books)-1 {
+			book.Next = bookSeries.Books[i+1]
+		}
+	}
+
+	return nil
+}
+
+// countCopies sets the availability of the books from their copies.
+func (s *bookService) countCopies(ctx context.Context, books ...*entity.Book) error {
+	bookIDs := make([]uuid.UUID, len(books))
+	for i, book := range books {
+		bookIDs[i] = book.ID
+	}
+
+	counts, err := s.repository.CountCopies(ctx, bookIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, book := range books {
+		availability := counts[book.ID]
+		book.Availability = &availability
+	}
+
+	return nil
+}
+
+func parseSeriesID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	seriesID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, ErrInvalidSeriesID
+	}
+
+	return &seriesID, nil
+}
+
+func sameSeries(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// indexBook keeps the search index in sync. Failures are logged but do not
+// fail the write, the database stays the source of truth.
+func (s *bookService) indexBook(ctx context.Context, book *entity.Book) {
+	if err := s.index.IndexBook(ctx, book); err != nil {
+		s.logger.Error().Err(err).Str("book_id", book.ID.String()).Msg("failed to index book")
+	}
 }
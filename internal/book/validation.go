@@ -0,0 +1,52 @@
+package book
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+// RegisterValidations registers the validation rules of the book module.
+func RegisterValidations(v *internalValidator.Validator) error {
+	if err := v.RegisterRules(internalValidator.Rule{
+		Tag: "volume_without_series",
+		Messages: map[string]string{
+			"en": "{0} requires a series_id",
+			"fr": "{0} nécessite un series_id",
+		},
+	}); err != nil {
+		return err
+	}
+
+	v.RegisterStructRules(
+		internalValidator.StructRule{
+			Func:  validateCreateBookRequest,
+			Types: []any{dto.CreateBookRequest{}},
+		},
+		internalValidator.StructRule{
+			Func:  validateUpdateBookRequest,
+			Types: []any{dto.UpdateBookRequest{}},
+		},
+	)
+
+	return nil
+}
+
+func validateCreateBookRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(dto.CreateBookRequest)
+
+	if req.SeriesVolume != nil && req.SeriesID == "" {
+		sl.ReportError(req.SeriesVolume, "series_volume", "SeriesVolume", "volume_without_series", "")
+	}
+}
+
+// validateUpdateBookRequest rejects a volume given while taking the book out
+// of its series, a volume alone moves the book within its current series.
+func validateUpdateBookRequest(sl validator.StructLevel) {
+	req := sl.Current().Interface().(dto.UpdateBookRequest)
+
+	if req.SeriesVolume != nil && req.SeriesID != nil && *req.SeriesID == "" {
+		sl.ReportError(req.SeriesVolume, "series_volume", "SeriesVolume", "volume_without_series", "")
+	}
+}
@@ -0,0 +1,97 @@
+package book_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestRegisterValidations(t *testing.T) {
+	volume := 2.5
+	noGenres := []string{}
+	noSeries := ""
+	invalidSeries := "not-a-uuid"
+	noImprint := ""
+
+	tests := []struct {
+		name     string
+		input    any
+		expected []response.ValidationErrorDetail
+	}{
+		{
+			name:  "success create in a series",
+			input: &dto.CreateBookRequest{Title: "Les Misérables", Description: "Fantine", AuthorID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"), SeriesID: "a1b2c3d4-e5f6-4890-9234-56789abcdef0", SeriesVolume: &volume},
+		},
+		{
+			name:  "error create with a volume without series",
+			input: &dto.CreateBookRequest{Title: "Les Misérables", Description: "Fantine", AuthorID: uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"), SeriesVolume: &volume},
+			expected: []response.ValidationErrorDetail{
+				{Field: "series_volume", Message: "series_volume requires a series_id"},
+			},
+		},
+		{
+			name:  "success update description",
+			input: &dto.UpdateBookRequest{Description: "Fantine"},
+		},
+		{
+			name:  "success remove all genres",
+			input: &dto.UpdateBookRequest{Description: "Fantine", GenreIDs: &noGenres},
+		},
+		{
+			name:  "success take out of its series",
+			input: &dto.UpdateBookRequest{Description: "Fantine", SeriesID: &noSeries},
+		},
+		{
+			name:  "success move within its series",
+			input: &dto.UpdateBookRequest{Description: "Fantine", SeriesVolume: &volume},
+		},
+		{
+			name:  "error take out of its series with a volume",
+			input: &dto.UpdateBookRequest{Description: "Fantine", SeriesID: &noSeries, SeriesVolume: &volume},
+			expected: []response.ValidationErrorDetail{
+				{Field: "series_volume", Message: "series_volume requires a series_id"},
+			},
+		},
+		{
+			name:  "error invalid series",
+			input: &dto.UpdateBookRequest{Description: "Fantine", SeriesID: &invalidSeries},
+			expected: []response.ValidationErrorDetail{
+				{Field: "series_id", Message: "series_id must be a lowercase UUID"},
+			},
+		},
+		{
+			name:  "success remove its imprint",
+			input: &dto.UpdateBookRequest{Description: "Fantine", ImprintID: &noImprint},
+		},
+		{
+			name:  "error missing description",
+			input: &dto.UpdateBookRequest{},
+			expected: []response.ValidationErrorDetail{
+				{Field: "description", Message: "description is required"},
+			},
+		},
+	}
+
+	v := validator.New()
+	require.NoError(t, book.RegisterValidations(v))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.Struct(test.input)
+
+			if test.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Equal(t, test.expected, v.FormatErrors(err, "en"))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"go-boilerplate-rest-api-chi/pkg/client"
+)
+
+const authorsUsage = `Usage:
+  bookctl authors get <id>
+  bookctl authors create -name name
+`
+
+func (a *App) runAuthors(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("authors")
+	fs.Usage = func() { fmt.Fprint(a.stderr, authorsUsage) }
+
+	if len(args) == 0 {
+		return a.usageError(fs, "missing authors command")
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "get":
+		return a.getAuthor(ctx, fs, args)
+	case "create":
+		return a.createAuthor(ctx, fs, args)
+	default:
+		return a.usageError(fs, "unknown authors command %q", command)
+	}
+}
+
+func (a *App) getAuthor(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	author, err := s.client.Authors.Get(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(author, func() table { return authorsTable(*author) })
+}
+
+func (a *App) createAuthor(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var req client.CreateAuthorRequest
+	fs.StringVar(&req.Name, "name", "", "name of the author")
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	author, err := s.client.Authors.Create(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(author, func() table { return authorsTable(*author) })
+}
+
+func authorsTable(authors ...client.Author) table {
+	t := table{header: []string{"ID", "NAME"}}
+
+	for _, author := range authors {
+		t.rows = append(t.rows, []string{author.ID, author.Name})
+	}
+
+	return t
+}
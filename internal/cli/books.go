@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"go-boilerplate-rest-api-chi/pkg/client"
+)
+
+const booksUsage = `Usage:
+  bookctl books list [-author id] [-title text]
+  bookctl books get <id>
+  bookctl books create -title title -description text -author id
+  bookctl books update <id> -description text
+  bookctl books delete <id>
+`
+
+func (a *App) runBooks(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("books")
+	fs.Usage = func() { fmt.Fprint(a.stderr, booksUsage) }
+
+	if len(args) == 0 {
+		return a.usageError(fs, "missing books command")
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "list":
+		return a.listBooks(ctx, fs, args)
+	case "get":
+		return a.getBook(ctx, fs, args)
+	case "create":
+		return a.createBook(ctx, fs, args)
+	case "update":
+		return a.updateBook(ctx, fs, args)
+	case "delete":
+		return a.deleteBook(ctx, fs, args)
+	default:
+		return a.usageError(fs, "unknown books command %q", command)
+	}
+}
+
+func (a *App) listBooks(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var filter client.BookFilter
+	fs.StringVar(&filter.AuthorID, "author", "", "only the books of this author id")
+	fs.StringVar(&filter.Title, "title", "", "only the books whose title contains this text")
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	books := []client.Book{}
+	for book, err := range s.client.Books.All(ctx, filter) {
+		if err != nil {
+			return err
+		}
+		books = append(books, *book)
+	}
+
+	return s.printer.print(books, func() table { return booksTable(books...) })
+}
+
+func (a *App) getBook(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	book, err := s.client.Books.Get(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(book, func() table { return booksTable(*book) })
+}
+
+func (a *App) createBook(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var req client.CreateBookRequest
+	fs.StringVar(&req.Title, "title", "", "title of the book")
+	fs.StringVar(&req.Description, "description", "", "description of the book")
+	fs.StringVar(&req.AuthorID, "author", "", "author id")
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	book, err := s.client.Books.Create(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(book, func() table { return booksTable(*book) })
+}
+
+func (a *App) updateBook(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	var req client.UpdateBookRequest
+	fs.StringVar(&req.Description, "description", "", "description of the book")
+
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	book, err := s.client.Books.Update(ctx, positional[0], req)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.print(book, func() table { return booksTable(*book) })
+}
+
+func (a *App) deleteBook(ctx context.Context, fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Books.Delete(ctx, positional[0]); err != nil {
+		return err
+	}
+
+	s.printer.message("Book %s deleted", positional[0])
+
+	return nil
+}
+
+func booksTable(books ...client.Book) table {
+	t := table{header: []string{"ID", "TITLE", "AUTHOR"}}
+
+	for _, book := range books {
+		author := ""
+		if book.Author != nil {
+			author = book.Author.Name
+		}
+
+		t.rows = append(t.rows, []string{book.ID, book.Title, author})
+	}
+
+	return t
+}
+
+// parseArgs parses the flags of a subcommand which takes exactly n
+// positional arguments.
+func parseArgs(a *App, fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	positional, err := parse(fs, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(positional) != n {
+		return nil, a.usageError(fs, "expected %d argument(s), got %d", n, len(positional))
+	}
+
+	return positional, nil
+}
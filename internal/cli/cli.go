@@ -0,0 +1,268 @@
+// Package cli implements bookctl, the command-line client administering the
+// catalogue through the REST API.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/pkg/client"
+)
+
+const usage = `bookctl administers the catalogue through the REST API.
+
+Usage:
+  bookctl [flags] <command> [arguments]
+
+Commands:
+  books list|get|create|update|delete   manage the books
+  authors get|create                    manage the authors
+  import <file>                         bulk import books from a CSV or NDJSON file
+  export                                export the books as CSV, NDJSON or JSON
+  login                                 store the access token of the profile
+  logout                                remove the access token of the profile
+  config list|set|use|delete            manage the profiles
+
+Flags, accepted by every command:
+  -config string    config file (default $BOOKCTL_CONFIG or <user config dir>/bookctl/config.yaml)
+  -profile string   profile to use (default the current profile)
+  -url string       API URL, overrides the profile
+  -token string     access token, overrides the profile and $BOOKCTL_TOKEN
+  -o string         output format: table, json or yaml
+
+Run "bookctl <command> -h" for the flags of a command.
+`
+
+// errUsage is a command line error, the usage was already printed.
+var errUsage = errors.New("usage")
+
+// globalFlags are accepted by every command.
+type globalFlags struct {
+	config  string
+	profile string
+	url     string
+	token   string
+	output  string
+}
+
+// App runs the commands.
+type App struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+
+	flags globalFlags
+}
+
+func NewApp(stdin io.Reader, stdout, stderr io.Writer, getenv func(string) string) *App {
+	return &App{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+		getenv: getenv,
+	}
+}
+
+// Run runs the command of args, without the program name, and returns the
+// exit code: 0 on success, 1 on failure and 2 on a command line error.
+func (a *App) Run(ctx context.Context, args []string) int {
+	err := a.run(ctx, args)
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		a.printError(err)
+		return 1
+	}
+}
+
+func (a *App) run(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("bookctl")
+	fs.Usage = func() { fmt.Fprint(a.stderr, usage) }
+
+	// the global flags before the command, the commands parse the others
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+
+	args = fs.Args()
+	if len(args) == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "books":
+		return a.runBooks(ctx, args)
+	case "authors":
+		return a.runAuthors(ctx, args)
+	case "import":
+		return a.runImport(ctx, args)
+	case "export":
+		return a.runExport(ctx, args)
+	case "login":
+		return a.runLogin(args)
+	case "logout":
+		return a.runLogout(args)
+	case "config":
+		return a.runConfig(args)
+	case "help":
+		fs.Usage()
+		return nil
+	default:
+		return a.usageError(fs, "unknown command %q", command)
+	}
+}
+
+// newFlagSet returns the flag set of a command, with the global flags.
+func (a *App) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	fs.StringVar(&a.flags.config, "config", a.flags.config, "config file")
+	fs.StringVar(&a.flags.profile, "profile", a.flags.profile, "profile to use")
+	fs.StringVar(&a.flags.url, "url", a.flags.url, "API URL, overrides the profile")
+	fs.StringVar(&a.flags.token, "token", a.flags.token, "access token, overrides the profile")
+	fs.StringVar(&a.flags.output, "o", a.flags.output, "output format: table, json or yaml")
+
+	return fs
+}
+
+// parse parses the flags of args, wherever they are, and returns the
+// positional arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, errUsage
+		}
+
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+
+		// flags stop at the first positional argument, resume after it
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func (a *App) usageError(fs *flag.FlagSet, format string, args ...any) error {
+	fmt.Fprintf(a.stderr, format+"\n\n", args...)
+	fs.Usage()
+	return errUsage
+}
+
+func (a *App) loadConfig() (*Config, error) {
+	path := a.flags.config
+	if path == "" {
+		var err error
+		if path, err = DefaultConfigPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	return LoadConfig(path)
+}
+
+// session is the client and the printer configured by the profile and the
+// global flags.
+type session struct {
+	client  *client.Client
+	printer *printer
+}
+
+func (a *App) newSession() (*session, error) {
+	config, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	_, profile, err := config.Profile(a.flags.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	url := firstNonEmpty(a.flags.url, profile.URL, DefaultURL)
+	token := firstNonEmpty(a.flags.token, a.getenv("BOOKCTL_TOKEN"), profile.Token)
+
+	opts := []client.Option{client.WithUserAgent("bookctl")}
+	if token != "" {
+		opts = append(opts, client.WithAuth(client.BearerToken(token)))
+	}
+	if profile.Language != "" {
+		opts = append(opts, client.WithLanguage(profile.Language))
+	}
+
+	c, err := client.New(url, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	printer, err := newPrinter(a.stdout, firstNonEmpty(a.flags.output, profile.Output))
+	if err != nil {
+		return nil, err
+	}
+
+	return &session{client: c, printer: printer}, nil
+}
+
+// printError writes err with the invalid fields of a validation error.
+func (a *App) printError(err error) {
+	fmt.Fprintf(a.stderr, "error: %s\n", errorMessage(err))
+
+	var validationErr *client.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, detail := range validationErr.Errors {
+			fmt.Fprintf(a.stderr, "  %s: %s\n", detail.Field, detail.Message)
+		}
+	}
+}
+
+func errorMessage(err error) string {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return fmt.Sprintf("%s (%d)", apiErr.Message, apiErr.StatusCode)
+	}
+
+	return err.Error()
+}
+
+// openInput opens the named file, the standard input for "-".
+func (a *App) openInput(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(a.stdin), nil
+	}
+
+	return os.Open(name)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
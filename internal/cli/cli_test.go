@@ -0,0 +1,203 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/cli"
+)
+
+const bookJSON = `{"id":"d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f","title":"1984","author":{"id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","name":"George Orwell"}}`
+
+type result struct {
+	code   int
+	stdout string
+	stderr string
+}
+
+// run runs bookctl with a config file in a temporary directory.
+func run(t *testing.T, configPath string, stdin string, env map[string]string, args ...string) result {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	app := cli.NewApp(strings.NewReader(stdin), &stdout, &stderr, func(key string) string { return env[key] })
+
+	code := app.Run(context.Background(), append([]string{"-config", configPath}, args...))
+
+	return result{code: code, stdout: stdout.String(), stderr: stderr.String()}
+}
+
+func newServer(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return server.URL + "/api"
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	fmt.Fprint(w, body)
+}
+
+func TestBooks(t *testing.T) {
+	t.Run("list as a table", func(t *testing.T) {
+		url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/books", r.URL.Path)
+			assert.Equal(t, "1984", r.URL.Query().Get("title"))
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Books retrieved successfully","books":[`+bookJSON+`]}`)
+		})
+
+		res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "books", "list", "-url", url, "-title", "1984")
+
+		require.Equal(t, 0, res.code, res.stderr)
+		assert.Equal(t, ""+
+			"ID                                     TITLE   AUTHOR\n"+
+			"d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f   1984    George Orwell\n", res.stdout)
+	})
+
+	t.Run("get as yaml", func(t *testing.T) {
+		url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Book retrieved successfully","book":`+bookJSON+`}`)
+		})
+
+		res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "-url", url, "-o", "yaml", "books", "get", "d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f")
+
+		require.Equal(t, 0, res.code, res.stderr)
+		assert.Equal(t, ""+
+			"id: d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f\n"+
+			"title: \"1984\"\n"+
+			"author:\n"+
+			"  id: aeca0955-bae4-47e9-9f85-6818dc68ca51\n"+
+			"  name: George Orwell\n", res.stdout)
+	})
+
+	t.Run("update sends the description", func(t *testing.T) {
+		url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPut, r.Method)
+
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"description":"A dystopian novel"}`, string(body))
+
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Book updated successfully","book":`+bookJSON+`}`)
+		})
+
+		res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "-url", url, "books", "update", "d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f", "-description", "A dystopian novel", "-o", "json")
+
+		require.Equal(t, 0, res.code, res.stderr)
+
+		var book map[string]any
+		require.NoError(t, json.Unmarshal([]byte(res.stdout), &book))
+		assert.Equal(t, "1984", book["title"])
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusBadRequest, `{"status":"error","message":"Validation failed","errors":[{"field":"title","message":"title is required"}]}`)
+		})
+
+		res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "-url", url, "books", "create", "-description", "Big Brother", "-author", "aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+		assert.Equal(t, 1, res.code)
+		assert.Equal(t, "error: Validation failed (400)\n  title: title is required\n", res.stderr)
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "books", "get")
+
+		assert.Equal(t, 2, res.code)
+		assert.Contains(t, res.stderr, "expected 1 argument(s), got 0")
+	})
+}
+
+func TestProfiles(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "bookctl", "config.yaml")
+
+	var tokens []string
+	url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, `{"status":"success","message":"Author retrieved successfully","author":{"id":"1","name":"George Orwell"}}`)
+	})
+
+	res := run(t, configPath, "", nil, "config", "set", "staging", "-url", url, "-output", "json")
+	require.Equal(t, 0, res.code, res.stderr)
+
+	res = run(t, configPath, "secret\n", nil, "login")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Equal(t, "Logged in to staging\n", res.stdout)
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	res = run(t, configPath, "", nil, "authors", "get", "1")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.JSONEq(t, `{"id":"1","name":"George Orwell"}`, res.stdout)
+
+	res = run(t, configPath, "", map[string]string{"BOOKCTL_TOKEN": "from-env"}, "authors", "get", "1")
+	require.Equal(t, 0, res.code, res.stderr)
+
+	assert.Equal(t, []string{"Bearer secret", "Bearer from-env"}, tokens)
+
+	res = run(t, configPath, "", nil, "-o", "table", "config", "list")
+	require.Equal(t, 0, res.code, res.stderr)
+	assert.Contains(t, res.stdout, "*         staging   "+url+"")
+	assert.NotContains(t, res.stdout, "secret")
+
+	res = run(t, configPath, "", nil, "config", "use", "production")
+	assert.Equal(t, 1, res.code)
+	assert.Equal(t, "error: unknown profile \"production\"\n", res.stderr)
+}
+
+func TestImport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "books.csv")
+	require.NoError(t, os.WriteFile(file, []byte("title,description,author\n1984,Big Brother,George Orwell\n,Animals,George Orwell\n"), 0o600))
+
+	url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "best_effort", r.URL.Query().Get("mode"))
+		writeJSON(w, http.StatusOK, `{"status":"success","message":"Import completed","report":{"mode":"best_effort","committed":true,"total":2,"succeeded":1,"failed":1,"rows":[{"line":2,"status":"created","book_id":"1"},{"line":3,"status":"failed","errors":[{"field":"title","message":"title is required"}]}]}}`)
+	})
+
+	res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "-url", url, "import", file)
+
+	assert.Equal(t, 1, res.code)
+	assert.Equal(t, ""+
+		"LINE   STATUS   FIELD   ERROR\n"+
+		"3      failed   title   title is required\n"+
+		"       2 row(s), 1 imported, 1 failed\n", res.stdout)
+	assert.Equal(t, "error: some rows were rejected\n", res.stderr)
+}
+
+func TestExport(t *testing.T) {
+	url := newServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "csv", r.URL.Query().Get("format"))
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprint(w, "id,title\n1,1984\n")
+	})
+
+	out := filepath.Join(t.TempDir(), "books.csv")
+
+	res := run(t, filepath.Join(t.TempDir(), "config.yaml"), "", nil, "-url", url, "export", "-out", out)
+
+	require.Equal(t, 0, res.code, res.stderr)
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "id,title\n1,1984\n", string(data))
+}
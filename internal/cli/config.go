@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultURL is the API of the local docker compose environment.
+const DefaultURL = "http://localhost:8080/api"
+
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// Profile is an environment of the API, such as local, staging or production.
+type Profile struct {
+	URL string `yaml:"url"`
+	// Token is the access token sent as a bearer token. The config file is
+	// only readable by its owner since it stores it.
+	Token    string `yaml:"token,omitempty"`
+	Language string `yaml:"language,omitempty"`
+	Output   string `yaml:"output,omitempty"`
+}
+
+// Config is the file holding the profiles of the CLI.
+type Config struct {
+	CurrentProfile string              `yaml:"current_profile"`
+	Profiles       map[string]*Profile `yaml:"profiles"`
+
+	path string
+}
+
+// DefaultConfigPath returns $BOOKCTL_CONFIG, or bookctl/config.yaml in the
+// user config directory.
+func DefaultConfigPath() (string, error) {
+	if path := os.Getenv("BOOKCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "bookctl", "config.yaml"), nil
+}
+
+// LoadConfig reads the config file, a missing file is an empty config.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = map[string]*Profile{}
+	}
+
+	return config, nil
+}
+
+// Save writes the config file, readable by its owner only.
+func (c *Config) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return err
+	}
+
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data.Bytes(), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, c.path)
+}
+
+// Profile returns the named profile, the current one when name is empty. The
+// default profile targets the local API until it is configured.
+func (c *Config) Profile(name string) (string, *Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+
+	if name == "" {
+		name = "default"
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		if name != "default" {
+			return "", nil, fmt.Errorf("%w %q", ErrUnknownProfile, name)
+		}
+
+		profile = &Profile{URL: DefaultURL}
+	}
+
+	return name, profile, nil
+}
+
+// ProfileNames returns the names of the profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
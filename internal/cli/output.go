@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+var ErrInvalidOutput = errors.New("output must be table, json or yaml")
+
+// table is the tabular view of a value, its header and rows.
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// printer writes the results in the chosen format.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case "":
+		format = OutputTable
+	case OutputTable, OutputJSON, OutputYAML:
+	default:
+		return nil, ErrInvalidOutput
+	}
+
+	return &printer{w: w, format: format}, nil
+}
+
+// print writes value as JSON or YAML, or as the table built by toTable.
+func (p *printer) print(value any, toTable func() table) error {
+	switch p.format {
+	case OutputJSON:
+		encoder := json.NewEncoder(p.w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case OutputYAML:
+		return writeYAML(p.w, value)
+	default:
+		return writeTable(p.w, toTable())
+	}
+}
+
+// message writes a confirmation, kept out of the JSON and YAML outputs so
+// that they stay parsable.
+func (p *printer) message(format string, args ...any) {
+	if p.format == OutputTable {
+		fmt.Fprintf(p.w, format+"\n", args...)
+	}
+}
+
+func writeTable(w io.Writer, t table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+	for _, row := range t.rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// writeYAML writes value with the keys and the key order of its JSON, the
+// API types only have JSON tags.
+func writeYAML(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	// JSON is YAML, decoding it into a node keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// resetStyle drops the flow style and the quotes of the JSON syntax.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
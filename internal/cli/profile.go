@@ -0,0 +1,286 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+const configUsage = `Usage:
+  bookctl config list
+  bookctl config set <profile> [-url url] [-language tag] [-output table|json|yaml]
+  bookctl config use <profile>
+  bookctl config delete <profile>
+`
+
+const loginUsage = `Usage:
+  bookctl login [-profile profile] [-token token]
+
+Stores the access token in the profile, read from the standard input unless
+-token is given. $BOOKCTL_TOKEN overrides the stored token.
+`
+
+func (a *App) runConfig(args []string) error {
+	fs := a.newFlagSet("config")
+	fs.Usage = func() { fmt.Fprint(a.stderr, configUsage) }
+
+	if len(args) == 0 {
+		return a.usageError(fs, "missing config command")
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "list":
+		return a.listProfiles(fs, args)
+	case "set":
+		return a.setProfile(fs, args)
+	case "use":
+		return a.useProfile(fs, args)
+	case "delete":
+		return a.deleteProfile(fs, args)
+	default:
+		return a.usageError(fs, "unknown config command %q", command)
+	}
+}
+
+type profileView struct {
+	Name     string `json:"name"`
+	Current  bool   `json:"current"`
+	URL      string `json:"url"`
+	Language string `json:"language,omitempty"`
+	Output   string `json:"output,omitempty"`
+	// LoggedIn tells whether a token is stored, the token is never shown.
+	LoggedIn bool `json:"logged_in"`
+}
+
+func (a *App) listProfiles(fs *flag.FlagSet, args []string) error {
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	config, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	printer, err := newPrinter(a.stdout, a.flags.output)
+	if err != nil {
+		return err
+	}
+
+	current, _, _ := config.Profile("")
+
+	views := []profileView{}
+	for _, name := range config.ProfileNames() {
+		profile := config.Profiles[name]
+		views = append(views, profileView{
+			Name:     name,
+			Current:  name == current,
+			URL:      profile.URL,
+			Language: profile.Language,
+			Output:   profile.Output,
+			LoggedIn: profile.Token != "",
+		})
+	}
+
+	return printer.print(views, func() table {
+		t := table{header: []string{"CURRENT", "NAME", "URL", "LANGUAGE", "OUTPUT", "LOGGED IN"}}
+		for _, view := range views {
+			t.rows = append(t.rows, []string{mark(view.Current), view.Name, view.URL, view.Language, view.Output, yesNo(view.LoggedIn)})
+		}
+		return t
+	})
+}
+
+func (a *App) setProfile(fs *flag.FlagSet, args []string) error {
+	// the global -url and -o flags set the profile fields
+	language := fs.String("language", "", "language of the validation messages (en, fr)")
+	output := fs.String("output", "", "default output format: table, json or yaml")
+
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	config, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	profile, ok := config.Profiles[name]
+	if !ok {
+		profile = &Profile{URL: DefaultURL}
+		config.Profiles[name] = profile
+	}
+
+	var invalid error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "url":
+			profile.URL = a.flags.url
+		case "language":
+			profile.Language = *language
+		case "output", "o":
+			value := firstNonEmpty(*output, a.flags.output)
+			if _, err := newPrinter(nil, value); err != nil {
+				invalid = err
+			}
+			profile.Output = value
+		}
+	})
+	if invalid != nil {
+		return invalid
+	}
+
+	if config.CurrentProfile == "" {
+		config.CurrentProfile = name
+	}
+
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Profile %s saved\n", name)
+
+	return nil
+}
+
+func (a *App) useProfile(fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	config, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if _, ok := config.Profiles[name]; !ok {
+		return fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+
+	config.CurrentProfile = name
+
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Switched to profile %s\n", name)
+
+	return nil
+}
+
+func (a *App) deleteProfile(fs *flag.FlagSet, args []string) error {
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	config, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if _, ok := config.Profiles[name]; !ok {
+		return fmt.Errorf("%w %q", ErrUnknownProfile, name)
+	}
+
+	delete(config.Profiles, name)
+	if config.CurrentProfile == name {
+		config.CurrentProfile = ""
+	}
+
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Profile %s deleted\n", name)
+
+	return nil
+}
+
+func (a *App) runLogin(args []string) error {
+	fs := a.newFlagSet("login")
+	fs.Usage = func() { fmt.Fprint(a.stderr, loginUsage) }
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	token := a.flags.token
+	if token == "" {
+		fmt.Fprint(a.stderr, "Access token: ")
+
+		line, err := bufio.NewReader(a.stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("read token: %w", err)
+		}
+		token = line
+	}
+
+	token = strings.TrimPrefix(strings.TrimSpace(token), "Bearer ")
+	if token == "" {
+		return a.usageError(fs, "empty token")
+	}
+
+	return a.updateProfile(func(profile *Profile) { profile.Token = token }, "Logged in to %s")
+}
+
+func (a *App) runLogout(args []string) error {
+	fs := a.newFlagSet("logout")
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	return a.updateProfile(func(profile *Profile) { profile.Token = "" }, "Logged out of %s")
+}
+
+// updateProfile applies update to the selected profile, creating the default
+// profile when needed, and saves the config.
+func (a *App) updateProfile(update func(*Profile), message string) error {
+	config, err := a.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	name, profile, err := config.Profile(a.flags.profile)
+	if err != nil {
+		return err
+	}
+
+	update(profile)
+	config.Profiles[name] = profile
+
+	if config.CurrentProfile == "" {
+		config.CurrentProfile = name
+	}
+
+	if err := config.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, message+"\n", name)
+
+	return nil
+}
+
+func mark(current bool) string {
+	if current {
+		return "*"
+	}
+	return ""
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/pkg/client"
+)
+
+const importUsage = `Usage:
+  bookctl import [-format csv|ndjson] [-mode best_effort|all_or_nothing] <file>
+
+The format is guessed from the file extension, "-" reads the standard input
+and requires -format. Exits with 1 when a row was rejected.
+`
+
+const exportUsage = `Usage:
+  bookctl export [-format csv|ndjson|json] [-out file] [-author id] [-title text]
+
+Writes to the standard output unless -out is given.
+`
+
+// errImportFailed is returned when rows were rejected, the report explains
+// why.
+var errImportFailed = errors.New("some rows were rejected")
+
+func (a *App) runImport(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("import")
+	fs.Usage = func() { fmt.Fprint(a.stderr, importUsage) }
+
+	var opts client.ImportOptions
+	fs.StringVar(&opts.Format, "format", "", "csv or ndjson, guessed from the file extension by default")
+	fs.StringVar(&opts.Mode, "mode", client.ImportBestEffort, "best_effort commits the valid rows, all_or_nothing commits only when every row is valid")
+
+	positional, err := parseArgs(a, fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	name := positional[0]
+	if opts.Format == "" {
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".csv":
+			opts.Format = client.ImportCSV
+		case ".ndjson", ".jsonl":
+			opts.Format = client.ImportNDJSON
+		default:
+			return a.usageError(fs, "cannot guess the format of %q, use -format", name)
+		}
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	file, err := a.openInput(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := s.client.Import.Books(ctx, file, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := s.printer.print(report, func() table { return importTable(report) }); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return errImportFailed
+	}
+
+	return nil
+}
+
+// importTable lists the rejected rows, the created ones are only counted.
+func importTable(report *client.ImportReport) table {
+	t := table{header: []string{"LINE", "STATUS", "FIELD", "ERROR"}}
+
+	for _, row := range report.Rows {
+		if len(row.Errors) == 0 {
+			continue
+		}
+
+		for _, detail := range row.Errors {
+			t.rows = append(t.rows, []string{strconv.Itoa(row.Line), row.Status, detail.Field, detail.Message})
+		}
+	}
+
+	summary := fmt.Sprintf("%d row(s), %d imported, %d failed", report.Total, report.Succeeded, report.Failed)
+	if !report.Committed {
+		summary += ", rolled back"
+	}
+	t.rows = append(t.rows, []string{"", summary})
+
+	return t
+}
+
+func (a *App) runExport(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("export")
+	fs.Usage = func() { fmt.Fprint(a.stderr, exportUsage) }
+
+	var filter client.BookFilter
+	format := fs.String("format", client.ExportCSV, "csv, ndjson or json")
+	out := fs.String("out", "", "file to write, the standard output by default")
+	fs.StringVar(&filter.AuthorID, "author", "", "only the books of this author id")
+	fs.StringVar(&filter.Title, "title", "", "only the books whose title contains this text")
+
+	if _, err := parseArgs(a, fs, args, 0); err != nil {
+		return err
+	}
+
+	s, err := a.newSession()
+	if err != nil {
+		return err
+	}
+
+	body, err := s.client.Books.Export(ctx, filter, *format)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if *out == "" {
+		_, err := io.Copy(a.stdout, body)
+		return err
+	}
+
+	// written next to the destination and renamed, so that an interrupted
+	// export does not leave a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(*out), "."+filepath.Base(*out)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, body)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), *out); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stderr, "Exported %d bytes to %s\n", n, *out)
+
+	return nil
+}
@@ -1,13 +1,31 @@
 package config
 
 import (
+	"reflect"
+	"time"
+
 	"github.com/caarlos0/env/v11"
+
+	"go-boilerplate-rest-api-chi/internal/money"
 )
 
 type Config struct {
-	Api      ApiConfig      `envPrefix:"API_"`
-	Log      LogConfig      `envPrefix:"LOG_"`
-	Database DatabaseConfig `envPrefix:"DATABASE_"`
+	Api         ApiConfig         `envPrefix:"API_"`
+	Log         LogConfig         `envPrefix:"LOG_"`
+	Database    DatabaseConfig    `envPrefix:"DATABASE_"`
+	Search      SearchConfig      `envPrefix:"SEARCH_"`
+	Import      ImportConfig      `envPrefix:"IMPORT_"`
+	Idempotency IdempotencyConfig `envPrefix:"IDEMPOTENCY_"`
+	Outbox      OutboxConfig      `envPrefix:"OUTBOX_"`
+	Webhook     WebhookConfig     `envPrefix:"WEBHOOK_"`
+	Stream      StreamConfig      `envPrefix:"STREAM_"`
+	Auth        AuthConfig        `envPrefix:"AUTH_"`
+	Live        LiveConfig        `envPrefix:"LIVE_"`
+	GraphQL     GraphQLConfig     `envPrefix:"GRAPHQL_"`
+	Grpc        GrpcConfig        `envPrefix:"GRPC_"`
+	Loan        LoanConfig        `envPrefix:"LOAN_"`
+	Hold        HoldConfig        `envPrefix:"HOLD_"`
+	Fine        FineConfig        `envPrefix:"FINE_"`
 }
 
 type ApiConfig struct {
@@ -30,10 +48,119 @@ type DatabaseConfig struct {
 	LogLevel string `env:"LOG_LEVEL,required,notEmpty"`
 }
 
+type SearchConfig struct {
+	Driver string `env:"DRIVER" envDefault:"mysql"`
+}
+
+type ImportConfig struct {
+	Timeout time.Duration `env:"TIMEOUT" envDefault:"10m"`
+}
+
+type IdempotencyConfig struct {
+	Driver string        `env:"DRIVER" envDefault:"database"`
+	TTL    time.Duration `env:"TTL" envDefault:"24h"`
+}
+
+type OutboxConfig struct {
+	// RelayEnabled is left to a single instance, the relay takes no lock and
+	// two of them would publish every event twice.
+	RelayEnabled   bool          `env:"RELAY_ENABLED" envDefault:"false"`
+	PollInterval   time.Duration `env:"POLL_INTERVAL" envDefault:"1s"`
+	BatchSize      int           `env:"BATCH_SIZE" envDefault:"100"`
+	MaxAttempts    int           `env:"MAX_ATTEMPTS" envDefault:"10"`
+	WebhookURL     string        `env:"WEBHOOK_URL"`
+	WebhookSecret  string        `env:"WEBHOOK_SECRET"`
+	WebhookTimeout time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"5s"`
+	FilePath       string        `env:"FILE_PATH"`
+}
+
+type WebhookConfig struct {
+	DispatcherEnabled bool          `env:"DISPATCHER_ENABLED" envDefault:"true"`
+	PollInterval      time.Duration `env:"POLL_INTERVAL" envDefault:"1s"`
+	BatchSize         int           `env:"BATCH_SIZE" envDefault:"50"`
+	MaxAttempts       int           `env:"MAX_ATTEMPTS" envDefault:"8"`
+	RetryBase         time.Duration `env:"RETRY_BASE" envDefault:"30s"`
+	Timeout           time.Duration `env:"TIMEOUT" envDefault:"5s"`
+	// AllowPrivateTargets lets the webhooks target the loopback, private and
+	// link-local addresses, for receivers in the internal network.
+	AllowPrivateTargets bool `env:"ALLOW_PRIVATE_TARGETS" envDefault:"false"`
+}
+
+type StreamConfig struct {
+	BufferSize        int           `env:"BUFFER_SIZE" envDefault:"1000"`
+	MaxClients        int           `env:"MAX_CLIENTS" envDefault:"500"`
+	HeartbeatInterval time.Duration `env:"HEARTBEAT_INTERVAL" envDefault:"15s"`
+}
+
+type AuthConfig struct {
+	JWTSecret string `env:"JWT_SECRET"`
+}
+
+type LiveConfig struct {
+	MaxClients     int           `env:"MAX_CLIENTS" envDefault:"500"`
+	MaxTopics      int           `env:"MAX_TOPICS" envDefault:"100"`
+	PingInterval   time.Duration `env:"PING_INTERVAL" envDefault:"30s"`
+	WriteTimeout   time.Duration `env:"WRITE_TIMEOUT" envDefault:"10s"`
+	AllowedOrigins []string      `env:"ALLOWED_ORIGINS" envSeparator:","`
+}
+
+type GraphQLConfig struct {
+	MaxDepth      int `env:"MAX_DEPTH" envDefault:"8"`
+	MaxComplexity int `env:"MAX_COMPLEXITY" envDefault:"1000"`
+}
+
+type GrpcConfig struct {
+	Enabled bool `env:"ENABLED" envDefault:"true"`
+	Port    int  `env:"PORT" envDefault:"9090"`
+	// SharePort serves gRPC on the API port over unencrypted HTTP/2 instead
+	// of a port of its own.
+	SharePort bool `env:"SHARE_PORT" envDefault:"false"`
+}
+
+type LoanConfig struct {
+	// PeriodDays is the length of a loan, and of every renewal.
+	PeriodDays  int `env:"PERIOD_DAYS" envDefault:"21"`
+	MaxRenewals int `env:"MAX_RENEWALS" envDefault:"2"`
+}
+
+type HoldConfig struct {
+	// PickupDays is how long a copy set aside stays ready for pickup.
+	PickupDays     int           `env:"PICKUP_DAYS" envDefault:"7"`
+	SweeperEnabled bool          `env:"SWEEPER_ENABLED" envDefault:"true"`
+	SweepInterval  time.Duration `env:"SWEEP_INTERVAL" envDefault:"5m"`
+	SweepBatchSize int           `env:"SWEEP_BATCH_SIZE" envDefault:"100"`
+}
+
+// FineConfig sets the fines of the overdue loans. The rates, caps and grace
+// periods are given per item type of the copies, such as
+// "standard:0.25,dvd:1.00", the standard entries apply to the item types
+// missing from a setting.
+type FineConfig struct {
+	DailyRates map[string]money.Amount `env:"DAILY_RATES" envDefault:"standard:0.25"`
+	// Caps bound the fine of a loan, 0 for no cap.
+	Caps map[string]money.Amount `env:"CAPS" envDefault:"standard:10.00"`
+	// GraceDays are the days late without a fine, past them every day late
+	// is charged.
+	GraceDays map[string]int `env:"GRACE_DAYS" envDefault:"standard:1"`
+	// BlockThreshold is the balance above which a member cannot check out.
+	BlockThreshold   money.Amount `env:"BLOCK_THRESHOLD" envDefault:"5.00"`
+	AccrualEnabled   bool         `env:"ACCRUAL_ENABLED" envDefault:"true"`
+	AccrualHour      int          `env:"ACCRUAL_HOUR" envDefault:"2"`
+	AccrualBatchSize int          `env:"ACCRUAL_BATCH_SIZE" envDefault:"100"`
+}
+
 func LoadConfig() (Config, error) {
 	var cfg Config
 
-	if err := env.Parse(&cfg); err != nil {
+	opts := env.Options{
+		FuncMap: map[reflect.Type]env.ParserFunc{
+			reflect.TypeOf(money.Amount(0)): func(value string) (any, error) {
+				return money.Parse(value)
+			},
+		},
+	}
+
+	if err := env.ParseWithOptions(&cfg, opts); err != nil {
 		return Config{}, err
 	}
 
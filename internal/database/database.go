@@ -50,6 +50,21 @@ func Init(cfg config.Config, logger zerolog.Logger) (*Database, error) {
 		// Models
 		&entity.Book{},
 		&entity.Author{},
+		&entity.AuthorAlias{},
+		&entity.Member{},
+		&entity.Copy{},
+		&entity.Loan{},
+		&entity.Hold{},
+		&entity.FineEntry{},
+		&entity.Review{},
+		&entity.Genre{},
+		&entity.GenreClosure{},
+		&entity.Tag{},
+		&entity.Series{},
+		&entity.Publisher{},
+		&entity.Imprint{},
+		&entity.WebhookSubscription{},
+		&entity.WebhookDelivery{},
 	); err != nil {
 		logger.Error().Err(err).Msg("auto-migration failed")
 		return nil, err
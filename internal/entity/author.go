@@ -7,15 +7,49 @@ import (
 	"gorm.io/gorm"
 )
 
+// Author is the canonical record of a writer. Names are not unique, authors
+// sharing a name are told apart by their dates and external identifiers.
 type Author struct {
+	ID          uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	Name        string     `gorm:"not null;size:255;index"`
+	Biography   string     `gorm:"type:text"`
+	BirthDate   *time.Time `gorm:"type:date"`
+	DeathDate   *time.Time `gorm:"type:date"`
+	Nationality string     `gorm:"size:2"`
+	// ISNI, VIAFID and WikidataID identify the author in external
+	// authority files, each one belongs to a single author.
+	ISNI       *string        `gorm:"size:16;uniqueIndex"`
+	VIAFID     *string        `gorm:"column:viaf_id;size:22;uniqueIndex"`
+	WikidataID *string        `gorm:"size:20;uniqueIndex"`
+	Aliases    []*AuthorAlias `gorm:"constraint:OnDelete:CASCADE"`
+	Book       []Book         `gorm:"constraint:OnDelete:SET NULL"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (a *Author) BeforeCreate(_ *gorm.DB) error {
+	a.ID = uuid.New()
+	return nil
+}
+
+// The kinds of alias.
+const (
+	AliasKindPenName = "pen_name"
+	AliasKindVariant = "variant"
+)
+
+// AuthorAlias is another name of an author: a pen name or a variant spelling
+// of the canonical name. It resolves to the author it belongs to.
+type AuthorAlias struct {
 	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
-	Name      string    `gorm:"not null;unique"`
-	Book      []Book    `gorm:"constraint:OnDelete:SET NULL"`
+	AuthorID  uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_author_aliases_author_name,priority:1"`
+	Name      string    `gorm:"not null;size:255;uniqueIndex:idx_author_aliases_author_name,priority:2;index"`
+	Kind      string    `gorm:"not null;size:20"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
-func (a *Author) BeforeCreate(_ *gorm.DB) error {
+func (a *AuthorAlias) BeforeCreate(_ *gorm.DB) error {
 	a.ID = uuid.New()
 	return nil
 }
@@ -12,9 +12,29 @@ type Book struct {
 	Title       string    `gorm:"not null;uniqueIndex"`
 	Description string    `gorm:"not null"`
 	AuthorID    *uuid.UUID
-	Author      *Author `gorm:"foreignKey:AuthorID"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Author      *Author    `gorm:"foreignKey:AuthorID"`
+	ImprintID   *uuid.UUID `gorm:"type:char(36);index"`
+	Imprint     *Imprint   `gorm:"foreignKey:ImprintID"`
+	Genres      []*Genre   `gorm:"many2many:book_genres;constraint:OnDelete:CASCADE"`
+	Tags        []*Tag     `gorm:"many2many:book_tags;constraint:OnDelete:CASCADE"`
+	SeriesID    *uuid.UUID `gorm:"type:char(36);uniqueIndex:idx_books_series_volume,priority:1"`
+	Series      *Series    `gorm:"foreignKey:SeriesID"`
+	// SeriesVolume places the book in its series, fractional volumes such as
+	// 2.5 sit between two others.
+	SeriesVolume *float64 `gorm:"uniqueIndex:idx_books_series_volume,priority:2"`
+	// Previous and Next are the neighbours of the book in its series, they
+	// are only set when read through the book service.
+	Previous *Book `gorm:"-"`
+	Next     *Book `gorm:"-"`
+	// RatingAverage and RatingCount sum up the approved reviews of the book,
+	// they are kept in sync by the review service.
+	RatingAverage float64 `gorm:"not null;default:0;index"`
+	RatingCount   int64   `gorm:"not null;default:0"`
+	// Availability sums up the copies of the book, it is only set when read
+	// through the book service.
+	Availability *CopyCounts `gorm:"-"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 func (b *Book) BeforeCreate(_ *gorm.DB) error {
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CopyStatusAvailable = "available"
+	CopyStatusOnLoan    = "on_loan"
+	CopyStatusOnHold    = "on_hold"
+	CopyStatusLost      = "lost"
+	CopyStatusInRepair  = "in_repair"
+)
+
+// ItemTypeStandard is the item type of the copies by default, the item type
+// sets the fines of an overdue copy.
+const ItemTypeStandard = "standard"
+
+const (
+	CopyConditionNew  = "new"
+	CopyConditionGood = "good"
+	CopyConditionFair = "fair"
+	CopyConditionPoor = "poor"
+)
+
+// Copy is a physical item of a book, identified by the barcode on its label.
+// Copies go with their book when it is deleted.
+type Copy struct {
+	ID            uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	BookID        uuid.UUID  `gorm:"type:char(36);not null;index:idx_copies_book_status,priority:1"`
+	Book          *Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	Barcode       string     `gorm:"size:64;not null;uniqueIndex"`
+	Condition     string     `gorm:"size:16;not null"`
+	ItemType      string     `gorm:"size:32;not null;default:standard"`
+	AcquiredOn    *time.Time `gorm:"type:date"`
+	Branch        string     `gorm:"size:100;not null;index"`
+	ShelfLocation string     `gorm:"size:100;not null"`
+	Status        string     `gorm:"size:16;not null;index:idx_copies_book_status,priority:2"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (c *Copy) BeforeCreate(_ *gorm.DB) error {
+	c.ID = uuid.New()
+	return nil
+}
+
+// CopyCounts sums up the copies of a book.
+type CopyCounts struct {
+	Total     int64
+	Available int64
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+const (
+	FineEntryCharge  = "charge"
+	FineEntryPayment = "payment"
+	FineEntryWaiver  = "waiver"
+)
+
+// FineEntry is a line of the fines ledger of a member. Charges add to the
+// balance owed, payments and waivers take from it, Amount is always positive.
+// Entries are never updated nor deleted.
+type FineEntry struct {
+	ID        uuid.UUID    `gorm:"type:char(36);not null;primaryKey"`
+	MemberID  uuid.UUID    `gorm:"type:char(36);not null;index:idx_fine_entries_member,priority:1"`
+	Member    *Member      `gorm:"foreignKey:MemberID;constraint:OnDelete:RESTRICT"`
+	LoanID    *uuid.UUID   `gorm:"type:char(36);index:idx_fine_entries_loan"`
+	Loan      *Loan        `gorm:"foreignKey:LoanID;constraint:OnDelete:RESTRICT"`
+	Kind      string       `gorm:"size:16;not null"`
+	Amount    money.Amount `gorm:"not null"`
+	Note      string       `gorm:"size:255;not null"`
+	CreatedAt time.Time    `gorm:"index:idx_fine_entries_member,priority:2"`
+}
+
+func (f *FineEntry) BeforeCreate(_ *gorm.DB) error {
+	f.ID = uuid.New()
+	return nil
+}
+
+// FineAccount is the fines ledger of a member with its balance owed.
+type FineAccount struct {
+	MemberID uuid.UUID
+	Balance  money.Amount
+	Entries  []*FineEntry
+}
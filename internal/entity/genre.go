@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Genre is a node of the tree of genres and subjects, the root genres have no
+// parent.
+type Genre struct {
+	ID        uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	Name      string     `gorm:"size:100;not null;uniqueIndex"`
+	ParentID  *uuid.UUID `gorm:"type:char(36);index"`
+	Parent    *Genre     `gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT"`
+	Children  []*Genre   `gorm:"foreignKey:ParentID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Ancestors is the path from the root down to the parent of the genre, it
+	// is only set when read through the genre service.
+	Ancestors []*Genre `gorm:"-"`
+}
+
+func (g *Genre) BeforeCreate(_ *gorm.DB) error {
+	g.ID = uuid.New()
+	return nil
+}
+
+// GenreClosure links a genre to each of its descendants, itself included at
+// depth 0, so that a whole subtree is read with a single join.
+type GenreClosure struct {
+	AncestorID   uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Ancestor     *Genre    `gorm:"foreignKey:AncestorID;constraint:OnDelete:CASCADE"`
+	DescendantID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	Descendant   *Genre    `gorm:"foreignKey:DescendantID;constraint:OnDelete:CASCADE"`
+	Depth        int       `gorm:"not null"`
+}
+
+// GenreFacet is the number of books of a genre, the books of its descendants
+// included.
+type GenreFacet struct {
+	Genre *Genre
+	Count int64
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	HoldStatusWaiting   = "waiting"
+	HoldStatusReady     = "ready"
+	HoldStatusFulfilled = "fulfilled"
+	HoldStatusCancelled = "cancelled"
+	HoldStatusExpired   = "expired"
+)
+
+// Hold is the reservation of a book by a member. Holds wait in a first in,
+// first out queue per book until a copy is set aside for them, the hold is
+// then ready for pickup until ExpiresAt.
+type Hold struct {
+	ID        uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	BookID    uuid.UUID  `gorm:"type:char(36);not null;index:idx_holds_queue,priority:1"`
+	Book      *Book      `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	MemberID  uuid.UUID  `gorm:"type:char(36);not null;index:idx_holds_member"`
+	Member    *Member    `gorm:"foreignKey:MemberID;constraint:OnDelete:RESTRICT"`
+	Status    string     `gorm:"size:16;not null;index:idx_holds_queue,priority:2"`
+	CopyID    *uuid.UUID `gorm:"type:char(36)"`
+	Copy      *Copy      `gorm:"foreignKey:CopyID;constraint:OnDelete:SET NULL"`
+	PlacedAt  time.Time  `gorm:"not null;index:idx_holds_queue,priority:3"`
+	ReadyAt   *time.Time
+	ExpiresAt *time.Time `gorm:"index"`
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// Position is the rank of a waiting hold in the queue of its book,
+	// starting at 1. It is only set when read through the hold service.
+	Position int64 `gorm:"-"`
+}
+
+func (h *Hold) BeforeCreate(_ *gorm.DB) error {
+	h.ID = uuid.New()
+	return nil
+}
+
+// Open reports whether the hold is still waiting or ready for pickup.
+func (h *Hold) Open() bool {
+	return h.Status == HoldStatusWaiting || h.Status == HoldStatusReady
+}
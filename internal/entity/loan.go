@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	LoanStatusActive   = "active"
+	LoanStatusReturned = "returned"
+)
+
+// Loan is the checkout of a copy of a book by a member, active until
+// ReturnedAt is set. Loans are kept once returned as the borrowing history.
+// Loans made before the copies were inventoried have no copy.
+type Loan struct {
+	ID         uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	BookID     uuid.UUID  `gorm:"type:char(36);not null;index:idx_loans_book"`
+	Book       *Book      `gorm:"foreignKey:BookID;constraint:OnDelete:RESTRICT"`
+	CopyID     *uuid.UUID `gorm:"type:char(36);index:idx_loans_copy"`
+	Copy       *Copy      `gorm:"foreignKey:CopyID;constraint:OnDelete:RESTRICT"`
+	MemberID   uuid.UUID  `gorm:"type:char(36);not null;index:idx_loans_member"`
+	Member     *Member    `gorm:"foreignKey:MemberID;constraint:OnDelete:RESTRICT"`
+	BorrowedAt time.Time  `gorm:"not null"`
+	DueAt      time.Time  `gorm:"not null"`
+	ReturnedAt *time.Time
+	Renewals   int `gorm:"not null;default:0"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (l *Loan) BeforeCreate(_ *gorm.DB) error {
+	l.ID = uuid.New()
+	return nil
+}
+
+func (l *Loan) Status() string {
+	if l.ReturnedAt != nil {
+		return LoanStatusReturned
+	}
+	return LoanStatusActive
+}
+
+// Overdue reports whether the copy is still out past its due date at now.
+func (l *Loan) Overdue(now time.Time) bool {
+	return l.ReturnedAt == nil && now.After(l.DueAt)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Member is a patron of the library, allowed to borrow books.
+type Member struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Name      string    `gorm:"not null"`
+	Email     string    `gorm:"size:254;not null;uniqueIndex"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (m *Member) BeforeCreate(_ *gorm.DB) error {
+	m.ID = uuid.New()
+	return nil
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Publisher struct {
+	ID        uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	Name      string     `gorm:"not null;unique"`
+	Imprints  []*Imprint `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (p *Publisher) BeforeCreate(_ *gorm.DB) error {
+	p.ID = uuid.New()
+	return nil
+}
+
+// Imprint is a brand under which its publisher releases books, its name is
+// unique within the publisher.
+type Imprint struct {
+	ID          uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	PublisherID uuid.UUID  `gorm:"type:char(36);not null;uniqueIndex:idx_imprints_publisher_name,priority:1"`
+	Publisher   *Publisher `gorm:"foreignKey:PublisherID"`
+	Name        string     `gorm:"size:255;not null;uniqueIndex:idx_imprints_publisher_name,priority:2"`
+	Books       []Book     `gorm:"constraint:OnDelete:SET NULL"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (i *Imprint) BeforeCreate(_ *gorm.DB) error {
+	i.ID = uuid.New()
+	return nil
+}
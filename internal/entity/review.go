@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// Review is the opinion of a member on a book, with a rating of 1 to 5 stars.
+// A member reviews a book at most once. Reviews wait for the moderation of a
+// librarian, only the approved ones count in the rating of the book.
+type Review struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BookID      uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_reviews_book_member,priority:1"`
+	Book        *Book     `gorm:"foreignKey:BookID;constraint:OnDelete:CASCADE"`
+	MemberID    uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_reviews_book_member,priority:2;index:idx_reviews_member"`
+	Member      *Member   `gorm:"foreignKey:MemberID;constraint:OnDelete:RESTRICT"`
+	Rating      int       `gorm:"not null"`
+	Text        string    `gorm:"type:text;not null"`
+	Status      string    `gorm:"size:16;not null;index"`
+	Reason      string    `gorm:"size:500;not null"`
+	ModeratedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (r *Review) BeforeCreate(_ *gorm.DB) error {
+	r.ID = uuid.New()
+	return nil
+}
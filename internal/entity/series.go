@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Series struct {
+	ID   uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Name string    `gorm:"size:255;not null;uniqueIndex"`
+	// Books is the reading order of the series, by volume.
+	Books     []*Book `gorm:"foreignKey:SeriesID;constraint:OnDelete:SET NULL"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (s *Series) BeforeCreate(_ *gorm.DB) error {
+	s.ID = uuid.New()
+	return nil
+}
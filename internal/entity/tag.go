@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Tag is a free-form label of books, its name is stored in lowercase.
+type Tag struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex"`
+	CreatedAt time.Time
+}
+
+func (t *Tag) BeforeCreate(_ *gorm.DB) error {
+	t.ID = uuid.New()
+	return nil
+}
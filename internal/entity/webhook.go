@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusDelivered = "delivered"
+	DeliveryStatusDead      = "dead"
+)
+
+type WebhookSubscription struct {
+	ID         uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	URL        string    `gorm:"size:2048;not null"`
+	EventTypes []string  `gorm:"serializer:json;type:text;not null"`
+	Secret     string    `gorm:"size:128;not null"`
+	Active     bool      `gorm:"not null;default:true"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (s *WebhookSubscription) BeforeCreate(_ *gorm.DB) error {
+	s.ID = uuid.New()
+	return nil
+}
+
+// WebhookDelivery is the delivery of an event to a subscription, kept as the
+// delivery log of the subscription. Body is the exact payload posted on every
+// attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID            `gorm:"type:char(36);not null;primaryKey"`
+	SubscriptionID uuid.UUID            `gorm:"type:char(36);not null;uniqueIndex:idx_webhook_deliveries_event"`
+	Subscription   *WebhookSubscription `gorm:"foreignKey:SubscriptionID;constraint:OnDelete:CASCADE"`
+	EventID        uuid.UUID            `gorm:"type:char(36);not null;uniqueIndex:idx_webhook_deliveries_event"`
+	EventType      string               `gorm:"size:64;not null"`
+	Body           string               `gorm:"type:text;not null"`
+	Status         string               `gorm:"size:16;not null;index:idx_webhook_deliveries_due"`
+	Attempts       int                  `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time            `gorm:"not null;index:idx_webhook_deliveries_due"`
+	LastAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (d *WebhookDelivery) BeforeCreate(_ *gorm.DB) error {
+	d.ID = uuid.New()
+	return nil
+}
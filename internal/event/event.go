@@ -0,0 +1,402 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/money"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type Type string
+
+const (
+	BookCreated      Type = "book.created"
+	BookUpdated      Type = "book.updated"
+	BookDeleted      Type = "book.deleted"
+	AuthorCreated    Type = "author.created"
+	AuthorUpdated    Type = "author.updated"
+	LoanCreated      Type = "loan.created"
+	LoanRenewed      Type = "loan.renewed"
+	LoanReturned     Type = "loan.returned"
+	HoldPlaced       Type = "hold.placed"
+	HoldReady        Type = "hold.ready"
+	HoldFulfilled    Type = "hold.fulfilled"
+	HoldCancelled    Type = "hold.cancelled"
+	HoldExpired      Type = "hold.expired"
+	FineCharged      Type = "fine.charged"
+	FinePaid         Type = "fine.paid"
+	FineWaived       Type = "fine.waived"
+	ReviewSubmitted  Type = "review.submitted"
+	ReviewUpdated    Type = "review.updated"
+	ReviewApproved   Type = "review.approved"
+	ReviewRejected   Type = "review.rejected"
+	ReviewDeleted    Type = "review.deleted"
+	PublisherCreated Type = "publisher.created"
+	ImprintCreated   Type = "imprint.created"
+)
+
+// Types lists every type of recorded event.
+var Types = []Type{BookCreated, BookUpdated, BookDeleted, AuthorCreated, AuthorUpdated, LoanCreated, LoanRenewed, LoanReturned, HoldPlaced, HoldReady, HoldFulfilled, HoldCancelled, HoldExpired, FineCharged, FinePaid, FineWaived, ReviewSubmitted, ReviewUpdated, ReviewApproved, ReviewRejected, ReviewDeleted, PublisherCreated, ImprintCreated}
+
+// PublicTypes lists the types of the catalogue events, the only ones which may
+// be shown to anonymous clients. The other events carry member data.
+var PublicTypes = []Type{BookCreated, BookUpdated, BookDeleted, AuthorCreated, AuthorUpdated}
+
+const (
+	AggregateBook   = "book"
+	AggregateAuthor = "author"
+	AggregateLoan   = "loan"
+	AggregateHold   = "hold"
+	AggregateFine   = "fine"
+	AggregateReview = "review"
+	// AggregatePublisher also holds the imprints of the publisher.
+	AggregatePublisher = "publisher"
+)
+
+// Event is a change of an aggregate. Events of an aggregate are published in
+// the order of their Sequence, assigned when they are recorded.
+type Event struct {
+	ID            uuid.UUID `json:"id"`
+	Type          Type      `json:"type"`
+	AggregateType string    `json:"aggregate_type"`
+	AggregateID   uuid.UUID `json:"aggregate_id"`
+	Sequence      uint64    `json:"sequence"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	// Payload is the aggregate state, serialized as JSON. It holds a
+	// json.RawMessage once read back from the outbox.
+	Payload any `json:"payload"`
+}
+
+func New(eventType Type, aggregateType string, aggregateID uuid.UUID, payload any) Event {
+	return Event{
+		ID:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		OccurredAt:    time.Now().UTC(),
+		Payload:       payload,
+	}
+}
+
+type BookPayload struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	AuthorID     string   `json:"author_id,omitempty"`
+	ImprintID    string   `json:"imprint_id,omitempty"`
+	SeriesID     string   `json:"series_id,omitempty"`
+	SeriesVolume *float64 `json:"series_volume,omitempty"`
+}
+
+type BookDeletedPayload struct {
+	ID string `json:"id"`
+}
+
+type AuthorPayload struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Biography   string   `json:"biography,omitempty"`
+	BirthDate   string   `json:"birth_date,omitempty"`
+	DeathDate   string   `json:"death_date,omitempty"`
+	Nationality string   `json:"nationality,omitempty"`
+	ISNI        string   `json:"isni,omitempty"`
+	VIAFID      string   `json:"viaf_id,omitempty"`
+	WikidataID  string   `json:"wikidata_id,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+type PublisherPayload struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type ImprintPayload struct {
+	ID          string `json:"id"`
+	PublisherID string `json:"publisher_id"`
+	Name        string `json:"name"`
+}
+
+type LoanPayload struct {
+	ID         string     `json:"id"`
+	BookID     string     `json:"book_id"`
+	CopyID     string     `json:"copy_id,omitempty"`
+	MemberID   string     `json:"member_id"`
+	BorrowedAt time.Time  `json:"borrowed_at"`
+	DueAt      time.Time  `json:"due_at"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	Renewals   int        `json:"renewals"`
+}
+
+type FinePayload struct {
+	ID        string       `json:"id"`
+	MemberID  string       `json:"member_id"`
+	LoanID    string       `json:"loan_id,omitempty"`
+	Kind      string       `json:"kind"`
+	Amount    money.Amount `json:"amount"`
+	Note      string       `json:"note,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type ReviewPayload struct {
+	ID          string     `json:"id"`
+	BookID      string     `json:"book_id"`
+	MemberID    string     `json:"member_id"`
+	Rating      int        `json:"rating"`
+	Text        string     `json:"text,omitempty"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+}
+
+type ReviewDeletedPayload struct {
+	ID     string `json:"id"`
+	BookID string `json:"book_id"`
+}
+
+type HoldPayload struct {
+	ID        string     `json:"id"`
+	BookID    string     `json:"book_id"`
+	MemberID  string     `json:"member_id"`
+	Status    string     `json:"status"`
+	CopyID    string     `json:"copy_id,omitempty"`
+	PlacedAt  time.Time  `json:"placed_at"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+}
+
+func NewBookCreated(book *entity.Book) Event {
+	return New(BookCreated, AggregateBook, book.ID, newBookPayload(book))
+}
+
+func NewBookUpdated(book *entity.Book) Event {
+	return New(BookUpdated, AggregateBook, book.ID, newBookPayload(book))
+}
+
+func NewBookDeleted(bookID uuid.UUID) Event {
+	return New(BookDeleted, AggregateBook, bookID, BookDeletedPayload{ID: bookID.String()})
+}
+
+func NewAuthorCreated(author *entity.Author) Event {
+	return New(AuthorCreated, AggregateAuthor, author.ID, newAuthorPayload(author))
+}
+
+func NewAuthorUpdated(author *entity.Author) Event {
+	return New(AuthorUpdated, AggregateAuthor, author.ID, newAuthorPayload(author))
+}
+
+func NewPublisherCreated(publisher *entity.Publisher) Event {
+	return New(PublisherCreated, AggregatePublisher, publisher.ID, PublisherPayload{
+		ID:   publisher.ID.String(),
+		Name: publisher.Name,
+	})
+}
+
+func NewImprintCreated(imprint *entity.Imprint) Event {
+	return New(ImprintCreated, AggregatePublisher, imprint.PublisherID, ImprintPayload{
+		ID:          imprint.ID.String(),
+		PublisherID: imprint.PublisherID.String(),
+		Name:        imprint.Name,
+	})
+}
+
+func NewLoanCreated(loan *entity.Loan) Event {
+	return New(LoanCreated, AggregateLoan, loan.ID, newLoanPayload(loan))
+}
+
+func NewLoanRenewed(loan *entity.Loan) Event {
+	return New(LoanRenewed, AggregateLoan, loan.ID, newLoanPayload(loan))
+}
+
+func NewLoanReturned(loan *entity.Loan) Event {
+	return New(LoanReturned, AggregateLoan, loan.ID, newLoanPayload(loan))
+}
+
+func newLoanPayload(loan *entity.Loan) LoanPayload {
+	payload := LoanPayload{
+		ID:         loan.ID.String(),
+		BookID:     loan.BookID.String(),
+		MemberID:   loan.MemberID.String(),
+		BorrowedAt: loan.BorrowedAt,
+		DueAt:      loan.DueAt,
+		ReturnedAt: loan.ReturnedAt,
+		Renewals:   loan.Renewals,
+	}
+
+	if loan.CopyID != nil {
+		payload.CopyID = loan.CopyID.String()
+	}
+
+	return payload
+}
+
+func NewHoldPlaced(hold *entity.Hold) Event {
+	return New(HoldPlaced, AggregateHold, hold.ID, newHoldPayload(hold))
+}
+
+// NewHoldReady tells that a copy is set aside for the member, who should be
+// notified to pick it up before it expires.
+func NewHoldReady(hold *entity.Hold) Event {
+	return New(HoldReady, AggregateHold, hold.ID, newHoldPayload(hold))
+}
+
+func NewHoldFulfilled(hold *entity.Hold) Event {
+	return New(HoldFulfilled, AggregateHold, hold.ID, newHoldPayload(hold))
+}
+
+func NewHoldCancelled(hold *entity.Hold) Event {
+	return New(HoldCancelled, AggregateHold, hold.ID, newHoldPayload(hold))
+}
+
+func NewHoldExpired(hold *entity.Hold) Event {
+	return New(HoldExpired, AggregateHold, hold.ID, newHoldPayload(hold))
+}
+
+func newHoldPayload(hold *entity.Hold) HoldPayload {
+	payload := HoldPayload{
+		ID:        hold.ID.String(),
+		BookID:    hold.BookID.String(),
+		MemberID:  hold.MemberID.String(),
+		Status:    hold.Status,
+		PlacedAt:  hold.PlacedAt,
+		ReadyAt:   hold.ReadyAt,
+		ExpiresAt: hold.ExpiresAt,
+		ClosedAt:  hold.ClosedAt,
+	}
+
+	if hold.CopyID != nil {
+		payload.CopyID = hold.CopyID.String()
+	}
+
+	return payload
+}
+
+// NewFineRecorded records the entry of a fines ledger, its type follows the
+// kind of the entry.
+func NewFineRecorded(entry *entity.FineEntry) Event {
+	eventType := FineCharged
+	switch entry.Kind {
+	case entity.FineEntryPayment:
+		eventType = FinePaid
+	case entity.FineEntryWaiver:
+		eventType = FineWaived
+	}
+
+	return New(eventType, AggregateFine, entry.ID, newFinePayload(entry))
+}
+
+func newFinePayload(entry *entity.FineEntry) FinePayload {
+	payload := FinePayload{
+		ID:        entry.ID.String(),
+		MemberID:  entry.MemberID.String(),
+		Kind:      entry.Kind,
+		Amount:    entry.Amount,
+		Note:      entry.Note,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.LoanID != nil {
+		payload.LoanID = entry.LoanID.String()
+	}
+
+	return payload
+}
+
+func NewReviewSubmitted(review *entity.Review) Event {
+	return New(ReviewSubmitted, AggregateReview, review.ID, newReviewPayload(review))
+}
+
+// NewReviewUpdated tells that the member edited the review, which waits for
+// moderation again.
+func NewReviewUpdated(review *entity.Review) Event {
+	return New(ReviewUpdated, AggregateReview, review.ID, newReviewPayload(review))
+}
+
+func NewReviewApproved(review *entity.Review) Event {
+	return New(ReviewApproved, AggregateReview, review.ID, newReviewPayload(review))
+}
+
+func NewReviewRejected(review *entity.Review) Event {
+	return New(ReviewRejected, AggregateReview, review.ID, newReviewPayload(review))
+}
+
+func NewReviewDeleted(review *entity.Review) Event {
+	return New(ReviewDeleted, AggregateReview, review.ID, ReviewDeletedPayload{
+		ID:     review.ID.String(),
+		BookID: review.BookID.String(),
+	})
+}
+
+func newReviewPayload(review *entity.Review) ReviewPayload {
+	return ReviewPayload{
+		ID:          review.ID.String(),
+		BookID:      review.BookID.String(),
+		MemberID:    review.MemberID.String(),
+		Rating:      review.Rating,
+		Text:        review.Text,
+		Status:      review.Status,
+		Reason:      review.Reason,
+		ModeratedAt: review.ModeratedAt,
+	}
+}
+
+func newAuthorPayload(author *entity.Author) AuthorPayload {
+	payload := AuthorPayload{
+		ID:          author.ID.String(),
+		Name:        author.Name,
+		Biography:   author.Biography,
+		Nationality: author.Nationality,
+	}
+
+	if author.BirthDate != nil {
+		payload.BirthDate = author.BirthDate.Format(internalValidator.DateLayout)
+	}
+
+	if author.DeathDate != nil {
+		payload.DeathDate = author.DeathDate.Format(internalValidator.DateLayout)
+	}
+
+	if author.ISNI != nil {
+		payload.ISNI = *author.ISNI
+	}
+
+	if author.VIAFID != nil {
+		payload.VIAFID = *author.VIAFID
+	}
+
+	if author.WikidataID != nil {
+		payload.WikidataID = *author.WikidataID
+	}
+
+	for _, alias := range author.Aliases {
+		payload.Aliases = append(payload.Aliases, alias.Name)
+	}
+
+	return payload
+}
+
+func newBookPayload(book *entity.Book) BookPayload {
+	payload := BookPayload{
+		ID:          book.ID.String(),
+		Title:       book.Title,
+		Description: book.Description,
+	}
+
+	if book.AuthorID != nil {
+		payload.AuthorID = book.AuthorID.String()
+	}
+
+	if book.ImprintID != nil {
+		payload.ImprintID = book.ImprintID.String()
+	}
+
+	if book.SeriesID != nil {
+		payload.SeriesID = book.SeriesID.String()
+		payload.SeriesVolume = book.SeriesVolume
+	}
+
+	return payload
+}
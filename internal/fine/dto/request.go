@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+// AccountFilter selects the fines ledger to read.
+type AccountFilter struct {
+	MemberID string `json:"member_id" validate:"required,uuid_strict"`
+}
+
+// NewAccountFilter reads the filter from the query string.
+func NewAccountFilter(query url.Values) AccountFilter {
+	return AccountFilter{
+		MemberID: strings.TrimSpace(query.Get("member_id")),
+	}
+}
+
+// PaymentRequest records a payment of a member, up to the balance owed.
+type PaymentRequest struct {
+	MemberID string       `json:"member_id" validate:"required,uuid_strict"`
+	Amount   money.Amount `json:"amount" validate:"gt=0" swaggertype:"string" example:"2.50"`
+	Note     string       `json:"note,omitempty" validate:"omitempty,trimmed,max=255"`
+}
+
+// WaiverRequest forgives a member part of the balance owed, optionally for
+// the fine of a given loan. The note gives the reason of the waiver.
+type WaiverRequest struct {
+	MemberID string       `json:"member_id" validate:"required,uuid_strict"`
+	LoanID   string       `json:"loan_id,omitempty" validate:"omitempty,uuid_strict"`
+	Amount   money.Amount `json:"amount" validate:"gt=0" swaggertype:"string" example:"2.50"`
+	Note     string       `json:"note" validate:"required,trimmed,max=255"`
+}
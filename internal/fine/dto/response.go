@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+type FineEntryResponse struct {
+	ID        string       `json:"id"`
+	MemberID  string       `json:"member_id"`
+	LoanID    string       `json:"loan_id,omitempty"`
+	Kind      string       `json:"kind" example:"charge" enums:"charge,payment,waiver"`
+	Amount    money.Amount `json:"amount" swaggertype:"string" example:"2.50"`
+	Note      string       `json:"note,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type FineAccountResponse struct {
+	MemberID string              `json:"member_id"`
+	Balance  money.Amount        `json:"balance" swaggertype:"string" example:"7.25"`
+	Entries  []FineEntryResponse `json:"entries"`
+}
+
+func ToFineEntryResponse(entry *entity.FineEntry) *FineEntryResponse {
+	response := &FineEntryResponse{
+		ID:        entry.ID.String(),
+		MemberID:  entry.MemberID.String(),
+		Kind:      entry.Kind,
+		Amount:    entry.Amount,
+		Note:      entry.Note,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.LoanID != nil {
+		response.LoanID = entry.LoanID.String()
+	}
+
+	return response
+}
+
+func ToFineAccountResponse(account *entity.FineAccount) *FineAccountResponse {
+	entries := make([]FineEntryResponse, len(account.Entries))
+	for i, entry := range account.Entries {
+		entries[i] = *ToFineEntryResponse(entry)
+	}
+
+	return &FineAccountResponse{
+		MemberID: account.MemberID.String(),
+		Balance:  account.Balance,
+		Entries:  entries,
+	}
+}
@@ -0,0 +1,11 @@
+package fine
+
+import "errors"
+
+var (
+	ErrInvalidMemberID = errors.New("invalid member ID")
+	ErrInvalidLoanID   = errors.New("invalid loan ID")
+	ErrLoanNotFound    = errors.New("loan not found")
+	ErrOverpayment     = errors.New("amount is more than the balance owed")
+	ErrMemberBlocked   = errors.New("member owes fines above the checkout limit")
+)
@@ -0,0 +1,218 @@
+package fine
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/fine/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type FineAccountSuccessResponse struct {
+	Status  string                   `json:"status" example:"success"`
+	Message string                   `json:"message" example:"Fines retrieved successfully"`
+	Account *dto.FineAccountResponse `json:"account"`
+}
+
+type FineEntrySuccessResponse struct {
+	Status  string                 `json:"status" example:"success"`
+	Message string                 `json:"message" example:"Payment recorded successfully"`
+	Entry   *dto.FineEntryResponse `json:"entry"`
+}
+
+type FineHandler struct {
+	service   FineService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewFineHandler(service FineService, validator *internalValidator.Validator, logger zerolog.Logger) *FineHandler {
+	return &FineHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *FineHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// a member reads their own ledger
+	r.With(auth.Require).Get("/", h.GetAccount)
+
+	// the ledger is settled by the staff only
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleLibrarian))
+
+		r.Post("/payments", h.RecordPayment)
+		r.Post("/waivers", h.WaiveFine)
+	})
+
+	return r
+}
+
+// GetAccount godoc
+//
+//	@Summary		Get the fines of a member
+//	@Description	Get the fines ledger of a member, the charges, payments and waivers oldest first, with the balance owed. Amounts are decimal strings. A member only gets their own ledger, whose ID is the subject of the access token.
+//	@Tags			fines
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			member_id		query		string	true	"Member ID"
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	FineAccountSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/fines [get]
+func (h *FineHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewAccountFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), filter.MemberID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	account, err := h.service.GetAccount(r.Context(), uuid.MustParse(filter.MemberID))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, FineAccountSuccessResponse{
+		Status:  "success",
+		Message: "Fines retrieved successfully",
+		Account: dto.ToFineAccountResponse(account),
+	})
+}
+
+// RecordPayment godoc
+//
+//	@Summary		Record a payment
+//	@Description	Record a payment of fines by a member, up to the balance owed
+//	@Tags			fines
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			payment			body		dto.PaymentRequest	true	"Payment data"
+//	@Param			Accept-Language	header		string				false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string				false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	FineEntrySuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/fines/payments [post]
+func (h *FineHandler) RecordPayment(w http.ResponseWriter, r *http.Request) {
+	var req dto.PaymentRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	entry, err := h.service.RecordPayment(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, FineEntrySuccessResponse{
+		Status:  "success",
+		Message: "Payment recorded successfully",
+		Entry:   dto.ToFineEntryResponse(entry),
+	})
+}
+
+// WaiveFine godoc
+//
+//	@Summary		Waive fines
+//	@Description	Forgive a member part of the balance owed, optionally for the fine of one of their loans. The note gives the reason of the waiver.
+//	@Tags			fines
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			waiver			body		dto.WaiverRequest	true	"Waiver data"
+//	@Param			Accept-Language	header		string				false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string				false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	FineEntrySuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/fines/waivers [post]
+func (h *FineHandler) WaiveFine(w http.ResponseWriter, r *http.Request) {
+	var req dto.WaiverRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	entry, err := h.service.WaiveFine(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, FineEntrySuccessResponse{
+		Status:  "success",
+		Message: "Fine waived successfully",
+		Entry:   dto.ToFineEntryResponse(entry),
+	})
+}
+
+func (h *FineHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, member.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Member not found")
+	case errors.Is(err, ErrLoanNotFound):
+		response.Error(w, http.StatusNotFound, "Loan not found")
+	case errors.Is(err, ErrInvalidMemberID):
+		response.Error(w, http.StatusBadRequest, "invalid member ID")
+	case errors.Is(err, ErrInvalidLoanID):
+		response.Error(w, http.StatusBadRequest, "invalid loan ID")
+	case errors.Is(err, ErrOverpayment):
+		response.Error(w, http.StatusConflict, "Amount is more than the balance owed")
+	case errors.Is(err, auth.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "Forbidden")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,241 @@
+package fine_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/fine/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/money"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestFineHandler_GetAccount(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	owner := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		query              string
+		configureMock      func(*mocks.MockFineService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:     "success get account",
+			identity: owner,
+			query:    "?member_id=" + memberID.String(),
+			configureMock: func(mockService *mocks.MockFineService) {
+				mockService.EXPECT().
+					GetAccount(gomock.Any(), memberID).
+					Return(&entity.FineAccount{
+						MemberID: memberID,
+						Balance:  money.MustParse("1.25"),
+						Entries: []*entity.FineEntry{{
+							ID:        entryID,
+							MemberID:  memberID,
+							LoanID:    &loanID,
+							Kind:      entity.FineEntryCharge,
+							Amount:    money.MustParse("1.25"),
+							Note:      "Overdue fine, 5 days late",
+							CreatedAt: createdAt,
+						}},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"status":"success","message":"Fines retrieved successfully","account":{
+				"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","balance":"1.25","entries":[{
+					"id":"3f2a1b0c-9d8e-4f7a-b6c5-d4e3f2a1b0c9","member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					"loan_id":"0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b","kind":"charge","amount":"1.25",
+					"note":"Overdue fine, 5 days late","created_at":"2024-03-01T10:00:00Z"}]}}`,
+		},
+		{
+			name:     "success librarian gets the account of a member",
+			identity: &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			query:    "?member_id=" + memberID.String(),
+			configureMock: func(mockService *mocks.MockFineService) {
+				mockService.EXPECT().
+					GetAccount(gomock.Any(), memberID).
+					Return(&entity.FineAccount{MemberID: memberID, Entries: []*entity.FineEntry{}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody: `{"status":"success","message":"Fines retrieved successfully","account":{
+				"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","balance":"0.00","entries":[]}}`,
+		},
+		{
+			name:               "error account of another member",
+			identity:           &auth.Identity{Subject: "61c8f3d2-7a4e-4b9f-a2c5-e8d1b6f0a347"},
+			query:              "?member_id=" + memberID.String(),
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"status":"error","message":"Forbidden"}`,
+		},
+		{
+			name:               "error anonymous caller",
+			query:              "?member_id=" + memberID.String(),
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"status":"error","message":"Unauthorized"}`,
+		},
+		{
+			name:               "error member id required",
+			identity:           owner,
+			query:              "",
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"status":"error","message":"Validation failed","errors":[{"field":"member_id","message":"member_id is required"}]}`,
+		},
+		{
+			name:     "error member not found",
+			identity: owner,
+			query:    "?member_id=" + memberID.String(),
+			configureMock: func(mockService *mocks.MockFineService) {
+				mockService.EXPECT().
+					GetAccount(gomock.Any(), memberID).
+					Return(nil, member.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       `{"status":"error","message":"Member not found"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockFineService(ctrl)
+			test.configureMock(mockService)
+
+			handler := fine.NewFineHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/fines"+test.query, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/fines", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.JSONEq(t, test.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestFineHandler_RecordPayment(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        string
+		configureMock      func(*mocks.MockFineService)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:        "success record payment",
+			identity:    librarian,
+			requestBody: `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"2.50"}`,
+			configureMock: func(mockService *mocks.MockFineService) {
+				input := &dto.PaymentRequest{MemberID: memberID.String(), Amount: money.MustParse("2.50")}
+
+				mockService.EXPECT().
+					RecordPayment(gomock.Any(), input).
+					Return(&entity.FineEntry{ID: entryID, MemberID: memberID, Kind: entity.FineEntryPayment, Amount: input.Amount}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedBody: `{"status":"success","message":"Payment recorded successfully","entry":{
+				"id":"3f2a1b0c-9d8e-4f7a-b6c5-d4e3f2a1b0c9","member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51",
+				"kind":"payment","amount":"2.50","created_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:               "error anonymous caller",
+			requestBody:        `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"2.50"}`,
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"status":"error","message":"Unauthorized"}`,
+		},
+		{
+			name:               "error caller is not a librarian",
+			identity:           &auth.Identity{Subject: memberID.String()},
+			requestBody:        `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"2.50"}`,
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"status":"error","message":"Forbidden"}`,
+		},
+		{
+			name:               "error amount with too many decimals",
+			identity:           librarian,
+			requestBody:        `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"2.505"}`,
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"status":"error","message":"Invalid request body","errors":[{"field":"","message":"invalid amount, expected a decimal with at most 2 decimals such as \"2.50\""}]}`,
+		},
+		{
+			name:               "error amount zero",
+			identity:           librarian,
+			requestBody:        `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"0"}`,
+			configureMock:      func(mockService *mocks.MockFineService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"status":"error","message":"Validation failed","errors":[{"field":"amount","message":"amount must be greater than 0"}]}`,
+		},
+		{
+			name:        "error overpayment",
+			identity:    librarian,
+			requestBody: `{"member_id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","amount":"20.00"}`,
+			configureMock: func(mockService *mocks.MockFineService) {
+				mockService.EXPECT().
+					RecordPayment(gomock.Any(), gomock.Any()).
+					Return(nil, fine.ErrOverpayment)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedBody:       `{"status":"error","message":"Amount is more than the balance owed"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockFineService(ctrl)
+			test.configureMock(mockService)
+
+			handler := fine.NewFineHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, "/fines/payments", bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/fines", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+			assert.JSONEq(t, test.expectedBody, w.Body.String())
+		})
+	}
+}
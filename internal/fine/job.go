@@ -0,0 +1,67 @@
+package fine
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+)
+
+// AccrualJob charges the fines of the overdue loans every night. The
+// accrual is idempotent, it also runs on start to catch up with the nights
+// missed while the API was down.
+type AccrualJob struct {
+	service FineService
+	hour    int
+	logger  zerolog.Logger
+}
+
+func NewAccrualJob(service FineService, cfg config.FineConfig, logger zerolog.Logger) *AccrualJob {
+	return &AccrualJob{
+		service: service,
+		hour:    cfg.AccrualHour,
+		logger:  logger,
+	}
+}
+
+// Run accrues the fines on start, then every night until ctx is done.
+func (j *AccrualJob) Run(ctx context.Context) {
+	for {
+		j.Accrue(ctx)
+
+		timer := time.NewTimer(time.Until(j.NextRun(time.Now())))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// NextRun returns the first accrual hour, in UTC, after now.
+func (j *AccrualJob) NextRun(now time.Time) time.Time {
+	now = now.UTC()
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), j.hour, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+
+	return next
+}
+
+// Accrue makes a single accrual pass over the overdue loans.
+func (j *AccrualJob) Accrue(ctx context.Context) {
+	charges, err := j.service.AccrueFines(ctx)
+	if err != nil && ctx.Err() == nil {
+		j.logger.Error().Err(err).Msg("failed to accrue fines")
+	}
+
+	if charges > 0 {
+		j.logger.Info().Int("charges", charges).Msg("fines accrued")
+	}
+}
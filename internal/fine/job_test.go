@@ -0,0 +1,55 @@
+package fine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+)
+
+func TestAccrualJob_NextRun(t *testing.T) {
+	job := fine.NewAccrualJob(nil, fineConfig, zerolog.Nop())
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "later the same night",
+			now:      time.Date(2024, 3, 1, 0, 30, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "the next night",
+			now:      time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "in UTC",
+			now:      time.Date(2024, 3, 1, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)),
+			expected: time.Date(2024, 3, 3, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, job.NextRun(test.now))
+		})
+	}
+}
+
+func TestAccrualJob_Accrue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	service := mocks.NewMockFineService(ctrl)
+	service.EXPECT().AccrueFines(gomock.Any()).Return(3, nil)
+
+	fine.NewAccrualJob(service, fineConfig, zerolog.Nop()).Accrue(context.Background())
+}
@@ -0,0 +1,96 @@
+package fine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/money"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+)
+
+// Ledger charges the fines of the overdue loans, and keeps the members owing
+// too much from borrowing. Its methods must run inside the transaction carried
+// by ctx.
+//
+//go:generate mockgen -destination=../mocks/mock_fine_ledger.go -package=mocks go-boilerplate-rest-api-chi/internal/fine Ledger
+type Ledger interface {
+	// Accrue charges the part of the fine of the loan not charged yet and
+	// returns the charge, or nil when nothing is due. The loan must be
+	// locked, with its copy.
+	Accrue(ctx context.Context, loan *entity.Loan) (*entity.FineEntry, error)
+	// CheckStanding returns ErrMemberBlocked when the member owes more than
+	// the block threshold. The member must be locked.
+	CheckStanding(ctx context.Context, memberID uuid.UUID) error
+}
+
+type ledger struct {
+	repository FineRepository
+	schedule   Schedule
+	outbox     outbox.Outbox
+	threshold  money.Amount
+	now        func() time.Time
+}
+
+func NewLedger(repository FineRepository, outbox outbox.Outbox, cfg config.FineConfig) Ledger {
+	return &ledger{
+		repository: repository,
+		schedule:   NewSchedule(cfg),
+		outbox:     outbox,
+		threshold:  cfg.BlockThreshold,
+		now:        time.Now,
+	}
+}
+
+func (l *ledger) Accrue(ctx context.Context, loan *entity.Loan) (*entity.FineEntry, error) {
+	now := l.now().UTC()
+
+	due := l.schedule.Fine(loan, now)
+	if due == 0 {
+		return nil, nil
+	}
+
+	charged, err := l.repository.GetCharged(ctx, loan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if due <= charged {
+		return nil, nil
+	}
+
+	entry, err := l.repository.Create(ctx, &entity.FineEntry{
+		MemberID: loan.MemberID,
+		LoanID:   &loan.ID,
+		Kind:     entity.FineEntryCharge,
+		Amount:   due - charged,
+		Note:     fmt.Sprintf("Overdue fine, %d days late", DaysLate(loan, now)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.outbox.Record(ctx, event.NewFineRecorded(entry)); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (l *ledger) CheckStanding(ctx context.Context, memberID uuid.UUID) error {
+	balance, err := l.repository.GetBalance(ctx, memberID)
+	if err != nil {
+		return err
+	}
+
+	if balance > l.threshold {
+		return ErrMemberBlocked
+	}
+
+	return nil
+}
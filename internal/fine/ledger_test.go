@@ -0,0 +1,138 @@
+package fine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+var (
+	memberID = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	loanID   = uuid.MustParse("0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b")
+	entryID  = uuid.MustParse("3f2a1b0c-9d8e-4f7a-b6c5-d4e3f2a1b0c9")
+)
+
+func TestLedger_Accrue(t *testing.T) {
+	// 5 days late, past the grace period of 2 days: 1.25 capped to 2.00
+	overdue := &entity.Loan{ID: loanID, MemberID: memberID, DueAt: time.Now().UTC().Add(-5*24*time.Hour - time.Hour)}
+
+	tests := []struct {
+		name           string
+		charged        money.Amount
+		expectedCharge money.Amount
+	}{
+		{
+			name:           "first accrual",
+			charged:        0,
+			expectedCharge: money.MustParse("1.25"),
+		},
+		{
+			name:           "only the days since the last accrual",
+			charged:        money.MustParse("1.00"),
+			expectedCharge: money.MustParse("0.25"),
+		},
+		{
+			name:    "already charged",
+			charged: money.MustParse("1.25"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := mocks.NewMockFineRepository(ctrl)
+			outbox := mocks.NewMockOutbox(ctrl)
+
+			repository.EXPECT().GetCharged(gomock.Any(), loanID).Return(test.charged, nil)
+
+			if test.expectedCharge > 0 {
+				repository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+						entry.ID = entryID
+						return entry, nil
+					})
+				outbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						require.Len(t, events, 1)
+						assert.Equal(t, event.FineCharged, events[0].Type)
+						return nil
+					})
+			}
+
+			got, err := fine.NewLedger(repository, outbox, fineConfig).Accrue(context.Background(), overdue)
+
+			require.NoError(t, err)
+
+			if test.expectedCharge == 0 {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, entity.FineEntryCharge, got.Kind)
+			assert.Equal(t, test.expectedCharge, got.Amount)
+			assert.Equal(t, &loanID, got.LoanID)
+			assert.Equal(t, memberID, got.MemberID)
+			assert.Equal(t, "Overdue fine, 5 days late", got.Note)
+		})
+	}
+
+	t.Run("nothing due within the grace period", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		ledger := fine.NewLedger(mocks.NewMockFineRepository(ctrl), mocks.NewMockOutbox(ctrl), fineConfig)
+
+		got, err := ledger.Accrue(context.Background(), &entity.Loan{ID: loanID, DueAt: time.Now().Add(-25 * time.Hour)})
+
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestLedger_CheckStanding(t *testing.T) {
+	tests := []struct {
+		name          string
+		balance       money.Amount
+		expectedError error
+	}{
+		{
+			name:    "at the threshold",
+			balance: money.MustParse("5.00"),
+		},
+		{
+			name:          "above the threshold",
+			balance:       money.MustParse("5.01"),
+			expectedError: fine.ErrMemberBlocked,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := mocks.NewMockFineRepository(ctrl)
+
+			repository.EXPECT().GetBalance(gomock.Any(), memberID).Return(test.balance, nil)
+
+			err := fine.NewLedger(repository, mocks.NewMockOutbox(ctrl), fineConfig).CheckStanding(context.Background(), memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package fine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/money"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_fine_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/fine FineRepository
+type FineRepository interface {
+	Create(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error)
+	// GetByMemberID returns the ledger of the member, oldest entry first.
+	GetByMemberID(ctx context.Context, memberID uuid.UUID) ([]*entity.FineEntry, error)
+	// GetBalance returns the amount owed by the member, the charges less the
+	// payments and the waivers.
+	GetBalance(ctx context.Context, memberID uuid.UUID) (money.Amount, error)
+	// GetCharged returns the total of the charges for the loan, the waivers do
+	// not count so that a waived fine is not charged again.
+	GetCharged(ctx context.Context, loanID uuid.UUID) (money.Amount, error)
+	// GetOverdueLoanIDs returns the ids of the loans still out past their due
+	// date at now, in id order after the given id, up to limit.
+	GetOverdueLoanIDs(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	GetLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+	// LockLoan reads the loan with its copy and locks its row until the end
+	// of the transaction carried by ctx.
+	LockLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+}
+
+type fineRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewFineRepository(db *gorm.DB, logger zerolog.Logger) FineRepository {
+	return &fineRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *fineRepository) Create(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(entry).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *fineRepository) GetByMemberID(ctx context.Context, memberID uuid.UUID) ([]*entity.FineEntry, error) {
+	var entries []*entity.FineEntry
+
+	err := transaction.DB(ctx, r.db).
+		Where("member_id = ?", memberID).
+		Order("created_at, id").
+		Find(&entries).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *fineRepository) GetBalance(ctx context.Context, memberID uuid.UUID) (money.Amount, error) {
+	var balance money.Amount
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.FineEntry{}).
+		Select("COALESCE(SUM(CASE WHEN kind = ? THEN amount ELSE -amount END), 0)", entity.FineEntryCharge).
+		Where("member_id = ?", memberID).
+		Scan(&balance).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (r *fineRepository) GetCharged(ctx context.Context, loanID uuid.UUID) (money.Amount, error) {
+	var charged money.Amount
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.FineEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("loan_id = ? AND kind = ?", loanID, entity.FineEntryCharge).
+		Scan(&charged).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return 0, err
+	}
+
+	return charged, nil
+}
+
+func (r *fineRepository) GetOverdueLoanIDs(ctx context.Context, now time.Time, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var loanIDs []uuid.UUID
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Loan{}).
+		Where("returned_at IS NULL AND due_at < ? AND id > ?", now, after).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &loanIDs).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loanIDs, nil
+}
+
+func (r *fineRepository) GetLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	return r.loan(transaction.DB(ctx, r.db), loanID)
+}
+
+func (r *fineRepository) LockLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	return r.loan(transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), loanID)
+}
+
+func (r *fineRepository) loan(query *gorm.DB, loanID uuid.UUID) (*entity.Loan, error) {
+	var loan *entity.Loan
+
+	if err := query.Preload("Copy").First(&loan, "id = ?", loanID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLoanNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loan, nil
+}
@@ -0,0 +1,167 @@
+package fine_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/money"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestFineRepository_GetBalance(t *testing.T) {
+	tests := []struct {
+		name            string
+		configureMock   func(sqlmock.Sqlmock)
+		expectedError   error
+		expectedBalance money.Amount
+	}{
+		{
+			name: "success charges less payments and waivers",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN kind = \? THEN amount ELSE -amount END\), 0\) FROM .fine_entries. WHERE member_id = \?`).
+					WithArgs(entity.FineEntryCharge, memberID).
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(200))
+			},
+			expectedBalance: money.MustParse("2.00"),
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN kind = \? THEN amount ELSE -amount END\), 0\) FROM .fine_entries. WHERE member_id = \?`).
+					WithArgs(entity.FineEntryCharge, memberID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := fine.NewFineRepository(db, zerolog.Nop())
+
+			balance, err := repo.GetBalance(context.Background(), memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedBalance, balance)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFineRepository_GetCharged(t *testing.T) {
+	tests := []struct {
+		name            string
+		configureMock   func(sqlmock.Sqlmock)
+		expectedError   error
+		expectedCharged money.Amount
+	}{
+		{
+			name: "success sums the charges only",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM .fine_entries. WHERE loan_id = \? AND kind = \?`).
+					WithArgs(loanID, entity.FineEntryCharge).
+					WillReturnRows(sqlmock.NewRows([]string{"charged"}).AddRow(400))
+			},
+			expectedCharged: money.MustParse("4.00"),
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM .fine_entries. WHERE loan_id = \? AND kind = \?`).
+					WithArgs(loanID, entity.FineEntryCharge).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := fine.NewFineRepository(db, zerolog.Nop())
+
+			charged, err := repo.GetCharged(context.Background(), loanID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCharged, charged)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFineRepository_GetOverdueLoanIDs(t *testing.T) {
+	now := time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC)
+	after := uuid.MustParse("00000000-0000-4000-8000-000000000001")
+
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedResponse []uuid.UUID
+	}{
+		{
+			name: "success get the page after the last loan",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .id. FROM .loans. WHERE returned_at IS NULL AND due_at < \? AND id > \? ORDER BY id LIMIT \?`).
+					WithArgs(now, after, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(loanID))
+			},
+			expectedResponse: []uuid.UUID{loanID},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .id. FROM .loans. WHERE returned_at IS NULL AND due_at < \? AND id > \? ORDER BY id LIMIT \?`).
+					WithArgs(now, after, 10).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := fine.NewFineRepository(db, zerolog.Nop())
+
+			loanIDs, err := repo.GetOverdueLoanIDs(context.Background(), now, after, 10)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, loanIDs)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse, loanIDs)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
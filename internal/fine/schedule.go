@@ -0,0 +1,108 @@
+package fine
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+// Policy is the fine of the overdue copies of an item type.
+type Policy struct {
+	DailyRate money.Amount
+	// Cap bounds the fine of a loan, 0 for no cap.
+	Cap       money.Amount
+	GraceDays int
+}
+
+// Schedule holds the policies of the item types.
+type Schedule struct {
+	policies map[string]Policy
+	standard Policy
+}
+
+// NewSchedule reads the policies from the configuration, the settings missing
+// for an item type are the ones of the standard item type.
+func NewSchedule(cfg config.FineConfig) Schedule {
+	standard := Policy{
+		DailyRate: cfg.DailyRates[entity.ItemTypeStandard],
+		Cap:       cfg.Caps[entity.ItemTypeStandard],
+		GraceDays: cfg.GraceDays[entity.ItemTypeStandard],
+	}
+
+	policies := make(map[string]Policy)
+	policy := func(itemType string) Policy {
+		if p, ok := policies[itemType]; ok {
+			return p
+		}
+		return standard
+	}
+
+	for itemType, rate := range cfg.DailyRates {
+		p := policy(itemType)
+		p.DailyRate = rate
+		policies[itemType] = p
+	}
+
+	for itemType, limit := range cfg.Caps {
+		p := policy(itemType)
+		p.Cap = limit
+		policies[itemType] = p
+	}
+
+	for itemType, days := range cfg.GraceDays {
+		p := policy(itemType)
+		p.GraceDays = days
+		policies[itemType] = p
+	}
+
+	return Schedule{policies: policies, standard: standard}
+}
+
+// Policy returns the policy of the item type.
+func (s Schedule) Policy(itemType string) Policy {
+	if p, ok := s.policies[itemType]; ok {
+		return p
+	}
+	return s.standard
+}
+
+// DaysLate returns the whole days the loan is late at at, or was late when
+// returned.
+func DaysLate(loan *entity.Loan, at time.Time) int {
+	if loan.ReturnedAt != nil {
+		at = *loan.ReturnedAt
+	}
+
+	if !at.After(loan.DueAt) {
+		return 0
+	}
+
+	return int(at.Sub(loan.DueAt) / (24 * time.Hour))
+}
+
+// Fine returns the fine the loan ran up at at, or when returned. Nothing is
+// owed within the grace period, past it every day late is charged, up to the
+// cap. The loans made before the copies were inventoried follow the standard
+// policy.
+func (s Schedule) Fine(loan *entity.Loan, at time.Time) money.Amount {
+	itemType := entity.ItemTypeStandard
+	if loan.Copy != nil {
+		itemType = loan.Copy.ItemType
+	}
+
+	policy := s.Policy(itemType)
+
+	days := DaysLate(loan, at)
+	if days == 0 || days <= policy.GraceDays {
+		return 0
+	}
+
+	fine := policy.DailyRate.Times(days)
+	if policy.Cap > 0 {
+		fine = money.Min(fine, policy.Cap)
+	}
+
+	return fine
+}
@@ -0,0 +1,102 @@
+package fine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+var fineConfig = config.FineConfig{
+	DailyRates:       map[string]money.Amount{"standard": money.MustParse("0.25"), "dvd": money.MustParse("1.00")},
+	Caps:             map[string]money.Amount{"standard": money.MustParse("2.00")},
+	GraceDays:        map[string]int{"standard": 2, "dvd": 0},
+	BlockThreshold:   money.MustParse("5.00"),
+	AccrualHour:      2,
+	AccrualBatchSize: 2,
+}
+
+func TestSchedule_Policy(t *testing.T) {
+	schedule := fine.NewSchedule(fineConfig)
+
+	assert.Equal(t, fine.Policy{DailyRate: 25, Cap: 200, GraceDays: 2}, schedule.Policy("standard"))
+	assert.Equal(t, fine.Policy{DailyRate: 100, Cap: 200, GraceDays: 0}, schedule.Policy("dvd"), "the cap falls back on the standard one")
+	assert.Equal(t, schedule.Policy("standard"), schedule.Policy("magazine"))
+}
+
+func TestSchedule_Fine(t *testing.T) {
+	schedule := fine.NewSchedule(fineConfig)
+
+	dueAt := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name         string
+		itemType     string
+		at           time.Time
+		returned     bool
+		expectedFine money.Amount
+	}{
+		{
+			name:         "not overdue",
+			at:           dueAt.Add(-time.Hour),
+			expectedFine: 0,
+		},
+		{
+			name:         "less than a day late",
+			at:           dueAt.Add(23 * time.Hour),
+			expectedFine: 0,
+		},
+		{
+			name:         "within the grace period",
+			at:           dueAt.Add(2 * day),
+			expectedFine: 0,
+		},
+		{
+			name:         "past the grace period every day late is charged",
+			at:           dueAt.Add(3*day + time.Hour),
+			expectedFine: money.MustParse("0.75"),
+		},
+		{
+			name:         "capped",
+			at:           dueAt.Add(30 * day),
+			expectedFine: money.MustParse("2.00"),
+		},
+		{
+			name:         "item type without grace period",
+			itemType:     "dvd",
+			at:           dueAt.Add(day),
+			expectedFine: money.MustParse("1.00"),
+		},
+		{
+			name:         "returned loans stop running up",
+			at:           dueAt.Add(4 * day),
+			returned:     true,
+			expectedFine: money.MustParse("1.00"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loan := &entity.Loan{DueAt: dueAt}
+
+			if test.itemType != "" {
+				loan.Copy = &entity.Copy{ItemType: test.itemType}
+			}
+
+			at := test.at
+			if test.returned {
+				returnedAt := test.at
+				loan.ReturnedAt = &returnedAt
+				at = returnedAt.Add(10 * day)
+			}
+
+			assert.Equal(t, test.expectedFine, schedule.Fine(loan, at))
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package fine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/fine/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/money"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_fine_service.go -package=mocks go-boilerplate-rest-api-chi/internal/fine FineService
+type FineService interface {
+	// GetAccount returns the fines ledger of the member with the balance owed.
+	GetAccount(ctx context.Context, memberID uuid.UUID) (*entity.FineAccount, error)
+	// RecordPayment records a payment of the member, up to the balance owed.
+	RecordPayment(ctx context.Context, req *dto.PaymentRequest) (*entity.FineEntry, error)
+	// WaiveFine forgives the member part of the balance owed.
+	WaiveFine(ctx context.Context, req *dto.WaiverRequest) (*entity.FineEntry, error)
+	// AccrueFines charges the fines the overdue loans ran up since the last
+	// accrual and returns the number of charges. Running it again the same
+	// day charges nothing more. A loan failing to accrue is skipped, its
+	// error is returned along with the ones of the other loans once the pass
+	// is over.
+	AccrueFines(ctx context.Context) (int, error)
+}
+
+type fineService struct {
+	repository       FineRepository
+	memberRepository member.MemberRepository
+	ledger           Ledger
+	transactions     transaction.Manager
+	outbox           outbox.Outbox
+	batchSize        int
+	logger           zerolog.Logger
+}
+
+func NewFineService(repository FineRepository, memberRepository member.MemberRepository, ledger Ledger, transactions transaction.Manager, outbox outbox.Outbox, cfg config.FineConfig, logger zerolog.Logger) FineService {
+	return &fineService{
+		repository:       repository,
+		memberRepository: memberRepository,
+		ledger:           ledger,
+		transactions:     transactions,
+		outbox:           outbox,
+		batchSize:        cfg.AccrualBatchSize,
+		logger:           logger,
+	}
+}
+
+func (s *fineService) GetAccount(ctx context.Context, memberID uuid.UUID) (*entity.FineAccount, error) {
+	if _, err := s.memberRepository.GetByID(ctx, memberID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repository.GetByMemberID(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the balance is summed up from the entries read, so that both match
+	var balance money.Amount
+	for _, entry := range entries {
+		if entry.Kind == entity.FineEntryCharge {
+			balance += entry.Amount
+		} else {
+			balance -= entry.Amount
+		}
+	}
+
+	return &entity.FineAccount{
+		MemberID: memberID,
+		Balance:  balance,
+		Entries:  entries,
+	}, nil
+}
+
+func (s *fineService) RecordPayment(ctx context.Context, req *dto.PaymentRequest) (*entity.FineEntry, error) {
+	memberID, err := uuid.Parse(req.MemberID)
+	if err != nil {
+		return nil, ErrInvalidMemberID
+	}
+
+	return s.credit(ctx, &entity.FineEntry{
+		MemberID: memberID,
+		Kind:     entity.FineEntryPayment,
+		Amount:   req.Amount,
+		Note:     req.Note,
+	})
+}
+
+func (s *fineService) WaiveFine(ctx context.Context, req *dto.WaiverRequest) (*entity.FineEntry, error) {
+	memberID, err := uuid.Parse(req.MemberID)
+	if err != nil {
+		return nil, ErrInvalidMemberID
+	}
+
+	waiver := &entity.FineEntry{
+		MemberID: memberID,
+		Kind:     entity.FineEntryWaiver,
+		Amount:   req.Amount,
+		Note:     req.Note,
+	}
+
+	if req.LoanID != "" {
+		loanID, err := uuid.Parse(req.LoanID)
+		if err != nil {
+			return nil, ErrInvalidLoanID
+		}
+
+		loan, err := s.repository.GetLoan(ctx, loanID)
+		if err != nil {
+			return nil, err
+		}
+
+		// a waiver only applies to the loans of the member
+		if loan.MemberID != memberID {
+			return nil, ErrLoanNotFound
+		}
+
+		waiver.LoanID = &loanID
+	}
+
+	return s.credit(ctx, waiver)
+}
+
+func (s *fineService) AccrueFines(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	after := uuid.Nil
+	charges := 0
+
+	var errs []error
+
+	for {
+		loanIDs, err := s.repository.GetOverdueLoanIDs(ctx, now, after, s.batchSize)
+		if err != nil {
+			return charges, errors.Join(append(errs, err)...)
+		}
+
+		// every loan is charged in its own transaction, a failure neither
+		// rolls back the charges already made nor holds back the next loans
+		for _, loanID := range loanIDs {
+			var charge *entity.FineEntry
+
+			err := s.transactions.Do(ctx, func(ctx context.Context) error {
+				loan, err := s.repository.LockLoan(ctx, loanID)
+				if err != nil {
+					return err
+				}
+
+				// returned in the meantime, the fine was charged on return
+				if loan.ReturnedAt != nil {
+					return nil
+				}
+
+				charge, err = s.ledger.Accrue(ctx, loan)
+				return err
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return charges, ctx.Err()
+				}
+
+				s.logger.Error().Err(err).Str("loan_id", loanID.String()).Msg("failed to accrue fine")
+				errs = append(errs, fmt.Errorf("loan %s: %w", loanID, err))
+				continue
+			}
+
+			if charge != nil {
+				charges++
+			}
+		}
+
+		if len(loanIDs) < s.batchSize {
+			return charges, errors.Join(errs...)
+		}
+
+		after = loanIDs[len(loanIDs)-1]
+	}
+}
+
+// credit records a payment or a waiver, which cannot take the balance of the
+// member below zero.
+func (s *fineService) credit(ctx context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		// the member stays locked until the entry is committed, two payments
+		// cannot both settle the same balance
+		if _, err := s.memberRepository.LockByID(ctx, entry.MemberID); err != nil {
+			return err
+		}
+
+		balance, err := s.repository.GetBalance(ctx, entry.MemberID)
+		if err != nil {
+			return err
+		}
+
+		if entry.Amount > balance {
+			return ErrOverpayment
+		}
+
+		if entry, err = s.repository.Create(ctx, entry); err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewFineRecorded(entry))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
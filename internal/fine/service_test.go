@@ -0,0 +1,348 @@
+package fine_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/fine/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/money"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestFineService_GetAccount(t *testing.T) {
+	tests := []struct {
+		name            string
+		configureMock   func(*mocks.MockFineRepository, *mocks.MockMemberRepository)
+		expectedBalance money.Amount
+		expectedEntries int
+		expectedError   error
+	}{
+		{
+			name: "success get account",
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository) {
+				mockMemberRepo.EXPECT().
+					GetByID(gomock.Any(), memberID).
+					Return(&entity.Member{ID: memberID}, nil)
+
+				mockRepo.EXPECT().
+					GetByMemberID(gomock.Any(), memberID).
+					Return([]*entity.FineEntry{
+						{Kind: entity.FineEntryCharge, Amount: money.MustParse("4.00")},
+						{Kind: entity.FineEntryPayment, Amount: money.MustParse("1.50")},
+						{Kind: entity.FineEntryWaiver, Amount: money.MustParse("0.50")},
+					}, nil)
+			},
+			expectedBalance: money.MustParse("2.00"),
+			expectedEntries: 3,
+		},
+		{
+			name: "error member not found",
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository) {
+				mockMemberRepo.EXPECT().
+					GetByID(gomock.Any(), memberID).
+					Return(nil, member.ErrNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			fineRepoMock := mocks.NewMockFineRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+
+			test.configureMock(fineRepoMock, memberRepoMock)
+			service := fine.NewFineService(fineRepoMock, memberRepoMock, mocks.NewMockLedger(ctrl), testutils.NewTransactionManager(ctrl), mocks.NewMockOutbox(ctrl), fineConfig, zerolog.Nop())
+
+			result, err := service.GetAccount(context.Background(), memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedBalance, result.Balance)
+			assert.Len(t, result.Entries, test.expectedEntries)
+		})
+	}
+}
+
+func TestFineService_RecordPayment(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.PaymentRequest
+		configureMock    func(*mocks.MockFineRepository, *mocks.MockMemberRepository, *mocks.MockOutbox)
+		expectedResponse *entity.FineEntry
+		expectedError    error
+	}{
+		{
+			name:  "success settles the balance",
+			input: &dto.PaymentRequest{MemberID: memberID.String(), Amount: money.MustParse("3.00")},
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().
+					LockByID(gomock.Any(), memberID).
+					Return(&entity.Member{ID: memberID}, nil)
+
+				mockRepo.EXPECT().
+					GetBalance(gomock.Any(), memberID).
+					Return(money.MustParse("3.00"), nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+						entry.ID = entryID
+						return entry, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.FinePaid, events[0].Type)
+						assert.Equal(t, entryID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.FineEntry{ID: entryID, MemberID: memberID, Kind: entity.FineEntryPayment, Amount: money.MustParse("3.00")},
+		},
+		{
+			name:  "error more than the balance",
+			input: &dto.PaymentRequest{MemberID: memberID.String(), Amount: money.MustParse("3.01")},
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().
+					LockByID(gomock.Any(), memberID).
+					Return(&entity.Member{ID: memberID}, nil)
+
+				mockRepo.EXPECT().
+					GetBalance(gomock.Any(), memberID).
+					Return(money.MustParse("3.00"), nil)
+			},
+			expectedError: fine.ErrOverpayment,
+		},
+		{
+			name:  "error member not found",
+			input: &dto.PaymentRequest{MemberID: memberID.String(), Amount: money.MustParse("3.00")},
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().
+					LockByID(gomock.Any(), memberID).
+					Return(nil, member.ErrNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			fineRepoMock := mocks.NewMockFineRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(fineRepoMock, memberRepoMock, outboxMock)
+			service := fine.NewFineService(fineRepoMock, memberRepoMock, mocks.NewMockLedger(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, fineConfig, zerolog.Nop())
+
+			result, err := service.RecordPayment(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse.ID, result.ID)
+			assert.Equal(t, test.expectedResponse.MemberID, result.MemberID)
+			assert.Equal(t, test.expectedResponse.Kind, result.Kind)
+			assert.Equal(t, test.expectedResponse.Amount, result.Amount)
+		})
+	}
+}
+
+func TestFineService_WaiveFine(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.WaiverRequest
+		configureMock    func(*mocks.MockFineRepository, *mocks.MockMemberRepository, *mocks.MockOutbox)
+		expectedResponse *entity.FineEntry
+		expectedError    error
+	}{
+		{
+			name:  "success waive fine",
+			input: &dto.WaiverRequest{MemberID: memberID.String(), LoanID: loanID.String(), Amount: money.MustParse("1.00"), Note: "Returned in the book drop"},
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().
+					GetLoan(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID}, nil)
+
+				mockMemberRepo.EXPECT().
+					LockByID(gomock.Any(), memberID).
+					Return(&entity.Member{ID: memberID}, nil)
+
+				mockRepo.EXPECT().
+					GetBalance(gomock.Any(), memberID).
+					Return(money.MustParse("1.00"), nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry *entity.FineEntry) (*entity.FineEntry, error) {
+						entry.ID = entryID
+						return entry, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.FineWaived, events[0].Type)
+						assert.Equal(t, entryID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.FineEntry{ID: entryID, MemberID: memberID, LoanID: &loanID, Kind: entity.FineEntryWaiver, Amount: money.MustParse("1.00"), Note: "Returned in the book drop"},
+		},
+		{
+			name:  "error loan of another member",
+			input: &dto.WaiverRequest{MemberID: memberID.String(), LoanID: loanID.String(), Amount: money.MustParse("1.00"), Note: "Returned in the book drop"},
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().
+					GetLoan(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: uuid.New()}, nil)
+			},
+			expectedError: fine.ErrLoanNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			fineRepoMock := mocks.NewMockFineRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(fineRepoMock, memberRepoMock, outboxMock)
+			service := fine.NewFineService(fineRepoMock, memberRepoMock, mocks.NewMockLedger(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, fineConfig, zerolog.Nop())
+
+			result, err := service.WaiveFine(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse.ID, result.ID)
+			assert.Equal(t, test.expectedResponse.MemberID, result.MemberID)
+			assert.Equal(t, test.expectedResponse.LoanID, result.LoanID)
+			assert.Equal(t, test.expectedResponse.Kind, result.Kind)
+			assert.Equal(t, test.expectedResponse.Amount, result.Amount)
+			assert.Equal(t, test.expectedResponse.Note, result.Note)
+		})
+	}
+}
+
+func TestFineService_AccrueFines(t *testing.T) {
+	errAccrual := errors.New("deadlock found when trying to get lock")
+	returnedAt := time.Now()
+	loans := []*entity.Loan{
+		{ID: uuid.MustParse("00000000-0000-4000-8000-000000000001")},
+		// returned since it was listed
+		{ID: uuid.MustParse("00000000-0000-4000-8000-000000000002"), ReturnedAt: &returnedAt},
+		{ID: uuid.MustParse("00000000-0000-4000-8000-000000000003")},
+	}
+
+	// the batches hold 2 loans, a full batch is followed by the next one
+	expectOverdueLoans := func(mockRepo *mocks.MockFineRepository) {
+		gomock.InOrder(
+			mockRepo.EXPECT().
+				GetOverdueLoanIDs(gomock.Any(), gomock.Any(), uuid.Nil, fineConfig.AccrualBatchSize).
+				Return([]uuid.UUID{loans[0].ID, loans[1].ID}, nil),
+			mockRepo.EXPECT().
+				GetOverdueLoanIDs(gomock.Any(), gomock.Any(), loans[1].ID, fineConfig.AccrualBatchSize).
+				Return([]uuid.UUID{loans[2].ID}, nil),
+		)
+
+		for _, loan := range loans {
+			mockRepo.EXPECT().LockLoan(gomock.Any(), loan.ID).Return(loan, nil)
+		}
+	}
+
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockFineRepository, *mocks.MockLedger)
+		expectedResponse int
+		expectedError    error
+	}{
+		{
+			name: "success charges every overdue loan",
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockLedger *mocks.MockLedger) {
+				expectOverdueLoans(mockRepo)
+
+				mockLedger.EXPECT().
+					Accrue(gomock.Any(), loans[0]).
+					Return(&entity.FineEntry{Kind: entity.FineEntryCharge}, nil)
+
+				mockLedger.EXPECT().
+					Accrue(gomock.Any(), loans[2]).
+					Return(nil, nil)
+			},
+			expectedResponse: 1,
+		},
+		{
+			name: "error failing loan does not stop the pass",
+			configureMock: func(mockRepo *mocks.MockFineRepository, mockLedger *mocks.MockLedger) {
+				expectOverdueLoans(mockRepo)
+
+				mockLedger.EXPECT().
+					Accrue(gomock.Any(), loans[0]).
+					Return(nil, errAccrual)
+
+				mockLedger.EXPECT().
+					Accrue(gomock.Any(), loans[2]).
+					Return(&entity.FineEntry{Kind: entity.FineEntryCharge}, nil)
+			},
+			expectedResponse: 1,
+			expectedError:    errAccrual,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			fineRepoMock := mocks.NewMockFineRepository(ctrl)
+			ledgerMock := mocks.NewMockLedger(ctrl)
+
+			test.configureMock(fineRepoMock, ledgerMock)
+			service := fine.NewFineService(fineRepoMock, mocks.NewMockMemberRepository(ctrl), ledgerMock, testutils.NewTransactionManager(ctrl), mocks.NewMockOutbox(ctrl), fineConfig, zerolog.Nop())
+
+			result, err := service.AccrueFines(context.Background())
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+// CreateGenreRequest adds a genre under the parent, or at the root of the
+// tree without one.
+type CreateGenreRequest struct {
+	Name     string `json:"name" validate:"required,trimmed,max=100"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,uuid_strict"`
+}
+
+// UpdateGenreRequest renames the genre and moves it with its descendants
+// under the parent, or to the root of the tree without one.
+type UpdateGenreRequest struct {
+	Name     string `json:"name" validate:"required,trimmed,max=100"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,uuid_strict"`
+}
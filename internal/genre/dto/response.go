@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type GenreResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+	// Ancestors is the path from the root down to the parent of the genre.
+	Ancestors []GenreResponse `json:"ancestors,omitempty"`
+	Children  []GenreResponse `json:"children,omitempty"`
+}
+
+func ToGenreResponse(genre *entity.Genre) *GenreResponse {
+	response := &GenreResponse{
+		ID:   genre.ID.String(),
+		Name: genre.Name,
+	}
+
+	if genre.ParentID != nil {
+		response.ParentID = genre.ParentID.String()
+	}
+
+	if len(genre.Ancestors) > 0 {
+		response.Ancestors = ToGenresResponse(genre.Ancestors)
+	}
+
+	if len(genre.Children) > 0 {
+		response.Children = ToGenresResponse(genre.Children)
+	}
+
+	return response
+}
+
+func ToGenresResponse(genres []*entity.Genre) []GenreResponse {
+	responses := make([]GenreResponse, len(genres))
+	for i, genre := range genres {
+		responses[i] = *ToGenreResponse(genre)
+	}
+	return responses
+}
@@ -0,0 +1,12 @@
+package genre
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("genre not found")
+	ErrParentNotFound  = errors.New("parent genre not found")
+	ErrInvalidParentID = errors.New("invalid parent genre ID")
+	ErrDuplicate       = errors.New("genre already exists")
+	ErrCycle           = errors.New("genre cannot be moved under itself or its descendants")
+	ErrHasChildren     = errors.New("genre has children")
+)
@@ -0,0 +1,253 @@
+package genre
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/genre/dto"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type GenreSuccessResponse struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Genre retrieved successfully"`
+	Genre   *dto.GenreResponse `json:"genre"`
+}
+
+type GenresSuccessResponse struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Genres retrieved successfully"`
+	Genres  []dto.GenreResponse `json:"genres"`
+}
+
+type GenreHandler struct {
+	service   GenreService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewGenreHandler(service GenreService, validator *internalValidator.Validator, logger zerolog.Logger) *GenreHandler {
+	return &GenreHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *GenreHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.CreateGenre)
+	r.Get("/", h.GetAllGenres)
+	r.Get("/{genre_id}", h.GetGenreByID)
+	r.Put("/{genre_id}", h.UpdateGenre)
+	r.Delete("/{genre_id}", h.DeleteGenre)
+
+	return r
+}
+
+// CreateGenre godoc
+//
+//	@Summary		Create a genre
+//	@Description	Add a genre or subject under its parent, or at the root of the tree without one
+//	@Tags			genres
+//	@Accept			json
+//	@Produce		json
+//	@Param			genre			body		dto.CreateGenreRequest	true	"Genre data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	GenreSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/genres [post]
+func (h *GenreHandler) CreateGenre(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateGenreRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	genre, err := h.service.CreateGenre(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, GenreSuccessResponse{
+		Status:  "success",
+		Message: "Genre created successfully",
+		Genre:   dto.ToGenreResponse(genre),
+	})
+}
+
+// GetAllGenres godoc
+//
+//	@Summary		Get all genres
+//	@Description	Get every genre ordered by name, the tree is rebuilt from their parent
+//	@Tags			genres
+//	@Produce		json
+//	@Success		200	{object}	GenresSuccessResponse
+//	@Failure		500	{object}	response.ErrorResponse
+//	@Router			/genres [get]
+func (h *GenreHandler) GetAllGenres(w http.ResponseWriter, r *http.Request) {
+	genres, err := h.service.GetAllGenres(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, GenresSuccessResponse{
+		Status:  "success",
+		Message: "Genres retrieved successfully",
+		Genres:  dto.ToGenresResponse(genres),
+	})
+}
+
+// GetGenreByID godoc
+//
+//	@Summary		Get genre by id
+//	@Description	Get a single genre by its ID, with its ancestors from the root and its children
+//	@Tags			genres
+//	@Produce		json
+//	@Param			genre_id	path		string	true	"Genre ID"
+//	@Success		200			{object}	GenreSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/genres/{genre_id} [get]
+func (h *GenreHandler) GetGenreByID(w http.ResponseWriter, r *http.Request) {
+	genreID, err := uuid.Parse(chi.URLParam(r, "genre_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	genre, err := h.service.GetGenreByID(r.Context(), genreID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, GenreSuccessResponse{
+		Status:  "success",
+		Message: "Genre retrieved successfully",
+		Genre:   dto.ToGenreResponse(genre),
+	})
+}
+
+// UpdateGenre godoc
+//
+//	@Summary		Update a genre
+//	@Description	Rename a genre and move it with its descendants under its parent, or to the root of the tree without one
+//	@Tags			genres
+//	@Accept			json
+//	@Produce		json
+//	@Param			genre_id		path		string					true	"Genre ID"
+//	@Param			genre			body		dto.UpdateGenreRequest	true	"Genre data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	GenreSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/genres/{genre_id} [put]
+func (h *GenreHandler) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	genreID, err := uuid.Parse(chi.URLParam(r, "genre_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.UpdateGenreRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	genre, err := h.service.UpdateGenre(r.Context(), &req, genreID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, GenreSuccessResponse{
+		Status:  "success",
+		Message: "Genre updated successfully",
+		Genre:   dto.ToGenreResponse(genre),
+	})
+}
+
+// DeleteGenre godoc
+//
+//	@Summary		Delete a genre
+//	@Description	Delete a genre without children, it is removed from the books assigned to it
+//	@Tags			genres
+//	@Produce		json
+//	@Param			genre_id	path		string	true	"Genre ID"
+//	@Success		200			{object}	response.SuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		409			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/genres/{genre_id} [delete]
+func (h *GenreHandler) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	genreID, err := uuid.Parse(chi.URLParam(r, "genre_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.DeleteGenre(r.Context(), genreID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Genre deleted successfully")
+}
+
+func (h *GenreHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Genre not found")
+	case errors.Is(err, ErrParentNotFound):
+		response.Error(w, http.StatusNotFound, "Parent genre not found")
+	case errors.Is(err, ErrInvalidParentID):
+		response.Error(w, http.StatusBadRequest, "invalid parent genre ID")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Genre with this name already exists")
+	case errors.Is(err, ErrCycle):
+		response.Error(w, http.StatusConflict, "Genre cannot be moved under itself or its descendants")
+	case errors.Is(err, ErrHasChildren):
+		response.Error(w, http.StatusConflict, "Genre has children and cannot be deleted")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,259 @@
+package genre_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/genre/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestGenreHandler_CreateGenre(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        interface{}
+		configureMock      func(*mocks.MockGenreService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:        "success create genre",
+			requestBody: dto.CreateGenreRequest{Name: "Crime", ParentID: parentID.String()},
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					CreateGenre(gomock.Any(), &dto.CreateGenreRequest{Name: "Crime", ParentID: parentID.String()}).
+					Return(&entity.Genre{ID: genreID, Name: "Crime", ParentID: &parentID}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &genre.GenreSuccessResponse{
+				Status:  "success",
+				Message: "Genre created successfully",
+				Genre: &dto.GenreResponse{
+					ID:       genreID.String(),
+					Name:     "Crime",
+					ParentID: parentID.String(),
+				},
+			},
+		},
+		{
+			name:               "error validation fails",
+			requestBody:        dto.CreateGenreRequest{ParentID: "not-a-uuid"},
+			configureMock:      func(mockService *mocks.MockGenreService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "name", Message: "name is required"},
+					{Field: "parent_id", Message: "parent_id must be a lowercase UUID"},
+				},
+			},
+		},
+		{
+			name:        "error parent not found",
+			requestBody: dto.CreateGenreRequest{Name: "Crime", ParentID: parentID.String()},
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					CreateGenre(gomock.Any(), gomock.Any()).
+					Return(nil, genre.ErrParentNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Parent genre not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockGenreService(ctrl)
+			test.configureMock(mockService)
+
+			handler := genre.NewGenreHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/genres", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/genres", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestGenreHandler_GetGenreByID(t *testing.T) {
+	tests := []struct {
+		name               string
+		idInUrlParam       string
+		configureMock      func(*mocks.MockGenreService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:         "success get genre with its ancestors",
+			idInUrlParam: genreID.String(),
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					GetGenreByID(gomock.Any(), genreID).
+					Return(&entity.Genre{
+						ID:        genreID,
+						Name:      "Crime",
+						ParentID:  &parentID,
+						Ancestors: []*entity.Genre{{ID: parentID, Name: "Fiction"}},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &genre.GenreSuccessResponse{
+				Status:  "success",
+				Message: "Genre retrieved successfully",
+				Genre: &dto.GenreResponse{
+					ID:        genreID.String(),
+					Name:      "Crime",
+					ParentID:  parentID.String(),
+					Ancestors: []dto.GenreResponse{{ID: parentID.String(), Name: "Fiction"}},
+				},
+			},
+		},
+		{
+			name:               "error invalid uuid",
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockGenreService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockGenreService(ctrl)
+			test.configureMock(mockService)
+
+			handler := genre.NewGenreHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/genres/"+test.idInUrlParam, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/genres", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestGenreHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		requestBody        string
+		configureMock      func(*mocks.MockGenreService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "error moved under its descendant",
+			method:      http.MethodPut,
+			requestBody: `{"name":"Crime","parent_id":"` + parentID.String() + `"}`,
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					UpdateGenre(gomock.Any(), gomock.Any(), genreID).
+					Return(nil, genre.ErrCycle)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Genre cannot be moved under itself or its descendants",
+		},
+		{
+			name:   "error delete with children",
+			method: http.MethodDelete,
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					DeleteGenre(gomock.Any(), genreID).
+					Return(genre.ErrHasChildren)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Genre has children and cannot be deleted",
+		},
+		{
+			name:   "error genre not found",
+			method: http.MethodDelete,
+			configureMock: func(mockService *mocks.MockGenreService) {
+				mockService.EXPECT().
+					DeleteGenre(gomock.Any(), genreID).
+					Return(genre.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Genre not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockGenreService(ctrl)
+			test.configureMock(mockService)
+
+			handler := genre.NewGenreHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(test.method, "/genres/"+genreID.String(), bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/genres", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package genre
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_genre_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/genre GenreRepository
+type GenreRepository interface {
+	// Create adds the genre under its parent in the tree, it must run in a
+	// transaction.
+	Create(ctx context.Context, newGenre *entity.Genre) (*entity.Genre, error)
+	GetAll(ctx context.Context) ([]*entity.Genre, error)
+	GetByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error)
+	// GetByIDs returns the genres ordered by name, the unknown IDs are
+	// skipped.
+	GetByIDs(ctx context.Context, genreIDs []uuid.UUID) ([]*entity.Genre, error)
+	LockByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error)
+	// GetAncestors returns the ancestors of the genre, the root first.
+	GetAncestors(ctx context.Context, genreID uuid.UUID) ([]*entity.Genre, error)
+	// GetSubtreeIDs returns the IDs of the genre and of all its descendants.
+	GetSubtreeIDs(ctx context.Context, genreID uuid.UUID) ([]uuid.UUID, error)
+	CountChildren(ctx context.Context, genreID uuid.UUID) (int64, error)
+	// Move detaches the genre and its descendants from their ancestors and
+	// attaches them under the parent, or at the root without one. It must run
+	// in a transaction and does not update the parent of the genre itself.
+	Move(ctx context.Context, genreID uuid.UUID, parentID *uuid.UUID) error
+	Update(ctx context.Context, genre *entity.Genre) (*entity.Genre, error)
+	Delete(ctx context.Context, genreID uuid.UUID) error
+}
+
+type genreRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewGenreRepository(db *gorm.DB, logger zerolog.Logger) GenreRepository {
+	return &genreRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *genreRepository) Create(ctx context.Context, newGenre *entity.Genre) (*entity.Genre, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newGenre).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	self := &entity.GenreClosure{AncestorID: newGenre.ID, DescendantID: newGenre.ID}
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(self).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	if err := r.attach(ctx, newGenre.ID, newGenre.ParentID); err != nil {
+		return nil, err
+	}
+
+	return newGenre, nil
+}
+
+// GetAll returns every genre ordered by name, the tree is rebuilt from their
+// parent.
+func (r *genreRepository) GetAll(ctx context.Context) ([]*entity.Genre, error) {
+	var genres []*entity.Genre
+
+	if err := transaction.DB(ctx, r.db).Order("name").Find(&genres).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepository) GetByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error) {
+	query := transaction.DB(ctx, r.db).Preload("Children", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	})
+
+	return r.first(query, genreID)
+}
+
+func (r *genreRepository) GetByIDs(ctx context.Context, genreIDs []uuid.UUID) ([]*entity.Genre, error) {
+	var genres []*entity.Genre
+
+	if len(genreIDs) == 0 {
+		return genres, nil
+	}
+
+	if err := transaction.DB(ctx, r.db).Order("name").Find(&genres, "id IN ?", genreIDs).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+// LockByID reads the genre and locks its row until the end of the
+// transaction carried by ctx.
+func (r *genreRepository) LockByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error) {
+	return r.first(transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), genreID)
+}
+
+func (r *genreRepository) GetAncestors(ctx context.Context, genreID uuid.UUID) ([]*entity.Genre, error) {
+	var genres []*entity.Genre
+
+	err := transaction.DB(ctx, r.db).
+		Joins("JOIN genre_closures ON genre_closures.ancestor_id = genres.id").
+		Where("genre_closures.descendant_id = ? AND genre_closures.depth > 0", genreID).
+		Order("genre_closures.depth DESC").
+		Find(&genres).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genres, nil
+}
+
+func (r *genreRepository) GetSubtreeIDs(ctx context.Context, genreID uuid.UUID) ([]uuid.UUID, error) {
+	var genreIDs []uuid.UUID
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.GenreClosure{}).
+		Where("ancestor_id = ?", genreID).
+		Pluck("descendant_id", &genreIDs).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genreIDs, nil
+}
+
+func (r *genreRepository) CountChildren(ctx context.Context, genreID uuid.UUID) (int64, error) {
+	var count int64
+
+	if err := transaction.DB(ctx, r.db).Model(&entity.Genre{}).Where("parent_id = ?", genreID).Count(&count).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *genreRepository) Move(ctx context.Context, genreID uuid.UUID, parentID *uuid.UUID) error {
+	subtreeIDs, err := r.GetSubtreeIDs(ctx, genreID)
+	if err != nil {
+		return err
+	}
+
+	// the paths inside the subtree are kept, only the ones coming from above
+	// are replaced
+	err = transaction.DB(ctx, r.db).
+		Where("descendant_id IN ? AND ancestor_id NOT IN ?", subtreeIDs, subtreeIDs).
+		Delete(&entity.GenreClosure{}).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return r.attach(ctx, genreID, parentID)
+}
+
+func (r *genreRepository) Update(ctx context.Context, genre *entity.Genre) (*entity.Genre, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(genre).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+// Delete removes the genre, its paths and its assignments to books go with
+// it.
+func (r *genreRepository) Delete(ctx context.Context, genreID uuid.UUID) error {
+	if err := transaction.DB(ctx, r.db).Where("id = ?", genreID).Delete(&entity.Genre{}).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+// attach links every ancestor of the parent, the parent included, to every
+// genre of the subtree rooted at genreID.
+func (r *genreRepository) attach(ctx context.Context, genreID uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	db := transaction.DB(ctx, r.db)
+
+	var above, below []*entity.GenreClosure
+
+	if err := db.Where("descendant_id = ?", *parentID).Find(&above).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	if err := db.Where("ancestor_id = ?", genreID).Find(&below).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	closures := make([]*entity.GenreClosure, 0, len(above)*len(below))
+	for _, a := range above {
+		for _, b := range below {
+			closures = append(closures, &entity.GenreClosure{
+				AncestorID:   a.AncestorID,
+				DescendantID: b.DescendantID,
+				Depth:        a.Depth + b.Depth + 1,
+			})
+		}
+	}
+
+	if len(closures) == 0 {
+		return nil
+	}
+
+	if err := db.Omit(clause.Associations).Create(&closures).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+func (r *genreRepository) first(query *gorm.DB, genreID uuid.UUID) (*entity.Genre, error) {
+	var genre *entity.Genre
+
+	if err := query.First(&genre, "id = ?", genreID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return genre, nil
+}
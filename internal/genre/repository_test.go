@@ -0,0 +1,373 @@
+package genre_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var closureColumns = []string{"ancestor_id", "descendant_id", "depth"}
+
+func TestGenreRepository_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Genre
+		configureMock    func(sqlmock.Sqlmock, *entity.Genre)
+		expectedError    error
+		expectedResponse *entity.Genre
+	}{
+		{
+			name:  "success create root genre",
+			input: &entity.Genre{Name: "Fiction"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Genre) {
+				mock.ExpectExec(`INSERT INTO .genres.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.Name,
+						input.ParentID,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				// the genre is its own descendant at depth 0
+				mock.ExpectExec(`INSERT INTO .genre_closures.`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Genre{Name: "Fiction"},
+		},
+		{
+			name:  "success create genre under its parent",
+			input: &entity.Genre{Name: "Crime", ParentID: &parentID},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Genre) {
+				mock.ExpectExec(`INSERT INTO .genres.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.Name,
+						input.ParentID,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectExec(`INSERT INTO .genre_closures.`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				rootID := uuid.MustParse("0e1f2a3b-4c5d-4e6f-8a7b-9c0d1e2f3a4b")
+
+				mock.ExpectQuery(`SELECT \* FROM .genre_closures. WHERE descendant_id = \?`).
+					WithArgs(parentID).
+					WillReturnRows(sqlmock.NewRows(closureColumns).
+						AddRow(rootID, parentID, 1).
+						AddRow(parentID, parentID, 0))
+
+				mock.ExpectQuery(`SELECT \* FROM .genre_closures. WHERE ancestor_id = \?`).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(closureColumns).
+						AddRow(genreID, genreID, 0))
+
+				// one path from each ancestor of the parent
+				mock.ExpectExec(`INSERT INTO .genre_closures.`).
+					WithArgs(rootID, genreID, 2, parentID, genreID, 1).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+			},
+			expectedResponse: &entity.Genre{Name: "Crime", ParentID: &parentID},
+		},
+		{
+			name:  "error duplicate genre",
+			input: &entity.Genre{Name: "Poetry"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Genre) {
+				mock.ExpectExec(`INSERT INTO .genres.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: genre.ErrDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := genre.NewGenreRepository(db, zerolog.Nop())
+
+			newGenre, err := repo.Create(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newGenre)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Name, newGenre.Name)
+				assert.Equal(t, test.expectedResponse.ParentID, newGenre.ParentID)
+				assert.NotEqual(t, uuid.Nil, newGenre.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenreRepository_GetAncestors(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedNames []string
+	}{
+		{
+			name: "success from the root down",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows([]string{"id", "name", "parent_id", "created_at", "updated_at"}).
+					AddRow(uuid.New(), "Fiction", nil, now, now).
+					AddRow(parentID, "Crime", nil, now, now)
+
+				mock.ExpectQuery(`SELECT .genres.\..*. FROM .genres. JOIN genre_closures ON genre_closures.ancestor_id = genres.id WHERE genre_closures.descendant_id = \? AND genre_closures.depth > 0 ORDER BY genre_closures.depth DESC`).
+					WithArgs(genreID).
+					WillReturnRows(rows)
+			},
+			expectedNames: []string{"Fiction", "Crime"},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM .genres. JOIN genre_closures`).
+					WithArgs(genreID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := genre.NewGenreRepository(db, zerolog.Nop())
+
+			ancestors, err := repo.GetAncestors(context.Background(), genreID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, ancestors)
+			} else {
+				require.NoError(t, err)
+
+				names := make([]string, len(ancestors))
+				for i, g := range ancestors {
+					names[i] = g.Name
+				}
+				assert.Equal(t, test.expectedNames, names)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenreRepository_Move(t *testing.T) {
+	childID := uuid.MustParse("9b8a7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d")
+
+	tests := []struct {
+		name          string
+		parentID      *uuid.UUID
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name:     "success move the subtree under the parent",
+			parentID: &parentID,
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .descendant_id. FROM .genre_closures. WHERE ancestor_id = \?`).
+					WithArgs(genreID).
+					WillReturnRows(sqlmock.NewRows([]string{"descendant_id"}).AddRow(genreID).AddRow(childID))
+
+				// the paths inside the subtree are kept
+				mock.ExpectExec(`DELETE FROM .genre_closures. WHERE descendant_id IN \(\?,\?\) AND ancestor_id NOT IN \(\?,\?\)`).
+					WithArgs(genreID, childID, genreID, childID).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+
+				mock.ExpectQuery(`SELECT \* FROM .genre_closures. WHERE descendant_id = \?`).
+					WithArgs(parentID).
+					WillReturnRows(sqlmock.NewRows(closureColumns).AddRow(parentID, parentID, 0))
+
+				mock.ExpectQuery(`SELECT \* FROM .genre_closures. WHERE ancestor_id = \?`).
+					WithArgs(genreID).
+					WillReturnRows(sqlmock.NewRows(closureColumns).
+						AddRow(genreID, genreID, 0).
+						AddRow(genreID, childID, 1))
+
+				mock.ExpectExec(`INSERT INTO .genre_closures.`).
+					WithArgs(parentID, genreID, 1, parentID, childID, 2).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+			},
+		},
+		{
+			name: "success move the subtree to the root",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .descendant_id. FROM .genre_closures. WHERE ancestor_id = \?`).
+					WithArgs(genreID).
+					WillReturnRows(sqlmock.NewRows([]string{"descendant_id"}).AddRow(genreID))
+
+				mock.ExpectExec(`DELETE FROM .genre_closures. WHERE descendant_id IN \(\?\) AND ancestor_id NOT IN \(\?\)`).
+					WithArgs(genreID, genreID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:     "error database connection failed",
+			parentID: &parentID,
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .descendant_id. FROM .genre_closures. WHERE ancestor_id = \?`).
+					WithArgs(genreID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := genre.NewGenreRepository(db, zerolog.Nop())
+
+			err := repo.Move(context.Background(), genreID, test.parentID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenreRepository_CountChildren(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedCount int64
+	}{
+		{
+			name: "success count children",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM .genres. WHERE parent_id = \?`).
+					WithArgs(genreID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM .genres. WHERE parent_id = \?`).
+					WithArgs(genreID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := genre.NewGenreRepository(db, zerolog.Nop())
+
+			count, err := repo.CountChildren(context.Background(), genreID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCount, count)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGenreRepository_GetByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedChildren []string
+	}{
+		{
+			name: "success with the children",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .genres. WHERE id = \? ORDER BY .genres.\..id. LIMIT \?`).
+					WithArgs(genreID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id", "created_at", "updated_at"}).
+						AddRow(genreID, "Fiction", nil, now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .genres. WHERE .genres.\..parent_id. = \? ORDER BY name`).
+					WithArgs(genreID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "parent_id", "created_at", "updated_at"}).
+						AddRow(uuid.New(), "Crime", genreID, now, now))
+			},
+			expectedChildren: []string{"Crime"},
+		},
+		{
+			name: "error genre not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .genres. WHERE id = \? ORDER BY .genres.\..id. LIMIT \?`).
+					WithArgs(genreID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: genre.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := genre.NewGenreRepository(db, zerolog.Nop())
+
+			got, err := repo.GetByID(context.Background(), genreID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+
+				children := make([]string, len(got.Children))
+				for i, g := range got.Children {
+					children[i] = g.Name
+				}
+				assert.Equal(t, test.expectedChildren, children)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package genre
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_genre_service.go -package=mocks go-boilerplate-rest-api-chi/internal/genre GenreService
+type GenreService interface {
+	CreateGenre(ctx context.Context, req *dto.CreateGenreRequest) (*entity.Genre, error)
+	GetAllGenres(ctx context.Context) ([]*entity.Genre, error)
+	// GetGenreByID returns the genre with its ancestors and its children.
+	GetGenreByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error)
+	// UpdateGenre renames the genre and moves it with its descendants under
+	// its new parent.
+	UpdateGenre(ctx context.Context, req *dto.UpdateGenreRequest, genreID uuid.UUID) (*entity.Genre, error)
+	// DeleteGenre removes a genre without children, the books assigned to it
+	// keep their other genres.
+	DeleteGenre(ctx context.Context, genreID uuid.UUID) error
+}
+
+type genreService struct {
+	repository   GenreRepository
+	transactions transaction.Manager
+	logger       zerolog.Logger
+}
+
+func NewGenreService(repository GenreRepository, transactions transaction.Manager, logger zerolog.Logger) GenreService {
+	return &genreService{
+		repository:   repository,
+		transactions: transactions,
+		logger:       logger,
+	}
+}
+
+func (s *genreService) CreateGenre(ctx context.Context, req *dto.CreateGenreRequest) (*entity.Genre, error) {
+	parentID, err := parseParentID(req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var genre *entity.Genre
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if parentID != nil {
+			if err := s.lockParent(ctx, *parentID); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		genre, err = s.repository.Create(ctx, &entity.Genre{
+			Name:     req.Name,
+			ParentID: parentID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) GetAllGenres(ctx context.Context) ([]*entity.Genre, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *genreService) GetGenreByID(ctx context.Context, genreID uuid.UUID) (*entity.Genre, error) {
+	genre, err := s.repository.GetByID(ctx, genreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if genre.Ancestors, err = s.repository.GetAncestors(ctx, genreID); err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) UpdateGenre(ctx context.Context, req *dto.UpdateGenreRequest, genreID uuid.UUID) (*entity.Genre, error) {
+	parentID, err := parseParentID(req.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var genre *entity.Genre
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		genre, err = s.repository.LockByID(ctx, genreID)
+		if err != nil {
+			return err
+		}
+
+		genre.Name = req.Name
+
+		if !sameParent(genre.ParentID, parentID) {
+			if err := s.move(ctx, genre, parentID); err != nil {
+				return err
+			}
+		}
+
+		genre, err = s.repository.Update(ctx, genre)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+func (s *genreService) DeleteGenre(ctx context.Context, genreID uuid.UUID) error {
+	return s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, genreID); err != nil {
+			return err
+		}
+
+		children, err := s.repository.CountChildren(ctx, genreID)
+		if err != nil {
+			return err
+		}
+
+		if children > 0 {
+			return ErrHasChildren
+		}
+
+		return s.repository.Delete(ctx, genreID)
+	})
+}
+
+// move attaches the genre under the parent, which must not belong to the
+// subtree of the genre.
+func (s *genreService) move(ctx context.Context, genre *entity.Genre, parentID *uuid.UUID) error {
+	if parentID != nil {
+		if err := s.lockParent(ctx, *parentID); err != nil {
+			return err
+		}
+
+		subtreeIDs, err := s.repository.GetSubtreeIDs(ctx, genre.ID)
+		if err != nil {
+			return err
+		}
+
+		if slices.Contains(subtreeIDs, *parentID) {
+			return ErrCycle
+		}
+	}
+
+	if err := s.repository.Move(ctx, genre.ID, parentID); err != nil {
+		return err
+	}
+
+	genre.ParentID = parentID
+	return nil
+}
+
+// lockParent keeps the parent from being deleted until the end of the
+// transaction.
+func (s *genreService) lockParent(ctx context.Context, parentID uuid.UUID) error {
+	_, err := s.repository.LockByID(ctx, parentID)
+	if errors.Is(err, ErrNotFound) {
+		return ErrParentNotFound
+	}
+	return err
+}
+
+func parseParentID(value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parentID, err := uuid.Parse(value)
+	if err != nil {
+		return nil, ErrInvalidParentID
+	}
+
+	return &parentID, nil
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
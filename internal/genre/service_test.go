@@ -0,0 +1,250 @@
+package genre_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/genre"
+	"go-boilerplate-rest-api-chi/internal/genre/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	genreID  = uuid.MustParse("5c1e9a4b-2d3f-4a6b-8c7d-9e0f1a2b3c4d")
+	parentID = uuid.MustParse("7a8b9c0d-1e2f-4a3b-9c5d-6e7f8a9b0c1d")
+)
+
+func TestGenreService_CreateGenre(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.CreateGenreRequest
+		configureMock    func(*mocks.MockGenreRepository)
+		expectedResponse *entity.Genre
+		expectedError    error
+	}{
+		{
+			name:  "success create genre under its parent",
+			input: &dto.CreateGenreRequest{Name: "Crime", ParentID: parentID.String()},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), parentID).
+					Return(&entity.Genre{ID: parentID}, nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &entity.Genre{Name: "Crime", ParentID: &parentID}).
+					Return(&entity.Genre{ID: genreID, Name: "Crime", ParentID: &parentID}, nil)
+			},
+			expectedResponse: &entity.Genre{ID: genreID, Name: "Crime", ParentID: &parentID},
+		},
+		{
+			name:  "error parent not found",
+			input: &dto.CreateGenreRequest{Name: "Crime", ParentID: parentID.String()},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), parentID).
+					Return(nil, genre.ErrNotFound)
+			},
+			expectedError: genre.ErrParentNotFound,
+		},
+		{
+			name:          "error invalid parent ID",
+			input:         &dto.CreateGenreRequest{Name: "Crime", ParentID: "not-a-uuid"},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {},
+			expectedError: genre.ErrInvalidParentID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			genreRepoMock := mocks.NewMockGenreRepository(ctrl)
+
+			test.configureMock(genreRepoMock)
+			service := genre.NewGenreService(genreRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			result, err := service.CreateGenre(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGenreService_UpdateGenre(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.UpdateGenreRequest
+		configureMock    func(*mocks.MockGenreRepository)
+		expectedResponse *entity.Genre
+		expectedError    error
+	}{
+		{
+			name:  "success moves the genre",
+			input: &dto.UpdateGenreRequest{Name: "Mystery", ParentID: parentID.String()},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(&entity.Genre{ID: genreID, Name: "Crime"}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), parentID).
+					Return(&entity.Genre{ID: parentID}, nil)
+
+				mockRepo.EXPECT().
+					GetSubtreeIDs(gomock.Any(), genreID).
+					Return([]uuid.UUID{genreID}, nil)
+
+				mockRepo.EXPECT().
+					Move(gomock.Any(), genreID, &parentID).
+					Return(nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &entity.Genre{ID: genreID, Name: "Mystery", ParentID: &parentID}).
+					DoAndReturn(func(_ context.Context, g *entity.Genre) (*entity.Genre, error) { return g, nil })
+			},
+			expectedResponse: &entity.Genre{ID: genreID, Name: "Mystery", ParentID: &parentID},
+		},
+		{
+			name:  "success rename keeps the parent",
+			input: &dto.UpdateGenreRequest{Name: "Mystery", ParentID: parentID.String()},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(&entity.Genre{ID: genreID, Name: "Crime", ParentID: &parentID}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &entity.Genre{ID: genreID, Name: "Mystery", ParentID: &parentID}).
+					DoAndReturn(func(_ context.Context, g *entity.Genre) (*entity.Genre, error) { return g, nil })
+			},
+			expectedResponse: &entity.Genre{ID: genreID, Name: "Mystery", ParentID: &parentID},
+		},
+		{
+			name:  "error moved under its descendant",
+			input: &dto.UpdateGenreRequest{Name: "Crime", ParentID: parentID.String()},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(&entity.Genre{ID: genreID, Name: "Crime"}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), parentID).
+					Return(&entity.Genre{ID: parentID, ParentID: &genreID}, nil)
+
+				mockRepo.EXPECT().
+					GetSubtreeIDs(gomock.Any(), genreID).
+					Return([]uuid.UUID{genreID, parentID}, nil)
+			},
+			expectedError: genre.ErrCycle,
+		},
+		{
+			name:          "error invalid parent ID",
+			input:         &dto.UpdateGenreRequest{Name: "Crime", ParentID: "not-a-uuid"},
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {},
+			expectedError: genre.ErrInvalidParentID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			genreRepoMock := mocks.NewMockGenreRepository(ctrl)
+
+			test.configureMock(genreRepoMock)
+			service := genre.NewGenreService(genreRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			result, err := service.UpdateGenre(context.Background(), test.input, genreID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestGenreService_DeleteGenre(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockGenreRepository)
+		expectedError error
+	}{
+		{
+			name: "success delete genre",
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(&entity.Genre{ID: genreID}, nil)
+
+				mockRepo.EXPECT().
+					CountChildren(gomock.Any(), genreID).
+					Return(int64(0), nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), genreID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error has children",
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(&entity.Genre{ID: genreID}, nil)
+
+				mockRepo.EXPECT().
+					CountChildren(gomock.Any(), genreID).
+					Return(int64(2), nil)
+			},
+			expectedError: genre.ErrHasChildren,
+		},
+		{
+			name: "error genre not found",
+			configureMock: func(mockRepo *mocks.MockGenreRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), genreID).
+					Return(nil, genre.ErrNotFound)
+			},
+			expectedError: genre.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			genreRepoMock := mocks.NewMockGenreRepository(ctrl)
+
+			test.configureMock(genreRepoMock)
+			service := genre.NewGenreService(genreRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			err := service.DeleteGenre(context.Background(), genreID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
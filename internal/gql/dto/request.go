@@ -0,0 +1,11 @@
+package dto
+
+// Request is a GraphQL request as posted by the clients.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	// Extensions are accepted for compatibility with the clients sending
+	// them, none is supported.
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/graphql-go/graphql/gqlerrors"
+
+// Response is the result of a GraphQL request. Data is absent when the
+// request failed before being executed.
+type Response struct {
+	Data   any                        `json:"data,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
@@ -0,0 +1,43 @@
+package gql
+
+import (
+	"errors"
+
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+// Codes of the errors, found in their extensions.
+const (
+	CodeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	CodeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	CodeQueryTooDeep     = "QUERY_TOO_DEEP"
+	CodeQueryTooComplex  = "QUERY_TOO_COMPLEX"
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+)
+
+var ErrMissingQuery = errors.New("missing query")
+
+// Error is an error returned to the client, its code and details are exposed
+// in the extensions of the GraphQL error.
+type Error struct {
+	Code    string
+	Message string
+	Details []response.ValidationErrorDetail
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]any {
+	extensions := map[string]any{"code": e.Code}
+
+	if len(e.Details) > 0 {
+		extensions["details"] = e.Details
+	}
+
+	return extensions
+}
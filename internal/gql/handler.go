@@ -0,0 +1,106 @@
+package gql
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/gql/dto"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+type GraphQLHandler struct {
+	schema *Schema
+	logger zerolog.Logger
+}
+
+func NewGraphQLHandler(schema *Schema, logger zerolog.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+func (h *GraphQLHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.Execute)
+
+	return r
+}
+
+// Execute godoc
+//
+//	@Summary		Execute a GraphQL request
+//	@Description	Run a GraphQL query or mutation over the books and authors. The response is always 200 once the request is decoded, the errors are listed with a code in their extensions. Queries deeper or more complex than the configured limits are rejected before being executed.
+//	@Tags			graphql
+//	@Accept			json
+//	@Produce		json
+//	@Param			request			body		dto.Request	true	"GraphQL request"
+//	@Param			Accept-Language	header		string		false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	dto.Response
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Router			/graphql [post]
+func (h *GraphQLHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	var req dto.Request
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if strings.TrimSpace(req.Query) == "" {
+		h.handleError(w, ErrMissingQuery)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, h.schema.Execute(r.Context(), req, r.Header.Get("Accept-Language")))
+}
+
+func (h *GraphQLHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrMissingQuery):
+		response.Error(w, http.StatusBadRequest, "Query is required")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+var playground = template.Must(template.New("graphiql").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+  <style>body { margin: 0; } #graphiql { height: 100vh; }</style>
+</head>
+<body>
+  <div id="graphiql">Loading...</div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: {{.}} });
+    ReactDOM.createRoot(document.getElementById("graphiql")).render(React.createElement(GraphiQL, { fetcher }));
+  </script>
+</body>
+</html>
+`))
+
+// Playground serves GraphiQL, sending its requests to endpoint. It loads its
+// assets from a CDN.
+func Playground(endpoint string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = playground.Execute(w, endpoint)
+	}
+}
@@ -0,0 +1,364 @@
+package gql_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/gql"
+	"go-boilerplate-rest-api-chi/internal/gql/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+var (
+	hugoID   = uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	martinID = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	bookID   = uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0")
+	otherID  = uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1")
+
+	hugo   = &entity.Author{ID: hugoID, Name: "Victor Hugo"}
+	martin = &entity.Author{ID: martinID, Name: "George R.R. Martin"}
+)
+
+type graphQLResult struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestGraphQLHandler_Execute(t *testing.T) {
+	tests := []struct {
+		name               string
+		request            dto.Request
+		configureMock      func(*mocks.MockBookService, *mocks.MockAuthorService)
+		expectedStatusCode int
+		expectedData       string
+		expectedCodes      []string
+	}{
+		{
+			name:    "success book with its preloaded author",
+			request: dto.Request{Query: `{ book(id: "a1b2c3d4-e5f6-7890-1234-56789abcdef0") { id title description author { name } } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetBookByID(gomock.Any(), bookID).
+					Return(&entity.Book{ID: bookID, Title: "Les Misérables", Description: "Fantine", AuthorID: &hugoID, Author: hugo}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"book": {"id": "a1b2c3d4-e5f6-7890-1234-56789abcdef0", "title": "Les Misérables", "description": "Fantine", "author": {"name": "Victor Hugo"}}}`,
+		},
+		{
+			name:    "success unknown book is null",
+			request: dto.Request{Query: `{ book(id: "a1b2c3d4-e5f6-7890-1234-56789abcdef0") { title } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetBookByID(gomock.Any(), bookID).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"book": null}`,
+		},
+		{
+			name: "success books of the authors loaded in one batch",
+			request: dto.Request{
+				Query:     `query Books($authorId: ID) { books(authorId: $authorId, first: 2) { nodes { title author { name books { title } } } nextCursor } }`,
+				Variables: map[string]any{"authorId": "eb21d07a-7ab3-40db-bfd3-448093bc5626"},
+			},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetAllBooks(gomock.Any(), bookDto.BookFilter{AuthorID: "eb21d07a-7ab3-40db-bfd3-448093bc5626"}, pagination.Page{Limit: 2}).
+					Return([]*entity.Book{
+						{ID: bookID, Title: "Les Misérables", AuthorID: &hugoID, Author: hugo},
+						{ID: otherID, Title: "A Game of Thrones", AuthorID: &martinID, Author: martin},
+					}, "eyJvIjoidGl0bGUsIGlkIn0", nil)
+
+				books.EXPECT().
+					GetBooksByAuthorIDs(gomock.Any(), gomock.InAnyOrder([]uuid.UUID{hugoID, martinID})).
+					Return([]*entity.Book{
+						{ID: otherID, Title: "A Game of Thrones", AuthorID: &martinID},
+						{ID: bookID, Title: "Les Misérables", AuthorID: &hugoID},
+					}, nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData: `{"books": {"nodes": [
+				{"title": "Les Misérables", "author": {"name": "Victor Hugo", "books": [{"title": "Les Misérables"}]}},
+				{"title": "A Game of Thrones", "author": {"name": "George R.R. Martin", "books": [{"title": "A Game of Thrones"}]}}
+			], "nextCursor": "eyJvIjoidGl0bGUsIGlkIn0"}}`,
+		},
+		{
+			name:    "success page of books cut to the maximum",
+			request: dto.Request{Query: `{ books(first: 500, after: "eyJvIjoidGl0bGUsIGlkIn0") { nodes { title } nextCursor } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetAllBooks(gomock.Any(), bookDto.BookFilter{}, pagination.Page{Limit: pagination.MaxLimit, Cursor: "eyJvIjoidGl0bGUsIGlkIn0"}).
+					Return([]*entity.Book{{ID: bookID, Title: "Les Misérables"}}, "", nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"books": {"nodes": [{"title": "Les Misérables"}], "nextCursor": null}}`,
+		},
+		{
+			name:    "success books of the default page size",
+			request: dto.Request{Query: `{ books { nodes { title } } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetAllBooks(gomock.Any(), bookDto.BookFilter{}, pagination.Page{Limit: pagination.DefaultLimit}).
+					Return(nil, "", book.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"books": {"nodes": []}}`,
+		},
+		{
+			name:               "error page of no books",
+			request:            dto.Request{Query: `{ books(first: 0) { nextCursor } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `null`,
+			expectedCodes:      []string{gql.CodeBadUserInput},
+		},
+		{
+			name:    "error invalid cursor",
+			request: dto.Request{Query: `{ books(after: "invalid") { nextCursor } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					GetAllBooks(gomock.Any(), bookDto.BookFilter{}, pagination.Page{Limit: pagination.DefaultLimit, Cursor: "invalid"}).
+					Return(nil, "", pagination.ErrInvalidCursor)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `null`,
+			expectedCodes:      []string{gql.CodeBadUserInput},
+		},
+		{
+			name: "success authors loaded in one batch",
+			request: dto.Request{Query: `{
+				first: author(id: "eb21d07a-7ab3-40db-bfd3-448093bc5626") { name }
+				second: author(id: "aeca0955-bae4-47e9-9f85-6818dc68ca51") { name }
+				unknown: author(id: "c1d2e3f4-a5b6-7890-1234-56789abcdef2") { name }
+			}`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				authors.EXPECT().
+					GetAuthorsByIDs(gomock.Any(), gomock.Len(3)).
+					Return([]*entity.Author{hugo, martin}, nil).
+					Times(1)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"first": {"name": "Victor Hugo"}, "second": {"name": "George R.R. Martin"}, "unknown": null}`,
+		},
+		{
+			name: "success create book",
+			request: dto.Request{Query: `mutation {
+				createBook(input: {title: "Les Misérables", description: "A novel", authorId: "eb21d07a-7ab3-40db-bfd3-448093bc5626"}) {
+					id description author { name }
+				}
+			}`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					CreateBook(gomock.Any(), &bookDto.CreateBookRequest{
+						Title:       "Les Misérables",
+						Description: "A novel",
+						AuthorID:    hugoID,
+					}).
+					DoAndReturn(func(_ any, req *bookDto.CreateBookRequest) (*entity.Book, error) {
+						created := book.NewBook(req, hugoID)
+						created.ID = bookID
+						created.Author = hugo
+						return created, nil
+					})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"createBook": {"id": "a1b2c3d4-e5f6-7890-1234-56789abcdef0", "description": "A novel", "author": {"name": "Victor Hugo"}}}`,
+		},
+		{
+			name:               "error create book validation fails",
+			request:            dto.Request{Query: `mutation { createBook(input: {title: " padded ", description: "A novel", authorId: "invalid"}) { id } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `null`,
+			expectedCodes:      []string{gql.CodeBadUserInput},
+		},
+		{
+			name:               "error update book validation fails",
+			request:            dto.Request{Query: `mutation { updateBook(id: "a1b2c3d4-e5f6-7890-1234-56789abcdef0", input: {description: " padded "}) { id } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `null`,
+			expectedCodes:      []string{gql.CodeBadUserInput},
+		},
+		{
+			name:    "error update unknown book",
+			request: dto.Request{Query: `mutation { updateBook(id: "a1b2c3d4-e5f6-7890-1234-56789abcdef0", input: {description: "A novel"}) { id } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				books.EXPECT().
+					UpdateBook(gomock.Any(), gomock.Any(), bookID).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `null`,
+			expectedCodes:      []string{gql.CodeNotFound},
+		},
+		{
+			name:    "error internal error is hidden",
+			request: dto.Request{Query: `{ author(id: "eb21d07a-7ab3-40db-bfd3-448093bc5626") { name } }`},
+			configureMock: func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {
+				authors.EXPECT().
+					GetAuthorsByIDs(gomock.Any(), []uuid.UUID{hugoID}).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"author": null}`,
+			expectedCodes:      []string{gql.CodeInternal},
+		},
+		{
+			name:               "error invalid book id",
+			request:            dto.Request{Query: `{ book(id: "invalid") { title } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedData:       `{"book": null}`,
+			expectedCodes:      []string{gql.CodeBadUserInput},
+		},
+		{
+			name:               "error syntax",
+			request:            dto.Request{Query: `{ books { nodes { title } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      []string{gql.CodeParseFailed},
+		},
+		{
+			name:               "error unknown field",
+			request:            dto.Request{Query: `{ books { nodes { pages } } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      []string{gql.CodeValidationFailed},
+		},
+		{
+			name:               "error query too deep",
+			request:            dto.Request{Query: `{ books { nodes { author { books { author { books { title } } } } } } }`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      []string{gql.CodeQueryTooDeep},
+		},
+		{
+			name: "error query too complex",
+			request: dto.Request{Query: `
+				query { books { nodes { ...details author { books { ...details } } } } }
+				fragment details on Book { id title description }
+			`},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusOK,
+			expectedCodes:      []string{gql.CodeQueryTooComplex},
+		},
+		{
+			name:               "error missing query",
+			request:            dto.Request{},
+			configureMock:      func(books *mocks.MockBookService, authors *mocks.MockAuthorService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockBookService := mocks.NewMockBookService(ctrl)
+			mockAuthorService := mocks.NewMockAuthorService(ctrl)
+			test.configureMock(mockBookService, mockAuthorService)
+
+			v := validator.New()
+			require.NoError(t, author.RegisterValidations(v))
+			require.NoError(t, book.RegisterValidations(v))
+
+			schema, err := gql.NewSchema(mockBookService, mockAuthorService, v, config.GraphQLConfig{MaxDepth: 5, MaxComplexity: 200}, zerolog.Nop())
+			require.NoError(t, err)
+
+			handler := gql.NewGraphQLHandler(schema, zerolog.Nop())
+
+			body, err := json.Marshal(test.request)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/graphql", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, test.expectedStatusCode, w.Code)
+
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var result graphQLResult
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+			if test.expectedData != "" {
+				assert.JSONEq(t, test.expectedData, string(result.Data))
+			} else {
+				assert.Empty(t, result.Data)
+			}
+
+			var codes []string
+			for _, e := range result.Errors {
+				codes = append(codes, e.Extensions["code"].(string))
+			}
+			assert.Equal(t, test.expectedCodes, codes)
+		})
+	}
+}
+
+func TestGraphQLHandler_ValidationDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	v := validator.New()
+	require.NoError(t, author.RegisterValidations(v))
+	require.NoError(t, book.RegisterValidations(v))
+
+	schema, err := gql.NewSchema(mocks.NewMockBookService(ctrl), mocks.NewMockAuthorService(ctrl), v, config.GraphQLConfig{MaxDepth: 8, MaxComplexity: 1000}, zerolog.Nop())
+	require.NoError(t, err)
+
+	body, err := json.Marshal(dto.Request{
+		Query: `{ books(authorId: "invalid") { nextCursor } }`,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "fr")
+	w := httptest.NewRecorder()
+
+	gql.NewGraphQLHandler(schema, zerolog.Nop()).Routes().ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result graphQLResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	require.Len(t, result.Errors, 1)
+
+	assert.Equal(t, "Validation failed", result.Errors[0].Message)
+	assert.Equal(t, []any{map[string]any{
+		"field":   "authorId",
+		"message": "authorId doit être un UUID en minuscules",
+	}}, result.Errors[0].Extensions["details"])
+}
@@ -0,0 +1,122 @@
+package gql
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ListSize is the number of items a list field is assumed to return when
+// computing the complexity of a query.
+const ListSize = 10
+
+// measure returns the depth and the complexity of the operation of a
+// validated document. Every field costs one, the fields selected below a list
+// cost ListSize times more. Introspection fields are not counted.
+func measure(schema *graphql.Schema, doc *ast.Document, operationName string) (depth, complexity int) {
+	m := measurer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+	}
+
+	var operation *ast.OperationDefinition
+
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+
+	if operation == nil {
+		return 0, 0
+	}
+
+	var root *graphql.Object
+	switch operation.Operation {
+	case ast.OperationTypeMutation:
+		root = schema.MutationType()
+	default:
+		root = schema.QueryType()
+	}
+
+	return m.selectionSet(operation.SelectionSet, root)
+}
+
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func (m *measurer) selectionSet(set *ast.SelectionSet, parent graphql.Type) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c = m.field(selection, parent)
+		case *ast.InlineFragment:
+			fragmentType := parent
+			if selection.TypeCondition != nil {
+				fragmentType = m.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			d, c = m.selectionSet(selection.SelectionSet, fragmentType)
+		case *ast.FragmentSpread:
+			// fragment cycles are rejected by the validation
+			if fragment, ok := m.fragments[selection.Name.Value]; ok {
+				d, c = m.selectionSet(fragment.SelectionSet, m.schema.Type(fragment.TypeCondition.Name.Value))
+			}
+		}
+
+		depth = max(depth, d)
+		complexity += c
+	}
+
+	return depth, complexity
+}
+
+func (m *measurer) field(field *ast.Field, parent graphql.Type) (depth, complexity int) {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return 0, 0
+	}
+
+	var definition *graphql.FieldDefinition
+	switch parent := parent.(type) {
+	case *graphql.Object:
+		definition = parent.Fields()[field.Name.Value]
+	case *graphql.Interface:
+		definition = parent.Fields()[field.Name.Value]
+	}
+
+	if definition == nil {
+		return 1, 1
+	}
+
+	fieldType, multiplier := graphql.Type(definition.Type), 1
+
+unwrap:
+	for {
+		switch t := fieldType.(type) {
+		case *graphql.NonNull:
+			fieldType = t.OfType
+		case *graphql.List:
+			fieldType = t.OfType
+			multiplier *= ListSize
+		default:
+			break unwrap
+		}
+	}
+
+	depth, complexity = m.selectionSet(field.SelectionSet, fieldType)
+
+	return depth + 1, 1 + multiplier*complexity
+}
@@ -0,0 +1,156 @@
+package gql
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+// BatchFunc fetches the values of many keys at once. A key missing from the
+// result has no value.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys loaded while a level of the query is resolved and
+// fetches them all with a single call to its batch function, the first time
+// one of their values is needed. Values are cached for the whole request.
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+
+	mu      sync.Mutex
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+func NewLoader[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:  batch,
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// Prime caches a value already known, it will not be fetched.
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.values[key] = value
+}
+
+// Load schedules the fetch of key and returns a thunk giving its value. The
+// batch runs when the first pending thunk is called.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.resolved(key) && !slices.Contains(l.pending, key) {
+		l.pending = append(l.pending, key)
+	}
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !l.resolved(key) {
+			l.dispatch(ctx)
+		}
+
+		return l.values[key], l.errs[key]
+	}
+}
+
+// dispatch fetches the pending keys, the lock must be held.
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.batch(ctx, keys)
+
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+
+		l.values[key] = values[key]
+	}
+}
+
+func (l *Loader[K, V]) resolved(key K) bool {
+	if _, ok := l.values[key]; ok {
+		return true
+	}
+
+	_, ok := l.errs[key]
+	return ok
+}
+
+// loaders are the loaders of a single request.
+type loaders struct {
+	authors *Loader[uuid.UUID, *entity.Author]
+	books   *Loader[uuid.UUID, []*entity.Book]
+}
+
+func newLoaders(bookService book.BookService, authorService author.AuthorService) *loaders {
+	return &loaders{
+		authors: NewLoader(func(ctx context.Context, authorIDs []uuid.UUID) (map[uuid.UUID]*entity.Author, error) {
+			authors, err := authorService.GetAuthorsByIDs(ctx, authorIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[uuid.UUID]*entity.Author, len(authors))
+			for _, a := range authors {
+				byID[a.ID] = a
+			}
+
+			return byID, nil
+		}),
+		books: NewLoader(func(ctx context.Context, authorIDs []uuid.UUID) (map[uuid.UUID][]*entity.Book, error) {
+			books, err := bookService.GetBooksByAuthorIDs(ctx, authorIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			// authors without books get an empty list rather than no value
+			byAuthor := make(map[uuid.UUID][]*entity.Book, len(authorIDs))
+			for _, authorID := range authorIDs {
+				byAuthor[authorID] = []*entity.Book{}
+			}
+
+			for _, b := range books {
+				if b.AuthorID != nil {
+					byAuthor[*b.AuthorID] = append(byAuthor[*b.AuthorID], b)
+				}
+			}
+
+			return byAuthor, nil
+		}),
+	}
+}
+
+// primeBooks caches the authors preloaded with the books.
+func (l *loaders) primeBooks(books ...*entity.Book) {
+	for _, b := range books {
+		if b.Author != nil {
+			l.authors.Prime(b.Author.ID, b.Author)
+		}
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
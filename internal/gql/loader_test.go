@@ -0,0 +1,81 @@
+package gql_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/gql"
+)
+
+func TestLoader(t *testing.T) {
+	t.Run("success pending keys fetched in one batch", func(t *testing.T) {
+		var batches [][]int
+
+		loader := gql.NewLoader(func(_ context.Context, keys []int) (map[int]string, error) {
+			batches = append(batches, keys)
+			return map[int]string{1: "one", 2: "two"}, nil
+		})
+
+		ctx := context.Background()
+		one := loader.Load(ctx, 1)
+		two := loader.Load(ctx, 2)
+		again := loader.Load(ctx, 1)
+		missing := loader.Load(ctx, 3)
+
+		value, err := one()
+		require.NoError(t, err)
+		assert.Equal(t, "one", value)
+
+		value, err = two()
+		require.NoError(t, err)
+		assert.Equal(t, "two", value)
+
+		value, err = again()
+		require.NoError(t, err)
+		assert.Equal(t, "one", value)
+
+		value, err = missing()
+		require.NoError(t, err)
+		assert.Empty(t, value)
+
+		// cached values are not fetched again
+		value, err = loader.Load(ctx, 2)()
+		require.NoError(t, err)
+		assert.Equal(t, "two", value)
+
+		assert.Equal(t, [][]int{{1, 2, 3}}, batches)
+	})
+
+	t.Run("success primed keys are not fetched", func(t *testing.T) {
+		loader := gql.NewLoader(func(_ context.Context, keys []int) (map[int]string, error) {
+			t.Fatalf("unexpected batch of %v", keys)
+			return nil, nil
+		})
+
+		loader.Prime(1, "one")
+
+		value, err := loader.Load(context.Background(), 1)()
+		require.NoError(t, err)
+		assert.Equal(t, "one", value)
+	})
+
+	t.Run("error batch fails every pending key", func(t *testing.T) {
+		loader := gql.NewLoader(func(_ context.Context, keys []int) (map[int]string, error) {
+			return nil, errors.New("database connection failed")
+		})
+
+		ctx := context.Background()
+		one := loader.Load(ctx, 1)
+		two := loader.Load(ctx, 2)
+
+		_, err := one()
+		assert.EqualError(t, err, "database connection failed")
+
+		_, err = two()
+		assert.EqualError(t, err, "database connection failed")
+	})
+}
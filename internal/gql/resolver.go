@@ -0,0 +1,351 @@
+package gql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	authorDto "go-boilerplate-rest-api-chi/internal/author/dto"
+	"go-boilerplate-rest-api-chi/internal/book"
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+// resolver resolves the fields of the schema with the book and author
+// services, the requests are validated as by the REST handlers.
+type resolver struct {
+	bookService   book.BookService
+	authorService author.AuthorService
+	validator     *internalValidator.Validator
+	logger        zerolog.Logger
+}
+
+// -------- Queries --------
+
+func (r *resolver) book(p graphql.ResolveParams) (any, error) {
+	bookID, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := r.bookService.GetBookByID(p.Context, bookID)
+	if errors.Is(err, book.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	loadersFrom(p.Context).primeBooks(b)
+
+	return b, nil
+}
+
+// bookPage is a page of the books and the cursor of the next page, empty on
+// the last page.
+type bookPage struct {
+	books []*entity.Book
+	next  string
+}
+
+func (r *resolver) books(p graphql.ResolveParams) (any, error) {
+	filter := bookDto.BookFilter{
+		AuthorID: strings.TrimSpace(stringArg(p.Args, "authorId")),
+		Title:    strings.TrimSpace(stringArg(p.Args, "title")),
+	}
+
+	if err := r.validate(p, &filter); err != nil {
+		return nil, err
+	}
+
+	// the page is never unbounded, a larger page is cut to the maximum
+	first, _ := p.Args["first"].(int)
+	if first < 1 {
+		return nil, r.handleError(pagination.ErrInvalidLimit)
+	}
+
+	page := pagination.Page{
+		Limit:  min(first, pagination.MaxLimit),
+		Cursor: strings.TrimSpace(stringArg(p.Args, "after")),
+	}
+
+	books, next, err := r.bookService.GetAllBooks(p.Context, filter, page)
+	if errors.Is(err, book.ErrNotFound) {
+		return &bookPage{books: []*entity.Book{}}, nil
+	}
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	loadersFrom(p.Context).primeBooks(books...)
+
+	return &bookPage{books: books, next: next}, nil
+}
+
+func (r *resolver) author(p graphql.ResolveParams) (any, error) {
+	authorID, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.loadAuthor(p, authorID), nil
+}
+
+// -------- Mutations --------
+
+func (r *resolver) createBook(p graphql.ResolveParams) (any, error) {
+	input := inputArg(p.Args)
+
+	authorID, err := idArg(input, "authorId")
+	if err != nil {
+		return nil, err
+	}
+
+	req := bookDto.CreateBookRequest{
+		Title:       stringArg(input, "title"),
+		Description: stringArg(input, "description"),
+		AuthorID:    authorID,
+	}
+
+	if err := r.validate(p, &req); err != nil {
+		return nil, err
+	}
+
+	b, err := r.bookService.CreateBook(p.Context, &req)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	loadersFrom(p.Context).primeBooks(b)
+
+	return b, nil
+}
+
+func (r *resolver) updateBook(p graphql.ResolveParams) (any, error) {
+	bookID, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	input := inputArg(p.Args)
+
+	req := bookDto.UpdateBookRequest{
+		Description: stringArg(input, "description"),
+	}
+
+	if err := r.validate(p, &req); err != nil {
+		return nil, err
+	}
+
+	b, err := r.bookService.UpdateBook(p.Context, &req, bookID)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	loadersFrom(p.Context).primeBooks(b)
+
+	return b, nil
+}
+
+func (r *resolver) deleteBook(p graphql.ResolveParams) (any, error) {
+	bookID, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.bookService.DeleteBook(p.Context, bookID); err != nil {
+		return nil, r.handleError(err)
+	}
+
+	return bookID.String(), nil
+}
+
+func (r *resolver) createAuthor(p graphql.ResolveParams) (any, error) {
+	req := authorDto.CreateAuthorRequest{
+		Name: stringArg(inputArg(p.Args), "name"),
+	}
+
+	if err := r.validate(p, &req); err != nil {
+		return nil, err
+	}
+
+	a, err := r.authorService.CreateAuthor(p.Context, &req)
+	if err != nil {
+		return nil, r.handleError(err)
+	}
+
+	loadersFrom(p.Context).authors.Prime(a.ID, a)
+
+	return a, nil
+}
+
+// -------- Book page fields --------
+
+func (r *resolver) bookPageNodes(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*bookPage).books, nil
+}
+
+// bookPageNextCursor is null on the last page.
+func (r *resolver) bookPageNextCursor(p graphql.ResolveParams) (any, error) {
+	if next := p.Source.(*bookPage).next; next != "" {
+		return next, nil
+	}
+
+	return nil, nil
+}
+
+// -------- Book fields --------
+
+func (r *resolver) bookID(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*entity.Book).ID.String(), nil
+}
+
+func (r *resolver) bookTitle(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*entity.Book).Title, nil
+}
+
+func (r *resolver) bookDescription(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*entity.Book).Description, nil
+}
+
+// bookAuthor goes through the loader, the authors preloaded with the books
+// are primed and the others are fetched together.
+func (r *resolver) bookAuthor(p graphql.ResolveParams) (any, error) {
+	b := p.Source.(*entity.Book)
+	if b.AuthorID == nil {
+		return nil, nil
+	}
+
+	return r.loadAuthor(p, *b.AuthorID), nil
+}
+
+// -------- Author fields --------
+
+func (r *resolver) authorID(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*entity.Author).ID.String(), nil
+}
+
+func (r *resolver) authorName(p graphql.ResolveParams) (any, error) {
+	return p.Source.(*entity.Author).Name, nil
+}
+
+// authorBooks fetches the books of all the authors of a level of the query at
+// once.
+func (r *resolver) authorBooks(p graphql.ResolveParams) (any, error) {
+	l := loadersFrom(p.Context)
+	thunk := l.books.Load(p.Context, p.Source.(*entity.Author).ID)
+
+	return func() (any, error) {
+		books, err := thunk()
+		if err != nil {
+			return nil, r.handleError(err)
+		}
+
+		l.primeBooks(books...)
+
+		return books, nil
+	}, nil
+}
+
+func (r *resolver) loadAuthor(p graphql.ResolveParams, authorID uuid.UUID) func() (any, error) {
+	thunk := loadersFrom(p.Context).authors.Load(p.Context, authorID)
+
+	return func() (any, error) {
+		a, err := thunk()
+		if err != nil {
+			return nil, r.handleError(err)
+		}
+
+		// an unknown author resolves to null
+		if a == nil {
+			return nil, nil
+		}
+
+		return a, nil
+	}
+}
+
+// -------- Helpers --------
+
+// validate validates a request as the REST handlers do, the fields of the
+// details are named after the GraphQL arguments.
+func (r *resolver) validate(p graphql.ResolveParams, req any) error {
+	err := r.validator.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	details := r.validator.FormatErrors(err, languageFrom(p.Context))
+	for i, detail := range details {
+		if detail.Field != "" {
+			details[i].Field = camelCase(detail.Field)
+			details[i].Message = strings.ReplaceAll(detail.Message, detail.Field, details[i].Field)
+		}
+	}
+
+	return &Error{
+		Code:    CodeBadUserInput,
+		Message: "Validation failed",
+		Details: details,
+	}
+}
+
+func (r *resolver) handleError(err error) error {
+	switch {
+	case errors.Is(err, book.ErrNotFound):
+		return &Error{Code: CodeNotFound, Message: "Book not found"}
+	case errors.Is(err, book.ErrDuplicate):
+		return &Error{Code: CodeConflict, Message: "Book with this name already exists"}
+	case errors.Is(err, book.ErrHasLoans):
+		return &Error{Code: CodeConflict, Message: "Book has loans and cannot be deleted"}
+	case errors.Is(err, author.ErrNotFound):
+		return &Error{Code: CodeNotFound, Message: "Author not found"}
+	case errors.Is(err, author.ErrDuplicate):
+		return &Error{Code: CodeConflict, Message: "Author with this identifier already exists"}
+	case errors.Is(err, pagination.ErrInvalidLimit):
+		return &Error{Code: CodeBadUserInput, Message: "first must be at least 1"}
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		return &Error{Code: CodeBadUserInput, Message: "Invalid cursor"}
+	default:
+		r.logger.Error().Err(err).Msg("unexpected error")
+		return &Error{Code: CodeInternal, Message: "Internal server error"}
+	}
+}
+
+func idArg(args map[string]any, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(stringArg(args, name))
+	if err != nil {
+		return uuid.Nil, &Error{Code: CodeBadUserInput, Message: "Invalid uuid"}
+	}
+
+	return id, nil
+}
+
+func inputArg(args map[string]any) map[string]any {
+	input, _ := args["input"].(map[string]any)
+	return input
+}
+
+func stringArg(args map[string]any, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
+
+// camelCase converts the snake case JSON names of the request fields to the
+// names of the GraphQL arguments.
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,268 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/gql/dto"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+// Schema is the GraphQL schema of the books and their authors.
+type Schema struct {
+	schema        graphql.Schema
+	bookService   book.BookService
+	authorService author.AuthorService
+	cfg           config.GraphQLConfig
+}
+
+func NewSchema(bookService book.BookService, authorService author.AuthorService, validator *internalValidator.Validator, cfg config.GraphQLConfig, logger zerolog.Logger) (*Schema, error) {
+	r := &resolver{
+		bookService:   bookService,
+		authorService: authorService,
+		validator:     validator,
+		logger:        logger,
+	}
+
+	authorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: r.authorID},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: r.authorName},
+		},
+	})
+
+	bookType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: r.bookID},
+			"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: r.bookTitle},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: r.bookDescription},
+			"author":      &graphql.Field{Type: authorType, Resolve: r.bookAuthor},
+		},
+	})
+
+	authorType.AddFieldConfig("books", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))),
+		Resolve: r.authorBooks,
+	})
+
+	bookPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BookPage",
+		Fields: graphql.Fields{
+			"nodes": &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookType))), Resolve: r.bookPageNodes},
+			"nextCursor": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Cursor of the next page, the after argument fetching it, null on the last page",
+				Resolve:     r.bookPageNextCursor,
+			},
+		},
+	})
+
+	createBookInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateBookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"authorId":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+
+	updateBookInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdateBookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"description": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	createAuthorInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateAuthorInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"name": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"book": &graphql.Field{
+				Type:    bookType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.book,
+			},
+			"books": &graphql.Field{
+				Type: graphql.NewNonNull(bookPageType),
+				Args: graphql.FieldConfigArgument{
+					"authorId": {Type: graphql.ID},
+					"title":    {Type: graphql.String, Description: "Only books whose title contains this text"},
+					"first": {
+						Type:         graphql.Int,
+						DefaultValue: pagination.DefaultLimit,
+						Description:  fmt.Sprintf("Number of books of the page, at most %d", pagination.MaxLimit),
+					},
+					"after": {Type: graphql.String, Description: "Cursor of the page, the nextCursor of the previous page"},
+				},
+				Resolve: r.books,
+			},
+			"author": &graphql.Field{
+				Type:    authorType,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: r.author,
+			},
+		},
+	})
+
+	mutationType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createBook": &graphql.Field{
+				Type:    graphql.NewNonNull(bookType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(createBookInput)}},
+				Resolve: r.createBook,
+			},
+			"updateBook": &graphql.Field{
+				Type: graphql.NewNonNull(bookType),
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: graphql.NewNonNull(graphql.ID)},
+					"input": {Type: graphql.NewNonNull(updateBookInput)},
+				},
+				Resolve: r.updateBook,
+			},
+			"deleteBook": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes the book and returns its id",
+				Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     r.deleteBook,
+			},
+			"createAuthor": &graphql.Field{
+				Type:    graphql.NewNonNull(authorType),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(createAuthorInput)}},
+				Resolve: r.createAuthor,
+			},
+		},
+	})
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query:    queryType,
+		Mutation: mutationType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{
+		schema:        schema,
+		bookService:   bookService,
+		authorService: authorService,
+		cfg:           cfg,
+	}, nil
+}
+
+// Execute runs a request. The query is parsed, validated and measured before
+// being executed, a query over the depth or complexity limits is rejected as a
+// whole. acceptLanguage selects the language of the validation messages.
+func (s *Schema) Execute(ctx context.Context, req dto.Request, acceptLanguage string) *dto.Response {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return failed(CodeParseFailed, gqlerrors.FormatError(err))
+	}
+
+	if result := graphql.ValidateDocument(&s.schema, doc, nil); !result.IsValid {
+		return failed(CodeValidationFailed, result.Errors...)
+	}
+
+	depth, complexity := measure(&s.schema, doc, req.OperationName)
+
+	if depth > s.cfg.MaxDepth {
+		return failed(CodeQueryTooDeep, gqlerrors.NewFormattedError("Query is too deep"))
+	}
+
+	if complexity > s.cfg.MaxComplexity {
+		return failed(CodeQueryTooComplex, gqlerrors.NewFormattedError("Query is too complex"))
+	}
+
+	ctx = withLanguage(ctx, acceptLanguage)
+	ctx = withLoaders(ctx, newLoaders(s.bookService, s.authorService))
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	for i, formatted := range result.Errors {
+		if formatted.Extensions == nil {
+			result.Errors[i].Extensions = extensions(formatted.OriginalError())
+		}
+	}
+
+	response := &dto.Response{
+		Data:   result.Data,
+		Errors: result.Errors,
+	}
+
+	// once executed, data is present even when an error nulled it
+	if response.Data == nil {
+		response.Data = json.RawMessage("null")
+	}
+
+	return response
+}
+
+// failed is the response of a request rejected before its execution.
+func failed(code string, errs ...gqlerrors.FormattedError) *dto.Response {
+	for i := range errs {
+		errs[i].Extensions = map[string]any{"code": code}
+	}
+
+	return &dto.Response{Errors: errs}
+}
+
+// extensions finds the extensions of the errors returned by the thunks, the
+// executor wraps them without keeping their extensions.
+func extensions(err error) map[string]any {
+	for err != nil {
+		var extended gqlerrors.ExtendedError
+		if errors.As(err, &extended) {
+			return extended.Extensions()
+		}
+
+		switch wrapped := err.(type) {
+		case gqlerrors.FormattedError:
+			err = wrapped.OriginalError()
+		case *gqlerrors.Error:
+			err = wrapped.OriginalError
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+type languageKey struct{}
+
+func withLanguage(ctx context.Context, acceptLanguage string) context.Context {
+	return context.WithValue(ctx, languageKey{}, acceptLanguage)
+}
+
+func languageFrom(ctx context.Context) string {
+	acceptLanguage, _ := ctx.Value(languageKey{}).(string)
+	return acceptLanguage
+}
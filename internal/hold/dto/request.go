@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PlaceHoldRequest reserves a book for a member.
+type PlaceHoldRequest struct {
+	BookID   string `json:"book_id" validate:"required,uuid_strict"`
+	MemberID string `json:"member_id" validate:"required,uuid_strict"`
+}
+
+// HoldFilter narrows the listed holds. Empty fields are ignored.
+type HoldFilter struct {
+	MemberID string `json:"member_id" validate:"omitempty,uuid_strict"`
+	BookID   string `json:"book_id" validate:"omitempty,uuid_strict"`
+	Status   string `json:"status" validate:"omitempty,oneof=waiting ready fulfilled cancelled expired"`
+}
+
+// NewHoldFilter reads the filter from the query string.
+func NewHoldFilter(query url.Values) HoldFilter {
+	return HoldFilter{
+		MemberID: strings.TrimSpace(query.Get("member_id")),
+		BookID:   strings.TrimSpace(query.Get("book_id")),
+		Status:   strings.TrimSpace(query.Get("status")),
+	}
+}
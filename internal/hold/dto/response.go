@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type HoldResponse struct {
+	ID       string `json:"id"`
+	BookID   string `json:"book_id"`
+	MemberID string `json:"member_id"`
+	Status   string `json:"status" example:"waiting" enums:"waiting,ready,fulfilled,cancelled,expired"`
+	// Position is the rank of a waiting hold in the queue of its book.
+	Position  int64      `json:"position,omitempty" example:"2"`
+	CopyID    string     `json:"copy_id,omitempty"`
+	Barcode   string     `json:"barcode,omitempty"`
+	PlacedAt  time.Time  `json:"placed_at"`
+	ReadyAt   *time.Time `json:"ready_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+}
+
+func ToHoldResponse(hold *entity.Hold) *HoldResponse {
+	response := &HoldResponse{
+		ID:        hold.ID.String(),
+		BookID:    hold.BookID.String(),
+		MemberID:  hold.MemberID.String(),
+		Status:    hold.Status,
+		Position:  hold.Position,
+		PlacedAt:  hold.PlacedAt,
+		ReadyAt:   hold.ReadyAt,
+		ExpiresAt: hold.ExpiresAt,
+		ClosedAt:  hold.ClosedAt,
+	}
+
+	if hold.CopyID != nil {
+		response.CopyID = hold.CopyID.String()
+	}
+
+	if hold.Copy != nil {
+		response.Barcode = hold.Copy.Barcode
+	}
+
+	return response
+}
+
+func ToHoldsResponse(holds []*entity.Hold) []HoldResponse {
+	responses := make([]HoldResponse, len(holds))
+	for i, hold := range holds {
+		responses[i] = *ToHoldResponse(hold)
+	}
+	return responses
+}
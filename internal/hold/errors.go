@@ -0,0 +1,11 @@
+package hold
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("hold not found")
+	ErrInvalidBookID   = errors.New("invalid book ID")
+	ErrInvalidMemberID = errors.New("invalid member ID")
+	ErrDuplicate       = errors.New("member already has an open hold on the book")
+	ErrClosed          = errors.New("hold is closed")
+)
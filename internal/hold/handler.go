@@ -0,0 +1,268 @@
+package hold
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/hold/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type HoldSuccessResponse struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Hold retrieved successfully"`
+	Hold    *dto.HoldResponse `json:"hold"`
+}
+
+type HoldsSuccessResponse struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Holds retrieved successfully"`
+	Holds   []dto.HoldResponse `json:"holds"`
+}
+
+type HoldHandler struct {
+	service   HoldService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewHoldHandler(service HoldService, validator *internalValidator.Validator, logger zerolog.Logger) *HoldHandler {
+	return &HoldHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *HoldHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// the members manage their own holds, the librarians the holds of
+	// everyone
+	r.Use(auth.Require)
+
+	r.Post("/", h.PlaceHold)
+	r.Get("/", h.GetHolds)
+	r.Get("/{hold_id}", h.GetHoldByID)
+	r.Post("/{hold_id}/cancel", h.CancelHold)
+
+	return r
+}
+
+// PlaceHold godoc
+//
+//	@Summary		Place a hold
+//	@Description	Reserve a book for a member. The hold waits in the queue of the book until a copy is set aside for the member, at once when one is available. A member only places holds for themselves, whose ID is the subject of the access token.
+//	@Tags			holds
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			hold			body		dto.PlaceHoldRequest	true	"Hold data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	HoldSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/holds [post]
+func (h *HoldHandler) PlaceHold(w http.ResponseWriter, r *http.Request) {
+	var req dto.PlaceHoldRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), req.MemberID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	hold, err := h.service.PlaceHold(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, HoldSuccessResponse{
+		Status:  "success",
+		Message: "Hold placed successfully",
+		Hold:    dto.ToHoldResponse(hold),
+	})
+}
+
+// GetHolds godoc
+//
+//	@Summary		Get holds
+//	@Description	Get the holds matching the filters in the order they were placed, with the queue position of the waiting ones. A member only gets their own holds, the librarians get the holds of every member.
+//	@Tags			holds
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			member_id		query		string	false	"Only the holds of this member, the authenticated member by default"
+//	@Param			book_id			query		string	false	"Only the holds of this book"
+//	@Param			status			query		string	false	"Only the holds with this status"	Enums(waiting, ready, fulfilled, cancelled, expired)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	HoldsSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/holds [get]
+func (h *HoldHandler) GetHolds(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewHoldFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	memberID, err := auth.ScopeSubject(r.Context(), filter.MemberID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	filter.MemberID = memberID
+
+	holds, err := h.service.GetHolds(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, HoldsSuccessResponse{
+		Status:  "success",
+		Message: "Holds retrieved successfully",
+		Holds:   dto.ToHoldsResponse(holds),
+	})
+}
+
+// GetHoldByID godoc
+//
+//	@Summary		Get hold by id
+//	@Description	Get a single hold by its ID, with its queue position while waiting. A member only gets their own holds.
+//	@Tags			holds
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			hold_id	path		string	true	"Hold ID"
+//	@Success		200		{object}	HoldSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/holds/{hold_id} [get]
+func (h *HoldHandler) GetHoldByID(w http.ResponseWriter, r *http.Request) {
+	holdID, err := uuid.Parse(chi.URLParam(r, "hold_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	hold, err := h.service.GetHoldByID(r.Context(), holdID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	// the holds of the other members are answered as missing, their IDs are
+	// not to be probed
+	if err := auth.ActFor(r.Context(), hold.MemberID.String()); err != nil {
+		h.handleError(w, ErrNotFound)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, HoldSuccessResponse{
+		Status:  "success",
+		Message: "Hold retrieved successfully",
+		Hold:    dto.ToHoldResponse(hold),
+	})
+}
+
+// CancelHold godoc
+//
+//	@Summary		Cancel a hold
+//	@Description	Cancel a waiting or ready hold, the copy set aside for it goes to the next hold of the queue. A member only cancels their own holds.
+//	@Tags			holds
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			hold_id	path		string	true	"Hold ID"
+//	@Success		200		{object}	HoldSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/holds/{hold_id}/cancel [post]
+func (h *HoldHandler) CancelHold(w http.ResponseWriter, r *http.Request) {
+	holdID, err := uuid.Parse(chi.URLParam(r, "hold_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	hold, err := h.service.GetHoldByID(r.Context(), holdID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), hold.MemberID.String()); err != nil {
+		h.handleError(w, ErrNotFound)
+		return
+	}
+
+	hold, err = h.service.CancelHold(r.Context(), holdID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, HoldSuccessResponse{
+		Status:  "success",
+		Message: "Hold cancelled successfully",
+		Hold:    dto.ToHoldResponse(hold),
+	})
+}
+
+func (h *HoldHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Hold not found")
+	case errors.Is(err, book.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, member.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Member not found")
+	case errors.Is(err, ErrInvalidBookID):
+		response.Error(w, http.StatusBadRequest, "invalid book ID")
+	case errors.Is(err, ErrInvalidMemberID):
+		response.Error(w, http.StatusBadRequest, "invalid member ID")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Member already has a hold on this book")
+	case errors.Is(err, ErrClosed):
+		response.Error(w, http.StatusConflict, "Hold is already closed")
+	case errors.Is(err, auth.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "Forbidden")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
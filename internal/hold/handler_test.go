@@ -0,0 +1,538 @@
+package hold_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/hold/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestHoldHandler_PlaceHold(t *testing.T) {
+	placedAt := time.Now().UTC().Truncate(time.Second)
+	holder := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        interface{}
+		configureMock      func(*mocks.MockHoldService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success place hold",
+			identity: holder,
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockHoldService) {
+				input := &dto.PlaceHoldRequest{
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+				}
+
+				mockService.EXPECT().
+					PlaceHold(gomock.Any(), input).
+					Return(&entity.Hold{
+						ID:       holdID,
+						BookID:   bookID,
+						MemberID: memberID,
+						Status:   entity.HoldStatusWaiting,
+						PlacedAt: placedAt,
+						Position: 2,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &hold.HoldSuccessResponse{
+				Status:  "success",
+				Message: "Hold placed successfully",
+				Hold: &dto.HoldResponse{
+					ID:       holdID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Status:   entity.HoldStatusWaiting,
+					Position: 2,
+					PlacedAt: placedAt,
+				},
+			},
+		},
+		{
+			name:     "success librarian places a hold for a member",
+			identity: &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					PlaceHold(gomock.Any(), gomock.Any()).
+					Return(&entity.Hold{
+						ID:       holdID,
+						BookID:   bookID,
+						MemberID: memberID,
+						Status:   entity.HoldStatusWaiting,
+						PlacedAt: placedAt,
+						Position: 1,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &hold.HoldSuccessResponse{
+				Status:  "success",
+				Message: "Hold placed successfully",
+				Hold: &dto.HoldResponse{
+					ID:       holdID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Status:   entity.HoldStatusWaiting,
+					Position: 1,
+					PlacedAt: placedAt,
+				},
+			},
+		},
+		{
+			name:     "error hold for another member",
+			identity: &auth.Identity{Subject: otherMemberID.String()},
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name: "error anonymous caller",
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:     "error validation fails invalid member id",
+			identity: holder,
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: "not-a-uuid",
+			},
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "member_id",
+					Message: "member_id must be a lowercase UUID",
+				}},
+			},
+		},
+		{
+			name:     "error duplicate",
+			identity: holder,
+			requestBody: dto.PlaceHoldRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					PlaceHold(gomock.Any(), gomock.Any()).
+					Return(nil, hold.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Member already has a hold on this book",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockHoldService(ctrl)
+			test.configureMock(mockService)
+
+			handler := hold.NewHoldHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/holds", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/holds", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestHoldHandler_GetHolds(t *testing.T) {
+	holder := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		query              string
+		configureMock      func(*mocks.MockHoldService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success filters on the member",
+			identity: holder,
+			query:    "member_id=" + memberID.String() + "&status=ready",
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHolds(gomock.Any(), dto.HoldFilter{MemberID: memberID.String(), Status: entity.HoldStatusReady}).
+					Return([]*entity.Hold{{
+						ID:       holdID,
+						BookID:   bookID,
+						MemberID: memberID,
+						Status:   entity.HoldStatusReady,
+						CopyID:   &copyID,
+						Copy:     &entity.Copy{ID: copyID, Barcode: "LIB-0001"},
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &hold.HoldsSuccessResponse{
+				Status:  "success",
+				Message: "Holds retrieved successfully",
+				Holds: []dto.HoldResponse{{
+					ID:       holdID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Status:   entity.HoldStatusReady,
+					CopyID:   copyID.String(),
+					Barcode:  "LIB-0001",
+				}},
+			},
+		},
+		{
+			name:     "success member lists their own holds",
+			identity: holder,
+			query:    "",
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHolds(gomock.Any(), dto.HoldFilter{MemberID: memberID.String()}).
+					Return([]*entity.Hold{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &hold.HoldsSuccessResponse{
+				Status:  "success",
+				Message: "Holds retrieved successfully",
+				Holds:   []dto.HoldResponse{},
+			},
+		},
+		{
+			name:               "error holds of another member",
+			identity:           &auth.Identity{Subject: otherMemberID.String()},
+			query:              "member_id=" + memberID.String(),
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:               "error invalid status",
+			identity:           holder,
+			query:              "status=lost",
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "status",
+					Message: "status must be one of [waiting ready fulfilled cancelled expired]",
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockHoldService(ctrl)
+			test.configureMock(mockService)
+
+			handler := hold.NewHoldHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/holds?"+test.query, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/holds", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestHoldHandler_GetHoldByID(t *testing.T) {
+	holder := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockHoldService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:         "success get own hold",
+			identity:     holder,
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Hold retrieved successfully",
+		},
+		{
+			name:         "success librarian gets the hold of a member",
+			identity:     &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Hold retrieved successfully",
+		},
+		{
+			name:         "error hold of another member",
+			identity:     &auth.Identity{Subject: otherMemberID.String()},
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Hold not found",
+		},
+		{
+			name:         "error hold not found",
+			identity:     holder,
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(nil, hold.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Hold not found",
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       holdID.String(),
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    "Unauthorized",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockHoldService(ctrl)
+			test.configureMock(mockService)
+
+			handler := hold.NewHoldHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/holds/"+test.idInUrlParam, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/holds", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
+
+func TestHoldHandler_CancelHold(t *testing.T) {
+	holder := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockHoldService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:         "success cancel hold",
+			identity:     holder,
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				closedAt := time.Now().UTC()
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+				mockService.EXPECT().
+					CancelHold(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, Status: entity.HoldStatusCancelled, ClosedAt: &closedAt}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Hold cancelled successfully",
+		},
+		{
+			name:         "error hold of another member",
+			identity:     &auth.Identity{Subject: otherMemberID.String()},
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Hold not found",
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       holdID.String(),
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    "Unauthorized",
+		},
+		{
+			name:               "error invalid uuid",
+			identity:           holder,
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockHoldService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Invalid uuid",
+		},
+		{
+			name:         "error hold not found",
+			identity:     holder,
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(nil, hold.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Hold not found",
+		},
+		{
+			name:         "error already closed",
+			identity:     holder,
+			idInUrlParam: holdID.String(),
+			configureMock: func(mockService *mocks.MockHoldService) {
+				mockService.EXPECT().
+					GetHoldByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, MemberID: memberID, Status: entity.HoldStatusCancelled}, nil)
+				mockService.EXPECT().
+					CancelHold(gomock.Any(), holdID).
+					Return(nil, hold.ErrClosed)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Hold is already closed",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockHoldService(ctrl)
+			test.configureMock(mockService)
+
+			handler := hold.NewHoldHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, "/holds/"+test.idInUrlParam+"/cancel", nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/holds", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+)
+
+// Queue hands the copies of a book to its holds, first placed first served.
+// Its methods must run inside the transaction carried by ctx, with the book
+// locked so that the queue of a book is served by one transaction at a time.
+//
+//go:generate mockgen -destination=../mocks/mock_hold_queue.go -package=mocks go-boilerplate-rest-api-chi/internal/hold Queue
+type Queue interface {
+	// Release gives a locked copy back to the queue of its book: it is set
+	// aside for the oldest waiting hold, or shelved when nobody waits.
+	Release(ctx context.Context, bookCopy *entity.Copy) error
+	// Advance sets the available copies of the book aside for its waiting
+	// holds and returns the number of holds made ready.
+	Advance(ctx context.Context, bookID uuid.UUID) (int, error)
+	// Claim fulfills the hold of the member ready for pickup and returns its
+	// copy, still on hold, or nil when the member has no ready hold.
+	Claim(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (*entity.Copy, error)
+}
+
+type queue struct {
+	repository     HoldRepository
+	copyRepository inventory.CopyRepository
+	outbox         outbox.Outbox
+	pickup         time.Duration
+	now            func() time.Time
+}
+
+func NewQueue(repository HoldRepository, copyRepository inventory.CopyRepository, outbox outbox.Outbox, cfg config.HoldConfig) Queue {
+	return &queue{
+		repository:     repository,
+		copyRepository: copyRepository,
+		outbox:         outbox,
+		pickup:         time.Duration(cfg.PickupDays) * 24 * time.Hour,
+		now:            time.Now,
+	}
+}
+
+func (q *queue) Release(ctx context.Context, bookCopy *entity.Copy) error {
+	next, err := q.repository.LockNextWaiting(ctx, bookCopy.BookID)
+	if errors.Is(err, ErrNotFound) {
+		bookCopy.Status = entity.CopyStatusAvailable
+		_, err = q.copyRepository.Update(ctx, bookCopy)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	return q.setAside(ctx, bookCopy, next)
+}
+
+func (q *queue) Advance(ctx context.Context, bookID uuid.UUID) (int, error) {
+	ready := 0
+
+	for {
+		next, err := q.repository.LockNextWaiting(ctx, bookID)
+		if errors.Is(err, ErrNotFound) {
+			return ready, nil
+		}
+		if err != nil {
+			return ready, err
+		}
+
+		bookCopy, err := q.copyRepository.LockAvailableByBookID(ctx, bookID)
+		if errors.Is(err, inventory.ErrNotFound) {
+			return ready, nil
+		}
+		if err != nil {
+			return ready, err
+		}
+
+		if err := q.setAside(ctx, bookCopy, next); err != nil {
+			return ready, err
+		}
+		ready++
+	}
+}
+
+func (q *queue) Claim(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (*entity.Copy, error) {
+	hold, err := q.repository.LockReady(ctx, bookID, memberID)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	heldCopy, err := q.copyRepository.LockByID(ctx, *hold.CopyID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := q.now().UTC()
+	hold.Status = entity.HoldStatusFulfilled
+	hold.ClosedAt = &now
+
+	if hold, err = q.repository.Update(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	if err := q.outbox.Record(ctx, event.NewHoldFulfilled(hold)); err != nil {
+		return nil, err
+	}
+
+	return heldCopy, nil
+}
+
+// setAside keeps the copy for the hold until the end of the pickup period.
+func (q *queue) setAside(ctx context.Context, bookCopy *entity.Copy, hold *entity.Hold) error {
+	bookCopy.Status = entity.CopyStatusOnHold
+	if _, err := q.copyRepository.Update(ctx, bookCopy); err != nil {
+		return err
+	}
+
+	now := q.now().UTC()
+	expiresAt := now.Add(q.pickup)
+
+	hold.Status = entity.HoldStatusReady
+	hold.CopyID = &bookCopy.ID
+	hold.ReadyAt = &now
+	hold.ExpiresAt = &expiresAt
+
+	hold, err := q.repository.Update(ctx, hold)
+	if err != nil {
+		return err
+	}
+
+	return q.outbox.Record(ctx, event.NewHoldReady(hold))
+}
@@ -0,0 +1,244 @@
+package hold_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+)
+
+var (
+	bookID        = uuid.MustParse("d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f")
+	memberID      = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	otherMemberID = uuid.MustParse("61c8f3d2-7a4e-4b9f-a2c5-e8d1b6f0a347")
+	holdID        = uuid.MustParse("7e3c1a9b-4d2f-4b8e-a6c5-0f1e2d3c4b5a")
+	copyID        = uuid.MustParse("5c1f0e7a-2b9d-4c3e-8f6a-7d4b2a1e9c08")
+)
+
+var holdConfig = config.HoldConfig{PickupDays: 7, SweepBatchSize: 100}
+
+func TestQueue_Release(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockHoldRepository, *mocks.MockCopyRepository, *mocks.MockOutbox)
+	}{
+		{
+			name: "success set aside for the next hold",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					LockNextWaiting(gomock.Any(), bookID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusWaiting}, nil)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) {
+						assert.Equal(t, entity.CopyStatusOnHold, c.Status)
+						return c, nil
+					})
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.Equal(t, entity.HoldStatusReady, h.Status)
+						assert.Equal(t, &copyID, h.CopyID)
+						require.NotNil(t, h.ReadyAt)
+						require.NotNil(t, h.ExpiresAt)
+						assert.Equal(t, h.ReadyAt.Add(7*24*time.Hour), *h.ExpiresAt)
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.HoldReady, events[0].Type)
+						assert.Equal(t, holdID, events[0].AggregateID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "success shelved when nobody waits",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					LockNextWaiting(gomock.Any(), bookID).
+					Return(nil, hold.ErrNotFound)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) {
+						assert.Equal(t, entity.CopyStatusAvailable, c.Status)
+						return c, nil
+					})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, copyRepoMock, outboxMock)
+			queue := hold.NewQueue(holdRepoMock, copyRepoMock, outboxMock, holdConfig)
+
+			err := queue.Release(context.Background(), &entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnLoan})
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestQueue_Advance(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockHoldRepository, *mocks.MockCopyRepository, *mocks.MockOutbox)
+		expectedResponse int
+	}{
+		{
+			name: "success until no copy is available",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				gomock.InOrder(
+					mockHoldRepo.EXPECT().
+						LockNextWaiting(gomock.Any(), bookID).
+						Return(&entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusWaiting}, nil),
+					mockCopyRepo.EXPECT().
+						LockAvailableByBookID(gomock.Any(), bookID).
+						Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusAvailable}, nil),
+					mockHoldRepo.EXPECT().
+						LockNextWaiting(gomock.Any(), bookID).
+						Return(&entity.Hold{ID: uuid.New(), BookID: bookID, Status: entity.HoldStatusWaiting}, nil),
+					mockCopyRepo.EXPECT().
+						LockAvailableByBookID(gomock.Any(), bookID).
+						Return(nil, inventory.ErrNotFound),
+				)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) {
+						assert.Equal(t, entity.CopyStatusOnHold, c.Status)
+						return c, nil
+					})
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.Equal(t, holdID, h.ID)
+						assert.Equal(t, entity.HoldStatusReady, h.Status)
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedResponse: 1,
+		},
+		{
+			name: "success nobody waits",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					LockNextWaiting(gomock.Any(), bookID).
+					Return(nil, hold.ErrNotFound)
+			},
+			expectedResponse: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, copyRepoMock, outboxMock)
+			queue := hold.NewQueue(holdRepoMock, copyRepoMock, outboxMock, holdConfig)
+
+			result, err := queue.Advance(context.Background(), bookID)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestQueue_Claim(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockHoldRepository, *mocks.MockCopyRepository, *mocks.MockOutbox)
+		expectedResponse *entity.Copy
+	}{
+		{
+			name: "success claim ready hold",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					LockReady(gomock.Any(), bookID, memberID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusReady, CopyID: &copyID}, nil)
+
+				mockCopyRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnHold}, nil)
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.Equal(t, entity.HoldStatusFulfilled, h.Status)
+						assert.NotNil(t, h.ClosedAt)
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.HoldFulfilled, events[0].Type)
+						assert.Equal(t, holdID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnHold},
+		},
+		{
+			name: "success no ready hold",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockCopyRepo *mocks.MockCopyRepository, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					LockReady(gomock.Any(), bookID, memberID).
+					Return(nil, hold.ErrNotFound)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, copyRepoMock, outboxMock)
+			queue := hold.NewQueue(holdRepoMock, copyRepoMock, outboxMock, holdConfig)
+
+			result, err := queue.Claim(context.Background(), bookID, memberID)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
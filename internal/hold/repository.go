@@ -0,0 +1,203 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/hold/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_hold_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/hold HoldRepository
+type HoldRepository interface {
+	Create(ctx context.Context, newHold *entity.Hold) (*entity.Hold, error)
+	GetAll(ctx context.Context, filter dto.HoldFilter) ([]*entity.Hold, error)
+	GetByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error)
+	LockByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error)
+	// LockNextWaiting locks the oldest waiting hold of the book, it returns
+	// ErrNotFound when nobody waits.
+	LockNextWaiting(ctx context.Context, bookID uuid.UUID) (*entity.Hold, error)
+	// LockReady locks the hold of the member ready for pickup, it returns
+	// ErrNotFound when there is none.
+	LockReady(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (*entity.Hold, error)
+	// GetExpired returns the oldest holds ready for pickup past their expiry
+	// at now, up to limit.
+	GetExpired(ctx context.Context, now time.Time, limit int) ([]*entity.Hold, error)
+	CountOpen(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (int64, error)
+	// CountWaitingBefore returns the number of waiting holds ahead of the hold
+	// in the queue of its book.
+	CountWaitingBefore(ctx context.Context, hold *entity.Hold) (int64, error)
+	// GetBookIDsToAdvance returns the books with waiting holds and available
+	// copies at once, such as when a copy comes back from repair.
+	GetBookIDsToAdvance(ctx context.Context, limit int) ([]uuid.UUID, error)
+	Update(ctx context.Context, hold *entity.Hold) (*entity.Hold, error)
+}
+
+type holdRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewHoldRepository(db *gorm.DB, logger zerolog.Logger) HoldRepository {
+	return &holdRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *holdRepository) Create(ctx context.Context, newHold *entity.Hold) (*entity.Hold, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newHold).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newHold, nil
+}
+
+// GetAll returns the filtered holds in the order they were placed.
+func (r *holdRepository) GetAll(ctx context.Context, filter dto.HoldFilter) ([]*entity.Hold, error) {
+	var holds []*entity.Hold
+
+	query := transaction.DB(ctx, r.db).Preload("Copy")
+
+	if filter.MemberID != "" {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+
+	if filter.BookID != "" {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Order("placed_at, id").Find(&holds).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *holdRepository) GetByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error) {
+	return r.first(transaction.DB(ctx, r.db).Preload("Copy"), "id = ?", holdID)
+}
+
+// LockByID reads the hold and locks its row until the end of the transaction
+// carried by ctx.
+func (r *holdRepository) LockByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error) {
+	return r.first(r.locked(ctx).Preload("Copy"), "id = ?", holdID)
+}
+
+func (r *holdRepository) LockNextWaiting(ctx context.Context, bookID uuid.UUID) (*entity.Hold, error) {
+	return r.first(r.locked(ctx).Order("placed_at, id"), "book_id = ? AND status = ?", bookID, entity.HoldStatusWaiting)
+}
+
+func (r *holdRepository) LockReady(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (*entity.Hold, error) {
+	return r.first(r.locked(ctx), "book_id = ? AND member_id = ? AND status = ?", bookID, memberID, entity.HoldStatusReady)
+}
+
+func (r *holdRepository) GetExpired(ctx context.Context, now time.Time, limit int) ([]*entity.Hold, error) {
+	var holds []*entity.Hold
+
+	err := transaction.DB(ctx, r.db).
+		Where("status = ? AND expires_at < ?", entity.HoldStatusReady, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&holds).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r *holdRepository) CountOpen(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID) (int64, error) {
+	var count int64
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Hold{}).
+		Where("book_id = ? AND member_id = ? AND status IN ?", bookID, memberID, []string{entity.HoldStatusWaiting, entity.HoldStatusReady}).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *holdRepository) CountWaitingBefore(ctx context.Context, hold *entity.Hold) (int64, error) {
+	var count int64
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Hold{}).
+		Where("book_id = ? AND status = ?", hold.BookID, entity.HoldStatusWaiting).
+		Where("placed_at < ? OR (placed_at = ? AND id < ?)", hold.PlacedAt, hold.PlacedAt, hold.ID).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *holdRepository) GetBookIDsToAdvance(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var bookIDs []uuid.UUID
+
+	available := transaction.DB(ctx, r.db).
+		Model(&entity.Copy{}).
+		Select("1").
+		Where("copies.book_id = holds.book_id AND copies.status = ?", entity.CopyStatusAvailable)
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Hold{}).
+		Distinct("book_id").
+		Where("status = ? AND EXISTS (?)", entity.HoldStatusWaiting, available).
+		Limit(limit).
+		Pluck("book_id", &bookIDs).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return bookIDs, nil
+}
+
+func (r *holdRepository) Update(ctx context.Context, hold *entity.Hold) (*entity.Hold, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(hold).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (r *holdRepository) locked(ctx context.Context) *gorm.DB {
+	return transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+}
+
+func (r *holdRepository) first(query *gorm.DB, conds ...any) (*entity.Hold, error) {
+	var hold *entity.Hold
+
+	if err := query.First(&hold, conds...).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return hold, nil
+}
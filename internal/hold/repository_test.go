@@ -0,0 +1,235 @@
+package hold_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var holdColumns = []string{"id", "book_id", "member_id", "status", "copy_id", "placed_at", "ready_at", "expires_at", "closed_at", "created_at", "updated_at"}
+
+func TestHoldRepository_LockNextWaiting(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedResponse *entity.Hold
+	}{
+		{
+			name: "success lock the oldest waiting hold",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				placedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+				rows := sqlmock.NewRows(holdColumns).
+					AddRow(holdID, bookID, memberID, entity.HoldStatusWaiting, nil, placedAt, nil, nil, nil, placedAt, placedAt)
+
+				mock.ExpectQuery(`SELECT \* FROM .holds. WHERE book_id = \? AND status = \? ORDER BY placed_at, id,.holds.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(bookID, entity.HoldStatusWaiting, 1).
+					WillReturnRows(rows)
+			},
+			expectedResponse: &entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting},
+		},
+		{
+			name: "error nobody waits",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .holds. WHERE book_id = \? AND status = \? ORDER BY placed_at, id,.holds.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(bookID, entity.HoldStatusWaiting, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: hold.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := hold.NewHoldRepository(db, zerolog.Nop())
+
+			next, err := repo.LockNextWaiting(context.Background(), bookID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, next)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.ID, next.ID)
+				assert.Equal(t, test.expectedResponse.MemberID, next.MemberID)
+				assert.Equal(t, test.expectedResponse.Status, next.Status)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_CountWaitingBefore(t *testing.T) {
+	placedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	waiting := &entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting, PlacedAt: placedAt}
+
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedCount int64
+	}{
+		{
+			name: "success behind a waiting hold",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM .holds. WHERE \(book_id = \? AND status = \?\) AND \(placed_at < \? OR \(placed_at = \? AND id < \?\)\)`).
+					WithArgs(bookID, entity.HoldStatusWaiting, placedAt, placedAt, holdID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM .holds.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := hold.NewHoldRepository(db, zerolog.Nop())
+
+			count, err := repo.CountWaitingBefore(context.Background(), waiting)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCount, count)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_GetExpired(t *testing.T) {
+	now := time.Date(2024, 3, 8, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedIDs   []uuid.UUID
+	}{
+		{
+			name: "success get the expired holds",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				expiresAt := now.Add(-time.Hour)
+
+				rows := sqlmock.NewRows(holdColumns).
+					AddRow(holdID, bookID, memberID, entity.HoldStatusReady, copyID, now, now, expiresAt, nil, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .holds. WHERE status = \? AND expires_at < \? ORDER BY expires_at LIMIT \?`).
+					WithArgs(entity.HoldStatusReady, now, 10).
+					WillReturnRows(rows)
+			},
+			expectedIDs: []uuid.UUID{holdID},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .holds. WHERE status = \? AND expires_at < \? ORDER BY expires_at LIMIT \?`).
+					WithArgs(entity.HoldStatusReady, now, 10).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := hold.NewHoldRepository(db, zerolog.Nop())
+
+			holds, err := repo.GetExpired(context.Background(), now, 10)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, holds)
+			} else {
+				require.NoError(t, err)
+
+				ids := make([]uuid.UUID, len(holds))
+				for i, h := range holds {
+					ids[i] = h.ID
+				}
+				assert.Equal(t, test.expectedIDs, ids)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_GetBookIDsToAdvance(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedResponse []uuid.UUID
+	}{
+		{
+			name: "success get the books with waiting holds and available copies",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT .book_id. FROM .holds. WHERE status = \? AND EXISTS \(SELECT 1 FROM .copies. WHERE copies.book_id = holds.book_id AND copies.status = \?\) LIMIT \?`).
+					WithArgs(entity.HoldStatusWaiting, entity.CopyStatusAvailable, 10).
+					WillReturnRows(sqlmock.NewRows([]string{"book_id"}).AddRow(bookID))
+			},
+			expectedResponse: []uuid.UUID{bookID},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT DISTINCT .book_id. FROM .holds.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := hold.NewHoldRepository(db, zerolog.Nop())
+
+			bookIDs, err := repo.GetBookIDsToAdvance(context.Background(), 10)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, bookIDs)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse, bookIDs)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
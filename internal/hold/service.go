@@ -0,0 +1,309 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/hold/dto"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_hold_service.go -package=mocks go-boilerplate-rest-api-chi/internal/hold HoldService
+type HoldService interface {
+	// PlaceHold puts the member in the queue of the book, the hold is ready
+	// at once when a copy is available.
+	PlaceHold(ctx context.Context, req *dto.PlaceHoldRequest) (*entity.Hold, error)
+	GetHolds(ctx context.Context, filter dto.HoldFilter) ([]*entity.Hold, error)
+	GetHoldByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error)
+	// CancelHold closes an open hold, its copy set aside goes to the next
+	// hold of the queue.
+	CancelHold(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error)
+	// ExpireHolds closes the holds not picked up in time and hands their copy
+	// to the next hold, it returns the number of expired holds.
+	ExpireHolds(ctx context.Context) (int, error)
+	// AdvanceQueues sets the available copies aside for the waiting holds of
+	// their book and returns the number of holds made ready.
+	AdvanceQueues(ctx context.Context) (int, error)
+}
+
+type holdService struct {
+	repository       HoldRepository
+	bookRepository   book.BookRepository
+	memberRepository member.MemberRepository
+	copyRepository   inventory.CopyRepository
+	queue            Queue
+	transactions     transaction.Manager
+	outbox           outbox.Outbox
+	batchSize        int
+	logger           zerolog.Logger
+}
+
+func NewHoldService(repository HoldRepository, bookRepository book.BookRepository, memberRepository member.MemberRepository, copyRepository inventory.CopyRepository, queue Queue, transactions transaction.Manager, outbox outbox.Outbox, cfg config.HoldConfig, logger zerolog.Logger) HoldService {
+	return &holdService{
+		repository:       repository,
+		bookRepository:   bookRepository,
+		memberRepository: memberRepository,
+		copyRepository:   copyRepository,
+		queue:            queue,
+		transactions:     transactions,
+		outbox:           outbox,
+		batchSize:        cfg.SweepBatchSize,
+		logger:           logger,
+	}
+}
+
+func (s *holdService) PlaceHold(ctx context.Context, req *dto.PlaceHoldRequest) (*entity.Hold, error) {
+	bookID, err := uuid.Parse(req.BookID)
+	if err != nil {
+		return nil, ErrInvalidBookID
+	}
+
+	memberID, err := uuid.Parse(req.MemberID)
+	if err != nil {
+		return nil, ErrInvalidMemberID
+	}
+
+	var hold *entity.Hold
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.memberRepository.LockByID(ctx, memberID); err != nil {
+			return err
+		}
+
+		if _, err := s.bookRepository.LockByID(ctx, bookID); err != nil {
+			return err
+		}
+
+		open, err := s.repository.CountOpen(ctx, bookID, memberID)
+		if err != nil {
+			return err
+		}
+
+		if open > 0 {
+			return ErrDuplicate
+		}
+
+		hold, err = s.repository.Create(ctx, &entity.Hold{
+			BookID:   bookID,
+			MemberID: memberID,
+			Status:   entity.HoldStatusWaiting,
+			PlacedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := s.outbox.Record(ctx, event.NewHoldPlaced(hold)); err != nil {
+			return err
+		}
+
+		if _, err := s.queue.Advance(ctx, bookID); err != nil {
+			return err
+		}
+
+		// the hold is ready when a copy was available
+		if hold, err = s.repository.GetByID(ctx, hold.ID); err != nil {
+			return err
+		}
+
+		return s.setPosition(ctx, hold)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (s *holdService) GetHolds(ctx context.Context, filter dto.HoldFilter) ([]*entity.Hold, error) {
+	holds, err := s.repository.GetAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hold := range holds {
+		if err := s.setPosition(ctx, hold); err != nil {
+			return nil, err
+		}
+	}
+
+	return holds, nil
+}
+
+func (s *holdService) GetHoldByID(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error) {
+	hold, err := s.repository.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.setPosition(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (s *holdService) CancelHold(ctx context.Context, holdID uuid.UUID) (*entity.Hold, error) {
+	hold, err := s.repository.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.bookRepository.LockByID(ctx, hold.BookID); err != nil {
+			return err
+		}
+
+		locked, err := s.repository.LockByID(ctx, holdID)
+		if err != nil {
+			return err
+		}
+
+		if !locked.Open() {
+			return ErrClosed
+		}
+
+		hold = locked
+		return s.close(ctx, hold, entity.HoldStatusCancelled, event.NewHoldCancelled)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (s *holdService) ExpireHolds(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+
+	holds, err := s.repository.GetExpired(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+
+	// every hold expires in its own transaction, a failure does not roll back
+	// the holds already expired
+	for _, candidate := range holds {
+		closed := false
+
+		err := s.transactions.Do(ctx, func(ctx context.Context) error {
+			if _, err := s.bookRepository.LockByID(ctx, candidate.BookID); err != nil {
+				return err
+			}
+
+			hold, err := s.repository.LockByID(ctx, candidate.ID)
+			if err != nil {
+				return err
+			}
+
+			// picked up or cancelled in the meantime
+			if hold.Status != entity.HoldStatusReady || !hold.ExpiresAt.Before(now) {
+				return nil
+			}
+
+			closed = true
+			return s.close(ctx, hold, entity.HoldStatusExpired, event.NewHoldExpired)
+		})
+		if err != nil {
+			return expired, err
+		}
+
+		if closed {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+func (s *holdService) AdvanceQueues(ctx context.Context) (int, error) {
+	bookIDs, err := s.repository.GetBookIDsToAdvance(ctx, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	ready := 0
+
+	for _, bookID := range bookIDs {
+		var advanced int
+
+		err := s.transactions.Do(ctx, func(ctx context.Context) error {
+			if _, err := s.bookRepository.LockByID(ctx, bookID); err != nil {
+				return err
+			}
+
+			var err error
+			advanced, err = s.queue.Advance(ctx, bookID)
+			return err
+		})
+		// the book and its holds were deleted in the meantime
+		if errors.Is(err, book.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return ready, err
+		}
+
+		ready += advanced
+	}
+
+	return ready, nil
+}
+
+// close ends an open hold, the copy set aside for a ready hold goes back to
+// the queue.
+func (s *holdService) close(ctx context.Context, hold *entity.Hold, status string, newEvent func(*entity.Hold) event.Event) error {
+	wasReady := hold.Status == entity.HoldStatusReady
+
+	now := time.Now().UTC()
+	hold.Status = status
+	hold.ClosedAt = &now
+
+	hold, err := s.repository.Update(ctx, hold)
+	if err != nil {
+		return err
+	}
+
+	if err := s.outbox.Record(ctx, newEvent(hold)); err != nil {
+		return err
+	}
+
+	if !wasReady || hold.CopyID == nil {
+		return nil
+	}
+
+	heldCopy, err := s.copyRepository.LockByID(ctx, *hold.CopyID)
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Release(ctx, heldCopy)
+}
+
+// setPosition ranks a waiting hold in the queue of its book.
+func (s *holdService) setPosition(ctx context.Context, hold *entity.Hold) error {
+	if hold.Status != entity.HoldStatusWaiting {
+		return nil
+	}
+
+	ahead, err := s.repository.CountWaitingBefore(ctx, hold)
+	if err != nil {
+		return err
+	}
+
+	hold.Position = ahead + 1
+	return nil
+}
@@ -0,0 +1,409 @@
+package hold_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/hold/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestHoldService_PlaceHold(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.PlaceHoldRequest
+		configureMock    func(*mocks.MockHoldRepository, *mocks.MockBookRepository, *mocks.MockMemberRepository, *mocks.MockQueue, *mocks.MockOutbox)
+		expectedResponse *entity.Hold
+		expectedError    error
+	}{
+		{
+			name:  "success waiting in the queue",
+			input: &dto.PlaceHoldRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockHoldRepo.EXPECT().CountOpen(gomock.Any(), bookID, memberID).Return(int64(0), nil)
+
+				mockHoldRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.Equal(t, entity.HoldStatusWaiting, h.Status)
+						h.ID = holdID
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.HoldPlaced, events[0].Type)
+						assert.Equal(t, holdID, events[0].AggregateID)
+						return nil
+					})
+
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+
+				mockHoldRepo.EXPECT().
+					CountWaitingBefore(gomock.Any(), gomock.Any()).
+					Return(int64(2), nil)
+			},
+			expectedResponse: &entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting, Position: 3},
+		},
+		{
+			name:  "success ready at once",
+			input: &dto.PlaceHoldRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockHoldRepo.EXPECT().CountOpen(gomock.Any(), bookID, memberID).Return(int64(0), nil)
+
+				mockHoldRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						h.ID = holdID
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(1, nil)
+
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusReady, CopyID: &copyID}, nil)
+			},
+			expectedResponse: &entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusReady, CopyID: &copyID},
+		},
+		{
+			name:  "error duplicate hold",
+			input: &dto.PlaceHoldRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockHoldRepo.EXPECT().CountOpen(gomock.Any(), bookID, memberID).Return(int64(1), nil)
+			},
+			expectedError: hold.ErrDuplicate,
+		},
+		{
+			name:  "error member not found",
+			input: &dto.PlaceHoldRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(nil, member.ErrNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+		{
+			name:  "error book not found",
+			input: &dto.PlaceHoldRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(nil, book.ErrNotFound)
+			},
+			expectedError: book.ErrNotFound,
+		},
+		{
+			name:  "error invalid book id",
+			input: &dto.PlaceHoldRequest{BookID: "not-a-uuid", MemberID: memberID.String()},
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+			},
+			expectedError: hold.ErrInvalidBookID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, bookRepoMock, memberRepoMock, queueMock, outboxMock)
+			service := hold.NewHoldService(holdRepoMock, bookRepoMock, memberRepoMock, mocks.NewMockCopyRepository(ctrl), queueMock, testutils.NewTransactionManager(ctrl), outboxMock, holdConfig, zerolog.Nop())
+
+			result, err := service.PlaceHold(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestHoldService_CancelHold(t *testing.T) {
+	tests := []struct {
+		name           string
+		configureMock  func(*mocks.MockHoldRepository, *mocks.MockBookRepository, *mocks.MockCopyRepository, *mocks.MockQueue, *mocks.MockOutbox)
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name: "success ready hold releases its copy",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusReady, CopyID: &copyID}, nil)
+
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockHoldRepo.EXPECT().
+					LockByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusReady, CopyID: &copyID}, nil)
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.NotNil(t, h.ClosedAt)
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.HoldCancelled, events[0].Type)
+						assert.Equal(t, holdID, events[0].AggregateID)
+						return nil
+					})
+
+				mockCopyRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnHold}, nil)
+
+				mockQueue.EXPECT().
+					Release(gomock.Any(), &entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnHold}).
+					Return(nil)
+			},
+			expectedStatus: entity.HoldStatusCancelled,
+		},
+		{
+			name: "success waiting hold",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockHoldRepo.EXPECT().
+					LockByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, MemberID: memberID, Status: entity.HoldStatusWaiting}, nil)
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) { return h, nil })
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedStatus: entity.HoldStatusCancelled,
+		},
+		{
+			name: "error hold closed",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusFulfilled}, nil)
+
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockHoldRepo.EXPECT().
+					LockByID(gomock.Any(), holdID).
+					Return(&entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusFulfilled}, nil)
+			},
+			expectedError: hold.ErrClosed,
+		},
+		{
+			name: "error hold not found",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					GetByID(gomock.Any(), holdID).
+					Return(nil, hold.ErrNotFound)
+			},
+			expectedError: hold.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, bookRepoMock, copyRepoMock, queueMock, outboxMock)
+			service := hold.NewHoldService(holdRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), copyRepoMock, queueMock, testutils.NewTransactionManager(ctrl), outboxMock, holdConfig, zerolog.Nop())
+
+			result, err := service.CancelHold(context.Background(), holdID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, result.Status)
+			assert.NotNil(t, result.ClosedAt)
+		})
+	}
+}
+
+func TestHoldService_ExpireHolds(t *testing.T) {
+	past := time.Now().UTC().Add(-time.Hour)
+	future := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockHoldRepository, *mocks.MockBookRepository, *mocks.MockCopyRepository, *mocks.MockQueue, *mocks.MockOutbox)
+		expectedResponse int
+	}{
+		{
+			name: "success skips the holds extended meanwhile",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				expired := &entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusReady, CopyID: &copyID, ExpiresAt: &past}
+				// picked up a moment ago, its pickup period was extended
+				extended := &entity.Hold{ID: holdID, BookID: bookID, Status: entity.HoldStatusReady, CopyID: &copyID, ExpiresAt: &future}
+
+				mockHoldRepo.EXPECT().
+					GetExpired(gomock.Any(), gomock.Any(), holdConfig.SweepBatchSize).
+					Return([]*entity.Hold{expired, extended}, nil)
+
+				mockBookRepo.EXPECT().
+					LockByID(gomock.Any(), bookID).
+					Return(&entity.Book{ID: bookID}, nil).
+					Times(2)
+
+				gomock.InOrder(
+					mockHoldRepo.EXPECT().LockByID(gomock.Any(), holdID).Return(expired, nil),
+					mockHoldRepo.EXPECT().LockByID(gomock.Any(), holdID).Return(extended, nil),
+				)
+
+				mockHoldRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, h *entity.Hold) (*entity.Hold, error) {
+						assert.Equal(t, entity.HoldStatusExpired, h.Status)
+						return h, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.HoldExpired, events[0].Type)
+						return nil
+					})
+
+				mockCopyRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID}, nil)
+
+				mockQueue.EXPECT().
+					Release(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedResponse: 1,
+		},
+		{
+			name: "success no expired hold",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockOutbox *mocks.MockOutbox) {
+				mockHoldRepo.EXPECT().
+					GetExpired(gomock.Any(), gomock.Any(), holdConfig.SweepBatchSize).
+					Return([]*entity.Hold{}, nil)
+			},
+			expectedResponse: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(holdRepoMock, bookRepoMock, copyRepoMock, queueMock, outboxMock)
+			service := hold.NewHoldService(holdRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), copyRepoMock, queueMock, testutils.NewTransactionManager(ctrl), outboxMock, holdConfig, zerolog.Nop())
+
+			result, err := service.ExpireHolds(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestHoldService_AdvanceQueues(t *testing.T) {
+	deletedID := uuid.New()
+
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockHoldRepository, *mocks.MockBookRepository, *mocks.MockQueue)
+		expectedResponse int
+	}{
+		{
+			name: "success skips the deleted books",
+			configureMock: func(mockHoldRepo *mocks.MockHoldRepository, mockBookRepo *mocks.MockBookRepository, mockQueue *mocks.MockQueue) {
+				mockHoldRepo.EXPECT().
+					GetBookIDsToAdvance(gomock.Any(), holdConfig.SweepBatchSize).
+					Return([]uuid.UUID{deletedID, bookID}, nil)
+
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), deletedID).Return(nil, book.ErrNotFound)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(2, nil)
+			},
+			expectedResponse: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			holdRepoMock := mocks.NewMockHoldRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+
+			test.configureMock(holdRepoMock, bookRepoMock, queueMock)
+			service := hold.NewHoldService(holdRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), mocks.NewMockCopyRepository(ctrl), queueMock, testutils.NewTransactionManager(ctrl), mocks.NewMockOutbox(ctrl), holdConfig, zerolog.Nop())
+
+			result, err := service.AdvanceQueues(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+)
+
+// Sweeper expires the holds not picked up in time, and advances the queues of
+// the books whose copies became available outside of a return, such as a new
+// copy or a copy back from repair.
+type Sweeper struct {
+	service  HoldService
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+func NewSweeper(service HoldService, cfg config.HoldConfig, logger zerolog.Logger) *Sweeper {
+	return &Sweeper{
+		service:  service,
+		interval: cfg.SweepInterval,
+		logger:   logger,
+	}
+}
+
+// Run sweeps the holds every interval until ctx is done.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep makes a single pass over the expired holds and the queues to advance.
+func (s *Sweeper) Sweep(ctx context.Context) {
+	expired, err := s.service.ExpireHolds(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error().Err(err).Msg("failed to expire holds")
+	}
+
+	ready, err := s.service.AdvanceQueues(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error().Err(err).Msg("failed to advance hold queues")
+	}
+
+	if expired > 0 || ready > 0 {
+		s.logger.Info().Int("expired", expired).Int("ready", ready).Msg("holds swept")
+	}
+}
@@ -0,0 +1,27 @@
+package hold_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+)
+
+func TestSweeper_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	service := mocks.NewMockHoldService(ctrl)
+
+	// a failure to expire the holds does not keep the queues from advancing
+	gomock.InOrder(
+		service.EXPECT().ExpireHolds(gomock.Any()).Return(0, errors.New("database connection failed")),
+		service.EXPECT().AdvanceQueues(gomock.Any()).Return(1, nil),
+	)
+
+	hold.NewSweeper(service, holdConfig, zerolog.Nop()).Sweep(context.Background())
+}
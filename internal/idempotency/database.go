@@ -0,0 +1,168 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+// idempotencyKey is a reservation, or once completed the stored response, of
+// an idempotency key.
+type idempotencyKey struct {
+	Key         string `gorm:"primaryKey;size:64"`
+	Fingerprint string `gorm:"size:64;not null"`
+	Completed   bool   `gorm:"not null;default:false"`
+	StatusCode  int
+	Header      string    `gorm:"type:text"`
+	Body        []byte    `gorm:"type:mediumblob"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func (idempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+type databaseStore struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	nextSweep time.Time
+}
+
+func NewDatabaseStore(db *gorm.DB, logger zerolog.Logger) Store {
+	return &databaseStore{
+		db:     db,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// MigrateDatabase creates the idempotency_keys table when it is missing.
+func MigrateDatabase(db *gorm.DB) error {
+	return db.AutoMigrate(&idempotencyKey{})
+}
+
+// Acquire relies on the primary key to elect a single owner among concurrent
+// requests: the first insert wins, the others read the winner's row.
+func (s *databaseStore) Acquire(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Response, error) {
+	db := s.db.WithContext(ctx)
+
+	// the second attempt follows the removal of an expired or released key
+	for range 2 {
+		now := s.now()
+		s.sweep(ctx, now)
+
+		err := db.Create(&idempotencyKey{
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   now.Add(lockTTL),
+		}).Error
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			s.logger.Error().Err(err).Msg("failed to reserve idempotency key")
+			return nil, err
+		}
+
+		var existing idempotencyKey
+		err = db.Where(map[string]any{"key": key}).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error().Err(err).Msg("failed to read idempotency key")
+			return nil, err
+		}
+
+		if !now.Before(existing.ExpiresAt) {
+			if err := db.Where(map[string]any{"key": key}).Where("expires_at <= ?", now).Delete(&idempotencyKey{}).Error; err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if existing.Fingerprint != fingerprint {
+			return nil, ErrFingerprintMismatch
+		}
+
+		if !existing.Completed {
+			return nil, ErrInFlight
+		}
+
+		header := http.Header{}
+		if existing.Header != "" {
+			if err := json.Unmarshal([]byte(existing.Header), &header); err != nil {
+				return nil, err
+			}
+		}
+
+		return &Response{
+			StatusCode: existing.StatusCode,
+			Header:     header,
+			Body:       existing.Body,
+		}, nil
+	}
+
+	return nil, ErrInFlight
+}
+
+func (s *databaseStore) Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.WithContext(ctx).
+		Model(&idempotencyKey{}).
+		Where(map[string]any{"key": key}).
+		Updates(map[string]any{
+			"completed":   true,
+			"status_code": response.StatusCode,
+			"header":      string(header),
+			"body":        response.Body,
+			"expires_at":  s.now().Add(ttl),
+		}).Error
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to store idempotent response")
+		return err
+	}
+
+	return nil
+}
+
+func (s *databaseStore) Release(ctx context.Context, key string) error {
+	err := s.db.WithContext(ctx).
+		Where(map[string]any{"key": key, "completed": false}).
+		Delete(&idempotencyKey{}).Error
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to release idempotency key")
+		return err
+	}
+
+	return nil
+}
+
+// sweep deletes the expired keys, at most once per sweepInterval and instance.
+// Failures only delay the cleanup.
+func (s *databaseStore) sweep(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Before(s.nextSweep) {
+		s.mu.Unlock()
+		return
+	}
+	s.nextSweep = now.Add(sweepInterval)
+	s.mu.Unlock()
+
+	if err := s.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&idempotencyKey{}).Error; err != nil {
+		s.logger.Warn().Err(err).Msg("failed to purge expired idempotency keys")
+	}
+}
@@ -0,0 +1,8 @@
+package idempotency
+
+import "errors"
+
+var (
+	ErrInFlight            = errors.New("idempotency key is in use by another request")
+	ErrFingerprintMismatch = errors.New("idempotency key was used with another payload")
+)
@@ -0,0 +1,97 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is the minimum delay between two purges of the expired keys.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	fingerprint string
+	response    *Response
+	expiresAt   time.Time
+}
+
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		entries: make(map[string]*memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Acquire(_ context.Context, key, fingerprint string, lockTTL time.Duration) (*Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, ok := s.entries[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		s.entries[key] = &memoryEntry{
+			fingerprint: fingerprint,
+			expiresAt:   now.Add(lockTTL),
+		}
+		return nil, nil
+	}
+
+	if entry.fingerprint != fingerprint {
+		return nil, ErrFingerprintMismatch
+	}
+
+	if entry.response == nil {
+		return nil, ErrInFlight
+	}
+
+	return entry.response, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, key string, response *Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry.response = response
+	entry.expiresAt = s.now().Add(ttl)
+
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.entries[key]; ok && entry.response == nil {
+		delete(s.entries, key)
+	}
+
+	return nil
+}
+
+// sweep drops the expired keys, at most once per sweepInterval.
+func (s *memoryStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+	s.nextSweep = now.Add(sweepInterval)
+
+	for key, entry := range s.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
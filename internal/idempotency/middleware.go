@@ -0,0 +1,236 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+const (
+	// HeaderKey is the request header carrying the idempotency key.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed is set on the responses replayed from the store.
+	HeaderReplayed = "Idempotent-Replayed"
+)
+
+const (
+	// MaxKeyLength is the maximum length of an idempotency key.
+	MaxKeyLength = 255
+	// lockTTL bounds the reservation of a key by a request that never
+	// completes, for instance when the instance handling it crashes.
+	lockTTL = time.Minute
+	// retryAfter is the delay suggested to a request racing another one.
+	retryAfter = 1
+)
+
+// Middleware makes the POST requests carrying an Idempotency-Key header safe
+// to retry. The first response of a key is stored for ttl and replayed on
+// retries with the same payload. Reusing a key with another payload is
+// rejected with 422 and a retry arriving while the first request is still
+// running is rejected with 409. Server errors are not stored, the request can
+// be retried with the same key.
+//
+// Keys are scoped by caller and route. The caller is the subject of the
+// authenticated caller, or else the address of the anonymous client, so that
+// a key leaking to another client does not get it the stored response
+// replayed. The address is the one resolved by middleware.RealIP: a mobile
+// client switching networks between two attempts is not recognised and its
+// retry is handled as a new request.
+func Middleware(store Store, ttl time.Duration, logger zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderKey)
+			if r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !validKey(key) {
+				response.Error(w, http.StatusBadRequest, "Idempotency-Key must be 1 to 255 printable ASCII characters")
+				return
+			}
+
+			fingerprint, err := fingerprintRequest(r)
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "Invalid request body")
+				return
+			}
+
+			scopedKey := scope(r, caller(r), key)
+
+			stored, err := store.Acquire(r.Context(), scopedKey, fingerprint, lockTTL)
+			switch {
+			case errors.Is(err, ErrFingerprintMismatch):
+				response.Error(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used with another payload")
+				return
+			case errors.Is(err, ErrInFlight):
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				response.Error(w, http.StatusConflict, "A request with this Idempotency-Key is already being processed")
+				return
+			case err != nil:
+				logger.Error().Err(err).Msg("failed to acquire idempotency key")
+				response.Error(w, http.StatusInternalServerError, "Internal server error")
+				return
+			case stored != nil:
+				replay(w, stored)
+				return
+			}
+
+			// the outcome is recorded even when the client went away
+			ctx := context.WithoutCancel(r.Context())
+
+			recorder := &responseRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.Release(ctx, scopedKey); err != nil {
+					logger.Error().Err(err).Msg("failed to release idempotency key")
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+
+			stored = recorder.response()
+			if stored.StatusCode >= http.StatusInternalServerError {
+				return
+			}
+
+			if err := store.Complete(ctx, scopedKey, stored, ttl); err != nil {
+				logger.Error().Err(err).Msg("failed to store idempotent response")
+				return
+			}
+			completed = true
+		})
+	}
+}
+
+// validKey accepts the keys made of printable ASCII characters.
+func validKey(key string) bool {
+	if len(key) > MaxKeyLength {
+		return false
+	}
+
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// caller identifies the caller a key belongs to. The prefixes keep a subject
+// from ever matching the address of an anonymous client.
+func caller(r *http.Request) string {
+	if identity, ok := auth.FromContext(r.Context()); ok {
+		return "subject:" + identity.Subject
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		// middleware.RealIP sets the bare address
+		host = r.RemoteAddr
+	}
+
+	return "address:" + host
+}
+
+// scope derives the store key from the caller, the route and the client key.
+func scope(r *http.Request, caller, key string) string {
+	callerHash := sha256.Sum256([]byte(caller))
+
+	hash := sha256.New()
+	hash.Write(callerHash[:])
+	io.WriteString(hash, r.Method+" "+r.URL.Path+"\n"+key)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// fingerprintRequest hashes the query string and the body, then restores the
+// body for the next handler. Only the first request.MaxBodyBytes bytes are
+// read here, the handler rejects larger bodies anyway.
+func fingerprintRequest(r *http.Request) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, request.MaxBodyBytes+1))
+	if err != nil {
+		return "", err
+	}
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+
+	hash := sha256.New()
+	io.WriteString(hash, r.URL.RawQuery+"\n")
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func replay(w http.ResponseWriter, stored *Response) {
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.Body)
+}
+
+// responseRecorder copies the response written by the next handler.
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode int
+	header     http.Header
+	body       bytes.Buffer
+}
+
+func (rr *responseRecorder) WriteHeader(statusCode int) {
+	if rr.statusCode == 0 {
+		rr.statusCode = statusCode
+		rr.header = rr.ResponseWriter.Header().Clone()
+	}
+	rr.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rr *responseRecorder) Write(b []byte) (int, error) {
+	if rr.statusCode == 0 {
+		rr.WriteHeader(http.StatusOK)
+	}
+	rr.body.Write(b)
+	return rr.ResponseWriter.Write(b)
+}
+
+func (rr *responseRecorder) Unwrap() http.ResponseWriter {
+	return rr.ResponseWriter
+}
+
+func (rr *responseRecorder) response() *Response {
+	if rr.statusCode == 0 {
+		return &Response{
+			StatusCode: http.StatusOK,
+			Header:     rr.ResponseWriter.Header().Clone(),
+		}
+	}
+
+	return &Response{
+		StatusCode: rr.statusCode,
+		Header:     rr.header,
+		Body:       rr.body.Bytes(),
+	}
+}
@@ -0,0 +1,282 @@
+package idempotency_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/idempotency"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+type testRequest struct {
+	method     string
+	path       string
+	key        string
+	subject    string
+	anonymous  bool
+	remoteAddr string
+	body       string
+}
+
+func newTestRouter(store idempotency.Store, calls *atomic.Int32, statusCode int) http.Handler {
+	r := chi.NewRouter()
+	r.Use(idempotency.Middleware(store, time.Hour, zerolog.Nop()))
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		call := calls.Add(1)
+		w.Header().Set("Location", fmt.Sprintf("/books/%d", call))
+		response.JSON(w, statusCode, map[string]any{"call": call})
+	}
+	r.Post("/books", handler)
+	r.Post("/authors", handler)
+	r.Get("/books", handler)
+
+	return r
+}
+
+func serve(handler http.Handler, req testRequest) *httptest.ResponseRecorder {
+	method := req.method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	path := req.path
+	if path == "" {
+		path = "/books"
+	}
+
+	r := httptest.NewRequest(method, path, strings.NewReader(req.body))
+	r.Header.Set("Content-Type", "application/json")
+	if req.remoteAddr != "" {
+		r.RemoteAddr = req.remoteAddr
+	}
+	if req.key != "" {
+		r.Header.Set(idempotency.HeaderKey, req.key)
+	}
+
+	subject := req.subject
+	if subject == "" {
+		subject = "alice"
+	}
+	if !req.anonymous {
+		r = r.WithContext(auth.NewContext(r.Context(), &auth.Identity{Subject: subject}))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name                 string
+		handlerStatusCode    int
+		first                testRequest
+		retry                testRequest
+		expectedStatusCode   int
+		expectedBody         string
+		expectedReplayed     bool
+		expectedHandlerCalls int32
+	}{
+		{
+			name:                 "success replay retry",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":1}`,
+			expectedReplayed:     true,
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:                 "success replay client error",
+			handlerStatusCode:    http.StatusConflict,
+			first:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusConflict,
+			expectedBody:         `{"call":1}`,
+			expectedReplayed:     true,
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:                 "success server error is not stored",
+			handlerStatusCode:    http.StatusInternalServerError,
+			first:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success without key",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{body: `{"title":"Dune"}`},
+			retry:                testRequest{body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success key ignored on GET",
+			handlerStatusCode:    http.StatusOK,
+			first:                testRequest{method: http.MethodGet, key: "key-1"},
+			retry:                testRequest{method: http.MethodGet, key: "key-1"},
+			expectedStatusCode:   http.StatusOK,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success key scoped by route",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", body: `{"name":"Dune"}`},
+			retry:                testRequest{path: "/authors", key: "key-1", body: `{"name":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success key scoped by caller",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", subject: "alice", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", subject: "bob", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success replay anonymous retry",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", anonymous: true, remoteAddr: "203.0.113.7:50000", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", anonymous: true, remoteAddr: "203.0.113.7:50001", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":1}`,
+			expectedReplayed:     true,
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:                 "success anonymous key scoped by client address",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", anonymous: true, remoteAddr: "203.0.113.7:50000", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", anonymous: true, remoteAddr: "198.51.100.4:50000", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "success anonymous key apart from the authenticated ones",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", anonymous: true, remoteAddr: "203.0.113.7", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", subject: "203.0.113.7", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusCreated,
+			expectedBody:         `{"call":2}`,
+			expectedHandlerCalls: 2,
+		},
+		{
+			name:                 "error key reused with another payload",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: "key-1", body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "key-1", body: `{"title":"Dune Messiah"}`},
+			expectedStatusCode:   http.StatusUnprocessableEntity,
+			expectedBody:         `{"status":"error","message":"Idempotency-Key was already used with another payload"}`,
+			expectedHandlerCalls: 1,
+		},
+		{
+			name:                 "error invalid key",
+			handlerStatusCode:    http.StatusCreated,
+			first:                testRequest{key: strings.Repeat("k", 256), body: `{"title":"Dune"}`},
+			retry:                testRequest{key: "keyé", body: `{"title":"Dune"}`},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedBody:         `{"status":"error","message":"Idempotency-Key must be 1 to 255 printable ASCII characters"}`,
+			expectedHandlerCalls: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int32
+			router := newTestRouter(idempotency.NewMemoryStore(), &calls, test.handlerStatusCode)
+
+			first := serve(router, test.first)
+			rec := serve(router, test.retry)
+
+			assert.Equal(t, test.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, test.expectedBody, rec.Body.String())
+			assert.Equal(t, test.expectedHandlerCalls, calls.Load())
+
+			if test.expectedReplayed {
+				assert.Equal(t, "true", rec.Header().Get(idempotency.HeaderReplayed))
+				assert.Equal(t, first.Header().Get("Location"), rec.Header().Get("Location"))
+				assert.Equal(t, first.Body.String(), rec.Body.String())
+			} else {
+				assert.Empty(t, rec.Header().Get(idempotency.HeaderReplayed))
+			}
+		})
+	}
+}
+
+func TestMiddleware_ConcurrentRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	r := chi.NewRouter()
+	r.Use(idempotency.Middleware(idempotency.NewMemoryStore(), time.Hour, zerolog.Nop()))
+	r.Post("/books", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		response.JSON(w, http.StatusCreated, map[string]any{"call": 1})
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- serve(r, testRequest{key: "key-1", body: `{"title":"Dune"}`})
+	}()
+
+	<-started
+
+	rec := serve(r, testRequest{key: "key-1", body: `{"title":"Dune"}`})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"status":"error","message":"A request with this Idempotency-Key is already being processed"}`, rec.Body.String())
+
+	close(release)
+	first := <-done
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	rec = serve(r, testRequest{key: "key-1", body: `{"title":"Dune"}`})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get(idempotency.HeaderReplayed))
+}
+
+func TestMiddleware_StoreFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	store := mocks.NewMockStore(ctrl)
+	store.EXPECT().
+		Acquire(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("database connection failed"))
+
+	var calls atomic.Int32
+	router := newTestRouter(store, &calls, http.StatusCreated)
+
+	rec := serve(router, testRequest{key: "key-1", body: `{"title":"Dune"}`})
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"status":"error","message":"Internal server error"}`, rec.Body.String())
+	assert.Zero(t, calls.Load())
+}
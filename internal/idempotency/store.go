@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+)
+
+const (
+	DriverDatabase = "database"
+	DriverMemory   = "memory"
+)
+
+// Response is the first response produced for an idempotency key, replayed on
+// every retry.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Store keeps track of the idempotency keys. Keys are opaque, the middleware
+// scopes them by caller and route before reaching the store.
+//
+//go:generate mockgen -destination=../mocks/mock_idempotency_store.go -package=mocks go-boilerplate-rest-api-chi/internal/idempotency Store
+type Store interface {
+	// Acquire reserves key for a request whose payload hashes to fingerprint,
+	// the reservation lasts at most lockTTL. It returns the stored response
+	// when the key was already completed, ErrInFlight while another request
+	// holds the key and ErrFingerprintMismatch when the key was used with
+	// another payload. A nil response and error means the caller owns the key.
+	Acquire(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Response, error)
+	// Complete stores the response of the request owning key for ttl.
+	Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error
+	// Release drops the reservation of a request that did not complete, so
+	// that it can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// NewStore creates the store matching the configured driver. The database
+// driver shares the keys between every instance of the API, the memory driver
+// only suits a single instance.
+func NewStore(cfg config.IdempotencyConfig, db *gorm.DB, logger zerolog.Logger) (Store, error) {
+	switch cfg.Driver {
+	case DriverDatabase:
+		if err := MigrateDatabase(db); err != nil {
+			logger.Error().Err(err).Msg("failed to migrate idempotency keys")
+			return nil, err
+		}
+		return NewDatabaseStore(db, logger), nil
+	case DriverMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown idempotency driver %q", cfg.Driver)
+	}
+}
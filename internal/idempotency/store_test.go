@@ -0,0 +1,129 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/idempotency"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func newStores(t *testing.T) map[string]idempotency.Store {
+	db := testutils.NewGormSQLite(t)
+	require.NoError(t, idempotency.MigrateDatabase(db))
+
+	return map[string]idempotency.Store{
+		idempotency.DriverMemory:   idempotency.NewMemoryStore(),
+		idempotency.DriverDatabase: idempotency.NewDatabaseStore(db, zerolog.Nop()),
+	}
+}
+
+func TestStore(t *testing.T) {
+	stored := &idempotency.Response{
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       []byte(`{"status":"success"}`),
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store idempotency.Store)
+	}{
+		{
+			name: "success acquire a new key",
+			run: func(t *testing.T, store idempotency.Store) {
+				response, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				assert.NoError(t, err)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "error key in flight",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+
+				response, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				assert.ErrorIs(t, err, idempotency.ErrInFlight)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "error key used with another payload",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+				require.NoError(t, store.Complete(context.Background(), "key", stored, time.Hour))
+
+				response, err := store.Acquire(context.Background(), "key", "other", time.Minute)
+				assert.ErrorIs(t, err, idempotency.ErrFingerprintMismatch)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "success replay completed key",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+				require.NoError(t, store.Complete(context.Background(), "key", stored, time.Hour))
+
+				response, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				assert.NoError(t, err)
+				assert.Equal(t, stored, response)
+			},
+		},
+		{
+			name: "success acquire released key",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+				require.NoError(t, store.Release(context.Background(), "key"))
+
+				response, err := store.Acquire(context.Background(), "key", "other", time.Minute)
+				assert.NoError(t, err)
+				assert.Nil(t, response)
+			},
+		},
+		{
+			name: "success release keeps completed key",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+				require.NoError(t, store.Complete(context.Background(), "key", stored, time.Hour))
+				require.NoError(t, store.Release(context.Background(), "key"))
+
+				response, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				assert.NoError(t, err)
+				assert.Equal(t, stored, response)
+			},
+		},
+		{
+			name: "success acquire expired key",
+			run: func(t *testing.T, store idempotency.Store) {
+				_, err := store.Acquire(context.Background(), "key", "fingerprint", time.Minute)
+				require.NoError(t, err)
+				require.NoError(t, store.Complete(context.Background(), "key", stored, time.Millisecond))
+
+				time.Sleep(5 * time.Millisecond)
+
+				response, err := store.Acquire(context.Background(), "key", "other", time.Minute)
+				assert.NoError(t, err)
+				assert.Nil(t, response)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for driver, store := range newStores(t) {
+			t.Run(driver+" "+test.name, func(t *testing.T) {
+				test.run(t, store)
+			})
+		}
+	}
+}
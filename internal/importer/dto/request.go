@@ -0,0 +1,9 @@
+package dto
+
+// ImportRow is a book to import, its author is looked up by name and created
+// when missing.
+type ImportRow struct {
+	Title       string `json:"title" validate:"required,trimmed"`
+	Description string `json:"description" validate:"required,trimmed"`
+	Author      string `json:"author" validate:"required,trimmed"`
+}
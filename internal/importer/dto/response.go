@@ -0,0 +1,27 @@
+package dto
+
+import "go-boilerplate-rest-api-chi/internal/response"
+
+const (
+	RowStatusCreated    = "created"
+	RowStatusFailed     = "failed"
+	RowStatusRolledBack = "rolled_back"
+)
+
+type ImportRowResult struct {
+	Line          int                              `json:"line" example:"2"`
+	Status        string                           `json:"status" example:"created"`
+	BookID        string                           `json:"book_id,omitempty"`
+	AuthorID      string                           `json:"author_id,omitempty"`
+	AuthorCreated bool                             `json:"author_created,omitempty"`
+	Errors        []response.ValidationErrorDetail `json:"errors,omitempty"`
+}
+
+type ImportReport struct {
+	Mode      string            `json:"mode" example:"best_effort"`
+	Committed bool              `json:"committed"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,10 @@
+package importer
+
+import "errors"
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported import format")
+	ErrInvalidMode       = errors.New("invalid import mode")
+	ErrInvalidHeader     = errors.New("invalid csv header")
+	ErrInvalidRow        = errors.New("invalid row")
+)
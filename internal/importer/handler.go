@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/importer/dto"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+// MaxImportBytes is the maximum size accepted for an import body.
+const MaxImportBytes int64 = 64 << 20
+
+type ImportResponse struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Import completed"`
+	Report  *dto.ImportReport `json:"report"`
+}
+
+type ImportHandler struct {
+	service ImportService
+	logger  zerolog.Logger
+}
+
+func NewImportHandler(service ImportService, logger zerolog.Logger) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ImportHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.Import)
+
+	return r
+}
+
+// Import godoc
+//
+//	@Summary		Bulk import books
+//	@Description	Import books from a CSV file with a header line (title, description, author) or from NDJSON objects with the same fields. Authors are matched by name and created when missing. The body is streamed row by row.
+//	@Tags			import
+//	@Accept			text/csv
+//	@Accept			application/x-ndjson
+//	@Produce		json
+//	@Param			mode			query		string	false	"best_effort (default) commits valid rows, all_or_nothing commits only when every row is valid"	Enums(best_effort, all_or_nothing)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	ImportResponse
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		422				{object}	ImportResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/import [post]
+func (h *ImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	format, err := formatFromContentType(r.Header.Get("Content-Type"))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, MaxImportBytes)
+
+	report, err := h.service.Import(r.Context(), body, ImportOptions{
+		Format:         format,
+		Mode:           r.URL.Query().Get("mode"),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+	})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if !report.Committed {
+		response.JSON(w, http.StatusUnprocessableEntity, ImportResponse{
+			Status:  "error",
+			Message: "Import rolled back, some rows are invalid",
+			Report:  report,
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ImportResponse{
+		Status:  "success",
+		Message: "Import completed",
+		Report:  report,
+	})
+}
+
+func formatFromContentType(contentType string) (string, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", ErrUnsupportedFormat
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV, nil
+	case "application/x-ndjson", "application/ndjson":
+		return FormatNDJSON, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+func (h *ImportHandler) handleError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, ErrUnsupportedFormat):
+		response.Error(w, http.StatusUnsupportedMediaType, "Content-Type header must be text/csv or application/x-ndjson")
+	case errors.Is(err, ErrInvalidMode):
+		response.Error(w, http.StatusBadRequest, "Mode must be best_effort or all_or_nothing")
+	case errors.Is(err, ErrInvalidHeader):
+		response.Error(w, http.StatusBadRequest, "Invalid CSV header line, "+strings.TrimPrefix(err.Error(), ErrInvalidHeader.Error()+": "))
+	case errors.As(err, &maxBytesErr):
+		response.Error(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit))
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,150 @@
+package importer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/importer"
+	"go-boilerplate-rest-api-chi/internal/importer/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+func TestImportHandler_Import(t *testing.T) {
+	committed := &dto.ImportReport{
+		Mode:      importer.ModeBestEffort,
+		Committed: true,
+		Total:     1,
+		Succeeded: 1,
+		Rows:      []dto.ImportRowResult{{Line: 2, Status: dto.RowStatusCreated}},
+	}
+	rolledBack := &dto.ImportReport{
+		Mode:   importer.ModeAllOrNothing,
+		Total:  1,
+		Failed: 1,
+		Rows:   []dto.ImportRowResult{{Line: 2, Status: dto.RowStatusFailed}},
+	}
+
+	tests := []struct {
+		name               string
+		url                string
+		contentType        string
+		configureMock      func(*mocks.MockImportService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:        "success import csv",
+			url:         "/import",
+			contentType: "text/csv; charset=utf-8",
+			configureMock: func(mockService *mocks.MockImportService) {
+				mockService.EXPECT().
+					Import(gomock.Any(), gomock.Any(), importer.ImportOptions{Format: importer.FormatCSV, AcceptLanguage: "fr"}).
+					Return(committed, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: importer.ImportResponse{
+				Status:  "success",
+				Message: "Import completed",
+				Report:  committed,
+			},
+		},
+		{
+			name:        "error import rolled back",
+			url:         "/import?mode=all_or_nothing",
+			contentType: "application/x-ndjson",
+			configureMock: func(mockService *mocks.MockImportService) {
+				mockService.EXPECT().
+					Import(gomock.Any(), gomock.Any(), importer.ImportOptions{Format: importer.FormatNDJSON, Mode: importer.ModeAllOrNothing, AcceptLanguage: "fr"}).
+					Return(rolledBack, nil)
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: importer.ImportResponse{
+				Status:  "error",
+				Message: "Import rolled back, some rows are invalid",
+				Report:  rolledBack,
+			},
+		},
+		{
+			name:               "error unsupported content type",
+			url:                "/import",
+			contentType:        "application/json",
+			configureMock:      func(mockService *mocks.MockImportService) {},
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Content-Type header must be text/csv or application/x-ndjson",
+			},
+		},
+		{
+			name:        "error invalid header",
+			url:         "/import",
+			contentType: "text/csv",
+			configureMock: func(mockService *mocks.MockImportService) {
+				mockService.EXPECT().
+					Import(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf(`%w: unknown column "summary"`, importer.ErrInvalidHeader))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: `Invalid CSV header line, unknown column "summary"`,
+			},
+		},
+		{
+			name:        "error service internal error",
+			url:         "/import",
+			contentType: "text/csv",
+			configureMock: func(mockService *mocks.MockImportService) {
+				mockService.EXPECT().
+					Import(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Internal server error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockImportService(ctrl)
+			test.configureMock(mockService)
+
+			handler := importer.NewImportHandler(mockService, zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, test.url, strings.NewReader("title\n"))
+			req.Header.Set("Content-Type", test.contentType)
+			req.Header.Set("Accept-Language", "fr")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/import", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/internal/importer/dto"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+// maxLineBytes bounds the size of a single NDJSON line.
+const maxLineBytes = 1 << 20
+
+// rowReader streams the rows of an import. Next returns io.EOF once every row
+// has been read, and an error wrapping ErrInvalidRow for a row that cannot be
+// decoded but does not prevent reading the next ones.
+type rowReader interface {
+	Next() (line int, row dto.ImportRow, err error)
+}
+
+func newRowReader(format string, body io.Reader) (rowReader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(body)
+	case FormatNDJSON:
+		return newNDJSONReader(body), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvColumns maps the accepted header names to the row fields.
+var csvColumns = map[string]func(*dto.ImportRow, string){
+	"title":       func(r *dto.ImportRow, v string) { r.Title = v },
+	"description": func(r *dto.ImportRow, v string) { r.Description = v },
+	"author":      func(r *dto.ImportRow, v string) { r.Author = v },
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	setters []func(*dto.ImportRow, string)
+}
+
+// newCSVReader reads the header line, which names the column of each field in
+// any order.
+func newCSVReader(body io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(body)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: missing header line", ErrInvalidHeader)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+
+	setters := make([]func(*dto.ImportRow, string), len(header))
+	for i, column := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+
+		setter, ok := csvColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidHeader, column)
+		}
+		setters[i] = setter
+	}
+
+	return &csvReader{
+		reader:  reader,
+		setters: setters,
+	}, nil
+}
+
+func (r *csvReader) Next() (int, dto.ImportRow, error) {
+	var row dto.ImportRow
+
+	record, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return parseErr.StartLine, row, fmt.Errorf("%w: %w", ErrInvalidRow, parseErr.Err)
+		}
+		return 0, row, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	for i, value := range record {
+		r.setters[i](&row, value)
+	}
+
+	return line, row, nil
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func newNDJSONReader(body io.Reader) *ndjsonReader {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	return &ndjsonReader{
+		scanner: scanner,
+	}
+}
+
+func (r *ndjsonReader) Next() (int, dto.ImportRow, error) {
+	var row dto.ImportRow
+
+	for r.scanner.Scan() {
+		r.line++
+
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&row); err != nil {
+			return r.line, row, fmt.Errorf("%w: %w", ErrInvalidRow, err)
+		}
+
+		if dec.More() {
+			return r.line, row, fmt.Errorf("%w: line must only contain a single JSON object", ErrInvalidRow)
+		}
+
+		return r.line, row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return r.line, row, err
+	}
+
+	return r.line, row, io.EOF
+}
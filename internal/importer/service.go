@@ -0,0 +1,280 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/importer/dto"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/search"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+const (
+	// ModeBestEffort commits every valid row and reports the others.
+	ModeBestEffort = "best_effort"
+	// ModeAllOrNothing only commits when every row is valid.
+	ModeAllOrNothing = "all_or_nothing"
+)
+
+// errRowFailed rolls back the savepoint of a row reported as failed.
+var errRowFailed = errors.New("row failed")
+
+type ImportOptions struct {
+	Format string
+	Mode   string
+	// AcceptLanguage selects the language of the validation messages.
+	AcceptLanguage string
+}
+
+//go:generate mockgen -destination=../mocks/mock_import_service.go -package=mocks go-boilerplate-rest-api-chi/internal/importer ImportService
+type ImportService interface {
+	Import(ctx context.Context, body io.Reader, opts ImportOptions) (*dto.ImportReport, error)
+}
+
+type importService struct {
+	transactions     transaction.Manager
+	bookRepository   book.BookRepository
+	authorRepository author.AuthorRepository
+	outbox           outbox.Outbox
+	validator        *internalValidator.Validator
+	index            search.Index
+	logger           zerolog.Logger
+}
+
+func NewImportService(transactions transaction.Manager, bookRepository book.BookRepository, authorRepository author.AuthorRepository, outbox outbox.Outbox, validator *internalValidator.Validator, index search.Index, logger zerolog.Logger) ImportService {
+	return &importService{
+		transactions:     transactions,
+		bookRepository:   bookRepository,
+		authorRepository: authorRepository,
+		outbox:           outbox,
+		validator:        validator,
+		index:            index,
+		logger:           logger,
+	}
+}
+
+// importRun holds the state of a single import.
+type importRun struct {
+	opts    ImportOptions
+	report  *dto.ImportReport
+	authors map[string]*entity.Author
+	books   []*entity.Book
+	created []*entity.Author
+}
+
+// Import streams the rows of body. Each row runs in its own transaction, or
+// savepoint of a single transaction in all-or-nothing mode, so that a failed
+// row never leaves a dangling author behind.
+func (s *importService) Import(ctx context.Context, body io.Reader, opts ImportOptions) (*dto.ImportReport, error) {
+	if opts.Mode == "" {
+		opts.Mode = ModeBestEffort
+	}
+
+	if opts.Mode != ModeBestEffort && opts.Mode != ModeAllOrNothing {
+		return nil, ErrInvalidMode
+	}
+
+	rows, err := newRowReader(opts.Format, body)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &importRun{
+		opts:    opts,
+		report:  &dto.ImportReport{Mode: opts.Mode, Rows: []dto.ImportRowResult{}},
+		authors: make(map[string]*entity.Author),
+	}
+
+	if opts.Mode == ModeBestEffort {
+		if err := s.importRows(ctx, rows, run); err != nil {
+			return nil, err
+		}
+
+		run.report.Committed = true
+		return run.report, nil
+	}
+
+	// the rows are read from the body as they are imported, the transaction
+	// cannot be replayed
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if err := s.importRows(ctx, rows, run); err != nil {
+			return err
+		}
+
+		if run.report.Failed > 0 {
+			return errRowFailed
+		}
+
+		return nil
+	}, transaction.WithoutRetry())
+
+	switch {
+	case err == nil:
+		run.report.Committed = true
+		s.indexRun(ctx, run)
+	case errors.Is(err, errRowFailed):
+		for i := range run.report.Rows {
+			if run.report.Rows[i].Status == dto.RowStatusCreated {
+				run.report.Rows[i].Status = dto.RowStatusRolledBack
+			}
+		}
+		run.report.Succeeded = 0
+	default:
+		return nil, err
+	}
+
+	return run.report, nil
+}
+
+func (s *importService) importRows(ctx context.Context, rows rowReader, run *importRun) error {
+	for {
+		line, row, err := rows.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil && !errors.Is(err, ErrInvalidRow) {
+			return err
+		}
+
+		run.report.Total++
+
+		var result dto.ImportRowResult
+		if err != nil {
+			result = failedRow(line, response.ValidationErrorDetail{Message: err.Error()})
+		} else {
+			result, err = s.importRow(ctx, line, row, run)
+			if err != nil {
+				return err
+			}
+		}
+
+		if result.Status == dto.RowStatusCreated {
+			run.report.Succeeded++
+		} else {
+			run.report.Failed++
+		}
+		run.report.Rows = append(run.report.Rows, result)
+	}
+}
+
+// importRow runs in its own transaction, retried on deadlock, or in a
+// savepoint of the import transaction in all-or-nothing mode.
+func (s *importService) importRow(ctx context.Context, line int, row dto.ImportRow, run *importRun) (dto.ImportRowResult, error) {
+	if err := s.validator.Struct(&row); err != nil {
+		return failedRow(line, s.validator.FormatErrors(err, run.opts.AcceptLanguage)...), nil
+	}
+
+	var result dto.ImportRowResult
+	var newBook *entity.Book
+	var newAuthor *entity.Author
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		result = dto.ImportRowResult{Line: line, Status: dto.RowStatusCreated}
+		newAuthor = nil
+
+		bookAuthor, ok := run.authors[row.Author]
+		if !ok {
+			var err error
+			bookAuthor, err = s.authorRepository.GetByName(ctx, row.Author)
+			if errors.Is(err, author.ErrAmbiguousName) {
+				result = failedRow(line, response.ValidationErrorDetail{
+					Field:   "author",
+					Message: "Several authors match this name, the book must be created with its author ID",
+				})
+				return errRowFailed
+			}
+			if errors.Is(err, author.ErrNotFound) {
+				bookAuthor, err = s.authorRepository.Create(ctx, &entity.Author{Name: row.Author})
+				newAuthor = bookAuthor
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		b := book.NewBook(&bookDto.CreateBookRequest{
+			Title:       row.Title,
+			Description: row.Description,
+		}, bookAuthor.ID)
+
+		var err error
+		if newBook, err = s.bookRepository.Create(ctx, b); err != nil {
+			if errors.Is(err, book.ErrDuplicate) {
+				result = failedRow(line, response.ValidationErrorDetail{
+					Field:   "title",
+					Message: "A book with this title already exists",
+				})
+				return errRowFailed
+			}
+			return err
+		}
+
+		newBook.Author = bookAuthor
+		result.BookID = newBook.ID.String()
+		result.AuthorID = bookAuthor.ID.String()
+		result.AuthorCreated = newAuthor != nil
+
+		events := []event.Event{event.NewBookCreated(newBook)}
+		if newAuthor != nil {
+			events = append([]event.Event{event.NewAuthorCreated(newAuthor)}, events...)
+		}
+
+		return s.outbox.Record(ctx, events...)
+	})
+	if errors.Is(err, errRowFailed) {
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	run.authors[row.Author] = newBook.Author
+	run.books = append(run.books, newBook)
+	if newAuthor != nil {
+		run.created = append(run.created, newAuthor)
+	}
+
+	if run.opts.Mode == ModeBestEffort {
+		s.indexRun(ctx, run)
+	}
+
+	return result, nil
+}
+
+// indexRun indexes the committed books and authors not indexed yet.
+func (s *importService) indexRun(ctx context.Context, run *importRun) {
+	for _, a := range run.created {
+		if err := s.index.IndexAuthor(ctx, a); err != nil {
+			s.logger.Error().Err(err).Str("author_id", a.ID.String()).Msg("failed to index author")
+		}
+	}
+
+	for _, b := range run.books {
+		if err := s.index.IndexBook(ctx, b); err != nil {
+			s.logger.Error().Err(err).Str("book_id", b.ID.String()).Msg("failed to index book")
+		}
+	}
+
+	run.created = run.created[:0]
+	run.books = run.books[:0]
+}
+
+func failedRow(line int, details ...response.ValidationErrorDetail) dto.ImportRowResult {
+	return dto.ImportRowResult{
+		Line:   line,
+		Status: dto.RowStatusFailed,
+		Errors: details,
+	}
+}
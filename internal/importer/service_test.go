@@ -0,0 +1,263 @@
+package importer_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/importer"
+	"go-boilerplate-rest-api-chi/internal/importer/dto"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/search"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+const csvImport = `title,description,author
+Les Misérables,Jean Valjean,Victor Hugo
+Notre-Dame de Paris,Quasimodo,Victor Hugo
+ Germinal,Les mineurs,Émile Zola
+Germinal,Les mineurs,Émile Zola
+`
+
+const ndjsonImport = `{"title":"Les Misérables","description":"Jean Valjean","author":"Victor Hugo"}
+
+{"title":"Notre-Dame de Paris","author":"Victor Hugo"}
+{"title":"Germinal","description":"Les mineurs","author":"Émile Zola","pages":591}
+not json
+`
+
+func newImportService(t *testing.T) (importer.ImportService, *gorm.DB, search.Index) {
+	t.Helper()
+
+	db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.AuthorAlias{}, &entity.Book{})
+	require.NoError(t, outbox.Migrate(db))
+	index := search.NewMemoryIndex()
+
+	service := importer.NewImportService(
+		transaction.NewManager(db, zerolog.Nop()),
+		book.NewBookRepository(db, zerolog.Nop()),
+		author.NewAuthorRepository(db, zerolog.Nop()),
+		outbox.NewOutbox(db, zerolog.Nop()),
+		validator.New(),
+		index,
+		zerolog.Nop(),
+	)
+
+	return service, db, index
+}
+
+func TestImportService_Import(t *testing.T) {
+	tests := []struct {
+		name                string
+		body                string
+		opts                importer.ImportOptions
+		expectedCommitted   bool
+		expectedSucceeded   int
+		expectedStatuses    []string
+		expectedErrors      map[int][]response.ValidationErrorDetail
+		expectedBookCount   int64
+		expectedAuthorCount int64
+	}{
+		{
+			name:              "best effort csv commits valid rows",
+			body:              csvImport,
+			opts:              importer.ImportOptions{Format: importer.FormatCSV, Mode: importer.ModeBestEffort},
+			expectedCommitted: true,
+			expectedSucceeded: 3,
+			expectedStatuses:  []string{dto.RowStatusCreated, dto.RowStatusCreated, dto.RowStatusFailed, dto.RowStatusCreated},
+			expectedErrors: map[int][]response.ValidationErrorDetail{
+				2: {{Field: "title", Message: "title must not start or end with spaces"}},
+			},
+			expectedBookCount:   3,
+			expectedAuthorCount: 2,
+		},
+		{
+			name:              "all or nothing csv rolls back every row",
+			body:              csvImport,
+			opts:              importer.ImportOptions{Format: importer.FormatCSV, Mode: importer.ModeAllOrNothing, AcceptLanguage: "fr"},
+			expectedCommitted: false,
+			expectedSucceeded: 0,
+			expectedStatuses:  []string{dto.RowStatusRolledBack, dto.RowStatusRolledBack, dto.RowStatusFailed, dto.RowStatusRolledBack},
+			expectedErrors: map[int][]response.ValidationErrorDetail{
+				2: {{Field: "title", Message: "title ne doit pas commencer ou finir par des espaces"}},
+			},
+			expectedBookCount:   0,
+			expectedAuthorCount: 0,
+		},
+		{
+			name:                "all or nothing commits when every row is valid",
+			body:                "author,title,description\nVictor Hugo,Les Misérables,Jean Valjean\nVictor Hugo,Les Contemplations,Poèmes\n",
+			opts:                importer.ImportOptions{Format: importer.FormatCSV, Mode: importer.ModeAllOrNothing},
+			expectedCommitted:   true,
+			expectedSucceeded:   2,
+			expectedStatuses:    []string{dto.RowStatusCreated, dto.RowStatusCreated},
+			expectedBookCount:   2,
+			expectedAuthorCount: 1,
+		},
+		{
+			name:              "best effort ndjson reports decoding errors",
+			body:              ndjsonImport,
+			opts:              importer.ImportOptions{Format: importer.FormatNDJSON},
+			expectedCommitted: true,
+			expectedSucceeded: 1,
+			expectedStatuses:  []string{dto.RowStatusCreated, dto.RowStatusFailed, dto.RowStatusFailed, dto.RowStatusFailed},
+			expectedErrors: map[int][]response.ValidationErrorDetail{
+				1: {{Field: "description", Message: "description is required"}},
+				2: {{Message: `invalid row: json: unknown field "pages"`}},
+				3: {{Message: "invalid row: invalid character 'o' in literal null (expecting 'u')"}},
+			},
+			expectedBookCount:   1,
+			expectedAuthorCount: 1,
+		},
+		{
+			name:              "duplicate book is reported without leaving its author",
+			body:              "title,description,author\nLes Misérables,Jean Valjean,Victor Hugo\nLes Misérables,Jean Valjean,Hugo\n",
+			opts:              importer.ImportOptions{Format: importer.FormatCSV},
+			expectedCommitted: true,
+			expectedSucceeded: 1,
+			expectedStatuses:  []string{dto.RowStatusCreated, dto.RowStatusFailed},
+			expectedErrors: map[int][]response.ValidationErrorDetail{
+				1: {{Field: "title", Message: "A book with this title already exists"}},
+			},
+			expectedBookCount:   1,
+			expectedAuthorCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, db, _ := newImportService(t)
+
+			report, err := service.Import(context.Background(), strings.NewReader(test.body), test.opts)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedCommitted, report.Committed)
+			assert.Equal(t, len(test.expectedStatuses), report.Total)
+			assert.Equal(t, test.expectedSucceeded, report.Succeeded)
+
+			statuses := make([]string, len(report.Rows))
+			for i, row := range report.Rows {
+				statuses[i] = row.Status
+				assert.Equal(t, test.expectedErrors[i], row.Errors)
+			}
+			assert.Equal(t, test.expectedStatuses, statuses)
+
+			var books, authors int64
+			require.NoError(t, db.Model(&entity.Book{}).Count(&books).Error)
+			require.NoError(t, db.Model(&entity.Author{}).Count(&authors).Error)
+			assert.Equal(t, test.expectedBookCount, books)
+			assert.Equal(t, test.expectedAuthorCount, authors)
+
+			// every created book and author is announced, rolled back rows
+			// leave no event behind
+			var events int64
+			require.NoError(t, db.Table("outbox_events").Count(&events).Error)
+			assert.Equal(t, test.expectedBookCount+test.expectedAuthorCount, events)
+		})
+	}
+}
+
+func TestImportService_ImportReuseAuthorAndIndex(t *testing.T) {
+	service, db, index := newImportService(t)
+
+	author := &entity.Author{Name: "Victor Hugo"}
+	require.NoError(t, db.Create(author).Error)
+
+	report, err := service.Import(context.Background(),
+		strings.NewReader("title,description,author\nLes Misérables,Jean Valjean,Victor Hugo\n"),
+		importer.ImportOptions{Format: importer.FormatCSV},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 1)
+	assert.Equal(t, 2, report.Rows[0].Line)
+	assert.Equal(t, author.ID.String(), report.Rows[0].AuthorID)
+	assert.False(t, report.Rows[0].AuthorCreated)
+
+	hits, err := index.Search(context.Background(), "miserables", 0)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, report.Rows[0].BookID, hits[0].ID.String())
+}
+
+func TestImportService_ImportResolveAlias(t *testing.T) {
+	service, db, _ := newImportService(t)
+
+	king := &entity.Author{Name: "Stephen King"}
+	require.NoError(t, db.Create(king).Error)
+	require.NoError(t, db.Create(&entity.AuthorAlias{AuthorID: king.ID, Name: "Richard Bachman", Kind: entity.AliasKindPenName}).Error)
+
+	// two authors share this name, the row cannot tell them apart
+	for range 2 {
+		require.NoError(t, db.Create(&entity.Author{Name: "John Smith"}).Error)
+	}
+
+	report, err := service.Import(context.Background(),
+		strings.NewReader("title,description,author\nThinner,A curse,Richard Bachman\nUntitled,Unknown,John Smith\n"),
+		importer.ImportOptions{Format: importer.FormatCSV},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, king.ID.String(), report.Rows[0].AuthorID)
+	assert.False(t, report.Rows[0].AuthorCreated)
+
+	assert.Equal(t, dto.RowStatusFailed, report.Rows[1].Status)
+	assert.Equal(t, []response.ValidationErrorDetail{{
+		Field:   "author",
+		Message: "Several authors match this name, the book must be created with its author ID",
+	}}, report.Rows[1].Errors)
+}
+
+func TestImportService_ImportErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		opts          importer.ImportOptions
+		expectedError error
+	}{
+		{
+			name:          "error unknown format",
+			opts:          importer.ImportOptions{Format: "xml"},
+			expectedError: importer.ErrUnsupportedFormat,
+		},
+		{
+			name:          "error unknown mode",
+			opts:          importer.ImportOptions{Format: importer.FormatCSV, Mode: "partial"},
+			expectedError: importer.ErrInvalidMode,
+		},
+		{
+			name:          "error missing csv header",
+			opts:          importer.ImportOptions{Format: importer.FormatCSV},
+			expectedError: importer.ErrInvalidHeader,
+		},
+		{
+			name:          "error unknown csv column",
+			body:          "title,summary\n",
+			opts:          importer.ImportOptions{Format: importer.FormatCSV},
+			expectedError: importer.ErrInvalidHeader,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, _, _ := newImportService(t)
+
+			report, err := service.Import(context.Background(), strings.NewReader(test.body), test.opts)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Nil(t, report)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CreateCopyRequest registers a new copy of a book, available for loan.
+type CreateCopyRequest struct {
+	BookID        string `json:"book_id" validate:"required,uuid_strict"`
+	Barcode       string `json:"barcode" validate:"required,trimmed,max=64"`
+	Condition     string `json:"condition" validate:"required,oneof=new good fair poor" enums:"new,good,fair,poor"`
+	ItemType      string `json:"item_type,omitempty" validate:"omitempty,lowercase,trimmed,max=32" example:"standard"`
+	AcquiredOn    string `json:"acquired_on,omitempty" validate:"omitempty,iso_date,not_future"`
+	Branch        string `json:"branch" validate:"required,trimmed,max=100"`
+	ShelfLocation string `json:"shelf_location" validate:"required,trimmed,max=100"`
+}
+
+// UpdateCopyRequest only updates the provided fields. The on_loan and on_hold
+// statuses are managed by the loans and the holds and cannot be set here.
+type UpdateCopyRequest struct {
+	Condition     *string `json:"condition,omitempty" validate:"omitnil,oneof=new good fair poor" enums:"new,good,fair,poor"`
+	ItemType      *string `json:"item_type,omitempty" validate:"omitnil,min=1,lowercase,trimmed,max=32"`
+	Branch        *string `json:"branch,omitempty" validate:"omitnil,min=1,trimmed,max=100"`
+	ShelfLocation *string `json:"shelf_location,omitempty" validate:"omitnil,min=1,trimmed,max=100"`
+	Status        *string `json:"status,omitempty" validate:"omitnil,oneof=available lost in_repair" enums:"available,lost,in_repair"`
+}
+
+// CopyFilter narrows the listed copies. Empty fields are ignored.
+type CopyFilter struct {
+	BookID  string `json:"book_id" validate:"omitempty,uuid_strict"`
+	Barcode string `json:"barcode"`
+	Branch  string `json:"branch"`
+	Status  string `json:"status" validate:"omitempty,oneof=available on_loan on_hold lost in_repair"`
+}
+
+// NewCopyFilter reads the filter from the query string.
+func NewCopyFilter(query url.Values) CopyFilter {
+	return CopyFilter{
+		BookID:  strings.TrimSpace(query.Get("book_id")),
+		Barcode: strings.TrimSpace(query.Get("barcode")),
+		Branch:  strings.TrimSpace(query.Get("branch")),
+		Status:  strings.TrimSpace(query.Get("status")),
+	}
+}
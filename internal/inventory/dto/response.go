@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type CopyResponse struct {
+	ID            string `json:"id"`
+	BookID        string `json:"book_id"`
+	Barcode       string `json:"barcode"`
+	Condition     string `json:"condition" example:"good" enums:"new,good,fair,poor"`
+	ItemType      string `json:"item_type" example:"standard"`
+	AcquiredOn    string `json:"acquired_on,omitempty"`
+	Branch        string `json:"branch"`
+	ShelfLocation string `json:"shelf_location"`
+	Status        string `json:"status" example:"available" enums:"available,on_loan,on_hold,lost,in_repair"`
+}
+
+func ToCopyResponse(bookCopy *entity.Copy) *CopyResponse {
+	response := &CopyResponse{
+		ID:            bookCopy.ID.String(),
+		BookID:        bookCopy.BookID.String(),
+		Barcode:       bookCopy.Barcode,
+		Condition:     bookCopy.Condition,
+		ItemType:      bookCopy.ItemType,
+		Branch:        bookCopy.Branch,
+		ShelfLocation: bookCopy.ShelfLocation,
+		Status:        bookCopy.Status,
+	}
+
+	if bookCopy.AcquiredOn != nil {
+		response.AcquiredOn = bookCopy.AcquiredOn.Format(internalValidator.DateLayout)
+	}
+
+	return response
+}
+
+func ToCopiesResponse(copies []*entity.Copy) []CopyResponse {
+	responses := make([]CopyResponse, len(copies))
+	for i, bookCopy := range copies {
+		responses[i] = *ToCopyResponse(bookCopy)
+	}
+	return responses
+}
@@ -0,0 +1,12 @@
+package inventory
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("copy not found")
+	ErrDuplicate     = errors.New("copy barcode already exists")
+	ErrInvalidBookID = errors.New("invalid book ID")
+	ErrOnLoan        = errors.New("copy is on loan")
+	ErrOnHold        = errors.New("copy is on hold")
+	ErrHasLoans      = errors.New("copy has loans")
+)
@@ -0,0 +1,284 @@
+package inventory
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type CopySuccessResponse struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Copy retrieved successfully"`
+	Copy    *dto.CopyResponse `json:"copy"`
+}
+
+type CopiesSuccessResponse struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Copies retrieved successfully"`
+	Copies  []dto.CopyResponse `json:"copies"`
+}
+
+type CopyHandler struct {
+	service   CopyService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewCopyHandler(service CopyService, validator *internalValidator.Validator, logger zerolog.Logger) *CopyHandler {
+	return &CopyHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *CopyHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// anyone can look up the copies on the shelves
+	r.Get("/", h.GetCopies)
+	r.Get("/{copy_id}", h.GetCopyByID)
+
+	// the copies are registered, repaired and withdrawn by the staff
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleLibrarian))
+
+		r.Post("/", h.CreateCopy)
+		r.Put("/{copy_id}", h.UpdateCopy)
+		r.Delete("/{copy_id}", h.DeleteCopy)
+	})
+
+	return r
+}
+
+// CreateCopy godoc
+//
+//	@Summary		Create a new copy
+//	@Description	Register a physical copy of a book, available for loan. The barcode must not be used by another copy. Restricted to the librarians.
+//	@Tags			copies
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			copy			body		dto.CreateCopyRequest	true	"Copy data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	CopySuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/copies [post]
+func (h *CopyHandler) CreateCopy(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateCopyRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	bookCopy, err := h.service.CreateCopy(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, CopySuccessResponse{
+		Status:  "success",
+		Message: "Copy created successfully",
+		Copy:    dto.ToCopyResponse(bookCopy),
+	})
+}
+
+// GetCopies godoc
+//
+//	@Summary		Get copies
+//	@Description	Get the copies matching the filters, ordered by branch and shelf location
+//	@Tags			copies
+//	@Produce		json
+//	@Param			book_id			query		string	false	"Only the copies of this book"
+//	@Param			barcode			query		string	false	"Only the copy with this barcode"
+//	@Param			branch			query		string	false	"Only the copies of this branch"
+//	@Param			status			query		string	false	"Only the copies with this status"	Enums(available, on_loan, on_hold, lost, in_repair)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	CopiesSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/copies [get]
+func (h *CopyHandler) GetCopies(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewCopyFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	copies, err := h.service.GetCopies(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CopiesSuccessResponse{
+		Status:  "success",
+		Message: "Copies retrieved successfully",
+		Copies:  dto.ToCopiesResponse(copies),
+	})
+}
+
+// GetCopyByID godoc
+//
+//	@Summary		Get copy by id
+//	@Description	Get a single copy by its ID
+//	@Tags			copies
+//	@Produce		json
+//	@Param			copy_id	path		string	true	"Copy ID"
+//	@Success		200		{object}	CopySuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/copies/{copy_id} [get]
+func (h *CopyHandler) GetCopyByID(w http.ResponseWriter, r *http.Request) {
+	copyID, err := uuid.Parse(chi.URLParam(r, "copy_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	bookCopy, err := h.service.GetCopyByID(r.Context(), copyID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CopySuccessResponse{
+		Status:  "success",
+		Message: "Copy retrieved successfully",
+		Copy:    dto.ToCopyResponse(bookCopy),
+	})
+}
+
+// UpdateCopy godoc
+//
+//	@Summary		Update a copy
+//	@Description	Update the condition, location or status of a copy. The status of a copy on loan or on hold cannot be changed. Restricted to the librarians.
+//	@Tags			copies
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			copy_id			path		string					true	"Copy ID"
+//	@Param			copy			body		dto.UpdateCopyRequest	true	"Copy data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	CopySuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/copies/{copy_id} [put]
+func (h *CopyHandler) UpdateCopy(w http.ResponseWriter, r *http.Request) {
+	copyID, err := uuid.Parse(chi.URLParam(r, "copy_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.UpdateCopyRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	bookCopy, err := h.service.UpdateCopy(r.Context(), &req, copyID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, CopySuccessResponse{
+		Status:  "success",
+		Message: "Copy updated successfully",
+		Copy:    dto.ToCopyResponse(bookCopy),
+	})
+}
+
+// DeleteCopy godoc
+//
+//	@Summary		Delete a copy
+//	@Description	Delete a copy registered by mistake. Copies which were ever lent are kept with the loans, mark them as lost instead. Restricted to the librarians.
+//	@Tags			copies
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			copy_id	path		string	true	"Copy ID"
+//	@Success		200		{object}	response.SuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/copies/{copy_id} [delete]
+func (h *CopyHandler) DeleteCopy(w http.ResponseWriter, r *http.Request) {
+	copyID, err := uuid.Parse(chi.URLParam(r, "copy_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.DeleteCopy(r.Context(), copyID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Copy deleted successfully")
+}
+
+func (h *CopyHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Copy not found")
+	case errors.Is(err, book.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, ErrInvalidBookID):
+		response.Error(w, http.StatusBadRequest, "invalid book ID")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Copy with this barcode already exists")
+	case errors.Is(err, ErrOnLoan):
+		response.Error(w, http.StatusConflict, "Copy is on loan")
+	case errors.Is(err, ErrOnHold):
+		response.Error(w, http.StatusConflict, "Copy is on hold")
+	case errors.Is(err, ErrHasLoans):
+		response.Error(w, http.StatusConflict, "Copy has loans and cannot be deleted")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
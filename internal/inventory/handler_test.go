@@ -0,0 +1,263 @@
+package inventory_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestCopyHandler_CreateCopy(t *testing.T) {
+	validRequest := dto.CreateCopyRequest{
+		BookID:        bookID.String(),
+		Barcode:       "LIB-0001",
+		Condition:     entity.CopyConditionGood,
+		Branch:        "Central",
+		ShelfLocation: "FIC-HUG-1",
+	}
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        interface{}
+		configureMock      func(*mocks.MockCopyService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:        "success create copy",
+			identity:    librarian,
+			requestBody: validRequest,
+			configureMock: func(mockService *mocks.MockCopyService) {
+				mockService.EXPECT().
+					CreateCopy(gomock.Any(), &validRequest).
+					Return(&entity.Copy{
+						ID:            copyID,
+						BookID:        bookID,
+						Barcode:       "LIB-0001",
+						Condition:     entity.CopyConditionGood,
+						Branch:        "Central",
+						ShelfLocation: "FIC-HUG-1",
+						Status:        entity.CopyStatusAvailable,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &inventory.CopySuccessResponse{
+				Status:  "success",
+				Message: "Copy created successfully",
+				Copy: &dto.CopyResponse{
+					ID:            copyID.String(),
+					BookID:        bookID.String(),
+					Barcode:       "LIB-0001",
+					Condition:     entity.CopyConditionGood,
+					Branch:        "Central",
+					ShelfLocation: "FIC-HUG-1",
+					Status:        entity.CopyStatusAvailable,
+				},
+			},
+		},
+		{
+			name:               "error anonymous caller",
+			requestBody:        validRequest,
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:               "error member creating a copy",
+			identity:           &auth.Identity{Subject: "member-1"},
+			requestBody:        validRequest,
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:     "error validation fails missing barcode",
+			identity: librarian,
+			requestBody: dto.CreateCopyRequest{
+				BookID:        bookID.String(),
+				Condition:     entity.CopyConditionGood,
+				Branch:        "Central",
+				ShelfLocation: "FIC-HUG-1",
+			},
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "barcode",
+					Message: "barcode is required",
+				}},
+			},
+		},
+		{
+			name:        "error duplicate barcode",
+			identity:    librarian,
+			requestBody: validRequest,
+			configureMock: func(mockService *mocks.MockCopyService) {
+				mockService.EXPECT().
+					CreateCopy(gomock.Any(), gomock.Any()).
+					Return(nil, inventory.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Copy with this barcode already exists",
+			},
+		},
+		{
+			name:        "error book not found",
+			identity:    librarian,
+			requestBody: validRequest,
+			configureMock: func(mockService *mocks.MockCopyService) {
+				mockService.EXPECT().
+					CreateCopy(gomock.Any(), gomock.Any()).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Book not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockCopyService(ctrl)
+			test.configureMock(mockService)
+
+			handler := inventory.NewCopyHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/copies", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/copies", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestCopyHandler_UpdateCopy(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        string
+		configureMock      func(*mocks.MockCopyService)
+		expectedStatusCode int
+	}{
+		{
+			name:        "success",
+			identity:    librarian,
+			requestBody: `{"status": "in_repair"}`,
+			configureMock: func(mockService *mocks.MockCopyService) {
+				mockService.EXPECT().
+					UpdateCopy(gomock.Any(), gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusInRepair}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "error on_loan cannot be set",
+			identity:           librarian,
+			requestBody:        `{"status": "on_loan"}`,
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:        "error copy on loan",
+			identity:    librarian,
+			requestBody: `{"status": "lost"}`,
+			configureMock: func(mockService *mocks.MockCopyService) {
+				mockService.EXPECT().
+					UpdateCopy(gomock.Any(), gomock.Any(), copyID).
+					Return(nil, inventory.ErrOnLoan)
+			},
+			expectedStatusCode: http.StatusConflict,
+		},
+		{
+			name:               "error anonymous caller",
+			requestBody:        `{"status": "lost"}`,
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:               "error member marking a copy lost",
+			identity:           &auth.Identity{Subject: "member-1"},
+			requestBody:        `{"status": "lost"}`,
+			configureMock:      func(mockService *mocks.MockCopyService) {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockCopyService(ctrl)
+			test.configureMock(mockService)
+
+			handler := inventory.NewCopyHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPut, "/copies/"+copyID.String(), bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/copies", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_copy_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/inventory CopyRepository
+type CopyRepository interface {
+	Create(ctx context.Context, newCopy *entity.Copy) (*entity.Copy, error)
+	GetAll(ctx context.Context, filter dto.CopyFilter) ([]*entity.Copy, error)
+	GetByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error)
+	LockByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error)
+	LockByBarcode(ctx context.Context, barcode string) (*entity.Copy, error)
+	// LockAvailableByBookID locks the oldest available copy of the book, it
+	// returns ErrNotFound when every copy is unavailable.
+	LockAvailableByBookID(ctx context.Context, bookID uuid.UUID) (*entity.Copy, error)
+	Update(ctx context.Context, bookCopy *entity.Copy) (*entity.Copy, error)
+	Delete(ctx context.Context, copyID uuid.UUID) error
+}
+
+type copyRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewCopyRepository(db *gorm.DB, logger zerolog.Logger) CopyRepository {
+	return &copyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *copyRepository) Create(ctx context.Context, newCopy *entity.Copy) (*entity.Copy, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newCopy).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newCopy, nil
+}
+
+// GetAll returns the filtered copies, ordered by branch and shelf location
+// as they are found in the library.
+func (r *copyRepository) GetAll(ctx context.Context, filter dto.CopyFilter) ([]*entity.Copy, error) {
+	var copies []*entity.Copy
+
+	query := transaction.DB(ctx, r.db)
+
+	if filter.BookID != "" {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+
+	if filter.Barcode != "" {
+		query = query.Where("barcode = ?", filter.Barcode)
+	}
+
+	if filter.Branch != "" {
+		query = query.Where("branch = ?", filter.Branch)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Order("branch, shelf_location, barcode").Find(&copies).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return copies, nil
+}
+
+func (r *copyRepository) GetByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error) {
+	return r.first(transaction.DB(ctx, r.db), "id = ?", copyID)
+}
+
+// LockByID reads the copy and locks its row until the end of the transaction
+// carried by ctx.
+func (r *copyRepository) LockByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error) {
+	return r.first(r.locked(ctx), "id = ?", copyID)
+}
+
+func (r *copyRepository) LockByBarcode(ctx context.Context, barcode string) (*entity.Copy, error) {
+	return r.first(r.locked(ctx), "barcode = ?", barcode)
+}
+
+func (r *copyRepository) LockAvailableByBookID(ctx context.Context, bookID uuid.UUID) (*entity.Copy, error) {
+	return r.first(r.locked(ctx).Order("created_at"), "book_id = ? AND status = ?", bookID, entity.CopyStatusAvailable)
+}
+
+func (r *copyRepository) Update(ctx context.Context, bookCopy *entity.Copy) (*entity.Copy, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(bookCopy).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (r *copyRepository) Delete(ctx context.Context, copyID uuid.UUID) error {
+	if err := transaction.DB(ctx, r.db).Where("id = ?", copyID).Delete(&entity.Copy{}).Error; err != nil {
+		// the loans keep the borrowing history, a copy which was ever lent
+		// is marked as lost instead
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			return ErrHasLoans
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+func (r *copyRepository) locked(ctx context.Context) *gorm.DB {
+	return transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+}
+
+func (r *copyRepository) first(query *gorm.DB, conds ...any) (*entity.Copy, error) {
+	var bookCopy *entity.Copy
+
+	if err := query.First(&bookCopy, conds...).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
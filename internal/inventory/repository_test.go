@@ -0,0 +1,243 @@
+package inventory_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var copyColumns = []string{"id", "book_id", "barcode", "condition", "item_type", "branch", "shelf_location", "status", "created_at", "updated_at"}
+
+func TestCopyRepository_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Copy
+		configureMock    func(sqlmock.Sqlmock, *entity.Copy)
+		expectedError    error
+		expectedResponse *entity.Copy
+	}{
+		{
+			name:  "success create copy",
+			input: &entity.Copy{BookID: bookID, Barcode: "LIB-0001", ItemType: entity.ItemTypeStandard, Status: entity.CopyStatusAvailable},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Copy) {
+				mock.ExpectExec(`INSERT INTO .copies.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.BookID,
+						input.Barcode,
+						input.Condition,
+						input.ItemType,
+						input.AcquiredOn,
+						input.Branch,
+						input.ShelfLocation,
+						input.Status,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Copy{BookID: bookID, Barcode: "LIB-0001"},
+		},
+		{
+			name:  "error duplicate barcode",
+			input: &entity.Copy{BookID: bookID, Barcode: "LIB-0001", ItemType: entity.ItemTypeStandard, Status: entity.CopyStatusAvailable},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Copy) {
+				mock.ExpectExec(`INSERT INTO .copies.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: inventory.ErrDuplicate,
+		},
+		{
+			name:  "error database connection failed",
+			input: &entity.Copy{BookID: bookID, Barcode: "LIB-0001", ItemType: entity.ItemTypeStandard, Status: entity.CopyStatusAvailable},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Copy) {
+				mock.ExpectExec(`INSERT INTO .copies.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := inventory.NewCopyRepository(db, zerolog.Nop())
+
+			newCopy, err := repo.Create(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newCopy)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Barcode, newCopy.Barcode)
+				assert.Equal(t, test.expectedResponse.BookID, newCopy.BookID)
+				assert.NotEqual(t, uuid.Nil, newCopy.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCopyRepository_GetAll(t *testing.T) {
+	tests := []struct {
+		name             string
+		filter           dto.CopyFilter
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedBarcodes []string
+	}{
+		{
+			name:   "success ordered by branch and shelf location",
+			filter: dto.CopyFilter{BookID: bookID.String()},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(copyColumns).
+					AddRow(uuid.New(), bookID, "LIB-0001", "", entity.ItemTypeStandard, "Central", "A-1", entity.CopyStatusOnLoan, now, now).
+					AddRow(uuid.New(), bookID, "LIB-0003", "", entity.ItemTypeStandard, "Central", "B-2", entity.CopyStatusAvailable, now, now).
+					AddRow(uuid.New(), bookID, "LIB-0002", "", entity.ItemTypeStandard, "North", "A-1", entity.CopyStatusAvailable, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE book_id = \? ORDER BY branch, shelf_location, barcode`).
+					WithArgs(bookID.String()).
+					WillReturnRows(rows)
+			},
+			expectedBarcodes: []string{"LIB-0001", "LIB-0003", "LIB-0002"},
+		},
+		{
+			name:   "success by branch and status",
+			filter: dto.CopyFilter{Branch: "Central", Status: entity.CopyStatusAvailable},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(copyColumns).
+					AddRow(uuid.New(), bookID, "LIB-0003", "", entity.ItemTypeStandard, "Central", "B-2", entity.CopyStatusAvailable, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE branch = \? AND status = \? ORDER BY branch, shelf_location, barcode`).
+					WithArgs("Central", entity.CopyStatusAvailable).
+					WillReturnRows(rows)
+			},
+			expectedBarcodes: []string{"LIB-0003"},
+		},
+		{
+			name:   "success by barcode",
+			filter: dto.CopyFilter{Barcode: "LIB-0002"},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(copyColumns).
+					AddRow(uuid.New(), bookID, "LIB-0002", "", entity.ItemTypeStandard, "North", "A-1", entity.CopyStatusAvailable, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE barcode = \? ORDER BY branch, shelf_location, barcode`).
+					WithArgs("LIB-0002").
+					WillReturnRows(rows)
+			},
+			expectedBarcodes: []string{"LIB-0002"},
+		},
+		{
+			name:   "error database connection failed",
+			filter: dto.CopyFilter{},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .copies. ORDER BY branch, shelf_location, barcode`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := inventory.NewCopyRepository(db, zerolog.Nop())
+
+			copies, err := repo.GetAll(context.Background(), test.filter)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, copies)
+			} else {
+				require.NoError(t, err)
+
+				barcodes := make([]string, len(copies))
+				for i, c := range copies {
+					barcodes[i] = c.Barcode
+				}
+				assert.Equal(t, test.expectedBarcodes, barcodes)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCopyRepository_LockAvailableByBookID(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedResponse *entity.Copy
+	}{
+		{
+			name: "success lock the oldest available copy",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(copyColumns).
+					AddRow(copyID, bookID, "LIB-0002", "", entity.ItemTypeStandard, "Central", "A-1", entity.CopyStatusAvailable, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE book_id = \? AND status = \? ORDER BY created_at,.copies.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(bookID, entity.CopyStatusAvailable, 1).
+					WillReturnRows(rows)
+			},
+			expectedResponse: &entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0002"},
+		},
+		{
+			name: "error no available copy",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE book_id = \? AND status = \? ORDER BY created_at,.copies.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(bookID, entity.CopyStatusAvailable, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: inventory.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := inventory.NewCopyRepository(db, zerolog.Nop())
+
+			bookCopy, err := repo.LockAvailableByBookID(context.Background(), bookID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, bookCopy)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.ID, bookCopy.ID)
+				assert.Equal(t, test.expectedResponse.Barcode, bookCopy.Barcode)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
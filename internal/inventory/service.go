@@ -0,0 +1,166 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+//go:generate mockgen -destination=../mocks/mock_copy_service.go -package=mocks go-boilerplate-rest-api-chi/internal/inventory CopyService
+type CopyService interface {
+	CreateCopy(ctx context.Context, req *dto.CreateCopyRequest) (*entity.Copy, error)
+	GetCopies(ctx context.Context, filter dto.CopyFilter) ([]*entity.Copy, error)
+	GetCopyByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error)
+	// UpdateCopy moves or describes a copy. The status of a copy on loan or
+	// on hold only changes when it is returned or picked up.
+	UpdateCopy(ctx context.Context, req *dto.UpdateCopyRequest, copyID uuid.UUID) (*entity.Copy, error)
+	DeleteCopy(ctx context.Context, copyID uuid.UUID) error
+}
+
+type copyService struct {
+	repository     CopyRepository
+	bookRepository book.BookRepository
+	transactions   transaction.Manager
+	logger         zerolog.Logger
+}
+
+func NewCopyService(repository CopyRepository, bookRepository book.BookRepository, transactions transaction.Manager, logger zerolog.Logger) CopyService {
+	return &copyService{
+		repository:     repository,
+		bookRepository: bookRepository,
+		transactions:   transactions,
+		logger:         logger,
+	}
+}
+
+func (s *copyService) CreateCopy(ctx context.Context, req *dto.CreateCopyRequest) (*entity.Copy, error) {
+	bookID, err := uuid.Parse(req.BookID)
+	if err != nil {
+		return nil, ErrInvalidBookID
+	}
+
+	newCopy := &entity.Copy{
+		BookID:        bookID,
+		Barcode:       req.Barcode,
+		Condition:     req.Condition,
+		ItemType:      req.ItemType,
+		Branch:        req.Branch,
+		ShelfLocation: req.ShelfLocation,
+		Status:        entity.CopyStatusAvailable,
+	}
+
+	if newCopy.ItemType == "" {
+		newCopy.ItemType = entity.ItemTypeStandard
+	}
+
+	if req.AcquiredOn != "" {
+		acquiredOn, err := time.Parse(internalValidator.DateLayout, req.AcquiredOn)
+		if err != nil {
+			return nil, err
+		}
+		newCopy.AcquiredOn = &acquiredOn
+	}
+
+	var bookCopy *entity.Copy
+
+	// the book stays locked until the copy is committed, it cannot be deleted
+	// in between
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.bookRepository.LockByID(ctx, bookID); err != nil {
+			return err
+		}
+
+		bookCopy, err = s.repository.Create(ctx, newCopy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (s *copyService) GetCopies(ctx context.Context, filter dto.CopyFilter) ([]*entity.Copy, error) {
+	return s.repository.GetAll(ctx, filter)
+}
+
+func (s *copyService) GetCopyByID(ctx context.Context, copyID uuid.UUID) (*entity.Copy, error) {
+	return s.repository.GetByID(ctx, copyID)
+}
+
+func (s *copyService) UpdateCopy(ctx context.Context, req *dto.UpdateCopyRequest, copyID uuid.UUID) (*entity.Copy, error) {
+	var bookCopy *entity.Copy
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		bookCopy, err = s.repository.LockByID(ctx, copyID)
+		if err != nil {
+			return err
+		}
+
+		if req.Status != nil && *req.Status != bookCopy.Status {
+			if err := checkReleased(bookCopy); err != nil {
+				return err
+			}
+			bookCopy.Status = *req.Status
+		}
+
+		if req.Condition != nil {
+			bookCopy.Condition = *req.Condition
+		}
+
+		if req.ItemType != nil {
+			bookCopy.ItemType = *req.ItemType
+		}
+
+		if req.Branch != nil {
+			bookCopy.Branch = *req.Branch
+		}
+
+		if req.ShelfLocation != nil {
+			bookCopy.ShelfLocation = *req.ShelfLocation
+		}
+
+		bookCopy, err = s.repository.Update(ctx, bookCopy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookCopy, nil
+}
+
+func (s *copyService) DeleteCopy(ctx context.Context, copyID uuid.UUID) error {
+	return s.transactions.Do(ctx, func(ctx context.Context) error {
+		bookCopy, err := s.repository.LockByID(ctx, copyID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkReleased(bookCopy); err != nil {
+			return err
+		}
+
+		return s.repository.Delete(ctx, copyID)
+	})
+}
+
+// checkReleased fails when the copy is held by a loan or a hold.
+func checkReleased(bookCopy *entity.Copy) error {
+	switch bookCopy.Status {
+	case entity.CopyStatusOnLoan:
+		return ErrOnLoan
+	case entity.CopyStatusOnHold:
+		return ErrOnHold
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package inventory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/inventory/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	bookID = uuid.MustParse("d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f")
+	copyID = uuid.MustParse("5c1f0e7a-2b9d-4c3e-8f6a-7d4b2a1e9c08")
+)
+
+func TestCopyService_CreateCopy(t *testing.T) {
+	acquiredOn := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		input            *dto.CreateCopyRequest
+		configureMock    func(*mocks.MockCopyRepository, *mocks.MockBookRepository)
+		expectedResponse *entity.Copy
+		expectedError    error
+	}{
+		{
+			name: "success create copy",
+			input: &dto.CreateCopyRequest{
+				BookID:        bookID.String(),
+				Barcode:       "LIB-0001",
+				Condition:     entity.CopyConditionNew,
+				AcquiredOn:    "2024-02-29",
+				Branch:        "Central",
+				ShelfLocation: "FIC-HUG-1",
+			},
+			configureMock: func(mockRepo *mocks.MockCopyRepository, mockBookRepo *mocks.MockBookRepository) {
+				mockBookRepo.EXPECT().
+					LockByID(gomock.Any(), bookID).
+					Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+			},
+			expectedResponse: &entity.Copy{
+				BookID:        bookID,
+				Barcode:       "LIB-0001",
+				Condition:     entity.CopyConditionNew,
+				ItemType:      entity.ItemTypeStandard,
+				AcquiredOn:    &acquiredOn,
+				Branch:        "Central",
+				ShelfLocation: "FIC-HUG-1",
+				Status:        entity.CopyStatusAvailable,
+			},
+		},
+		{
+			name: "error book not found",
+			input: &dto.CreateCopyRequest{
+				BookID:    bookID.String(),
+				Barcode:   "LIB-0001",
+				Condition: entity.CopyConditionNew,
+			},
+			configureMock: func(mockRepo *mocks.MockCopyRepository, mockBookRepo *mocks.MockBookRepository) {
+				mockBookRepo.EXPECT().
+					LockByID(gomock.Any(), bookID).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedError: book.ErrNotFound,
+		},
+		{
+			name: "error duplicate barcode",
+			input: &dto.CreateCopyRequest{
+				BookID:    bookID.String(),
+				Barcode:   "LIB-0001",
+				Condition: entity.CopyConditionNew,
+			},
+			configureMock: func(mockRepo *mocks.MockCopyRepository, mockBookRepo *mocks.MockBookRepository) {
+				mockBookRepo.EXPECT().
+					LockByID(gomock.Any(), bookID).
+					Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, inventory.ErrDuplicate)
+			},
+			expectedError: inventory.ErrDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+
+			test.configureMock(copyRepoMock, bookRepoMock)
+			service := inventory.NewCopyService(copyRepoMock, bookRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			result, err := service.CreateCopy(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCopyService_UpdateCopy(t *testing.T) {
+	lost := entity.CopyStatusLost
+	shelf := "FIC-HUG-2"
+
+	tests := []struct {
+		name             string
+		input            *dto.UpdateCopyRequest
+		configureMock    func(*mocks.MockCopyRepository)
+		expectedResponse *entity.Copy
+		expectedError    error
+	}{
+		{
+			name:  "success mark as lost",
+			input: &dto.UpdateCopyRequest{Status: &lost},
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusAvailable}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+			},
+			expectedResponse: &entity.Copy{ID: copyID, Status: entity.CopyStatusLost},
+		},
+		{
+			name:  "success move a copy on loan",
+			input: &dto.UpdateCopyRequest{ShelfLocation: &shelf},
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusOnLoan}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+			},
+			expectedResponse: &entity.Copy{ID: copyID, ShelfLocation: "FIC-HUG-2", Status: entity.CopyStatusOnLoan},
+		},
+		{
+			name:  "error copy on loan",
+			input: &dto.UpdateCopyRequest{Status: &lost},
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusOnLoan}, nil)
+			},
+			expectedError: inventory.ErrOnLoan,
+		},
+		{
+			name:  "error copy on hold",
+			input: &dto.UpdateCopyRequest{Status: &lost},
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusOnHold}, nil)
+			},
+			expectedError: inventory.ErrOnHold,
+		},
+		{
+			name:  "error copy not found",
+			input: &dto.UpdateCopyRequest{Status: &lost},
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(nil, inventory.ErrNotFound)
+			},
+			expectedError: inventory.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+
+			test.configureMock(copyRepoMock)
+			service := inventory.NewCopyService(copyRepoMock, mocks.NewMockBookRepository(ctrl), testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			result, err := service.UpdateCopy(context.Background(), test.input, copyID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestCopyService_DeleteCopy(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockCopyRepository)
+		expectedError error
+	}{
+		{
+			name: "success delete copy",
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusAvailable}, nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), copyID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error copy on loan",
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusOnLoan}, nil)
+			},
+			expectedError: inventory.ErrOnLoan,
+		},
+		{
+			name: "error database error",
+			configureMock: func(mockRepo *mocks.MockCopyRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, Status: entity.CopyStatusAvailable}, nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), copyID).
+					Return(errors.New("database connection failed"))
+			},
+			expectedError: errors.New("database connection failed"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+
+			test.configureMock(copyRepoMock)
+			service := inventory.NewCopyService(copyRepoMock, mocks.NewMockBookRepository(ctrl), testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			err := service.DeleteCopy(context.Background(), copyID)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
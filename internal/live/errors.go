@@ -0,0 +1,10 @@
+package live
+
+import "errors"
+
+var (
+	ErrTooManyClients = errors.New("too many live clients")
+	ErrTooManyTopics  = errors.New("too many topics")
+	ErrInvalidTopic   = errors.New("invalid topic")
+	ErrClosed         = errors.New("live hub closed")
+)
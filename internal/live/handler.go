@@ -0,0 +1,242 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+// maxMessageBytes bounds the size of a message received from a client.
+const maxMessageBytes = 16 << 10
+
+type LiveHandler struct {
+	hub            *Hub
+	verifier       *auth.Verifier
+	bookService    book.BookService
+	authorService  author.AuthorService
+	pingInterval   time.Duration
+	writeTimeout   time.Duration
+	allowedOrigins []string
+	logger         zerolog.Logger
+}
+
+func NewLiveHandler(hub *Hub, verifier *auth.Verifier, bookService book.BookService, authorService author.AuthorService, cfg config.LiveConfig, logger zerolog.Logger) *LiveHandler {
+	return &LiveHandler{
+		hub:            hub,
+		verifier:       verifier,
+		bookService:    bookService,
+		authorService:  authorService,
+		pingInterval:   cfg.PingInterval,
+		writeTimeout:   cfg.WriteTimeout,
+		allowedOrigins: cfg.AllowedOrigins,
+		logger:         logger,
+	}
+}
+
+func (h *LiveHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Get("/", h.Connect)
+
+	return r
+}
+
+// Connect godoc
+//
+//	@Summary		Live collaboration
+//	@Description	Upgrade to a WebSocket exchanging JSON messages. Send {"type":"subscribe","topics":["book:<id>","author:<id>"]} to receive the changes of these books and authors as "event" messages, and the users subscribed to the same topics as "presence" messages. A connection too slow to read its messages is closed with status 1013.
+//	@Tags			live
+//	@Security		ApiKeyAuth
+//	@Param			access_token	query		string	false	"Access token, for clients unable to set the Authorization header"
+//	@Success		101				{string}	string	"Switching Protocols"
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		503				{object}	response.ErrorResponse
+//	@Router			/live [get]
+func (h *LiveHandler) Connect(w http.ResponseWriter, r *http.Request) {
+	identity, err := h.verifier.Verify(auth.TokenFromRequest(r))
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	client, err := h.hub.Register(User{ID: identity.Subject, Name: identity.Name})
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	defer h.hub.Unregister(client)
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: h.allowedOrigins,
+	})
+	if err != nil {
+		// Accept already wrote the response
+		h.logger.Debug().Err(err).Msg("websocket upgrade failed")
+		return
+	}
+	defer conn.CloseNow()
+
+	conn.SetReadLimit(maxMessageBytes)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	go func() {
+		defer cancel()
+		h.write(ctx, conn, client)
+	}()
+
+	h.read(ctx, conn, client)
+}
+
+// read handles the messages of the client until the connection fails.
+func (h *LiveHandler) read(ctx context.Context, conn *websocket.Conn, client *Client) {
+	for {
+		typ, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		if typ != websocket.MessageText {
+			h.hub.Reply(client, ServerMessage{Type: MessageError, Message: "messages must be JSON text"})
+			continue
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.hub.Reply(client, ServerMessage{Type: MessageError, Message: "invalid JSON message"})
+			continue
+		}
+
+		h.handleMessage(ctx, client, msg)
+	}
+}
+
+func (h *LiveHandler) handleMessage(ctx context.Context, client *Client, msg ClientMessage) {
+	switch msg.Type {
+	case MessageSubscribe:
+		if err := h.checkTopics(ctx, msg.Topics); err != nil {
+			h.replyError(client, err)
+			return
+		}
+
+		if err := h.hub.Subscribe(client, msg.Topics); err != nil {
+			h.replyError(client, err)
+		}
+	case MessageUnsubscribe:
+		h.hub.Unsubscribe(client, msg.Topics)
+	default:
+		h.hub.Reply(client, ServerMessage{Type: MessageError, Message: fmt.Sprintf("unknown message type %q", msg.Type)})
+	}
+}
+
+// checkTopics makes sure that the books and authors of the topics exist.
+func (h *LiveHandler) checkTopics(ctx context.Context, topics []string) error {
+	if len(topics) == 0 {
+		return fmt.Errorf("%w: at least one topic is required", ErrInvalidTopic)
+	}
+
+	for _, value := range topics {
+		topic, err := ParseTopic(value)
+		if err != nil {
+			return err
+		}
+
+		switch topic.Aggregate {
+		case event.AggregateBook:
+			_, err = h.bookService.GetBookByID(ctx, topic.ID)
+		case event.AggregateAuthor:
+			_, err = h.authorService.GetAuthorByID(ctx, topic.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write sends the messages of the client, and pings it, until the connection
+// fails or the hub drops the client.
+func (h *LiveHandler) write(ctx context.Context, conn *websocket.Conn, client *Client) {
+	ping := time.NewTicker(h.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-client.Done():
+			switch client.Reason() {
+			case ReasonSlowConsumer:
+				_ = conn.Close(websocket.StatusTryAgainLater, ReasonSlowConsumer)
+			case ReasonShutdown:
+				_ = conn.Close(websocket.StatusGoingAway, ReasonShutdown)
+			}
+			return
+		case data := <-client.Send():
+			writeCtx, cancel := context.WithTimeout(ctx, h.writeTimeout)
+			err := conn.Write(writeCtx, websocket.MessageText, data)
+			cancel()
+			if err != nil {
+				return
+			}
+		case <-ping.C:
+			pingCtx, cancel := context.WithTimeout(ctx, h.writeTimeout)
+			err := conn.Ping(pingCtx)
+			cancel()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *LiveHandler) replyError(client *Client, err error) {
+	var message string
+
+	switch {
+	case errors.Is(err, ErrInvalidTopic):
+		message = err.Error()
+	case errors.Is(err, ErrTooManyTopics):
+		message = "too many topics for a single connection"
+	case errors.Is(err, book.ErrNotFound):
+		message = "book not found"
+	case errors.Is(err, author.ErrNotFound):
+		message = "author not found"
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		message = "internal server error"
+	}
+
+	h.hub.Reply(client, ServerMessage{Type: MessageError, Message: message})
+}
+
+func (h *LiveHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, auth.ErrMissingToken), errors.Is(err, auth.ErrInvalidToken):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		response.Error(w, http.StatusUnauthorized, "Unauthorized")
+	case errors.Is(err, ErrTooManyClients), errors.Is(err, ErrClosed):
+		w.Header().Set("Retry-After", "5")
+		response.Error(w, http.StatusServiceUnavailable, "Live collaboration unavailable, retry later")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,225 @@
+package live_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/live"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+)
+
+const secret = "test-secret"
+
+func newLiveServer(t *testing.T, hub *live.Hub, configureMock func(*mocks.MockBookService, *mocks.MockAuthorService)) *httptest.Server {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	bookService := mocks.NewMockBookService(ctrl)
+	authorService := mocks.NewMockAuthorService(ctrl)
+	configureMock(bookService, authorService)
+
+	handler := live.NewLiveHandler(hub, auth.NewVerifier(secret), bookService, authorService, config.LiveConfig{
+		PingInterval: time.Second,
+		WriteTimeout: time.Second,
+	}, zerolog.Nop())
+
+	r := chi.NewRouter()
+	r.Mount("/live", handler.Routes())
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func token(t *testing.T, subject string) string {
+	t.Helper()
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  subject,
+		"name": strings.ToUpper(subject),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(secret))
+	require.NoError(t, err)
+
+	return signed
+}
+
+func dial(t *testing.T, srv *httptest.Server, subject string) *websocket.Conn {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/live", &websocket.DialOptions{
+		HTTPHeader: http.Header{"Authorization": []string{"Bearer " + token(t, subject)}},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.CloseNow() })
+
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg live.ClientMessage) {
+	t.Helper()
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, data))
+}
+
+func receive(t *testing.T, conn *websocket.Conn) live.ServerMessage {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, data, err := conn.Read(ctx)
+	require.NoError(t, err)
+
+	var msg live.ServerMessage
+	require.NoError(t, json.Unmarshal(data, &msg))
+
+	return msg
+}
+
+func TestLiveHandler_Connect(t *testing.T) {
+	hub := newHub(10, 10)
+	srv := newLiveServer(t, hub, func(bookService *mocks.MockBookService, authorService *mocks.MockAuthorService) {
+		bookService.EXPECT().
+			GetBookByID(gomock.Any(), bookID).
+			Return(&entity.Book{ID: bookID}, nil).
+			Times(2)
+
+		bookService.EXPECT().
+			GetBookByID(gomock.Any(), authorID).
+			Return(nil, book.ErrNotFound)
+	})
+
+	alice := dial(t, srv, "alice")
+	send(t, alice, live.ClientMessage{Type: live.MessageSubscribe, Topics: []string{bookTopic}})
+
+	assert.Equal(t, live.ServerMessage{Type: live.MessageSubscribed, Topics: []string{bookTopic}}, receive(t, alice))
+	assert.Equal(t, live.ServerMessage{
+		Type:  live.MessagePresence,
+		Topic: bookTopic,
+		Users: []live.User{{ID: "alice", Name: "ALICE"}},
+	}, receive(t, alice))
+
+	bob := dial(t, srv, "bob")
+	send(t, bob, live.ClientMessage{Type: live.MessageSubscribe, Topics: []string{bookTopic}})
+
+	assert.Equal(t, live.MessageSubscribed, receive(t, bob).Type)
+
+	presence := receive(t, alice)
+	assert.Equal(t, []string{"alice", "bob"}, userIDs(presence))
+	assert.Equal(t, presence, receive(t, bob))
+
+	e := event.NewBookDeleted(bookID)
+	require.NoError(t, hub.Publish(context.Background(), e))
+
+	for _, conn := range []*websocket.Conn{alice, bob} {
+		msg := receive(t, conn)
+		assert.Equal(t, live.MessageEvent, msg.Type)
+		assert.Equal(t, e.ID, msg.Event.ID)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		send(t, bob, live.ClientMessage{Type: live.MessageSubscribe, Topics: []string{"book:" + authorID.String()}})
+		assert.Equal(t, live.ServerMessage{Type: live.MessageError, Message: "book not found"}, receive(t, bob))
+
+		send(t, bob, live.ClientMessage{Type: live.MessageSubscribe, Topics: []string{"loan:1"}})
+		assert.Equal(t, live.ServerMessage{Type: live.MessageError, Message: `invalid topic: "loan:1"`}, receive(t, bob))
+
+		send(t, bob, live.ClientMessage{Type: "edit"})
+		assert.Equal(t, live.ServerMessage{Type: live.MessageError, Message: `unknown message type "edit"`}, receive(t, bob))
+	})
+
+	t.Run("presence on disconnect", func(t *testing.T) {
+		require.NoError(t, bob.Close(websocket.StatusNormalClosure, ""))
+
+		assert.Equal(t, []string{"alice"}, userIDs(receive(t, alice)))
+	})
+
+	t.Run("closed on shutdown", func(t *testing.T) {
+		hub.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, _, err := alice.Read(ctx)
+		assert.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+	})
+}
+
+func TestLiveHandler_Unauthorized(t *testing.T) {
+	srv := newLiveServer(t, newHub(10, 10), func(*mocks.MockBookService, *mocks.MockAuthorService) {})
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "missing token"},
+		{name: "invalid token", query: "?" + auth.QueryToken + "=abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/live"+test.query, nil)
+			require.Error(t, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		})
+	}
+
+	t.Run("token in the query", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/live?"+auth.QueryToken+"="+token(t, "alice"), nil)
+		require.NoError(t, err)
+		_ = conn.CloseNow()
+	})
+}
+
+func TestLiveHandler_TooManyClients(t *testing.T) {
+	srv := newLiveServer(t, newHub(1, 10), func(*mocks.MockBookService, *mocks.MockAuthorService) {})
+
+	dial(t, srv, "alice")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/live", &websocket.DialOptions{
+		HTTPHeader: http.Header{"Authorization": []string{"Bearer " + token(t, "bob")}},
+	})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "5", resp.Header.Get("Retry-After"))
+}
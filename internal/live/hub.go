@@ -0,0 +1,281 @@
+package live
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+// sendBuffer is the number of messages a client may lag behind before it is
+// dropped.
+const sendBuffer = 64
+
+// Close reasons of a client dropped by the hub.
+const (
+	ReasonSlowConsumer = "slow consumer"
+	ReasonShutdown     = "server shutting down"
+)
+
+// Client is a connection registered to the hub. Its messages are read from
+// Send until Done is closed, Reason then tells why the hub dropped it.
+type Client struct {
+	user   User
+	send   chan []byte
+	done   chan struct{}
+	topics map[string]struct{}
+	reason string
+}
+
+func (c *Client) Send() <-chan []byte {
+	return c.send
+}
+
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Reason is only set once Done is closed.
+func (c *Client) Reason() string {
+	return c.reason
+}
+
+// Hub routes the change events and the presence of the users to the clients
+// subscribed to their topics. A client too slow to read its messages is
+// dropped rather than holding the others back.
+//
+// Only the events relayed by this instance reach its clients.
+type Hub struct {
+	mu         sync.Mutex
+	clients    map[*Client]struct{}
+	topics     map[string]map[*Client]struct{}
+	maxClients int
+	maxTopics  int
+	closed     bool
+}
+
+func NewHub(cfg config.LiveConfig) *Hub {
+	return &Hub{
+		clients:    make(map[*Client]struct{}),
+		topics:     make(map[string]map[*Client]struct{}),
+		maxClients: cfg.MaxClients,
+		maxTopics:  cfg.MaxTopics,
+	}
+}
+
+func (h *Hub) Register(user User) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	if len(h.clients) >= h.maxClients {
+		return nil, ErrTooManyClients
+	}
+
+	c := &Client{
+		user:   user,
+		send:   make(chan []byte, sendBuffer),
+		done:   make(chan struct{}),
+		topics: make(map[string]struct{}),
+	}
+	h.clients[c] = struct{}{}
+
+	return c, nil
+}
+
+// Unregister leaves every topic of the client, it may be called more than
+// once.
+func (h *Hub) Unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(c, "")
+}
+
+// Subscribe adds the topics to the client and acknowledges them. The presence
+// of each new topic is sent to its subscribers.
+func (h *Hub) Subscribe(c *Client, topics []string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; !ok {
+		return ErrClosed
+	}
+
+	var added []string
+	for _, topic := range topics {
+		if _, ok := c.topics[topic]; !ok && !slices.Contains(added, topic) {
+			added = append(added, topic)
+		}
+	}
+
+	if len(c.topics)+len(added) > h.maxTopics {
+		return ErrTooManyTopics
+	}
+
+	for _, topic := range added {
+		if h.topics[topic] == nil {
+			h.topics[topic] = make(map[*Client]struct{})
+		}
+		h.topics[topic][c] = struct{}{}
+		c.topics[topic] = struct{}{}
+	}
+
+	h.enqueue(c, ServerMessage{Type: MessageSubscribed, Topics: topics})
+
+	for _, topic := range added {
+		h.broadcastPresence(topic)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the topics from the client and acknowledges them.
+func (h *Hub) Unsubscribe(c *Client, topics []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	for _, topic := range topics {
+		h.leave(c, topic)
+	}
+
+	h.enqueue(c, ServerMessage{Type: MessageUnsubscribed, Topics: topics})
+}
+
+// Reply sends msg to the client alone.
+func (h *Hub) Reply(c *Client, msg ServerMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.enqueue(c, msg)
+}
+
+// Publish sends e to the clients subscribed to its topics. It is meant to be
+// subscribed to the outbox bus.
+func (h *Hub) Publish(_ context.Context, e event.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// a client subscribed to both the book and its author is notified once
+	notified := make(map[*Client]struct{})
+
+	for _, topic := range eventTopics(e, data) {
+		msg, err := json.Marshal(ServerMessage{Type: MessageEvent, Topic: topic, Event: &e})
+		if err != nil {
+			return err
+		}
+
+		for c := range h.topics[topic] {
+			if _, ok := notified[c]; ok {
+				continue
+			}
+			notified[c] = struct{}{}
+
+			h.send(c, msg)
+		}
+	}
+
+	return nil
+}
+
+// Close drops every client and refuses new ones.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for c := range h.clients {
+		h.drop(c, ReasonShutdown)
+	}
+}
+
+// The following methods must be called with mu held.
+
+func (h *Hub) enqueue(c *Client, msg ServerMessage) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	h.send(c, data)
+}
+
+func (h *Hub) send(c *Client, data []byte) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	select {
+	case c.send <- data:
+	default:
+		h.drop(c, ReasonSlowConsumer)
+	}
+}
+
+func (h *Hub) drop(c *Client, reason string) {
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+
+	delete(h.clients, c)
+	c.reason = reason
+	close(c.done)
+
+	for topic := range c.topics {
+		h.leave(c, topic)
+	}
+}
+
+func (h *Hub) leave(c *Client, topic string) {
+	if _, ok := c.topics[topic]; !ok {
+		return
+	}
+
+	delete(c.topics, topic)
+	delete(h.topics[topic], c)
+
+	if len(h.topics[topic]) == 0 {
+		delete(h.topics, topic)
+		return
+	}
+
+	h.broadcastPresence(topic)
+}
+
+// broadcastPresence sends the users subscribed to topic to each of them.
+func (h *Hub) broadcastPresence(topic string) {
+	subscribers := h.topics[topic]
+
+	users := make([]User, 0, len(subscribers))
+	for c := range subscribers {
+		if !slices.ContainsFunc(users, func(u User) bool { return u.ID == c.user.ID }) {
+			users = append(users, c.user)
+		}
+	}
+	slices.SortFunc(users, func(a, b User) int { return cmp.Compare(a.ID, b.ID) })
+
+	data, err := json.Marshal(ServerMessage{Type: MessagePresence, Topic: topic, Users: users})
+	if err != nil {
+		return
+	}
+
+	for c := range subscribers {
+		h.send(c, data)
+	}
+}
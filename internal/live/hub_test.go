@@ -0,0 +1,198 @@
+package live_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/live"
+)
+
+var (
+	bookID   = uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0")
+	authorID = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+	bookTopic   = "book:" + bookID.String()
+	authorTopic = "author:" + authorID.String()
+)
+
+func newHub(maxClients, maxTopics int) *live.Hub {
+	return live.NewHub(config.LiveConfig{
+		MaxClients: maxClients,
+		MaxTopics:  maxTopics,
+	})
+}
+
+func register(t *testing.T, hub *live.Hub, userID string) *live.Client {
+	t.Helper()
+
+	client, err := hub.Register(live.User{ID: userID, Name: "name of " + userID})
+	require.NoError(t, err)
+
+	return client
+}
+
+// received returns the messages already sent to the client.
+func received(t *testing.T, client *live.Client) []live.ServerMessage {
+	t.Helper()
+
+	var messages []live.ServerMessage
+	for {
+		select {
+		case data := <-client.Send():
+			var msg live.ServerMessage
+			require.NoError(t, json.Unmarshal(data, &msg))
+			messages = append(messages, msg)
+		default:
+			return messages
+		}
+	}
+}
+
+func userIDs(msg live.ServerMessage) []string {
+	var ids []string
+	for _, user := range msg.Users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func TestHub_Presence(t *testing.T) {
+	hub := newHub(10, 10)
+
+	alice := register(t, hub, "alice")
+	bob := register(t, hub, "bob")
+	bobAgain := register(t, hub, "bob")
+
+	require.NoError(t, hub.Subscribe(alice, []string{bookTopic}))
+
+	messages := received(t, alice)
+	require.Len(t, messages, 2)
+	assert.Equal(t, live.ServerMessage{Type: live.MessageSubscribed, Topics: []string{bookTopic}}, messages[0])
+	assert.Equal(t, live.MessagePresence, messages[1].Type)
+	assert.Equal(t, []string{"alice"}, userIDs(messages[1]))
+
+	require.NoError(t, hub.Subscribe(bob, []string{bookTopic}))
+	require.NoError(t, hub.Subscribe(bobAgain, []string{bookTopic}))
+
+	messages = received(t, alice)
+	require.Len(t, messages, 2)
+	assert.Equal(t, []string{"alice", "bob"}, userIDs(messages[1]))
+
+	hub.Unregister(bob)
+	hub.Unregister(bob)
+
+	// bob is still connected once
+	messages = received(t, alice)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"alice", "bob"}, userIDs(messages[0]))
+
+	hub.Unsubscribe(bobAgain, []string{bookTopic})
+
+	messages = received(t, alice)
+	require.Len(t, messages, 1)
+	assert.Equal(t, bookTopic, messages[0].Topic)
+	assert.Equal(t, []string{"alice"}, userIDs(messages[0]))
+}
+
+func TestHub_Publish(t *testing.T) {
+	hub := newHub(10, 10)
+
+	bookReader := register(t, hub, "alice")
+	authorReader := register(t, hub, "bob")
+	both := register(t, hub, "carol")
+	other := register(t, hub, "dave")
+
+	require.NoError(t, hub.Subscribe(bookReader, []string{bookTopic}))
+	require.NoError(t, hub.Subscribe(authorReader, []string{authorTopic}))
+	require.NoError(t, hub.Subscribe(both, []string{bookTopic, authorTopic}))
+	require.NoError(t, hub.Subscribe(other, []string{"book:" + uuid.NewString()}))
+	for _, client := range []*live.Client{bookReader, authorReader, both, other} {
+		received(t, client)
+	}
+
+	e := event.NewBookUpdated(&entity.Book{ID: bookID, Title: "Dune", AuthorID: &authorID})
+	require.NoError(t, hub.Publish(context.Background(), e))
+
+	// a client subscribed to the book and its author is notified once
+	expectedTopics := map[*live.Client]string{
+		bookReader:   bookTopic,
+		authorReader: authorTopic,
+		both:         bookTopic,
+	}
+
+	for client, topic := range expectedTopics {
+		messages := received(t, client)
+		require.Len(t, messages, 1)
+		assert.Equal(t, live.MessageEvent, messages[0].Type)
+		assert.Equal(t, topic, messages[0].Topic)
+		assert.Equal(t, e.ID, messages[0].Event.ID)
+		assert.Equal(t, event.BookUpdated, messages[0].Event.Type)
+	}
+
+	assert.Empty(t, received(t, other))
+}
+
+func TestHub_Limits(t *testing.T) {
+	t.Run("slow consumer", func(t *testing.T) {
+		hub := newHub(10, 10)
+		slow := register(t, hub, "alice")
+		require.NoError(t, hub.Subscribe(slow, []string{bookTopic}))
+
+		for range 100 {
+			require.NoError(t, hub.Publish(context.Background(), event.NewBookDeleted(bookID)))
+		}
+
+		<-slow.Done()
+		assert.Equal(t, live.ReasonSlowConsumer, slow.Reason())
+	})
+
+	t.Run("too many topics", func(t *testing.T) {
+		hub := newHub(10, 1)
+		client := register(t, hub, "alice")
+
+		assert.ErrorIs(t, hub.Subscribe(client, []string{bookTopic, authorTopic}), live.ErrTooManyTopics)
+		require.NoError(t, hub.Subscribe(client, []string{bookTopic, bookTopic}))
+		require.NoError(t, hub.Subscribe(client, []string{bookTopic}))
+	})
+
+	t.Run("too many clients", func(t *testing.T) {
+		hub := newHub(1, 10)
+		register(t, hub, "alice")
+
+		_, err := hub.Register(live.User{ID: "bob"})
+		assert.ErrorIs(t, err, live.ErrTooManyClients)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		hub := newHub(10, 10)
+		client := register(t, hub, "alice")
+
+		hub.Close()
+
+		<-client.Done()
+		assert.Equal(t, live.ReasonShutdown, client.Reason())
+
+		_, err := hub.Register(live.User{ID: "bob"})
+		assert.ErrorIs(t, err, live.ErrClosed)
+	})
+}
+
+func TestParseTopic(t *testing.T) {
+	topic, err := live.ParseTopic(bookTopic)
+	require.NoError(t, err)
+	assert.Equal(t, live.Topic{Aggregate: event.AggregateBook, ID: bookID}, topic)
+	assert.Equal(t, bookTopic, topic.String())
+
+	for _, value := range []string{"", "book", "book:abc", "loan:" + bookID.String()} {
+		_, err := live.ParseTopic(value)
+		assert.ErrorIs(t, err, live.ErrInvalidTopic, value)
+	}
+}
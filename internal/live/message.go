@@ -0,0 +1,98 @@
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+// Messages sent by the clients.
+const (
+	MessageSubscribe   = "subscribe"
+	MessageUnsubscribe = "unsubscribe"
+)
+
+// Messages sent to the clients.
+const (
+	MessageSubscribed   = "subscribed"
+	MessageUnsubscribed = "unsubscribed"
+	MessageEvent        = "event"
+	MessagePresence     = "presence"
+	MessageError        = "error"
+)
+
+// ClientMessage is a message received from a client. Topics are written
+// "<aggregate>:<id>", such as "book:aeca0955-bae4-47e9-9f85-6818dc68ca51".
+type ClientMessage struct {
+	Type   string   `json:"type"`
+	Topics []string `json:"topics,omitempty"`
+}
+
+// ServerMessage is a message sent to a client.
+type ServerMessage struct {
+	Type   string   `json:"type"`
+	Topic  string   `json:"topic,omitempty"`
+	Topics []string `json:"topics,omitempty"`
+	// Event is set on MessageEvent.
+	Event *event.Event `json:"event,omitempty"`
+	// Users lists the users subscribed to Topic on MessagePresence.
+	Users   []User `json:"users,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// User is a client shown in the presence of a topic, a user connected more
+// than once is only shown once.
+type User struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// Topic is the subject of a subscription, a book or an author.
+type Topic struct {
+	Aggregate string
+	ID        uuid.UUID
+}
+
+func (t Topic) String() string {
+	return t.Aggregate + ":" + t.ID.String()
+}
+
+// ParseTopic reads a topic written "<aggregate>:<id>".
+func ParseTopic(value string) (Topic, error) {
+	aggregate, rawID, ok := strings.Cut(value, ":")
+	if !ok || (aggregate != event.AggregateBook && aggregate != event.AggregateAuthor) {
+		return Topic{}, fmt.Errorf("%w: %q", ErrInvalidTopic, value)
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return Topic{}, fmt.Errorf("%w: %q", ErrInvalidTopic, value)
+	}
+
+	return Topic{Aggregate: aggregate, ID: id}, nil
+}
+
+// eventTopics returns the topics notified of e: its aggregate, and the author
+// of a book when the payload names it.
+func eventTopics(e event.Event, data []byte) []string {
+	topics := []string{Topic{Aggregate: e.AggregateType, ID: e.AggregateID}.String()}
+
+	if e.AggregateType != event.AggregateBook {
+		return topics
+	}
+
+	var book struct {
+		Payload struct {
+			AuthorID string `json:"author_id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &book); err != nil || book.Payload.AuthorID == "" {
+		return topics
+	}
+
+	return append(topics, event.AggregateAuthor+":"+book.Payload.AuthorID)
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CheckoutRequest lends a copy of a book to a member. Without barcode, the
+// first available copy of the book is lent.
+type CheckoutRequest struct {
+	BookID   string `json:"book_id" validate:"required,uuid_strict"`
+	MemberID string `json:"member_id" validate:"required,uuid_strict"`
+	Barcode  string `json:"barcode,omitempty" validate:"omitempty,trimmed,max=64"`
+}
+
+// LoanFilter narrows the listed loans. Empty fields are ignored.
+type LoanFilter struct {
+	MemberID string `json:"member_id" validate:"omitempty,uuid_strict"`
+	BookID   string `json:"book_id" validate:"omitempty,uuid_strict"`
+	Status   string `json:"status" validate:"omitempty,oneof=active returned"`
+}
+
+// NewLoanFilter reads the filter from the query string.
+func NewLoanFilter(query url.Values) LoanFilter {
+	return LoanFilter{
+		MemberID: strings.TrimSpace(query.Get("member_id")),
+		BookID:   strings.TrimSpace(query.Get("book_id")),
+		Status:   strings.TrimSpace(query.Get("status")),
+	}
+}
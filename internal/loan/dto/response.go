@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type LoanResponse struct {
+	ID         string                `json:"id"`
+	BookID     string                `json:"book_id"`
+	Book       *bookDto.BookResponse `json:"book,omitempty"`
+	CopyID     string                `json:"copy_id,omitempty"`
+	Barcode    string                `json:"barcode,omitempty"`
+	MemberID   string                `json:"member_id"`
+	Status     string                `json:"status" example:"active" enums:"active,returned"`
+	Overdue    bool                  `json:"overdue"`
+	BorrowedAt time.Time             `json:"borrowed_at"`
+	DueAt      time.Time             `json:"due_at"`
+	ReturnedAt *time.Time            `json:"returned_at,omitempty"`
+	Renewals   int                   `json:"renewals"`
+}
+
+// ToLoanResponse describes the loan as of now.
+func ToLoanResponse(loan *entity.Loan, now time.Time) *LoanResponse {
+	resp := &LoanResponse{
+		ID:         loan.ID.String(),
+		BookID:     loan.BookID.String(),
+		MemberID:   loan.MemberID.String(),
+		Status:     loan.Status(),
+		Overdue:    loan.Overdue(now),
+		BorrowedAt: loan.BorrowedAt,
+		DueAt:      loan.DueAt,
+		ReturnedAt: loan.ReturnedAt,
+		Renewals:   loan.Renewals,
+	}
+
+	if loan.Book != nil {
+		resp.Book = bookDto.ToBookResponse(loan.Book)
+	}
+
+	if loan.CopyID != nil {
+		resp.CopyID = loan.CopyID.String()
+	}
+
+	if loan.Copy != nil {
+		resp.Barcode = loan.Copy.Barcode
+	}
+
+	return resp
+}
+
+func ToLoansResponse(loans []*entity.Loan, now time.Time) []LoanResponse {
+	responses := make([]LoanResponse, len(loans))
+	for i, loan := range loans {
+		responses[i] = *ToLoanResponse(loan, now)
+	}
+	return responses
+}
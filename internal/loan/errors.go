@@ -0,0 +1,16 @@
+package loan
+
+import "errors"
+
+var (
+	ErrNotFound            = errors.New("loan not found")
+	ErrInvalidBookID       = errors.New("invalid book ID")
+	ErrInvalidMemberID     = errors.New("invalid member ID")
+	ErrBookUnavailable     = errors.New("no copy of the book is available")
+	ErrCopyUnavailable     = errors.New("copy is not available")
+	ErrCopyOfAnotherBook   = errors.New("copy is a copy of another book")
+	ErrHeldCopyExpected    = errors.New("member must borrow the copy set aside for their hold")
+	ErrAlreadyReturned     = errors.New("loan is already returned")
+	ErrRenewalLimitReached = errors.New("loan renewal limit reached")
+	ErrOverdue             = errors.New("loan is overdue")
+)
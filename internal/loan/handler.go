@@ -0,0 +1,324 @@
+package loan
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type LoanSuccessResponse struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Loan retrieved successfully"`
+	Loan    *dto.LoanResponse `json:"loan"`
+}
+
+type LoansSuccessResponse struct {
+	Status  string             `json:"status" example:"success"`
+	Message string             `json:"message" example:"Loans retrieved successfully"`
+	Loans   []dto.LoanResponse `json:"loans"`
+}
+
+type LoanHandler struct {
+	service   LoanService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewLoanHandler(service LoanService, validator *internalValidator.Validator, logger zerolog.Logger) *LoanHandler {
+	return &LoanHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *LoanHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// the members read and renew their own loans
+	r.Group(func(r chi.Router) {
+		r.Use(auth.Require)
+
+		r.Get("/", h.GetLoans)
+		r.Get("/{loan_id}", h.GetLoanByID)
+		r.Post("/{loan_id}/renew", h.RenewLoan)
+	})
+
+	// the copies are lent and taken back at the desk
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleLibrarian))
+
+		r.Post("/", h.CheckoutBook)
+		r.Post("/{loan_id}/return", h.ReturnLoan)
+	})
+
+	return r
+}
+
+// CheckoutBook godoc
+//
+//	@Summary		Check out a book
+//	@Description	Lend a copy of a book to a member for a loan period: the copy set aside for a hold of the member, or else either the copy with the barcode or the first available one left by the waiting holds. Members owing fines above the checkout limit cannot borrow. Restricted to the librarians.
+//	@Tags			loans
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			loan			body		dto.CheckoutRequest	true	"Loan data"
+//	@Param			Accept-Language	header		string				false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string				false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	LoanSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/loans [post]
+func (h *LoanHandler) CheckoutBook(w http.ResponseWriter, r *http.Request) {
+	var req dto.CheckoutRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	loan, err := h.service.CheckoutBook(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, LoanSuccessResponse{
+		Status:  "success",
+		Message: "Book checked out successfully",
+		Loan:    dto.ToLoanResponse(loan, time.Now()),
+	})
+}
+
+// GetLoans godoc
+//
+//	@Summary		Get loans
+//	@Description	Get the loans matching the filters, the latest first. Filter on a member to get their active and past loans. A member only gets their own loans, whose ID is the subject of the access token, the librarians get the loans of every member.
+//	@Tags			loans
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			member_id		query		string	false	"Only the loans of this member, the authenticated member by default"
+//	@Param			book_id			query		string	false	"Only the loans of this book"
+//	@Param			status			query		string	false	"Only the loans with this status"	Enums(active, returned)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	LoansSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/loans [get]
+func (h *LoanHandler) GetLoans(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewLoanFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	memberID, err := auth.ScopeSubject(r.Context(), filter.MemberID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	filter.MemberID = memberID
+
+	loans, err := h.service.GetLoans(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, LoansSuccessResponse{
+		Status:  "success",
+		Message: "Loans retrieved successfully",
+		Loans:   dto.ToLoansResponse(loans, time.Now()),
+	})
+}
+
+// GetLoanByID godoc
+//
+//	@Summary		Get loan by id
+//	@Description	Get a single loan by its ID, a member only gets their own loans
+//	@Tags			loans
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			loan_id	path		string	true	"Loan ID"
+//	@Success		200		{object}	LoanSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/loans/{loan_id} [get]
+func (h *LoanHandler) GetLoanByID(w http.ResponseWriter, r *http.Request) {
+	loanID, err := uuid.Parse(chi.URLParam(r, "loan_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	loan, err := h.service.GetLoanByID(r.Context(), loanID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), loan.MemberID.String()); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, LoanSuccessResponse{
+		Status:  "success",
+		Message: "Loan retrieved successfully",
+		Loan:    dto.ToLoanResponse(loan, time.Now()),
+	})
+}
+
+// RenewLoan godoc
+//
+//	@Summary		Renew a loan
+//	@Description	Extend the due date of an active loan by a loan period, up to the renewal limit. Overdue loans cannot be renewed. A member only renews their own loans.
+//	@Tags			loans
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			loan_id	path		string	true	"Loan ID"
+//	@Success		200		{object}	LoanSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/loans/{loan_id}/renew [post]
+func (h *LoanHandler) RenewLoan(w http.ResponseWriter, r *http.Request) {
+	loanID, err := uuid.Parse(chi.URLParam(r, "loan_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	loan, err := h.service.GetLoanByID(r.Context(), loanID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), loan.MemberID.String()); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	loan, err = h.service.RenewLoan(r.Context(), loanID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, LoanSuccessResponse{
+		Status:  "success",
+		Message: "Loan renewed successfully",
+		Loan:    dto.ToLoanResponse(loan, time.Now()),
+	})
+}
+
+// ReturnLoan godoc
+//
+//	@Summary		Return a loan
+//	@Description	Return the book of an active loan, its copy goes to the next hold or back on the shelf. A late return is charged its fine. Restricted to the librarians.
+//	@Tags			loans
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			loan_id	path		string	true	"Loan ID"
+//	@Success		200		{object}	LoanSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		401		{object}	response.ErrorResponse
+//	@Failure		403		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		409		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/loans/{loan_id}/return [post]
+func (h *LoanHandler) ReturnLoan(w http.ResponseWriter, r *http.Request) {
+	loanID, err := uuid.Parse(chi.URLParam(r, "loan_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	loan, err := h.service.ReturnLoan(r.Context(), loanID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, LoanSuccessResponse{
+		Status:  "success",
+		Message: "Book returned successfully",
+		Loan:    dto.ToLoanResponse(loan, time.Now()),
+	})
+}
+
+func (h *LoanHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Loan not found")
+	case errors.Is(err, book.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, inventory.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Copy not found")
+	case errors.Is(err, member.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Member not found")
+	case errors.Is(err, ErrInvalidBookID):
+		response.Error(w, http.StatusBadRequest, "invalid book ID")
+	case errors.Is(err, ErrInvalidMemberID):
+		response.Error(w, http.StatusBadRequest, "invalid member ID")
+	case errors.Is(err, ErrBookUnavailable):
+		response.Error(w, http.StatusConflict, "No copy of this book is available")
+	case errors.Is(err, ErrCopyUnavailable):
+		response.Error(w, http.StatusConflict, "Copy is not available")
+	case errors.Is(err, ErrCopyOfAnotherBook):
+		response.Error(w, http.StatusBadRequest, "Copy is a copy of another book")
+	case errors.Is(err, fine.ErrMemberBlocked):
+		response.Error(w, http.StatusConflict, "Member owes fines above the checkout limit")
+	case errors.Is(err, ErrHeldCopyExpected):
+		response.Error(w, http.StatusConflict, "Member has another copy of this book on hold")
+	case errors.Is(err, ErrAlreadyReturned):
+		response.Error(w, http.StatusConflict, "Loan is already returned")
+	case errors.Is(err, ErrOverdue):
+		response.Error(w, http.StatusConflict, "Overdue loans cannot be renewed")
+	case errors.Is(err, ErrRenewalLimitReached):
+		response.Error(w, http.StatusConflict, "Loan renewal limit reached")
+	case errors.Is(err, auth.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "Forbidden")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,650 @@
+package loan_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/loan"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestLoanHandler_CheckoutBook(t *testing.T) {
+	borrowedAt := time.Now().UTC().Truncate(time.Second)
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        interface{}
+		configureMock      func(*mocks.MockLoanService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success checkout book",
+			identity: librarian,
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockLoanService) {
+				input := &dto.CheckoutRequest{
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+				}
+
+				mockService.EXPECT().
+					CheckoutBook(gomock.Any(), input).
+					Return(&entity.Loan{
+						ID:         loanID,
+						BookID:     bookID,
+						MemberID:   memberID,
+						BorrowedAt: borrowedAt,
+						DueAt:      borrowedAt.Add(21 * 24 * time.Hour),
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &loan.LoanSuccessResponse{
+				Status:  "success",
+				Message: "Book checked out successfully",
+				Loan: &dto.LoanResponse{
+					ID:         loanID.String(),
+					BookID:     bookID.String(),
+					MemberID:   memberID.String(),
+					Status:     entity.LoanStatusActive,
+					BorrowedAt: borrowedAt,
+					DueAt:      borrowedAt.Add(21 * 24 * time.Hour),
+				},
+			},
+		},
+		{
+			name: "error anonymous caller",
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:     "error member checking out a book",
+			identity: &auth.Identity{Subject: memberID.String()},
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:     "error validation fails invalid book id",
+			identity: librarian,
+			requestBody: dto.CheckoutRequest{
+				BookID:   "not-a-uuid",
+				MemberID: memberID.String(),
+			},
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "book_id",
+					Message: "book_id must be a lowercase UUID",
+				}},
+			},
+		},
+		{
+			name:     "error no copy available",
+			identity: librarian,
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					CheckoutBook(gomock.Any(), gomock.Any()).
+					Return(nil, loan.ErrBookUnavailable)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "No copy of this book is available",
+			},
+		},
+		{
+			name:     "error fines above the checkout limit",
+			identity: librarian,
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					CheckoutBook(gomock.Any(), gomock.Any()).
+					Return(nil, fine.ErrMemberBlocked)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Member owes fines above the checkout limit",
+			},
+		},
+		{
+			name:     "error book not found",
+			identity: librarian,
+			requestBody: dto.CheckoutRequest{
+				BookID:   bookID.String(),
+				MemberID: memberID.String(),
+			},
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					CheckoutBook(gomock.Any(), gomock.Any()).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Book not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockLoanService(ctrl)
+			test.configureMock(mockService)
+
+			handler := loan.NewLoanHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/loans", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/loans", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestLoanHandler_GetLoans(t *testing.T) {
+	dueAt := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	borrower := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		query              string
+		configureMock      func(*mocks.MockLoanService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success filters on the member",
+			identity: borrower,
+			query:    "member_id=" + memberID.String() + "&status=active",
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoans(gomock.Any(), dto.LoanFilter{MemberID: memberID.String(), Status: entity.LoanStatusActive}).
+					Return([]*entity.Loan{{ID: loanID, BookID: bookID, MemberID: memberID, DueAt: dueAt}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &loan.LoansSuccessResponse{
+				Status:  "success",
+				Message: "Loans retrieved successfully",
+				Loans: []dto.LoanResponse{{
+					ID:       loanID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Status:   entity.LoanStatusActive,
+					Overdue:  true,
+					DueAt:    dueAt,
+				}},
+			},
+		},
+		{
+			name:     "success member lists their own loans",
+			identity: borrower,
+			query:    "",
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoans(gomock.Any(), dto.LoanFilter{MemberID: memberID.String()}).
+					Return([]*entity.Loan{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &loan.LoansSuccessResponse{
+				Status:  "success",
+				Message: "Loans retrieved successfully",
+				Loans:   []dto.LoanResponse{},
+			},
+		},
+		{
+			name:     "success librarian lists every loan",
+			identity: &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			query:    "",
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoans(gomock.Any(), dto.LoanFilter{}).
+					Return([]*entity.Loan{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &loan.LoansSuccessResponse{
+				Status:  "success",
+				Message: "Loans retrieved successfully",
+				Loans:   []dto.LoanResponse{},
+			},
+		},
+		{
+			name:               "error loans of another member",
+			identity:           &auth.Identity{Subject: otherMemberID.String()},
+			query:              "member_id=" + memberID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:               "error anonymous caller",
+			query:              "member_id=" + memberID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:               "error invalid status",
+			identity:           borrower,
+			query:              "status=lost",
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "status",
+					Message: "status must be one of [active returned]",
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockLoanService(ctrl)
+			test.configureMock(mockService)
+
+			handler := loan.NewLoanHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/loans?"+test.query, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/loans", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestLoanHandler_GetLoanByID(t *testing.T) {
+	dueAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockLoanService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:         "success get own loan",
+			identity:     &auth.Identity{Subject: memberID.String()},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, BookID: bookID, MemberID: memberID, DueAt: dueAt}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &loan.LoanSuccessResponse{
+				Status:  "success",
+				Message: "Loan retrieved successfully",
+				Loan: &dto.LoanResponse{
+					ID:       loanID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Status:   entity.LoanStatusActive,
+					DueAt:    dueAt,
+				},
+			},
+		},
+		{
+			name:         "error loan of another member",
+			identity:     &auth.Identity{Subject: otherMemberID.String()},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, BookID: bookID, MemberID: memberID, DueAt: dueAt}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       loanID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockLoanService(ctrl)
+			test.configureMock(mockService)
+
+			handler := loan.NewLoanHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/loans/"+test.idInUrlParam, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/loans", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestLoanHandler_RenewLoan(t *testing.T) {
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockLoanService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:         "success renew own loan",
+			identity:     &auth.Identity{Subject: memberID.String()},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID}, nil)
+				mockService.EXPECT().
+					RenewLoan(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID, Renewals: 1}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Loan renewed successfully",
+		},
+		{
+			name:         "success librarian renews the loan of a member",
+			identity:     &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID}, nil)
+				mockService.EXPECT().
+					RenewLoan(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID, Renewals: 1}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Loan renewed successfully",
+		},
+		{
+			name:         "error loan of another member",
+			identity:     &auth.Identity{Subject: otherMemberID.String()},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    "Forbidden",
+		},
+		{
+			name:         "error renewal limit reached",
+			identity:     &auth.Identity{Subject: memberID.String()},
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					GetLoanByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, MemberID: memberID}, nil)
+				mockService.EXPECT().
+					RenewLoan(gomock.Any(), loanID).
+					Return(nil, loan.ErrRenewalLimitReached)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Loan renewal limit reached",
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       loanID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    "Unauthorized",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockLoanService(ctrl)
+			test.configureMock(mockService)
+
+			handler := loan.NewLoanHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, "/loans/"+test.idInUrlParam+"/renew", nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/loans", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
+
+func TestLoanHandler_ReturnLoan(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockLoanService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:         "success return loan",
+			identity:     librarian,
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				returnedAt := time.Now().UTC()
+				mockService.EXPECT().
+					ReturnLoan(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, ReturnedAt: &returnedAt}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Book returned successfully",
+		},
+		{
+			name:               "error member returning their own loan",
+			identity:           &auth.Identity{Subject: memberID.String()},
+			idInUrlParam:       loanID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    "Forbidden",
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       loanID.String(),
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    "Unauthorized",
+		},
+		{
+			name:               "error invalid uuid",
+			identity:           librarian,
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockLoanService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Invalid uuid",
+		},
+		{
+			name:         "error loan not found",
+			identity:     librarian,
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					ReturnLoan(gomock.Any(), loanID).
+					Return(nil, loan.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Loan not found",
+		},
+		{
+			name:         "error already returned",
+			identity:     librarian,
+			idInUrlParam: loanID.String(),
+			configureMock: func(mockService *mocks.MockLoanService) {
+				mockService.EXPECT().
+					ReturnLoan(gomock.Any(), loanID).
+					Return(nil, loan.ErrAlreadyReturned)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Loan is already returned",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockLoanService(ctrl)
+			test.configureMock(mockService)
+
+			handler := loan.NewLoanHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, "/loans/"+test.idInUrlParam+"/return", nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/loans", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package loan
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_loan_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/loan LoanRepository
+type LoanRepository interface {
+	Create(ctx context.Context, loan *entity.Loan) (*entity.Loan, error)
+	GetAll(ctx context.Context, filter dto.LoanFilter) ([]*entity.Loan, error)
+	GetByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+	LockByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+	Update(ctx context.Context, loan *entity.Loan) (*entity.Loan, error)
+}
+
+type loanRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewLoanRepository(db *gorm.DB, logger zerolog.Logger) LoanRepository {
+	return &loanRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *loanRepository) Create(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(loan).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// GetAll returns the filtered loans, the latest first.
+func (r *loanRepository) GetAll(ctx context.Context, filter dto.LoanFilter) ([]*entity.Loan, error) {
+	var loans []*entity.Loan
+
+	query := transaction.DB(ctx, r.db).Preload("Book.Author").Preload("Copy")
+
+	if filter.MemberID != "" {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+
+	if filter.BookID != "" {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+
+	switch filter.Status {
+	case entity.LoanStatusActive:
+		query = query.Where("returned_at IS NULL")
+	case entity.LoanStatusReturned:
+		query = query.Where("returned_at IS NOT NULL")
+	}
+
+	if err := query.Order("borrowed_at DESC").Find(&loans).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (r *loanRepository) GetByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	var loan *entity.Loan
+
+	if err := transaction.DB(ctx, r.db).Preload("Book.Author").Preload("Copy").First(&loan, "id = ?", loanID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// LockByID reads the loan and locks its row until the end of the transaction
+// carried by ctx.
+func (r *loanRepository) LockByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	var loan *entity.Loan
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Preload("Book.Author").Preload("Copy").
+		First(&loan, "id = ?", loanID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (r *loanRepository) Update(ctx context.Context, loan *entity.Loan) (*entity.Loan, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(loan).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return loan, nil
+}
@@ -0,0 +1,188 @@
+package loan_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/loan"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var loanColumns = []string{"id", "book_id", "copy_id", "member_id", "borrowed_at", "due_at", "returned_at", "renewals", "created_at", "updated_at"}
+
+func TestLoanRepository_GetAll(t *testing.T) {
+	borrowedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	returnedAt := borrowedAt.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name             string
+		filter           dto.LoanFilter
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedStatuses []string
+	}{
+		{
+			name:   "success active loans of a member",
+			filter: dto.LoanFilter{MemberID: memberID.String(), Status: entity.LoanStatusActive},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE member_id = \? AND returned_at IS NULL ORDER BY borrowed_at DESC`).
+					WithArgs(memberID.String()).
+					WillReturnRows(sqlmock.NewRows(loanColumns).
+						AddRow(loanID, bookID, copyID, memberID, borrowedAt, borrowedAt.Add(21*24*time.Hour), nil, 0, borrowedAt, borrowedAt))
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE .books.\..id. = \?`).
+					WithArgs(bookID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id"}).
+						AddRow(bookID, "Les Misérables", nil))
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE .copies.\..id. = \?`).
+					WithArgs(copyID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "barcode"}).
+						AddRow(copyID, bookID, "LM-0001"))
+			},
+			expectedStatuses: []string{entity.LoanStatusActive},
+		},
+		{
+			name:   "success returned loans of a book",
+			filter: dto.LoanFilter{BookID: bookID.String(), Status: entity.LoanStatusReturned},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE book_id = \? AND returned_at IS NOT NULL ORDER BY borrowed_at DESC`).
+					WithArgs(bookID.String()).
+					WillReturnRows(sqlmock.NewRows(loanColumns).
+						AddRow(loanID, bookID, nil, memberID, borrowedAt, borrowedAt.Add(21*24*time.Hour), returnedAt, 1, borrowedAt, returnedAt))
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE .books.\..id. = \?`).
+					WithArgs(bookID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id"}).
+						AddRow(bookID, "Les Misérables", nil))
+			},
+			expectedStatuses: []string{entity.LoanStatusReturned},
+		},
+		{
+			name:   "success no loan",
+			filter: dto.LoanFilter{},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. ORDER BY borrowed_at DESC`).
+					WillReturnRows(sqlmock.NewRows(loanColumns))
+			},
+			expectedStatuses: []string{},
+		},
+		{
+			name:   "error database connection failed",
+			filter: dto.LoanFilter{Status: entity.LoanStatusActive},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE returned_at IS NULL ORDER BY borrowed_at DESC`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := loan.NewLoanRepository(db, zerolog.Nop())
+
+			loans, err := repo.GetAll(context.Background(), test.filter)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, loans)
+			} else {
+				require.NoError(t, err)
+
+				statuses := make([]string, len(loans))
+				for i, l := range loans {
+					statuses[i] = l.Status()
+					require.NotNil(t, l.Book)
+				}
+				assert.Equal(t, test.expectedStatuses, statuses)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLoanRepository_LockByID(t *testing.T) {
+	borrowedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "success lock loan with its book and copy",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE id = \? ORDER BY .loans.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(loanID, 1).
+					WillReturnRows(sqlmock.NewRows(loanColumns).
+						AddRow(loanID, bookID, copyID, memberID, borrowedAt, borrowedAt.Add(21*24*time.Hour), nil, 0, borrowedAt, borrowedAt))
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE .books.\..id. = \?`).
+					WithArgs(bookID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id"}).
+						AddRow(bookID, "Les Misérables", nil))
+
+				mock.ExpectQuery(`SELECT \* FROM .copies. WHERE .copies.\..id. = \?`).
+					WithArgs(copyID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "book_id", "barcode"}).
+						AddRow(copyID, bookID, "LM-0001"))
+			},
+		},
+		{
+			name: "error loan not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE id = \? ORDER BY .loans.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(loanID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: loan.ErrNotFound,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .loans. WHERE id = \? ORDER BY .loans.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(loanID, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := loan.NewLoanRepository(db, zerolog.Nop())
+
+			locked, err := repo.LockByID(context.Background(), loanID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, locked)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, loanID, locked.ID)
+				require.NotNil(t, locked.Book)
+				require.NotNil(t, locked.Copy)
+				assert.Equal(t, "LM-0001", locked.Copy.Barcode)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
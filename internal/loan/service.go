@@ -0,0 +1,275 @@
+package loan
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/hold"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_loan_service.go -package=mocks go-boilerplate-rest-api-chi/internal/loan LoanService
+type LoanService interface {
+	// CheckoutBook lends a copy of the book to the member for a loan period:
+	// the copy set aside for a hold of the member, or else the copy with the
+	// requested barcode or the first available one. Members owing fines above
+	// the block threshold cannot borrow.
+	CheckoutBook(ctx context.Context, req *dto.CheckoutRequest) (*entity.Loan, error)
+	GetLoans(ctx context.Context, filter dto.LoanFilter) ([]*entity.Loan, error)
+	GetLoanByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+	// RenewLoan extends the due date by a loan period, up to the renewal
+	// limit. Overdue loans must be returned instead.
+	RenewLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+	// ReturnLoan ends the loan and charges the fine of a late return.
+	ReturnLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error)
+}
+
+type loanService struct {
+	repository       LoanRepository
+	bookRepository   book.BookRepository
+	copyRepository   inventory.CopyRepository
+	memberRepository member.MemberRepository
+	queue            hold.Queue
+	fines            fine.Ledger
+	transactions     transaction.Manager
+	outbox           outbox.Outbox
+	period           time.Duration
+	maxRenewals      int
+	logger           zerolog.Logger
+}
+
+func NewLoanService(repository LoanRepository, bookRepository book.BookRepository, copyRepository inventory.CopyRepository, memberRepository member.MemberRepository, queue hold.Queue, fines fine.Ledger, transactions transaction.Manager, outbox outbox.Outbox, cfg config.LoanConfig, logger zerolog.Logger) LoanService {
+	return &loanService{
+		repository:       repository,
+		bookRepository:   bookRepository,
+		copyRepository:   copyRepository,
+		memberRepository: memberRepository,
+		queue:            queue,
+		fines:            fines,
+		transactions:     transactions,
+		outbox:           outbox,
+		period:           time.Duration(cfg.PeriodDays) * 24 * time.Hour,
+		maxRenewals:      cfg.MaxRenewals,
+		logger:           logger,
+	}
+}
+
+func (s *loanService) CheckoutBook(ctx context.Context, req *dto.CheckoutRequest) (*entity.Loan, error) {
+	bookID, err := uuid.Parse(req.BookID)
+	if err != nil {
+		return nil, ErrInvalidBookID
+	}
+
+	memberID, err := uuid.Parse(req.MemberID)
+	if err != nil {
+		return nil, ErrInvalidMemberID
+	}
+
+	var loan *entity.Loan
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		// the member stays locked until the loan is committed, a payment cannot
+		// be recorded in between
+		if _, err := s.memberRepository.LockByID(ctx, memberID); err != nil {
+			return err
+		}
+
+		if err := s.fines.CheckStanding(ctx, memberID); err != nil {
+			return err
+		}
+
+		// the book stays locked until the loan is committed, so that two
+		// checkouts of the same book cannot both pick the same copy
+		loanedBook, err := s.bookRepository.LockByID(ctx, bookID)
+		if err != nil {
+			return err
+		}
+
+		loanedCopy, err := s.lockCopy(ctx, bookID, memberID, req.Barcode)
+		if err != nil {
+			return err
+		}
+
+		loanedCopy.Status = entity.CopyStatusOnLoan
+		if _, err := s.copyRepository.Update(ctx, loanedCopy); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+
+		loan, err = s.repository.Create(ctx, &entity.Loan{
+			BookID:     bookID,
+			CopyID:     &loanedCopy.ID,
+			MemberID:   memberID,
+			BorrowedAt: now,
+			DueAt:      now.Add(s.period),
+		})
+		if err != nil {
+			return err
+		}
+
+		loan.Book = loanedBook
+		loan.Copy = loanedCopy
+		return s.outbox.Record(ctx, event.NewLoanCreated(loan))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// lockCopy locks the copy of the book to lend. The waiting holds are served
+// first, the copies they leave are lent on a first come first served basis.
+func (s *loanService) lockCopy(ctx context.Context, bookID uuid.UUID, memberID uuid.UUID, barcode string) (*entity.Copy, error) {
+	if _, err := s.queue.Advance(ctx, bookID); err != nil {
+		return nil, err
+	}
+
+	heldCopy, err := s.queue.Claim(ctx, bookID, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if heldCopy != nil {
+		if barcode != "" && barcode != heldCopy.Barcode {
+			return nil, ErrHeldCopyExpected
+		}
+		return heldCopy, nil
+	}
+
+	if barcode == "" {
+		loanedCopy, err := s.copyRepository.LockAvailableByBookID(ctx, bookID)
+		if errors.Is(err, inventory.ErrNotFound) {
+			return nil, ErrBookUnavailable
+		}
+		return loanedCopy, err
+	}
+
+	loanedCopy, err := s.copyRepository.LockByBarcode(ctx, barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case loanedCopy.BookID != bookID:
+		return nil, ErrCopyOfAnotherBook
+	case loanedCopy.Status != entity.CopyStatusAvailable:
+		return nil, ErrCopyUnavailable
+	}
+
+	return loanedCopy, nil
+}
+
+func (s *loanService) GetLoans(ctx context.Context, filter dto.LoanFilter) ([]*entity.Loan, error) {
+	return s.repository.GetAll(ctx, filter)
+}
+
+func (s *loanService) GetLoanByID(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	return s.repository.GetByID(ctx, loanID)
+}
+
+func (s *loanService) RenewLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	var loan *entity.Loan
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		loan, err = s.repository.LockByID(ctx, loanID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case loan.ReturnedAt != nil:
+			return ErrAlreadyReturned
+		case loan.Overdue(time.Now()):
+			return ErrOverdue
+		case loan.Renewals >= s.maxRenewals:
+			return ErrRenewalLimitReached
+		}
+
+		loan.DueAt = loan.DueAt.Add(s.period)
+		loan.Renewals++
+
+		if loan, err = s.repository.Update(ctx, loan); err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewLoanRenewed(loan))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (s *loanService) ReturnLoan(ctx context.Context, loanID uuid.UUID) (*entity.Loan, error) {
+	var loan *entity.Loan
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		loan, err = s.repository.LockByID(ctx, loanID)
+		if err != nil {
+			return err
+		}
+
+		if loan.ReturnedAt != nil {
+			return ErrAlreadyReturned
+		}
+
+		returnedAt := time.Now().UTC()
+		loan.ReturnedAt = &returnedAt
+
+		if loan, err = s.repository.Update(ctx, loan); err != nil {
+			return err
+		}
+
+		if loan.CopyID != nil {
+			// the book is locked before the copy, like in a checkout, since
+			// the copy goes back to the hold queue of the book
+			if _, err := s.bookRepository.LockByID(ctx, loan.BookID); err != nil {
+				return err
+			}
+
+			if err := s.shelveCopy(ctx, *loan.CopyID); err != nil {
+				return err
+			}
+		}
+
+		if _, err := s.fines.Accrue(ctx, loan); err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewLoanReturned(loan))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+// shelveCopy gives the returned copy to the next hold of its book, or makes
+// it available again.
+func (s *loanService) shelveCopy(ctx context.Context, copyID uuid.UUID) error {
+	returnedCopy, err := s.copyRepository.LockByID(ctx, copyID)
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Release(ctx, returnedCopy)
+}
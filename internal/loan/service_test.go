@@ -0,0 +1,492 @@
+package loan_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/fine"
+	"go-boilerplate-rest-api-chi/internal/inventory"
+	"go-boilerplate-rest-api-chi/internal/loan"
+	"go-boilerplate-rest-api-chi/internal/loan/dto"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	bookID        = uuid.MustParse("d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f")
+	memberID      = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	otherMemberID = uuid.MustParse("61c8f3d2-7a4e-4b9f-a2c5-e8d1b6f0a347")
+	loanID        = uuid.MustParse("0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b")
+	copyID        = uuid.MustParse("5c1f0e7a-2b9d-4c3e-8f6a-7d4b2a1e9c08")
+)
+
+var loanConfig = config.LoanConfig{PeriodDays: 21, MaxRenewals: 2}
+
+func TestLoanService_CheckoutBook(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.CheckoutRequest
+		configureMock func(*mocks.MockLoanRepository, *mocks.MockBookRepository, *mocks.MockCopyRepository, *mocks.MockMemberRepository, *mocks.MockQueue, *mocks.MockLedger, *mocks.MockOutbox)
+		expectedCopy  *entity.Copy
+		expectedError error
+	}{
+		{
+			name:  "success checkout book",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID, Title: "1984"}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().Claim(gomock.Any(), bookID, memberID).Return(nil, nil)
+
+				mockCopyRepo.EXPECT().
+					LockAvailableByBookID(gomock.Any(), bookID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusAvailable}, nil)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) {
+						assert.Equal(t, entity.CopyStatusOnLoan, c.Status)
+						return c, nil
+					})
+
+				mockLoanRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) {
+						l.ID = loanID
+						return l, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.LoanCreated, events[0].Type)
+						assert.Equal(t, loanID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedCopy: &entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnLoan},
+		},
+		{
+			name:  "success checkout copy by barcode",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String(), Barcode: "LIB-0001"},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().Claim(gomock.Any(), bookID, memberID).Return(nil, nil)
+
+				mockCopyRepo.EXPECT().
+					LockByBarcode(gomock.Any(), "LIB-0001").
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusAvailable}, nil)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+
+				mockLoanRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) {
+						l.ID = loanID
+						return l, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedCopy: &entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnLoan},
+		},
+		{
+			name:  "success checkout held copy",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().
+					Claim(gomock.Any(), bookID, memberID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnHold}, nil)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+
+				mockLoanRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) {
+						l.ID = loanID
+						return l, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedCopy: &entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnLoan},
+		},
+		{
+			name:  "success checkout held copy by its barcode",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String(), Barcode: "LIB-0001"},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().
+					Claim(gomock.Any(), bookID, memberID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnHold}, nil)
+
+				mockCopyRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) (*entity.Copy, error) { return c, nil })
+
+				mockLoanRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) {
+						l.ID = loanID
+						return l, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			expectedCopy: &entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnLoan},
+		},
+		{
+			name:  "error held copy expected",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String(), Barcode: "LIB-0002"},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().
+					Claim(gomock.Any(), bookID, memberID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Barcode: "LIB-0001", Status: entity.CopyStatusOnHold}, nil)
+			},
+			expectedError: loan.ErrHeldCopyExpected,
+		},
+		{
+			name:  "error copy of another book",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String(), Barcode: "LIB-0001"},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().Claim(gomock.Any(), bookID, memberID).Return(nil, nil)
+
+				mockCopyRepo.EXPECT().
+					LockByBarcode(gomock.Any(), "LIB-0001").
+					Return(&entity.Copy{ID: copyID, BookID: uuid.New(), Status: entity.CopyStatusAvailable}, nil)
+			},
+			expectedError: loan.ErrCopyOfAnotherBook,
+		},
+		{
+			name:  "error copy in repair",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String(), Barcode: "LIB-0001"},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().Claim(gomock.Any(), bookID, memberID).Return(nil, nil)
+
+				mockCopyRepo.EXPECT().
+					LockByBarcode(gomock.Any(), "LIB-0001").
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusInRepair}, nil)
+			},
+			expectedError: loan.ErrCopyUnavailable,
+		},
+		{
+			name:  "error no copy available",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+				mockQueue.EXPECT().Advance(gomock.Any(), bookID).Return(0, nil)
+				mockQueue.EXPECT().Claim(gomock.Any(), bookID, memberID).Return(nil, nil)
+
+				mockCopyRepo.EXPECT().
+					LockAvailableByBookID(gomock.Any(), bookID).
+					Return(nil, inventory.ErrNotFound)
+			},
+			expectedError: loan.ErrBookUnavailable,
+		},
+		{
+			name:  "error fines above the block threshold",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(fine.ErrMemberBlocked)
+			},
+			expectedError: fine.ErrMemberBlocked,
+		},
+		{
+			name:  "error member not found",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(nil, member.ErrNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+		{
+			name:  "error book not found",
+			input: &dto.CheckoutRequest{BookID: bookID.String(), MemberID: memberID.String()},
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockMemberRepo *mocks.MockMemberRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockLedger.EXPECT().CheckStanding(gomock.Any(), memberID).Return(nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(nil, book.ErrNotFound)
+			},
+			expectedError: book.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			loanRepoMock := mocks.NewMockLoanRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+			ledgerMock := mocks.NewMockLedger(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(loanRepoMock, bookRepoMock, copyRepoMock, memberRepoMock, queueMock, ledgerMock, outboxMock)
+			service := loan.NewLoanService(loanRepoMock, bookRepoMock, copyRepoMock, memberRepoMock, queueMock, ledgerMock, testutils.NewTransactionManager(ctrl), outboxMock, loanConfig, zerolog.Nop())
+
+			before := time.Now()
+			result, err := service.CheckoutBook(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, loanID, result.ID)
+			assert.Equal(t, bookID, result.BookID)
+			assert.Equal(t, memberID, result.MemberID)
+			assert.Equal(t, &copyID, result.CopyID)
+			assert.Equal(t, test.expectedCopy, result.Copy)
+			assert.WithinRange(t, result.BorrowedAt, before.Add(-time.Second), time.Now())
+			assert.Equal(t, result.BorrowedAt.Add(21*24*time.Hour), result.DueAt)
+			assert.Nil(t, result.ReturnedAt)
+		})
+	}
+}
+
+func TestLoanService_RenewLoan(t *testing.T) {
+	now := time.Now().UTC()
+	returnedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockLoanRepository, *mocks.MockOutbox)
+		expectedResponse *entity.Loan
+		expectedError    error
+	}{
+		{
+			name: "success renew loan",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, DueAt: now.Add(24 * time.Hour), Renewals: 1}, nil)
+
+				mockLoanRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) { return l, nil })
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.LoanRenewed, events[0].Type)
+						assert.Equal(t, loanID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.Loan{ID: loanID, DueAt: now.Add(22 * 24 * time.Hour), Renewals: 2},
+		},
+		{
+			name: "error renewal limit reached",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, DueAt: now.Add(24 * time.Hour), Renewals: 2}, nil)
+			},
+			expectedError: loan.ErrRenewalLimitReached,
+		},
+		{
+			name: "error overdue",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, DueAt: now.Add(-time.Minute)}, nil)
+			},
+			expectedError: loan.ErrOverdue,
+		},
+		{
+			name: "error already returned",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, DueAt: now, ReturnedAt: &returnedAt}, nil)
+			},
+			expectedError: loan.ErrAlreadyReturned,
+		},
+		{
+			name: "error loan not found",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(nil, loan.ErrNotFound)
+			},
+			expectedError: loan.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			loanRepoMock := mocks.NewMockLoanRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(loanRepoMock, outboxMock)
+			service := loan.NewLoanService(loanRepoMock, mocks.NewMockBookRepository(ctrl), mocks.NewMockCopyRepository(ctrl), mocks.NewMockMemberRepository(ctrl), mocks.NewMockQueue(ctrl), mocks.NewMockLedger(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, loanConfig, zerolog.Nop())
+
+			result, err := service.RenewLoan(context.Background(), loanID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestLoanService_ReturnLoan(t *testing.T) {
+	returnedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockLoanRepository, *mocks.MockBookRepository, *mocks.MockCopyRepository, *mocks.MockQueue, *mocks.MockLedger, *mocks.MockOutbox)
+		expectedError error
+	}{
+		{
+			name: "success return loan",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, BookID: bookID, CopyID: &copyID, DueAt: time.Now().Add(-time.Hour)}, nil)
+
+				mockLoanRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.Loan, error) { return l, nil })
+
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockCopyRepo.EXPECT().
+					LockByID(gomock.Any(), copyID).
+					Return(&entity.Copy{ID: copyID, BookID: bookID, Status: entity.CopyStatusOnLoan}, nil)
+
+				mockQueue.EXPECT().
+					Release(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, c *entity.Copy) error {
+						assert.Equal(t, copyID, c.ID)
+						return nil
+					})
+
+				mockLedger.EXPECT().
+					Accrue(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, l *entity.Loan) (*entity.FineEntry, error) {
+						assert.NotNil(t, l.ReturnedAt, "the fine runs up to the return")
+						return &entity.FineEntry{LoanID: &l.ID, Kind: entity.FineEntryCharge}, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.LoanReturned, events[0].Type)
+						assert.Equal(t, loanID, events[0].AggregateID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "error already returned",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(&entity.Loan{ID: loanID, ReturnedAt: &returnedAt}, nil)
+			},
+			expectedError: loan.ErrAlreadyReturned,
+		},
+		{
+			name: "error loan not found",
+			configureMock: func(mockLoanRepo *mocks.MockLoanRepository, mockBookRepo *mocks.MockBookRepository, mockCopyRepo *mocks.MockCopyRepository, mockQueue *mocks.MockQueue, mockLedger *mocks.MockLedger, mockOutbox *mocks.MockOutbox) {
+				mockLoanRepo.EXPECT().
+					LockByID(gomock.Any(), loanID).
+					Return(nil, loan.ErrNotFound)
+			},
+			expectedError: loan.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			loanRepoMock := mocks.NewMockLoanRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			copyRepoMock := mocks.NewMockCopyRepository(ctrl)
+			queueMock := mocks.NewMockQueue(ctrl)
+			ledgerMock := mocks.NewMockLedger(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(loanRepoMock, bookRepoMock, copyRepoMock, queueMock, ledgerMock, outboxMock)
+			service := loan.NewLoanService(loanRepoMock, bookRepoMock, copyRepoMock, mocks.NewMockMemberRepository(ctrl), queueMock, ledgerMock, testutils.NewTransactionManager(ctrl), outboxMock, loanConfig, zerolog.Nop())
+
+			result, err := service.ReturnLoan(context.Background(), loanID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result.ReturnedAt)
+			assert.Equal(t, entity.LoanStatusReturned, result.Status())
+			assert.False(t, result.Overdue(time.Now()), "a returned loan is never overdue")
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package dto
+
+type CreateMemberRequest struct {
+	Name  string `json:"name" validate:"required,trimmed,max=255"`
+	Email string `json:"email" validate:"required,email,max=254"`
+}
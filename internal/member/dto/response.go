@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type MemberResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToMemberResponse(member *entity.Member) *MemberResponse {
+	return &MemberResponse{
+		ID:        member.ID.String(),
+		Name:      member.Name,
+		Email:     member.Email,
+		CreatedAt: member.CreatedAt,
+	}
+}
@@ -0,0 +1,8 @@
+package member
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("member not found")
+	ErrDuplicate = errors.New("member already exists")
+)
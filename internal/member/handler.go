@@ -0,0 +1,149 @@
+package member
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/member/dto"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type MemberSuccessResponse struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Member retrieved successfully"`
+	Member  *dto.MemberResponse `json:"member"`
+}
+
+type MemberHandler struct {
+	service   MemberService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewMemberHandler(service MemberService, validator *internalValidator.Validator, logger zerolog.Logger) *MemberHandler {
+	return &MemberHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *MemberHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// a member reads their own account
+	r.With(auth.Require).Get("/{member_id}", h.GetMemberByID)
+
+	// the members are registered by the librarians
+	r.With(auth.RequireRole(auth.RoleLibrarian)).Post("/", h.CreateMember)
+
+	return r
+}
+
+// CreateMember godoc
+//
+//	@Summary		Create a new member
+//	@Description	Register a member allowed to borrow books, the email must not be used by another member. Restricted to the librarians.
+//	@Tags			members
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			member			body		dto.CreateMemberRequest	true	"Member data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	MemberSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/members [post]
+func (h *MemberHandler) CreateMember(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateMemberRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	member, err := h.service.CreateMember(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, MemberSuccessResponse{
+		Status:  "success",
+		Message: "Member created successfully",
+		Member:  dto.ToMemberResponse(member),
+	})
+}
+
+// GetMemberByID godoc
+//
+//	@Summary		Get member by id
+//	@Description	Get a single member by its ID. A member only gets their own account, whose ID is the subject of the access token.
+//	@Tags			members
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			member_id	path		string	true	"Member ID"
+//	@Success		200			{object}	MemberSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/members/{member_id} [get]
+func (h *MemberHandler) GetMemberByID(w http.ResponseWriter, r *http.Request) {
+	memberID, err := uuid.Parse(chi.URLParam(r, "member_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := auth.ActFor(r.Context(), memberID.String()); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	member, err := h.service.GetMemberByID(r.Context(), memberID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, MemberSuccessResponse{
+		Status:  "success",
+		Message: "Member retrieved successfully",
+		Member:  dto.ToMemberResponse(member),
+	})
+}
+
+func (h *MemberHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Member not found")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Member with this email already exists")
+	case errors.Is(err, auth.ErrForbidden):
+		response.Error(w, http.StatusForbidden, "Forbidden")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,334 @@
+package member_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/member/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+var createdAt = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func TestMemberHandler_CreateMember(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        interface{}
+		configureMock      func(*mocks.MockMemberService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success create member",
+			identity: librarian,
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "ada@example.com",
+			},
+			configureMock: func(mockService *mocks.MockMemberService) {
+				input := &dto.CreateMemberRequest{
+					Name:  "Ada Lovelace",
+					Email: "ada@example.com",
+				}
+
+				mockService.EXPECT().
+					CreateMember(gomock.Any(), input).
+					Return(&entity.Member{
+						ID:        uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"),
+						Name:      "Ada Lovelace",
+						Email:     "ada@example.com",
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &member.MemberSuccessResponse{
+				Status:  "success",
+				Message: "Member created successfully",
+				Member: &dto.MemberResponse{
+					ID:        "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					Name:      "Ada Lovelace",
+					Email:     "ada@example.com",
+					CreatedAt: createdAt,
+				},
+			},
+		},
+		{
+			name: "error anonymous caller",
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "ada@example.com",
+			},
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:     "error member registering a member",
+			identity: &auth.Identity{Subject: "aeca0955-bae4-47e9-9f85-6818dc68ca51"},
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "ada@example.com",
+			},
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:     "error validation fails invalid email",
+			identity: librarian,
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "not-an-email",
+			},
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "email",
+					Message: "email must be a valid email address",
+				}},
+			},
+		},
+		{
+			name:     "error duplicate email",
+			identity: librarian,
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "ada@example.com",
+			},
+			configureMock: func(mockService *mocks.MockMemberService) {
+				mockService.EXPECT().
+					CreateMember(gomock.Any(), gomock.Any()).
+					Return(nil, member.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Member with this email already exists",
+			},
+		},
+		{
+			name:     "error service internal error",
+			identity: librarian,
+			requestBody: dto.CreateMemberRequest{
+				Name:  "Ada Lovelace",
+				Email: "ada@example.com",
+			},
+			configureMock: func(mockService *mocks.MockMemberService) {
+				mockService.EXPECT().
+					CreateMember(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Internal server error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockMemberService(ctrl)
+			test.configureMock(mockService)
+
+			handler := member.NewMemberHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/members", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/members", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestMemberHandler_GetMemberByID(t *testing.T) {
+	owner := &auth.Identity{Subject: "aeca0955-bae4-47e9-9f85-6818dc68ca51"}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		idInUrlParam       string
+		configureMock      func(*mocks.MockMemberService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:         "success get member",
+			identity:     owner,
+			idInUrlParam: "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			configureMock: func(mockService *mocks.MockMemberService) {
+				mockService.EXPECT().
+					GetMemberByID(gomock.Any(), uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")).
+					Return(&entity.Member{
+						ID:        uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"),
+						Name:      "Ada Lovelace",
+						Email:     "ada@example.com",
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &member.MemberSuccessResponse{
+				Status:  "success",
+				Message: "Member retrieved successfully",
+				Member: &dto.MemberResponse{
+					ID:        "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					Name:      "Ada Lovelace",
+					Email:     "ada@example.com",
+					CreatedAt: createdAt,
+				},
+			},
+		},
+		{
+			name:         "success librarian gets a member",
+			identity:     &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			idInUrlParam: "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			configureMock: func(mockService *mocks.MockMemberService) {
+				mockService.EXPECT().
+					GetMemberByID(gomock.Any(), uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")).
+					Return(&entity.Member{
+						ID:        uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"),
+						Name:      "Ada Lovelace",
+						Email:     "ada@example.com",
+						CreatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &member.MemberSuccessResponse{
+				Status:  "success",
+				Message: "Member retrieved successfully",
+				Member: &dto.MemberResponse{
+					ID:        "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					Name:      "Ada Lovelace",
+					Email:     "ada@example.com",
+					CreatedAt: createdAt,
+				},
+			},
+		},
+		{
+			name:               "error account of another member",
+			identity:           &auth.Identity{Subject: "61c8f3d2-7a4e-4b9f-a2c5-e8d1b6f0a347"},
+			idInUrlParam:       "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Forbidden",
+			},
+		},
+		{
+			name:               "error anonymous caller",
+			idInUrlParam:       "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:               "error invalid uuid",
+			identity:           owner,
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockMemberService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+		{
+			name:         "error member not found",
+			identity:     owner,
+			idInUrlParam: "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+			configureMock: func(mockService *mocks.MockMemberService) {
+				mockService.EXPECT().
+					GetMemberByID(gomock.Any(), gomock.Any()).
+					Return(nil, member.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Member not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockMemberService(ctrl)
+			test.configureMock(mockService)
+
+			handler := member.NewMemberHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/members/"+test.idInUrlParam, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/members", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
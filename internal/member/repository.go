@@ -0,0 +1,82 @@
+package member
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_member_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/member MemberRepository
+type MemberRepository interface {
+	Create(ctx context.Context, newMember *entity.Member) (*entity.Member, error)
+	GetByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error)
+	LockByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error)
+}
+
+type memberRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewMemberRepository(db *gorm.DB, logger zerolog.Logger) MemberRepository {
+	return &memberRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *memberRepository) Create(ctx context.Context, newMember *entity.Member) (*entity.Member, error) {
+	if err := transaction.DB(ctx, r.db).Create(newMember).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newMember, nil
+}
+
+func (r *memberRepository) GetByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error) {
+	var member *entity.Member
+
+	if err := transaction.DB(ctx, r.db).First(&member, "id = ?", memberID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// LockByID reads the member and locks its row until the end of the
+// transaction carried by ctx, so that the checkouts of a member are
+// serialized.
+func (r *memberRepository) LockByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error) {
+	var member *entity.Member
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&member, "id = ?", memberID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,145 @@
+package member_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/member"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestMemberRepository_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Member
+		configureMock    func(sqlmock.Sqlmock, *entity.Member)
+		expectedError    error
+		expectedResponse *entity.Member
+	}{
+		{
+			name:  "success create member",
+			input: &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Member) {
+				mock.ExpectExec(`INSERT INTO .members.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.Name,
+						input.Email,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+		},
+		{
+			name:  "error duplicate email",
+			input: &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Member) {
+				mock.ExpectExec(`INSERT INTO .members.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: member.ErrDuplicate,
+		},
+		{
+			name:  "error database connection failed",
+			input: &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Member) {
+				mock.ExpectExec(`INSERT INTO .members.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := member.NewMemberRepository(db, zerolog.Nop())
+
+			newMember, err := repo.Create(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newMember)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Name, newMember.Name)
+				assert.Equal(t, test.expectedResponse.Email, newMember.Email)
+				assert.NotEqual(t, uuid.Nil, newMember.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMemberRepository_LockByID(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "success lock member",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .members. WHERE id = \? ORDER BY .members.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(memberID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "created_at", "updated_at"}).
+						AddRow(memberID, "Jean Valjean", "jean.valjean@example.com", now, now))
+			},
+		},
+		{
+			name: "error member not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .members. WHERE id = \? ORDER BY .members.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(memberID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .members. WHERE id = \? ORDER BY .members.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(memberID, 1).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := member.NewMemberRepository(db, zerolog.Nop())
+
+			locked, err := repo.LockByID(context.Background(), memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, locked)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, memberID, locked.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package member
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/member/dto"
+)
+
+//go:generate mockgen -destination=../mocks/mock_member_service.go -package=mocks go-boilerplate-rest-api-chi/internal/member MemberService
+type MemberService interface {
+	CreateMember(ctx context.Context, req *dto.CreateMemberRequest) (*entity.Member, error)
+	GetMemberByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error)
+}
+
+type memberService struct {
+	repository MemberRepository
+	logger     zerolog.Logger
+}
+
+func NewMemberService(repository MemberRepository, logger zerolog.Logger) MemberService {
+	return &memberService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *memberService) CreateMember(ctx context.Context, req *dto.CreateMemberRequest) (*entity.Member, error) {
+	// emails are compared case insensitively, a member cannot register twice
+	// with a different case
+	return s.repository.Create(ctx, &entity.Member{
+		Name:  req.Name,
+		Email: strings.ToLower(req.Email),
+	})
+}
+
+func (s *memberService) GetMemberByID(ctx context.Context, memberID uuid.UUID) (*entity.Member, error) {
+	return s.repository.GetByID(ctx, memberID)
+}
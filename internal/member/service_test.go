@@ -0,0 +1,143 @@
+package member_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/member/dto"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+)
+
+var memberID = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+
+func TestMemberService_CreateMember(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.CreateMemberRequest
+		configureMock    func(*mocks.MockMemberRepository)
+		expectedResponse *entity.Member
+		expectedError    error
+	}{
+		{
+			name: "success create member with the email in lower case",
+			input: &dto.CreateMemberRequest{
+				Name:  "Jean Valjean",
+				Email: "Jean.Valjean@Example.com",
+			},
+			configureMock: func(mockRepo *mocks.MockMemberRepository) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"}).
+					Return(&entity.Member{ID: memberID, Name: "Jean Valjean", Email: "jean.valjean@example.com"}, nil)
+			},
+			expectedResponse: &entity.Member{ID: memberID, Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+		},
+		{
+			name: "error duplicate email",
+			input: &dto.CreateMemberRequest{
+				Name:  "Jean Valjean",
+				Email: "JEAN.VALJEAN@EXAMPLE.COM",
+			},
+			configureMock: func(mockRepo *mocks.MockMemberRepository) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), &entity.Member{Name: "Jean Valjean", Email: "jean.valjean@example.com"}).
+					Return(nil, member.ErrDuplicate)
+			},
+			expectedError: member.ErrDuplicate,
+		},
+		{
+			name: "error database error",
+			input: &dto.CreateMemberRequest{
+				Name:  "Jean Valjean",
+				Email: "jean.valjean@example.com",
+			},
+			configureMock: func(mockRepo *mocks.MockMemberRepository) {
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedError: errors.New("database connection failed"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockRepo := mocks.NewMockMemberRepository(ctrl)
+			test.configureMock(mockRepo)
+
+			service := member.NewMemberService(mockRepo, zerolog.Nop())
+
+			result, err := service.CreateMember(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				require.Error(t, err)
+				assert.Equal(t, test.expectedError.Error(), err.Error())
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse, result)
+			}
+		})
+	}
+}
+
+func TestMemberService_GetMemberByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockMemberRepository)
+		expectedResponse *entity.Member
+		expectedError    error
+	}{
+		{
+			name: "success get member",
+			configureMock: func(mockRepo *mocks.MockMemberRepository) {
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), memberID).
+					Return(&entity.Member{ID: memberID, Name: "Jean Valjean", Email: "jean.valjean@example.com"}, nil)
+			},
+			expectedResponse: &entity.Member{ID: memberID, Name: "Jean Valjean", Email: "jean.valjean@example.com"},
+		},
+		{
+			name: "error member not found",
+			configureMock: func(mockRepo *mocks.MockMemberRepository) {
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), memberID).
+					Return(nil, member.ErrNotFound)
+			},
+			expectedError: member.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockRepo := mocks.NewMockMemberRepository(ctrl)
+			test.configureMock(mockRepo)
+
+			service := member.NewMemberService(mockRepo, zerolog.Nop())
+
+			result, err := service.GetMemberByID(context.Background(), memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse, result)
+			}
+		})
+	}
+}
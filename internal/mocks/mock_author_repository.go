@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	entity "go-boilerplate-rest-api-chi/internal/entity"
+	pagination "go-boilerplate-rest-api-chi/internal/pagination"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -57,6 +58,66 @@ func (mr *MockAuthorRepositoryMockRecorder) Create(ctx, newAuthor any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorRepository)(nil).Create), ctx, newAuthor)
 }
 
+// CreateAlias mocks base method.
+func (m *MockAuthorRepository) CreateAlias(ctx context.Context, newAlias *entity.AuthorAlias) (*entity.AuthorAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlias", ctx, newAlias)
+	ret0, _ := ret[0].(*entity.AuthorAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlias indicates an expected call of CreateAlias.
+func (mr *MockAuthorRepositoryMockRecorder) CreateAlias(ctx, newAlias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlias", reflect.TypeOf((*MockAuthorRepository)(nil).CreateAlias), ctx, newAlias)
+}
+
+// DeleteAlias mocks base method.
+func (m *MockAuthorRepository) DeleteAlias(ctx context.Context, authorID, aliasID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlias", ctx, authorID, aliasID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlias indicates an expected call of DeleteAlias.
+func (mr *MockAuthorRepositoryMockRecorder) DeleteAlias(ctx, authorID, aliasID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlias", reflect.TypeOf((*MockAuthorRepository)(nil).DeleteAlias), ctx, authorID, aliasID)
+}
+
+// FindByName mocks base method.
+func (m *MockAuthorRepository) FindByName(ctx context.Context, name string) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockAuthorRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockAuthorRepository)(nil).FindByName), ctx, name)
+}
+
+// GetAll mocks base method.
+func (m *MockAuthorRepository) GetAll(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, page)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAuthorRepositoryMockRecorder) GetAll(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAuthorRepository)(nil).GetAll), ctx, page)
+}
+
 // GetByID mocks base method.
 func (m *MockAuthorRepository) GetByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
 	m.ctrl.T.Helper()
@@ -71,3 +132,63 @@ func (mr *MockAuthorRepositoryMockRecorder) GetByID(ctx, authorID any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAuthorRepository)(nil).GetByID), ctx, authorID)
 }
+
+// GetByIDs mocks base method.
+func (m *MockAuthorRepository) GetByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, authorIDs)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAuthorRepositoryMockRecorder) GetByIDs(ctx, authorIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAuthorRepository)(nil).GetByIDs), ctx, authorIDs)
+}
+
+// GetByName mocks base method.
+func (m *MockAuthorRepository) GetByName(ctx context.Context, name string) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockAuthorRepositoryMockRecorder) GetByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockAuthorRepository)(nil).GetByName), ctx, name)
+}
+
+// LockByID mocks base method.
+func (m *MockAuthorRepository) LockByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", ctx, authorID)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockAuthorRepositoryMockRecorder) LockByID(ctx, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockAuthorRepository)(nil).LockByID), ctx, authorID)
+}
+
+// Update mocks base method.
+func (m *MockAuthorRepository) Update(ctx context.Context, author *entity.Author) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, author)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorRepositoryMockRecorder) Update(ctx, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorRepository)(nil).Update), ctx, author)
+}
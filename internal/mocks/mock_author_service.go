@@ -13,6 +13,7 @@ import (
 	context "context"
 	dto "go-boilerplate-rest-api-chi/internal/author/dto"
 	entity "go-boilerplate-rest-api-chi/internal/entity"
+	pagination "go-boilerplate-rest-api-chi/internal/pagination"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -43,6 +44,21 @@ func (m *MockAuthorService) EXPECT() *MockAuthorServiceMockRecorder {
 	return m.recorder
 }
 
+// AddAlias mocks base method.
+func (m *MockAuthorService) AddAlias(ctx context.Context, req *dto.CreateAliasRequest, authorID uuid.UUID) (*entity.AuthorAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlias", ctx, req, authorID)
+	ret0, _ := ret[0].(*entity.AuthorAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAlias indicates an expected call of AddAlias.
+func (mr *MockAuthorServiceMockRecorder) AddAlias(ctx, req, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlias", reflect.TypeOf((*MockAuthorService)(nil).AddAlias), ctx, req, authorID)
+}
+
 // CreateAuthor mocks base method.
 func (m *MockAuthorService) CreateAuthor(ctx context.Context, req *dto.CreateAuthorRequest) (*entity.Author, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +74,37 @@ func (mr *MockAuthorServiceMockRecorder) CreateAuthor(ctx, req any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockAuthorService)(nil).CreateAuthor), ctx, req)
 }
 
+// FindAuthorsByName mocks base method.
+func (m *MockAuthorService) FindAuthorsByName(ctx context.Context, name string) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAuthorsByName", ctx, name)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAuthorsByName indicates an expected call of FindAuthorsByName.
+func (mr *MockAuthorServiceMockRecorder) FindAuthorsByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAuthorsByName", reflect.TypeOf((*MockAuthorService)(nil).FindAuthorsByName), ctx, name)
+}
+
+// GetAllAuthors mocks base method.
+func (m *MockAuthorService) GetAllAuthors(ctx context.Context, page pagination.Page) ([]*entity.Author, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllAuthors", ctx, page)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllAuthors indicates an expected call of GetAllAuthors.
+func (mr *MockAuthorServiceMockRecorder) GetAllAuthors(ctx, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllAuthors", reflect.TypeOf((*MockAuthorService)(nil).GetAllAuthors), ctx, page)
+}
+
 // GetAuthorByID mocks base method.
 func (m *MockAuthorService) GetAuthorByID(ctx context.Context, authorID uuid.UUID) (*entity.Author, error) {
 	m.ctrl.T.Helper()
@@ -72,3 +119,47 @@ func (mr *MockAuthorServiceMockRecorder) GetAuthorByID(ctx, authorID any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorByID", reflect.TypeOf((*MockAuthorService)(nil).GetAuthorByID), ctx, authorID)
 }
+
+// GetAuthorsByIDs mocks base method.
+func (m *MockAuthorService) GetAuthorsByIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorsByIDs", ctx, authorIDs)
+	ret0, _ := ret[0].([]*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorsByIDs indicates an expected call of GetAuthorsByIDs.
+func (mr *MockAuthorServiceMockRecorder) GetAuthorsByIDs(ctx, authorIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorsByIDs", reflect.TypeOf((*MockAuthorService)(nil).GetAuthorsByIDs), ctx, authorIDs)
+}
+
+// RemoveAlias mocks base method.
+func (m *MockAuthorService) RemoveAlias(ctx context.Context, authorID, aliasID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAlias", ctx, authorID, aliasID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAlias indicates an expected call of RemoveAlias.
+func (mr *MockAuthorServiceMockRecorder) RemoveAlias(ctx, authorID, aliasID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAlias", reflect.TypeOf((*MockAuthorService)(nil).RemoveAlias), ctx, authorID, aliasID)
+}
+
+// UpdateAuthor mocks base method.
+func (m *MockAuthorService) UpdateAuthor(ctx context.Context, req *dto.UpdateAuthorRequest, authorID uuid.UUID) (*entity.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuthor", ctx, req, authorID)
+	ret0, _ := ret[0].(*entity.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAuthor indicates an expected call of UpdateAuthor.
+func (mr *MockAuthorServiceMockRecorder) UpdateAuthor(ctx, req, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuthor", reflect.TypeOf((*MockAuthorService)(nil).UpdateAuthor), ctx, req, authorID)
+}
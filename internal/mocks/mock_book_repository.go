@@ -11,7 +11,9 @@ package mocks
 
 import (
 	context "context"
+	dto "go-boilerplate-rest-api-chi/internal/book/dto"
 	entity "go-boilerplate-rest-api-chi/internal/entity"
+	pagination "go-boilerplate-rest-api-chi/internal/pagination"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -42,6 +44,36 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CountByGenre mocks base method.
+func (m *MockBookRepository) CountByGenre(ctx context.Context, filter dto.BookFilter) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByGenre", ctx, filter)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByGenre indicates an expected call of CountByGenre.
+func (mr *MockBookRepositoryMockRecorder) CountByGenre(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByGenre", reflect.TypeOf((*MockBookRepository)(nil).CountByGenre), ctx, filter)
+}
+
+// CountCopies mocks base method.
+func (m *MockBookRepository) CountCopies(ctx context.Context, bookIDs []uuid.UUID) (map[uuid.UUID]entity.CopyCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCopies", ctx, bookIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]entity.CopyCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCopies indicates an expected call of CountCopies.
+func (mr *MockBookRepositoryMockRecorder) CountCopies(ctx, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCopies", reflect.TypeOf((*MockBookRepository)(nil).CountCopies), ctx, bookIDs)
+}
+
 // Create mocks base method.
 func (m *MockBookRepository) Create(ctx context.Context, book *entity.Book) (*entity.Book, error) {
 	m.ctrl.T.Helper()
@@ -72,18 +104,34 @@ func (mr *MockBookRepositoryMockRecorder) Delete(ctx, bookID any) *gomock.Call {
 }
 
 // GetAll mocks base method.
-func (m *MockBookRepository) GetAll(ctx context.Context) ([]*entity.Book, error) {
+func (m *MockBookRepository) GetAll(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, page)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockBookRepositoryMockRecorder) GetAll(ctx, filter, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBookRepository)(nil).GetAll), ctx, filter, page)
+}
+
+// GetByAuthorIDs mocks base method.
+func (m *MockBookRepository) GetByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetByAuthorIDs", ctx, authorIDs)
 	ret0, _ := ret[0].([]*entity.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockBookRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+// GetByAuthorIDs indicates an expected call of GetByAuthorIDs.
+func (mr *MockBookRepositoryMockRecorder) GetByAuthorIDs(ctx, authorIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockBookRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthorIDs", reflect.TypeOf((*MockBookRepository)(nil).GetByAuthorIDs), ctx, authorIDs)
 }
 
 // GetByID mocks base method.
@@ -101,6 +149,63 @@ func (mr *MockBookRepositoryMockRecorder) GetByID(ctx, bookID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookRepository)(nil).GetByID), ctx, bookID)
 }
 
+// LockByID mocks base method.
+func (m *MockBookRepository) LockByID(ctx context.Context, bookID uuid.UUID) (*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByID", ctx, bookID)
+	ret0, _ := ret[0].(*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockByID indicates an expected call of LockByID.
+func (mr *MockBookRepositoryMockRecorder) LockByID(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByID", reflect.TypeOf((*MockBookRepository)(nil).LockByID), ctx, bookID)
+}
+
+// ReplaceGenres mocks base method.
+func (m *MockBookRepository) ReplaceGenres(ctx context.Context, book *entity.Book, genres []*entity.Genre) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceGenres", ctx, book, genres)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceGenres indicates an expected call of ReplaceGenres.
+func (mr *MockBookRepositoryMockRecorder) ReplaceGenres(ctx, book, genres any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceGenres", reflect.TypeOf((*MockBookRepository)(nil).ReplaceGenres), ctx, book, genres)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockBookRepository) ReplaceTags(ctx context.Context, book *entity.Book, tags []*entity.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", ctx, book, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockBookRepositoryMockRecorder) ReplaceTags(ctx, book, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockBookRepository)(nil).ReplaceTags), ctx, book, tags)
+}
+
+// Stream mocks base method.
+func (m *MockBookRepository) Stream(ctx context.Context, filter dto.BookFilter, batchSize int, fn func([]*entity.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stream", ctx, filter, batchSize, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stream indicates an expected call of Stream.
+func (mr *MockBookRepositoryMockRecorder) Stream(ctx, filter, batchSize, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stream", reflect.TypeOf((*MockBookRepository)(nil).Stream), ctx, filter, batchSize, fn)
+}
+
 // Update mocks base method.
 func (m *MockBookRepository) Update(ctx context.Context, book *entity.Book) (*entity.Book, error) {
 	m.ctrl.T.Helper()
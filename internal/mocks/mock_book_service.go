@@ -13,6 +13,7 @@ import (
 	context "context"
 	dto "go-boilerplate-rest-api-chi/internal/book/dto"
 	entity "go-boilerplate-rest-api-chi/internal/entity"
+	pagination "go-boilerplate-rest-api-chi/internal/pagination"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -72,19 +73,34 @@ func (mr *MockBookServiceMockRecorder) DeleteBook(ctx, bookID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookService)(nil).DeleteBook), ctx, bookID)
 }
 
+// ExportBooks mocks base method.
+func (m *MockBookService) ExportBooks(ctx context.Context, filter dto.BookFilter, fn func([]*entity.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBooks", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBooks indicates an expected call of ExportBooks.
+func (mr *MockBookServiceMockRecorder) ExportBooks(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBooks", reflect.TypeOf((*MockBookService)(nil).ExportBooks), ctx, filter, fn)
+}
+
 // GetAllBooks mocks base method.
-func (m *MockBookService) GetAllBooks(ctx context.Context) ([]*entity.Book, error) {
+func (m *MockBookService) GetAllBooks(ctx context.Context, filter dto.BookFilter, page pagination.Page) ([]*entity.Book, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllBooks", ctx)
+	ret := m.ctrl.Call(m, "GetAllBooks", ctx, filter, page)
 	ret0, _ := ret[0].([]*entity.Book)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetAllBooks indicates an expected call of GetAllBooks.
-func (mr *MockBookServiceMockRecorder) GetAllBooks(ctx any) *gomock.Call {
+func (mr *MockBookServiceMockRecorder) GetAllBooks(ctx, filter, page any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBooks", reflect.TypeOf((*MockBookService)(nil).GetAllBooks), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllBooks", reflect.TypeOf((*MockBookService)(nil).GetAllBooks), ctx, filter, page)
 }
 
 // GetBookByID mocks base method.
@@ -102,6 +118,36 @@ func (mr *MockBookServiceMockRecorder) GetBookByID(ctx, bookID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByID", reflect.TypeOf((*MockBookService)(nil).GetBookByID), ctx, bookID)
 }
 
+// GetBooksByAuthorIDs mocks base method.
+func (m *MockBookService) GetBooksByAuthorIDs(ctx context.Context, authorIDs []uuid.UUID) ([]*entity.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBooksByAuthorIDs", ctx, authorIDs)
+	ret0, _ := ret[0].([]*entity.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBooksByAuthorIDs indicates an expected call of GetBooksByAuthorIDs.
+func (mr *MockBookServiceMockRecorder) GetBooksByAuthorIDs(ctx, authorIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBooksByAuthorIDs", reflect.TypeOf((*MockBookService)(nil).GetBooksByAuthorIDs), ctx, authorIDs)
+}
+
+// GetGenreFacets mocks base method.
+func (m *MockBookService) GetGenreFacets(ctx context.Context, filter dto.BookFilter) ([]*entity.GenreFacet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreFacets", ctx, filter)
+	ret0, _ := ret[0].([]*entity.GenreFacet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreFacets indicates an expected call of GetGenreFacets.
+func (mr *MockBookServiceMockRecorder) GetGenreFacets(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreFacets", reflect.TypeOf((*MockBookService)(nil).GetGenreFacets), ctx, filter)
+}
+
 // UpdateBook mocks base method.
 func (m *MockBookService) UpdateBook(ctx context.Context, req *dto.UpdateBookRequest, bookID uuid.UUID) (*entity.Book, error) {
 	m.ctrl.T.Helper()
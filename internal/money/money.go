@@ -0,0 +1,119 @@
+// Package money handles amounts of money without floating point rounding,
+// as a whole number of cents.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Amount is an amount of money in cents. It is written as a decimal string
+// with two decimals, such as "12.50", in JSON and in the configuration.
+type Amount int64
+
+// ErrInvalid is returned for a string which is not an amount.
+var ErrInvalid = errors.New(`invalid amount, expected a decimal with at most 2 decimals such as "2.50"`)
+
+// Parse reads a decimal amount with at most two decimals, an amount with more
+// decimals is rejected rather than rounded.
+func Parse(s string) (Amount, error) {
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	units, cents, hasCents := strings.Cut(s, ".")
+	if units == "" || (hasCents && (cents == "" || len(cents) > 2)) || !digits(units) || !digits(cents) {
+		return 0, ErrInvalid
+	}
+
+	fraction := int64(0)
+	if cents != "" {
+		// "2.5" is 2 units and 50 cents
+		fraction, _ = strconv.ParseInt((cents + "0")[:2], 10, 64)
+	}
+
+	// the units are bounded with the cents added, so that whole*100 + fraction
+	// cannot wrap around
+	whole, err := strconv.ParseInt(units, 10, 64)
+	if err != nil || whole > (math.MaxInt64-fraction)/100 {
+		return 0, ErrInvalid
+	}
+
+	amount := Amount(whole*100 + fraction)
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+// MustParse is like Parse but panics on an invalid amount, it is meant for
+// constants.
+func MustParse(s string) Amount {
+	amount, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+func (a Amount) String() string {
+	sign := ""
+	cents := int64(a)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Times returns the amount multiplied by n.
+func (a Amount) Times(n int) Amount {
+	return a * Amount(n)
+}
+
+// Min returns the smallest of the two amounts.
+func Min(a, b Amount) Amount {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON only accepts strings, a JSON number would go through a
+// float64 in most clients.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return ErrInvalid
+	}
+
+	return a.UnmarshalText([]byte(s))
+}
+
+func (a *Amount) UnmarshalText(text []byte) error {
+	amount, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
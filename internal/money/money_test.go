@@ -0,0 +1,74 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/money"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedAmount money.Amount
+		expectedError  bool
+	}{
+		{input: "12", expectedAmount: 1200},
+		{input: "12.5", expectedAmount: 1250},
+		{input: "0.05", expectedAmount: 5},
+		{input: "-3.10", expectedAmount: -310},
+		{input: "0.125", expectedError: true},
+		{input: "12.", expectedError: true},
+		{input: ".50", expectedError: true},
+		{input: "1e3", expectedError: true},
+		{input: "+1.00", expectedError: true},
+		{input: "", expectedError: true},
+		{input: "99999999999999999999", expectedError: true},
+		{input: "92233720368547758.07", expectedAmount: math.MaxInt64},
+		{input: "92233720368547758.08", expectedError: true},
+		{input: "92233720368547758.99", expectedError: true},
+		{input: "92233720368547759", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			got, err := money.Parse(test.input)
+
+			if test.expectedError {
+				assert.ErrorIs(t, err, money.ErrInvalid)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedAmount, got)
+		})
+	}
+}
+
+func TestAmount_String(t *testing.T) {
+	assert.Equal(t, "0.00", money.Amount(0).String())
+	assert.Equal(t, "0.05", money.Amount(5).String())
+	assert.Equal(t, "12.50", money.Amount(1250).String())
+	assert.Equal(t, "-3.10", money.Amount(-310).String())
+}
+
+func TestAmount_JSON(t *testing.T) {
+	var payload struct {
+		Amount money.Amount `json:"amount"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":"0.10"}`), &payload))
+	assert.Equal(t, money.Amount(10), payload.Amount)
+
+	// three payments of 0.10 make exactly 0.30
+	payload.Amount = payload.Amount.Times(3)
+	b, err := json.Marshal(payload)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"0.30"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"amount":0.1}`), &payload), "numbers go through a float")
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+// Handler reacts to an event published on the bus.
+type Handler func(ctx context.Context, e event.Event) error
+
+// Bus is the in-process sink, it hands every event to its subscribers.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[int]subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	name    string
+	handler Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[int]subscriber),
+	}
+}
+
+// Subscribe registers handler under name until the returned function is
+// called. The name tracks the delivery of an event to the handler, it must be
+// unique within the bus.
+func (b *Bus) Subscribe(name string, handler Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = subscriber{name: name, handler: handler}
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		delete(b.subscribers, id)
+	}
+}
+
+// Publish calls the subscribers one after the other, in the order they
+// subscribed. Their errors are joined.
+func (b *Bus) Publish(ctx context.Context, e event.Event) error {
+	_, err := b.PublishTargets(ctx, e, nil)
+	return err
+}
+
+// PublishTargets calls the subscribers missing from delivered, so that an
+// event failed by one of them is handed again only to that one.
+func (b *Bus) PublishTargets(ctx context.Context, e event.Event, delivered []string) ([]string, error) {
+	b.mu.RLock()
+	ids := slices.Sorted(maps.Keys(b.subscribers))
+	subscribers := make([]subscriber, len(ids))
+	for i, id := range ids {
+		subscribers[i] = b.subscribers[id]
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subscribers {
+		if slices.Contains(delivered, s.name) {
+			continue
+		}
+
+		if err := s.handler(ctx, e); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = append(delivered, s.name)
+	}
+
+	return delivered, errors.Join(errs...)
+}
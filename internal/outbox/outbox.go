@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+// outboxEvent is an event waiting in the outbox, or already published.
+// Sequence orders the events, in particular the events of an aggregate.
+// Delivered lists the targets of a fanout sink the event already reached.
+type outboxEvent struct {
+	Sequence      uint64    `gorm:"primaryKey;autoIncrement"`
+	EventID       uuid.UUID `gorm:"type:char(36);not null;uniqueIndex"`
+	Type          string    `gorm:"size:64;not null"`
+	AggregateType string    `gorm:"size:32;not null"`
+	AggregateID   uuid.UUID `gorm:"type:char(36);not null;index"`
+	Payload       string    `gorm:"type:text;not null"`
+	OccurredAt    time.Time `gorm:"not null"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"type:text"`
+	Delivered     string    `gorm:"type:text"`
+	NextAttemptAt *time.Time
+	PublishedAt   *time.Time `gorm:"index"`
+	FailedAt      *time.Time
+}
+
+func (outboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func (e *outboxEvent) delivered() []string {
+	if e.Delivered == "" {
+		return nil
+	}
+
+	return strings.Split(e.Delivered, ",")
+}
+
+func (e *outboxEvent) event() event.Event {
+	return event.Event{
+		ID:            e.EventID,
+		Type:          event.Type(e.Type),
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		Sequence:      e.Sequence,
+		OccurredAt:    e.OccurredAt,
+		Payload:       json.RawMessage(e.Payload),
+	}
+}
+
+//go:generate mockgen -destination=../mocks/mock_outbox.go -package=mocks go-boilerplate-rest-api-chi/internal/outbox Outbox
+type Outbox interface {
+	// Record stores the events with the transaction carried by ctx, they are
+	// only published once it commits.
+	Record(ctx context.Context, events ...event.Event) error
+}
+
+type outbox struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewOutbox(db *gorm.DB, logger zerolog.Logger) Outbox {
+	return &outbox{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Migrate creates the outbox_events table when it is missing.
+func Migrate(db *gorm.DB) error {
+	return db.AutoMigrate(&outboxEvent{})
+}
+
+func (o *outbox) Record(ctx context.Context, events ...event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]*outboxEvent, len(events))
+	for i, e := range events {
+		payload, err := json.Marshal(e.Payload)
+		if err != nil {
+			return err
+		}
+
+		rows[i] = &outboxEvent{
+			EventID:       e.ID,
+			Type:          string(e.Type),
+			AggregateType: e.AggregateType,
+			AggregateID:   e.AggregateID,
+			Payload:       string(payload),
+			OccurredAt:    e.OccurredAt,
+		}
+	}
+
+	if err := transaction.DB(ctx, o.db).Create(&rows).Error; err != nil {
+		o.logger.Error().Err(err).Msg("failed to record events")
+		return err
+	}
+
+	return nil
+}
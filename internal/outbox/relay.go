@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+)
+
+// maxRetryDelay caps the delay between two deliveries of a failing event.
+const maxRetryDelay = 10 * time.Minute
+
+// Relay publishes the recorded events to a sink. Delivery is at least once:
+// an event is published again when the relay stops before marking it. The
+// events of an aggregate are published in order, a failing event holds back
+// the next events of its aggregate until it is delivered or given up after
+// MaxAttempts.
+//
+// A single relay must run against a database, concurrent relays would break
+// the ordering.
+type Relay struct {
+	db           *gorm.DB
+	sink         Sink
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	logger       zerolog.Logger
+	now          func() time.Time
+}
+
+func NewRelay(db *gorm.DB, sink Sink, cfg config.OutboxConfig, logger zerolog.Logger) *Relay {
+	return &Relay{
+		db:           db,
+		sink:         sink,
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		maxAttempts:  cfg.MaxAttempts,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// Run publishes the pending events until ctx is done, polling the outbox every
+// poll interval while it is drained.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := r.PublishPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error().Err(err).Msg("failed to relay events")
+		}
+
+		// a full batch of attempts means more events are probably waiting
+		if err == nil && processed == r.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishPending makes a single pass over the oldest events due for delivery
+// and returns the number of events it attempted to publish. The events held
+// back by an earlier event of their aggregate waiting for a retry are left
+// out of the pass, so that they do not take the place of the other aggregates.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	now := r.now()
+
+	var pending []*outboxEvent
+
+	err := r.db.WithContext(ctx).
+		Where("published_at IS NULL AND failed_at IS NULL").
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM outbox_events AS held
+			WHERE held.aggregate_type = outbox_events.aggregate_type
+				AND held.aggregate_id = outbox_events.aggregate_id
+				AND held.sequence < outbox_events.sequence
+				AND held.published_at IS NULL AND held.failed_at IS NULL
+				AND held.next_attempt_at > ?
+		)`, now).
+		Order("sequence").
+		Limit(r.batchSize).
+		Find(&pending).Error
+	if err != nil {
+		return 0, err
+	}
+
+	attempted := 0
+	blocked := make(map[string]bool)
+
+	for _, e := range pending {
+		// an event failing during the pass holds back the next ones
+		aggregate := e.AggregateType + ":" + e.AggregateID.String()
+		if blocked[aggregate] {
+			continue
+		}
+
+		attempted++
+
+		delivered, err := r.publish(ctx, e)
+		if err != nil {
+			if ctx.Err() != nil {
+				return attempted, ctx.Err()
+			}
+
+			if !r.markFailed(ctx, e, delivered, err, now) {
+				blocked[aggregate] = true
+			}
+			continue
+		}
+
+		if err := r.db.WithContext(ctx).Model(e).Update("published_at", now).Error; err != nil {
+			return attempted, err
+		}
+	}
+
+	return attempted, nil
+}
+
+// publish hands e to the sink. With a fanout sink, only the targets e did not
+// reach yet receive it, the targets reached so far are returned.
+func (r *Relay) publish(ctx context.Context, e *outboxEvent) ([]string, error) {
+	fanout, ok := r.sink.(Fanout)
+	if !ok {
+		return nil, r.sink.Publish(ctx, e.event())
+	}
+
+	return fanout.PublishTargets(ctx, e.event(), e.delivered())
+}
+
+// markFailed schedules the next delivery of e and reports whether it was
+// given up.
+func (r *Relay) markFailed(ctx context.Context, e *outboxEvent, delivered []string, cause error, now time.Time) bool {
+	attempts := e.Attempts + 1
+	updates := map[string]any{
+		"attempts":   attempts,
+		"last_error": cause.Error(),
+		"delivered":  strings.Join(delivered, ","),
+	}
+
+	givenUp := attempts >= r.maxAttempts
+	if givenUp {
+		updates["failed_at"] = now
+		r.logger.Error().Err(cause).Str("event_id", e.EventID.String()).Int("attempts", attempts).Msg("giving up publishing event")
+	} else {
+		updates["next_attempt_at"] = now.Add(retryDelay(r.pollInterval, attempts))
+		r.logger.Warn().Err(cause).Str("event_id", e.EventID.String()).Int("attempts", attempts).Msg("failed to publish event")
+	}
+
+	if err := r.db.WithContext(ctx).Model(e).Updates(updates).Error; err != nil {
+		r.logger.Error().Err(err).Str("event_id", e.EventID.String()).Msg("failed to record event failure")
+	}
+
+	return givenUp
+}
+
+// retryDelay doubles the delay on every attempt, up to maxRetryDelay.
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
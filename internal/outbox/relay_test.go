@@ -0,0 +1,275 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+var (
+	bookA = uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0")
+	bookB = uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1")
+)
+
+// recordingSink keeps the published events and fails the ones listed in
+// failures, the given number of times.
+type recordingSink struct {
+	mu        sync.Mutex
+	published []event.Event
+	failures  map[event.Type]int
+}
+
+func (s *recordingSink) Publish(_ context.Context, e event.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures[e.Type] > 0 {
+		s.failures[e.Type]--
+		return errors.New("sink unavailable")
+	}
+
+	s.published = append(s.published, e)
+	return nil
+}
+
+func (s *recordingSink) types() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	types := make([]string, len(s.published))
+	for i, e := range s.published {
+		types[i] = e.AggregateID.String()[:1] + ":" + string(e.Type)
+	}
+	return types
+}
+
+func newOutboxDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db := testutils.NewGormSQLite(t)
+	require.NoError(t, outbox.Migrate(db))
+
+	return db
+}
+
+func newRelay(db *gorm.DB, sink outbox.Sink, maxAttempts int) *outbox.Relay {
+	return outbox.NewRelay(db, sink, config.OutboxConfig{
+		PollInterval: time.Millisecond,
+		BatchSize:    10,
+		MaxAttempts:  maxAttempts,
+	}, zerolog.Nop())
+}
+
+func record(t *testing.T, db *gorm.DB, events ...event.Event) {
+	t.Helper()
+	require.NoError(t, outbox.NewOutbox(db, zerolog.Nop()).Record(context.Background(), events...))
+}
+
+func TestOutbox_Record(t *testing.T) {
+	db := newOutboxDB(t)
+	tm := transaction.NewManager(db, zerolog.Nop())
+	events := outbox.NewOutbox(db, zerolog.Nop())
+
+	book := &entity.Book{ID: bookA, Title: "Les Misérables", Description: "Jean Valjean"}
+
+	err := tm.Do(context.Background(), func(ctx context.Context) error {
+		if err := events.Record(ctx, event.NewBookCreated(book)); err != nil {
+			return err
+		}
+		return errors.New("rolled back")
+	})
+	require.Error(t, err)
+
+	err = tm.Do(context.Background(), func(ctx context.Context) error {
+		return events.Record(ctx, event.NewBookCreated(book), event.NewBookDeleted(book.ID))
+	})
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	processed, err := newRelay(db, sink, 3).PublishPending(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, processed)
+	assert.Equal(t, []string{"a:book.created", "a:book.deleted"}, sink.types())
+
+	var payload event.BookPayload
+	require.NoError(t, json.Unmarshal(sink.published[0].Payload.(json.RawMessage), &payload))
+	assert.Equal(t, "Les Misérables", payload.Title)
+	assert.Less(t, sink.published[0].Sequence, sink.published[1].Sequence)
+}
+
+func TestRelay_PublishPending(t *testing.T) {
+	tests := []struct {
+		name              string
+		maxAttempts       int
+		failures          map[event.Type]int
+		passes            int
+		expectedPublished []string
+	}{
+		{
+			name:        "success publish in order",
+			maxAttempts: 3,
+			passes:      1,
+			expectedPublished: []string{
+				"a:book.created", "b:book.created", "a:book.updated", "a:book.deleted",
+			},
+		},
+		{
+			name:        "success published only once",
+			maxAttempts: 3,
+			passes:      3,
+			expectedPublished: []string{
+				"a:book.created", "b:book.created", "a:book.updated", "a:book.deleted",
+			},
+		},
+		{
+			name:        "success failure holds back the aggregate",
+			maxAttempts: 3,
+			failures:    map[event.Type]int{event.BookUpdated: 1},
+			passes:      1,
+			expectedPublished: []string{
+				"a:book.created", "b:book.created",
+			},
+		},
+		{
+			name:        "success failed event is retried in order",
+			maxAttempts: 3,
+			failures:    map[event.Type]int{event.BookUpdated: 1},
+			passes:      2,
+			expectedPublished: []string{
+				"a:book.created", "b:book.created", "a:book.updated", "a:book.deleted",
+			},
+		},
+		{
+			name:        "success event given up after max attempts",
+			maxAttempts: 2,
+			failures:    map[event.Type]int{event.BookUpdated: 5},
+			passes:      3,
+			expectedPublished: []string{
+				"a:book.created", "b:book.created", "a:book.deleted",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := newOutboxDB(t)
+
+			record(t, db,
+				event.NewBookCreated(&entity.Book{ID: bookA}),
+				event.NewBookCreated(&entity.Book{ID: bookB}),
+				event.NewBookUpdated(&entity.Book{ID: bookA}),
+				event.NewBookDeleted(bookA),
+			)
+
+			sink := &recordingSink{failures: test.failures}
+			relay := newRelay(db, sink, test.maxAttempts)
+
+			for range test.passes {
+				_, err := relay.PublishPending(context.Background())
+				require.NoError(t, err)
+
+				// let the retry delay elapse
+				time.Sleep(5 * time.Millisecond)
+			}
+
+			assert.Equal(t, test.expectedPublished, sink.types())
+		})
+	}
+}
+
+func TestRelay_PublishPendingHeldBack(t *testing.T) {
+	db := newOutboxDB(t)
+
+	record(t, db,
+		event.NewBookCreated(&entity.Book{ID: bookA}),
+		event.NewBookUpdated(&entity.Book{ID: bookA}),
+		event.NewBookDeleted(bookA),
+		event.NewBookCreated(&entity.Book{ID: bookB}),
+		event.NewBookUpdated(&entity.Book{ID: bookB}),
+		event.NewBookDeleted(bookB),
+	)
+
+	sink := &recordingSink{failures: map[event.Type]int{event.BookCreated: 1}}
+
+	// the retry of the first event is an hour away
+	relay := outbox.NewRelay(db, sink, config.OutboxConfig{
+		PollInterval: time.Hour,
+		BatchSize:    2,
+		MaxAttempts:  3,
+	}, zerolog.Nop())
+
+	var attempted []int
+	for range 4 {
+		processed, err := relay.PublishPending(context.Background())
+		require.NoError(t, err)
+
+		attempted = append(attempted, processed)
+	}
+
+	assert.Equal(t, []int{1, 2, 1, 0}, attempted)
+	assert.Equal(t, []string{"b:book.created", "b:book.updated", "b:book.deleted"}, sink.types())
+}
+
+func TestRelay_PublishPendingFanout(t *testing.T) {
+	db := newOutboxDB(t)
+
+	record(t, db, event.NewBookCreated(&entity.Book{ID: bookA}))
+
+	ok := &recordingSink{}
+	failing := &recordingSink{failures: map[event.Type]int{event.BookCreated: 1}}
+
+	relay := newRelay(db, outbox.Sinks(
+		outbox.Target{Name: "ok", Sink: ok},
+		outbox.Target{Name: "failing", Sink: failing},
+	), 3)
+
+	for range 3 {
+		_, err := relay.PublishPending(context.Background())
+		require.NoError(t, err)
+
+		// let the retry delay elapse
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// the retry only went to the failing target
+	assert.Equal(t, []string{"a:book.created"}, ok.types())
+	assert.Equal(t, []string{"a:book.created"}, failing.types())
+}
+
+func TestRelay_Run(t *testing.T) {
+	db := newOutboxDB(t)
+	sink := &recordingSink{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		newRelay(db, sink, 3).Run(ctx)
+		close(done)
+	}()
+
+	record(t, db, event.NewBookCreated(&entity.Book{ID: bookA}))
+
+	assert.Eventually(t, func() bool {
+		return len(sink.types()) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+//go:generate mockgen -destination=../mocks/mock_outbox_sink.go -package=mocks go-boilerplate-rest-api-chi/internal/outbox Sink
+type Sink interface {
+	// Publish delivers the event. An error makes the relay retry later, a
+	// sink may thus receive an event more than once.
+	Publish(ctx context.Context, e event.Event) error
+}
+
+// Fanout is implemented by the sinks delivering an event to several named
+// targets. PublishTargets skips the targets listed in delivered and returns
+// them along with the ones it reached, the relay keeps that list so that a
+// retry only goes to the targets that failed.
+type Fanout interface {
+	Sink
+	PublishTargets(ctx context.Context, e event.Event, delivered []string) ([]string, error)
+}
+
+// Target is a sink of a fanout, its name must be unique within the fanout and
+// stable across restarts as it is kept with the event.
+type Target struct {
+	Name string
+	Sink Sink
+}
+
+type multiSink []Target
+
+// Sinks publishes every event to each target. When one of them fails only the
+// failing targets receive the event again. The targets which are themselves a
+// fanout are tracked down to their own targets.
+func Sinks(targets ...Target) Fanout {
+	return multiSink(targets)
+}
+
+func (m multiSink) Publish(ctx context.Context, e event.Event) error {
+	_, err := m.PublishTargets(ctx, e, nil)
+	return err
+}
+
+func (m multiSink) PublishTargets(ctx context.Context, e event.Event, delivered []string) ([]string, error) {
+	var errs []error
+	for _, target := range m {
+		if fanout, ok := target.Sink.(Fanout); ok {
+			// the targets of a nested fanout are prefixed by its name
+			prefix := target.Name + "/"
+
+			var nested []string
+			for _, name := range delivered {
+				if after, found := strings.CutPrefix(name, prefix); found {
+					nested = append(nested, after)
+				}
+			}
+
+			reached, err := fanout.PublishTargets(ctx, e, nested)
+			for _, name := range reached {
+				if !slices.Contains(nested, name) {
+					delivered = append(delivered, prefix+name)
+				}
+			}
+
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if slices.Contains(delivered, target.Name) {
+			continue
+		}
+
+		if err := target.Sink.Publish(ctx, e); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = append(delivered, target.Name)
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// NewSink assembles the sinks enabled by the configuration, the bus is always
+// part of them.
+func NewSink(cfg config.OutboxConfig, bus *Bus) (Sink, error) {
+	targets := []Target{{Name: "bus", Sink: bus}}
+
+	if cfg.WebhookURL != "" {
+		targets = append(targets, Target{
+			Name: "webhook",
+			Sink: NewWebhookSink(cfg.WebhookURL, cfg.WebhookSecret, &http.Client{Timeout: cfg.WebhookTimeout}),
+		})
+	}
+
+	if cfg.FilePath != "" {
+		fileSink, err := NewFileSink(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, Target{Name: "file", Sink: fileSink})
+	}
+
+	return Sinks(targets...), nil
+}
@@ -0,0 +1,199 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+)
+
+func TestWebhookSink_Publish(t *testing.T) {
+	tests := []struct {
+		name              string
+		secret            string
+		statusCode        int
+		expectedSignature bool
+		expectedError     bool
+	}{
+		{
+			name:              "success signed delivery",
+			secret:            "s3cr3t",
+			statusCode:        http.StatusNoContent,
+			expectedSignature: true,
+		},
+		{
+			name:       "success unsigned delivery",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:          "error non 2xx response",
+			statusCode:    http.StatusServiceUnavailable,
+			expectedError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := event.NewBookCreated(&entity.Book{ID: bookA, Title: "Les Misérables"})
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, e.ID.String(), r.Header.Get(outbox.HeaderEventID))
+				assert.Equal(t, "book.created", r.Header.Get(outbox.HeaderEventType))
+
+				if test.expectedSignature {
+					assert.Equal(t, outbox.Sign(test.secret, body), r.Header.Get(outbox.HeaderSignature))
+				} else {
+					assert.Empty(t, r.Header.Get(outbox.HeaderSignature))
+				}
+
+				var received map[string]any
+				require.NoError(t, json.Unmarshal(body, &received))
+				assert.Equal(t, "book.created", received["type"])
+				assert.Equal(t, "Les Misérables", received["payload"].(map[string]any)["title"])
+
+				w.WriteHeader(test.statusCode)
+			}))
+			t.Cleanup(server.Close)
+
+			sink := outbox.NewWebhookSink(server.URL, test.secret, &http.Client{Timeout: time.Second})
+			err := sink.Publish(context.Background(), e)
+
+			if test.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	assert.Equal(t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		outbox.Sign("key", []byte("The quick brown fox jumps over the lazy dog")),
+	)
+}
+
+func TestFileSink_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+
+	sink, err := outbox.NewFileSink(path)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Publish(context.Background(), event.NewBookCreated(&entity.Book{ID: bookA})))
+	require.NoError(t, sink.Publish(context.Background(), event.NewBookDeleted(bookA)))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var e event.Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, event.BookDeleted, e.Type)
+	assert.Equal(t, bookA, e.AggregateID)
+}
+
+func TestBus_Publish(t *testing.T) {
+	bus := outbox.NewBus()
+
+	var first, second []event.Type
+	unsubscribe := bus.Subscribe("first", func(_ context.Context, e event.Event) error {
+		first = append(first, e.Type)
+		return nil
+	})
+	bus.Subscribe("second", func(_ context.Context, e event.Event) error {
+		second = append(second, e.Type)
+		return errors.New("handler failed")
+	})
+
+	err := bus.Publish(context.Background(), event.NewBookCreated(&entity.Book{ID: bookA}))
+	assert.EqualError(t, err, "handler failed")
+
+	unsubscribe()
+
+	err = bus.Publish(context.Background(), event.NewBookDeleted(bookA))
+	assert.Error(t, err)
+
+	assert.Equal(t, []event.Type{event.BookCreated}, first)
+	assert.Equal(t, []event.Type{event.BookCreated, event.BookDeleted}, second)
+}
+
+func TestSinks_Publish(t *testing.T) {
+	ok := &recordingSink{}
+	failing := &recordingSink{failures: map[event.Type]int{event.BookCreated: 1}}
+
+	sink := outbox.Sinks(outbox.Target{Name: "ok", Sink: ok}, outbox.Target{Name: "failing", Sink: failing})
+
+	err := sink.Publish(context.Background(), event.NewBookCreated(&entity.Book{ID: bookA}))
+	assert.Error(t, err)
+	assert.Equal(t, []string{"a:book.created"}, ok.types())
+	assert.Empty(t, failing.types())
+}
+
+func TestSinks_PublishTargets(t *testing.T) {
+	e := event.NewBookCreated(&entity.Book{ID: bookA})
+
+	var live, stream []event.Type
+	bus := outbox.NewBus()
+	bus.Subscribe("live", func(_ context.Context, e event.Event) error {
+		live = append(live, e.Type)
+		return nil
+	})
+
+	streamFailures := 1
+	bus.Subscribe("stream", func(_ context.Context, e event.Event) error {
+		if streamFailures > 0 {
+			streamFailures--
+			return errors.New("stream unavailable")
+		}
+		stream = append(stream, e.Type)
+		return nil
+	})
+
+	webhook := &recordingSink{failures: map[event.Type]int{event.BookCreated: 2}}
+	file := &recordingSink{}
+
+	sink := outbox.Sinks(
+		outbox.Target{Name: "bus", Sink: bus},
+		outbox.Target{Name: "webhook", Sink: webhook},
+		outbox.Target{Name: "file", Sink: file},
+	)
+
+	delivered, err := sink.PublishTargets(context.Background(), e, nil)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"bus/live", "file"}, delivered)
+
+	delivered, err = sink.PublishTargets(context.Background(), e, delivered)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"bus/live", "file", "bus/stream"}, delivered)
+
+	delivered, err = sink.PublishTargets(context.Background(), e, delivered)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bus/live", "file", "bus/stream", "webhook"}, delivered)
+
+	// every target received the event exactly once
+	assert.Equal(t, []event.Type{event.BookCreated}, live)
+	assert.Equal(t, []event.Type{event.BookCreated}, stream)
+	assert.Equal(t, []string{"a:book.created"}, webhook.types())
+	assert.Equal(t, []string{"a:book.created"}, file.types())
+}
@@ -0,0 +1,81 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+const (
+	HeaderEventID   = "X-Event-Id"
+	HeaderEventType = "X-Event-Type"
+	// HeaderSignature holds the hex encoded HMAC-SHA256 of the body, keyed by
+	// the webhook secret and prefixed by "sha256=".
+	HeaderSignature = "X-Signature-256"
+)
+
+type webhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookSink posts every event as JSON to url. The body is signed when a
+// secret is set. Any response but a 2xx is a failure.
+func NewWebhookSink(url, secret string, client *http.Client) Sink {
+	return &webhookSink{
+		url:    url,
+		secret: secret,
+		client: client,
+	}
+}
+
+func (s *webhookSink) Publish(ctx context.Context, e event.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventID, e.ID.String())
+	req.Header.Set(HeaderEventType, string(e.Type))
+	if s.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns the value of the HeaderSignature header for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
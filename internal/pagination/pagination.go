@@ -0,0 +1,183 @@
+// Package pagination pages through the lists with an opaque cursor. A cursor
+// holds the position of the last item of a page in the order of the list, the
+// next page starts right after it, so that inserts and deletes between two
+// requests neither skip nor repeat an item.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 100
+)
+
+var (
+	ErrInvalidLimit  = errors.New("invalid page limit")
+	ErrInvalidCursor = errors.New("invalid page cursor")
+)
+
+// Page selects at most Limit items after Cursor, an empty cursor starts at
+// the first item. A zero Limit selects every item.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// NewPage reads the page from the limit and cursor parameters of the query
+// string, the limit defaults to DefaultLimit.
+func NewPage(query url.Values) (Page, error) {
+	page := Page{
+		Limit:  DefaultLimit,
+		Cursor: strings.TrimSpace(query.Get("cursor")),
+	}
+
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return Page{}, ErrInvalidLimit
+		}
+
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// Key is a column of the order of a list.
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+// Order is the order of a list. Its last key must be unique, usually the id,
+// so that every item has a distinct position.
+type Order []Key
+
+// String returns the ORDER BY clause of the order.
+func (o Order) String() string {
+	columns := make([]string, len(o))
+	for i, key := range o {
+		columns[i] = key.Column
+		if key.Desc {
+			columns[i] += " DESC"
+		}
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// After returns the condition matching the items positioned after values, one
+// value per key of the order.
+func (o Order) After(values []any) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	// (a > ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND c > ?) ...
+	for i, key := range o {
+		var terms []string
+		for j := range i {
+			terms = append(terms, o[j].Column+" = ?")
+			args = append(args, values[j])
+		}
+
+		operator := " > ?"
+		if key.Desc {
+			operator = " < ?"
+		}
+		terms = append(terms, key.Column+operator)
+		args = append(args, values[i])
+
+		conditions = append(conditions, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// cursor is the encoded content of a cursor. The order is kept to refuse a
+// cursor handed to a list ordered otherwise.
+type cursor struct {
+	Order  string `json:"o"`
+	Values []any  `json:"v"`
+}
+
+// Cursor returns the cursor of the item positioned at values.
+func (o Order) Cursor(values ...any) string {
+	// the values are ids, strings and numbers, they always marshal
+	raw, _ := json.Marshal(cursor{Order: o.String(), Values: values})
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Decode returns the position held by a cursor of the order. It returns
+// ErrInvalidCursor when the cursor was not issued for this order.
+func (o Order) Decode(encoded string) ([]any, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if c.Order != o.String() || len(c.Values) != len(o) {
+		return nil, ErrInvalidCursor
+	}
+
+	for _, value := range c.Values {
+		switch value.(type) {
+		case string, float64:
+		default:
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return c.Values, nil
+}
+
+// Find reads the page of query in the given order into a slice and returns the
+// cursor of the next page, empty on the last page. position returns the
+// values of an item for each key of the order.
+func Find[T any](query *gorm.DB, order Order, page Page, position func(item T) []any) ([]T, string, error) {
+	query = query.Order(order.String())
+
+	if page.Cursor != "" {
+		values, err := order.Decode(page.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		condition, args := order.After(values)
+		query = query.Where(condition, args...)
+	}
+
+	if page.Limit > 0 {
+		// one more item tells whether a next page exists
+		query = query.Limit(page.Limit + 1)
+	}
+
+	var items []T
+	if err := query.Find(&items).Error; err != nil {
+		return nil, "", err
+	}
+
+	if page.Limit == 0 || len(items) <= page.Limit {
+		return items, "", nil
+	}
+
+	items = items[:page.Limit]
+
+	return items, order.Cursor(position(items[len(items)-1])...), nil
+}
@@ -0,0 +1,94 @@
+package pagination_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/pagination"
+)
+
+func TestNewPage(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedPage  pagination.Page
+		expectedError error
+	}{
+		{
+			name:         "success default limit",
+			query:        "",
+			expectedPage: pagination.Page{Limit: pagination.DefaultLimit},
+		},
+		{
+			name:         "success limit and cursor",
+			query:        "limit=10&cursor=abc",
+			expectedPage: pagination.Page{Limit: 10, Cursor: "abc"},
+		},
+		{
+			name:          "error limit not a number",
+			query:         "limit=ten",
+			expectedError: pagination.ErrInvalidLimit,
+		},
+		{
+			name:          "error limit zero",
+			query:         "limit=0",
+			expectedError: pagination.ErrInvalidLimit,
+		},
+		{
+			name:          "error limit above the maximum",
+			query:         "limit=101",
+			expectedError: pagination.ErrInvalidLimit,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := url.ParseQuery(test.query)
+			require.NoError(t, err)
+
+			page, err := pagination.NewPage(query)
+
+			assert.ErrorIs(t, err, test.expectedError)
+			assert.Equal(t, test.expectedPage, page)
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	order := pagination.Order{{Column: "rating", Desc: true}, {Column: "title"}, {Column: "id"}}
+
+	t.Run("order by clause", func(t *testing.T) {
+		assert.Equal(t, "rating DESC, title, id", order.String())
+	})
+
+	t.Run("after condition", func(t *testing.T) {
+		condition, args := order.After([]any{4.5, "Hernani", "1"})
+
+		assert.Equal(t, "((rating < ?) OR (rating = ? AND title > ?) OR (rating = ? AND title = ? AND id > ?))", condition)
+		assert.Equal(t, []any{4.5, 4.5, "Hernani", 4.5, "Hernani", "1"}, args)
+	})
+
+	t.Run("cursor round trip", func(t *testing.T) {
+		values, err := order.Decode(order.Cursor(4.5, "Hernani", "1"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []any{4.5, "Hernani", "1"}, values)
+	})
+
+	t.Run("error cursor of another order", func(t *testing.T) {
+		other := pagination.Order{{Column: "title"}, {Column: "id"}}
+
+		_, err := order.Decode(other.Cursor("Hernani", "1"))
+
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+
+	t.Run("error malformed cursor", func(t *testing.T) {
+		_, err := order.Decode("not a cursor")
+
+		assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+	})
+}
@@ -0,0 +1,9 @@
+package dto
+
+type CreatePublisherRequest struct {
+	Name string `json:"name" validate:"required,trimmed"`
+}
+
+type CreateImprintRequest struct {
+	Name string `json:"name" validate:"required,trimmed,max=255"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "go-boilerplate-rest-api-chi/internal/entity"
+
+type PublisherResponse struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Imprints []ImprintResponse `json:"imprints,omitempty"`
+}
+
+type ImprintResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Publisher is only returned with the imprint of a book.
+	Publisher *PublisherResponse `json:"publisher,omitempty"`
+}
+
+func ToPublisherResponse(publisher *entity.Publisher) *PublisherResponse {
+	response := &PublisherResponse{
+		ID:   publisher.ID.String(),
+		Name: publisher.Name,
+	}
+
+	if len(publisher.Imprints) > 0 {
+		response.Imprints = make([]ImprintResponse, len(publisher.Imprints))
+		for i, imprint := range publisher.Imprints {
+			response.Imprints[i] = *ToImprintResponse(imprint)
+		}
+	}
+
+	return response
+}
+
+func ToImprintResponse(imprint *entity.Imprint) *ImprintResponse {
+	response := &ImprintResponse{
+		ID:   imprint.ID.String(),
+		Name: imprint.Name,
+	}
+
+	if imprint.Publisher != nil {
+		response.Publisher = ToPublisherResponse(imprint.Publisher)
+	}
+
+	return response
+}
@@ -0,0 +1,10 @@
+package publisher
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("publisher not found")
+	ErrDuplicate        = errors.New("publisher already exists")
+	ErrImprintNotFound  = errors.New("imprint not found")
+	ErrDuplicateImprint = errors.New("imprint already exists")
+)
@@ -0,0 +1,234 @@
+package publisher
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/publisher/dto"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type PublisherSuccessResponse struct {
+	Status    string                 `json:"status" example:"success"`
+	Message   string                 `json:"message" example:"Publisher retrieved successfully"`
+	Publisher *dto.PublisherResponse `json:"publisher"`
+}
+
+type ImprintSuccessResponse struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Imprint created successfully"`
+	Imprint *dto.ImprintResponse `json:"imprint"`
+}
+
+type PublisherBooksSuccessResponse struct {
+	Status  string                 `json:"status" example:"success"`
+	Message string                 `json:"message" example:"Books retrieved successfully"`
+	Books   []bookDto.BookResponse `json:"books"`
+}
+
+type PublisherHandler struct {
+	service   PublisherService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewPublisherHandler(service PublisherService, validator *internalValidator.Validator, logger zerolog.Logger) *PublisherHandler {
+	return &PublisherHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *PublisherHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.CreatePublisher)
+	r.Get("/{publisher_id}", h.GetPublisherByID)
+	r.Post("/{publisher_id}/imprints", h.CreateImprint)
+	r.Get("/{publisher_id}/books", h.GetPublisherBooks)
+
+	return r
+}
+
+// CreatePublisher godoc
+//
+//	@Summary		Create a new publisher
+//	@Description	Create a new publisher with the provided data
+//	@Tags			publishers
+//	@Accept			json
+//	@Produce		json
+//	@Param			publisher		body		dto.CreatePublisherRequest	true	"Publisher data"
+//	@Param			Accept-Language	header		string						false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string						false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	PublisherSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/publishers [post]
+func (h *PublisherHandler) CreatePublisher(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreatePublisherRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	publisher, err := h.service.CreatePublisher(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, PublisherSuccessResponse{
+		Status:    "success",
+		Message:   "Publisher created successfully",
+		Publisher: dto.ToPublisherResponse(publisher),
+	})
+}
+
+// GetPublisherByID godoc
+//
+//	@Summary		Get publisher by id
+//	@Description	Get a single publisher by its ID, with its imprints ordered by name
+//	@Tags			publishers
+//	@Produce		json
+//	@Param			publisher_id	path		string	true	"Publisher ID"
+//	@Success		200				{object}	PublisherSuccessResponse
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/publishers/{publisher_id} [get]
+func (h *PublisherHandler) GetPublisherByID(w http.ResponseWriter, r *http.Request) {
+	publisherID, err := uuid.Parse(chi.URLParam(r, "publisher_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	publisher, err := h.service.GetPublisherByID(r.Context(), publisherID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, PublisherSuccessResponse{
+		Status:    "success",
+		Message:   "Publisher retrieved successfully",
+		Publisher: dto.ToPublisherResponse(publisher),
+	})
+}
+
+// CreateImprint godoc
+//
+//	@Summary		Create an imprint
+//	@Description	Add an imprint to the publisher, books are released under an imprint
+//	@Tags			publishers
+//	@Accept			json
+//	@Produce		json
+//	@Param			publisher_id	path		string						true	"Publisher ID"
+//	@Param			imprint			body		dto.CreateImprintRequest	true	"Imprint data"
+//	@Param			Accept-Language	header		string						false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string						false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	ImprintSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/publishers/{publisher_id}/imprints [post]
+func (h *PublisherHandler) CreateImprint(w http.ResponseWriter, r *http.Request) {
+	publisherID, err := uuid.Parse(chi.URLParam(r, "publisher_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.CreateImprintRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	imprint, err := h.service.CreateImprint(r.Context(), &req, publisherID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, ImprintSuccessResponse{
+		Status:  "success",
+		Message: "Imprint created successfully",
+		Imprint: dto.ToImprintResponse(imprint),
+	})
+}
+
+// GetPublisherBooks godoc
+//
+//	@Summary		Get the books of a publisher
+//	@Description	Get the books released under any imprint of the publisher, ordered by title
+//	@Tags			publishers
+//	@Produce		json
+//	@Param			publisher_id	path		string	true	"Publisher ID"
+//	@Success		200				{object}	PublisherBooksSuccessResponse
+//	@Failure		400				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/publishers/{publisher_id}/books [get]
+func (h *PublisherHandler) GetPublisherBooks(w http.ResponseWriter, r *http.Request) {
+	publisherID, err := uuid.Parse(chi.URLParam(r, "publisher_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	books, err := h.service.GetPublisherBooks(r.Context(), publisherID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, PublisherBooksSuccessResponse{
+		Status:  "success",
+		Message: "Books retrieved successfully",
+		Books:   bookDto.ToBooksResponse(books),
+	})
+}
+
+func (h *PublisherHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Publisher not found")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Publisher with this name already exists")
+	case errors.Is(err, ErrDuplicateImprint):
+		response.Error(w, http.StatusConflict, "Imprint with this name already exists for this publisher")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
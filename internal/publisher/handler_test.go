@@ -0,0 +1,265 @@
+package publisher_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	bookDto "go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	"go-boilerplate-rest-api-chi/internal/publisher/dto"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestPublisherHandler_CreateImprint(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        interface{}
+		configureMock      func(*mocks.MockPublisherService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:        "success create imprint",
+			requestBody: dto.CreateImprintRequest{Name: "Vintage"},
+			configureMock: func(mockService *mocks.MockPublisherService) {
+				mockService.EXPECT().
+					CreateImprint(gomock.Any(), &dto.CreateImprintRequest{Name: "Vintage"}, publisherID).
+					Return(&entity.Imprint{ID: imprintID, PublisherID: publisherID, Name: "Vintage"}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &publisher.ImprintSuccessResponse{
+				Status:  "success",
+				Message: "Imprint created successfully",
+				Imprint: &dto.ImprintResponse{
+					ID:   imprintID.String(),
+					Name: "Vintage",
+				},
+			},
+		},
+		{
+			name:               "error validation fails",
+			requestBody:        dto.CreateImprintRequest{},
+			configureMock:      func(mockService *mocks.MockPublisherService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "name", Message: "name is required"},
+				},
+			},
+		},
+		{
+			name:        "error publisher not found",
+			requestBody: dto.CreateImprintRequest{Name: "Vintage"},
+			configureMock: func(mockService *mocks.MockPublisherService) {
+				mockService.EXPECT().
+					CreateImprint(gomock.Any(), gomock.Any(), publisherID).
+					Return(nil, publisher.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Publisher not found",
+			},
+		},
+		{
+			name:        "error duplicate imprint",
+			requestBody: dto.CreateImprintRequest{Name: "Vintage"},
+			configureMock: func(mockService *mocks.MockPublisherService) {
+				mockService.EXPECT().
+					CreateImprint(gomock.Any(), gomock.Any(), publisherID).
+					Return(nil, publisher.ErrDuplicateImprint)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Imprint with this name already exists for this publisher",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockPublisherService(ctrl)
+			test.configureMock(mockService)
+
+			handler := publisher.NewPublisherHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/publishers/"+publisherID.String()+"/imprints", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/publishers", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestPublisherHandler_GetPublisherBooks(t *testing.T) {
+	tests := []struct {
+		name               string
+		idInUrlParam       string
+		configureMock      func(*mocks.MockPublisherService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:         "success get books with their imprint",
+			idInUrlParam: publisherID.String(),
+			configureMock: func(mockService *mocks.MockPublisherService) {
+				mockService.EXPECT().
+					GetPublisherBooks(gomock.Any(), publisherID).
+					Return([]*entity.Book{{
+						Title: "Beloved",
+						Imprint: &entity.Imprint{
+							ID:        imprintID,
+							Name:      "Knopf",
+							Publisher: &entity.Publisher{ID: publisherID, Name: "Penguin Random House"},
+						},
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &publisher.PublisherBooksSuccessResponse{
+				Status:  "success",
+				Message: "Books retrieved successfully",
+				Books: []bookDto.BookResponse{{
+					ID:    uuid.Nil.String(),
+					Title: "Beloved",
+					Imprint: &dto.ImprintResponse{
+						ID:        imprintID.String(),
+						Name:      "Knopf",
+						Publisher: &dto.PublisherResponse{ID: publisherID.String(), Name: "Penguin Random House"},
+					},
+				}},
+			},
+		},
+		{
+			name:               "error invalid uuid",
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockPublisherService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockPublisherService(ctrl)
+			test.configureMock(mockService)
+
+			handler := publisher.NewPublisherHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/publishers/"+test.idInUrlParam+"/books", nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/publishers", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestPublisherHandler_GetPublisherByID(t *testing.T) {
+	tests := []struct {
+		name               string
+		idInUrlParam       string
+		configureMock      func(*mocks.MockPublisherService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:               "error invalid uuid",
+			idInUrlParam:       "not-a-uuid",
+			configureMock:      func(mockService *mocks.MockPublisherService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+		{
+			name:         "error publisher not found",
+			idInUrlParam: publisherID.String(),
+			configureMock: func(mockService *mocks.MockPublisherService) {
+				mockService.EXPECT().
+					GetPublisherByID(gomock.Any(), publisherID).
+					Return(nil, publisher.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Publisher not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockPublisherService(ctrl)
+			test.configureMock(mockService)
+
+			handler := publisher.NewPublisherHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/publishers/"+test.idInUrlParam, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/publishers", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
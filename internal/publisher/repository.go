@@ -0,0 +1,143 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_publisher_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/publisher PublisherRepository
+type PublisherRepository interface {
+	Create(ctx context.Context, newPublisher *entity.Publisher) (*entity.Publisher, error)
+	// GetByID returns the publisher with its imprints ordered by name.
+	GetByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error)
+	LockByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error)
+	CreateImprint(ctx context.Context, newImprint *entity.Imprint) (*entity.Imprint, error)
+	// LockImprintByID reads the imprint with its publisher and locks the
+	// imprint row until the end of the transaction carried by ctx.
+	LockImprintByID(ctx context.Context, imprintID uuid.UUID) (*entity.Imprint, error)
+	// GetBooks returns the books released under any imprint of the publisher,
+	// ordered by title.
+	GetBooks(ctx context.Context, publisherID uuid.UUID) ([]*entity.Book, error)
+}
+
+type publisherRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewPublisherRepository(db *gorm.DB, logger zerolog.Logger) PublisherRepository {
+	return &publisherRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *publisherRepository) Create(ctx context.Context, newPublisher *entity.Publisher) (*entity.Publisher, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newPublisher).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newPublisher, nil
+}
+
+func (r *publisherRepository) GetByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error) {
+	var publisher *entity.Publisher
+
+	err := transaction.DB(ctx, r.db).
+		Preload("Imprints", func(db *gorm.DB) *gorm.DB { return db.Order("name") }).
+		First(&publisher, "id = ?", publisherID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+// LockByID reads the publisher and locks its row until the end of the
+// transaction carried by ctx, so that it cannot be changed or deleted
+// meanwhile.
+func (r *publisherRepository) LockByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error) {
+	var publisher *entity.Publisher
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&publisher, "id = ?", publisherID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (r *publisherRepository) CreateImprint(ctx context.Context, newImprint *entity.Imprint) (*entity.Imprint, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newImprint).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicateImprint
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newImprint, nil
+}
+
+func (r *publisherRepository) LockImprintByID(ctx context.Context, imprintID uuid.UUID) (*entity.Imprint, error) {
+	var imprint *entity.Imprint
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Preload("Publisher").
+		First(&imprint, "id = ?", imprintID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImprintNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return imprint, nil
+}
+
+func (r *publisherRepository) GetBooks(ctx context.Context, publisherID uuid.UUID) ([]*entity.Book, error) {
+	var books []*entity.Book
+
+	err := transaction.DB(ctx, r.db).
+		Joins("JOIN imprints ON imprints.id = books.imprint_id").
+		Where("imprints.publisher_id = ?", publisherID).
+		Preload("Author").
+		Preload("Imprint.Publisher").
+		Order("books.title").
+		Find(&books).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return books, nil
+}
@@ -0,0 +1,278 @@
+package publisher_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestPublisherRepository_GetByID(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(sqlmock.Sqlmock)
+		expectedError    error
+		expectedImprints []string
+	}{
+		{
+			name: "success with the imprints by name",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .publishers. WHERE id = \? ORDER BY .publishers.\..id. LIMIT \?`).
+					WithArgs(publisherID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+						AddRow(publisherID, "Penguin Random House", now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .imprints. WHERE .imprints.\..publisher_id. = \? ORDER BY name`).
+					WithArgs(publisherID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "publisher_id", "name", "created_at", "updated_at"}).
+						AddRow(uuid.New(), publisherID, "Knopf", now, now).
+						AddRow(imprintID, publisherID, "Vintage", now, now))
+			},
+			expectedImprints: []string{"Knopf", "Vintage"},
+		},
+		{
+			name: "error publisher not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .publishers. WHERE id = \? ORDER BY .publishers.\..id. LIMIT \?`).
+					WithArgs(publisherID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: publisher.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := publisher.NewPublisherRepository(db, zerolog.Nop())
+
+			got, err := repo.GetByID(context.Background(), publisherID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+
+				imprints := make([]string, len(got.Imprints))
+				for i, imprint := range got.Imprints {
+					imprints[i] = imprint.Name
+				}
+				assert.Equal(t, test.expectedImprints, imprints)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublisherRepository_CreateImprint(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Imprint
+		configureMock    func(sqlmock.Sqlmock, *entity.Imprint)
+		expectedError    error
+		expectedResponse *entity.Imprint
+	}{
+		{
+			name:  "success create imprint",
+			input: &entity.Imprint{PublisherID: publisherID, Name: "Vintage"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Imprint) {
+				mock.ExpectExec(`INSERT INTO .imprints.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.PublisherID,
+						input.Name,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Imprint{PublisherID: publisherID, Name: "Vintage"},
+		},
+		{
+			name:  "error duplicate imprint within the publisher",
+			input: &entity.Imprint{PublisherID: publisherID, Name: "Vintage"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Imprint) {
+				mock.ExpectExec(`INSERT INTO .imprints.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: publisher.ErrDuplicateImprint,
+		},
+		{
+			name:  "error database connection failed",
+			input: &entity.Imprint{PublisherID: publisherID, Name: "Vintage"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Imprint) {
+				mock.ExpectExec(`INSERT INTO .imprints.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := publisher.NewPublisherRepository(db, zerolog.Nop())
+
+			newImprint, err := repo.CreateImprint(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newImprint)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Name, newImprint.Name)
+				assert.Equal(t, test.expectedResponse.PublisherID, newImprint.PublisherID)
+				assert.NotEqual(t, uuid.Nil, newImprint.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublisherRepository_LockImprintByID(t *testing.T) {
+	tests := []struct {
+		name              string
+		configureMock     func(sqlmock.Sqlmock)
+		expectedError     error
+		expectedPublisher string
+	}{
+		{
+			name: "success with its publisher",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .imprints. WHERE id = \? ORDER BY .imprints.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(imprintID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "publisher_id", "name", "created_at", "updated_at"}).
+						AddRow(imprintID, publisherID, "Vintage", now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .publishers. WHERE .publishers.\..id. = \?`).
+					WithArgs(publisherID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+						AddRow(publisherID, "Penguin Random House", now, now))
+			},
+			expectedPublisher: "Penguin Random House",
+		},
+		{
+			name: "error imprint not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .imprints. WHERE id = \? ORDER BY .imprints.\..id. LIMIT \? FOR UPDATE`).
+					WithArgs(imprintID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: publisher.ErrImprintNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := publisher.NewPublisherRepository(db, zerolog.Nop())
+
+			locked, err := repo.LockImprintByID(context.Background(), imprintID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, locked)
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, locked.Publisher)
+				assert.Equal(t, test.expectedPublisher, locked.Publisher.Name)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublisherRepository_GetBooks(t *testing.T) {
+	tests := []struct {
+		name           string
+		configureMock  func(sqlmock.Sqlmock)
+		expectedError  error
+		expectedTitles []string
+	}{
+		{
+			name: "success the books of every imprint",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+				knopfID := uuid.MustParse("4a6c8e0b-2d4f-4b1a-8c3e-5f7a9b1d3e5c")
+
+				mock.ExpectQuery(`SELECT .books.\..*. FROM .books. JOIN imprints ON imprints.id = books.imprint_id WHERE imprints.publisher_id = \? ORDER BY books.title`).
+					WithArgs(publisherID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "author_id", "imprint_id", "created_at", "updated_at"}).
+						AddRow(uuid.New(), "Beloved", "Beloved", nil, knopfID, now, now).
+						AddRow(uuid.New(), "The Remains of the Day", "The Remains of the Day", nil, imprintID, now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .imprints. WHERE .imprints.\..id. IN \(\?,\?\)`).
+					WithArgs(knopfID, imprintID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "publisher_id", "name", "created_at", "updated_at"}).
+						AddRow(knopfID, publisherID, "Knopf", now, now).
+						AddRow(imprintID, publisherID, "Vintage", now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .publishers. WHERE .publishers.\..id. = \?`).
+					WithArgs(publisherID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+						AddRow(publisherID, "Penguin Random House", now, now))
+			},
+			expectedTitles: []string{"Beloved", "The Remains of the Day"},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .books.\..*. FROM .books. JOIN imprints`).
+					WithArgs(publisherID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := publisher.NewPublisherRepository(db, zerolog.Nop())
+
+			books, err := repo.GetBooks(context.Background(), publisherID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, books)
+			} else {
+				require.NoError(t, err)
+
+				titles := make([]string, len(books))
+				for i, book := range books {
+					titles[i] = book.Title
+					require.NotNil(t, book.Imprint.Publisher)
+				}
+				assert.Equal(t, test.expectedTitles, titles)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
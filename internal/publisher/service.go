@@ -0,0 +1,101 @@
+package publisher
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/publisher/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_publisher_service.go -package=mocks go-boilerplate-rest-api-chi/internal/publisher PublisherService
+type PublisherService interface {
+	CreatePublisher(ctx context.Context, req *dto.CreatePublisherRequest) (*entity.Publisher, error)
+	GetPublisherByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error)
+	CreateImprint(ctx context.Context, req *dto.CreateImprintRequest, publisherID uuid.UUID) (*entity.Imprint, error)
+	// GetPublisherBooks returns the books released under any imprint of the
+	// publisher, ordered by title. A publisher without books is not an error.
+	GetPublisherBooks(ctx context.Context, publisherID uuid.UUID) ([]*entity.Book, error)
+}
+
+type publisherService struct {
+	repository   PublisherRepository
+	transactions transaction.Manager
+	outbox       outbox.Outbox
+	logger       zerolog.Logger
+}
+
+func NewPublisherService(repository PublisherRepository, transactions transaction.Manager, outbox outbox.Outbox, logger zerolog.Logger) PublisherService {
+	return &publisherService{
+		repository:   repository,
+		transactions: transactions,
+		outbox:       outbox,
+		logger:       logger,
+	}
+}
+
+func (s *publisherService) CreatePublisher(ctx context.Context, req *dto.CreatePublisherRequest) (*entity.Publisher, error) {
+	publisher := &entity.Publisher{
+		Name: req.Name,
+	}
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		publisher, err = s.repository.Create(ctx, publisher)
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewPublisherCreated(publisher))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return publisher, nil
+}
+
+func (s *publisherService) GetPublisherByID(ctx context.Context, publisherID uuid.UUID) (*entity.Publisher, error) {
+	return s.repository.GetByID(ctx, publisherID)
+}
+
+func (s *publisherService) CreateImprint(ctx context.Context, req *dto.CreateImprintRequest, publisherID uuid.UUID) (*entity.Imprint, error) {
+	var imprint *entity.Imprint
+
+	// the publisher stays locked until the imprint is committed, it cannot be
+	// deleted in between
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, publisherID); err != nil {
+			return err
+		}
+
+		var err error
+		imprint, err = s.repository.CreateImprint(ctx, &entity.Imprint{
+			PublisherID: publisherID,
+			Name:        req.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewImprintCreated(imprint))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imprint, nil
+}
+
+func (s *publisherService) GetPublisherBooks(ctx context.Context, publisherID uuid.UUID) ([]*entity.Book, error) {
+	if _, err := s.repository.GetByID(ctx, publisherID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetBooks(ctx, publisherID)
+}
@@ -0,0 +1,146 @@
+package publisher_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/publisher"
+	"go-boilerplate-rest-api-chi/internal/publisher/dto"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	publisherID = uuid.MustParse("3e5a7c9b-2d4f-4b6a-8c1e-0f2a4b6c8d1e")
+	imprintID   = uuid.MustParse("8f1b3d5c-7e9a-4c2b-9d4f-6a8c0e2b4d6f")
+)
+
+func TestPublisherService_CreateImprint(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.CreateImprintRequest
+		configureMock    func(*mocks.MockPublisherRepository, *mocks.MockOutbox)
+		expectedResponse *entity.Imprint
+		expectedError    error
+	}{
+		{
+			name:  "success create imprint",
+			input: &dto.CreateImprintRequest{Name: "Vintage"},
+			configureMock: func(mockRepo *mocks.MockPublisherRepository, mockOutbox *mocks.MockOutbox) {
+				gomock.InOrder(
+					mockRepo.EXPECT().
+						LockByID(gomock.Any(), publisherID).
+						Return(&entity.Publisher{ID: publisherID}, nil),
+					mockRepo.EXPECT().
+						CreateImprint(gomock.Any(), &entity.Imprint{PublisherID: publisherID, Name: "Vintage"}).
+						Return(&entity.Imprint{ID: imprintID, PublisherID: publisherID, Name: "Vintage"}, nil),
+				)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ImprintCreated, events[0].Type)
+						assert.Equal(t, publisherID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.Imprint{ID: imprintID, PublisherID: publisherID, Name: "Vintage"},
+		},
+		{
+			name:  "error publisher not found",
+			input: &dto.CreateImprintRequest{Name: "Vintage"},
+			configureMock: func(mockRepo *mocks.MockPublisherRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), publisherID).
+					Return(nil, publisher.ErrNotFound)
+			},
+			expectedError: publisher.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			publisherRepoMock := mocks.NewMockPublisherRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(publisherRepoMock, outboxMock)
+			service := publisher.NewPublisherService(publisherRepoMock, testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			result, err := service.CreateImprint(context.Background(), test.input, publisherID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPublisherService_GetPublisherBooks(t *testing.T) {
+	tests := []struct {
+		name             string
+		configureMock    func(*mocks.MockPublisherRepository)
+		expectedResponse []*entity.Book
+		expectedError    error
+	}{
+		{
+			name: "success get publisher books",
+			configureMock: func(mockRepo *mocks.MockPublisherRepository) {
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), publisherID).
+					Return(&entity.Publisher{ID: publisherID}, nil)
+
+				mockRepo.EXPECT().
+					GetBooks(gomock.Any(), publisherID).
+					Return([]*entity.Book{{Title: "Beloved"}}, nil)
+			},
+			expectedResponse: []*entity.Book{{Title: "Beloved"}},
+		},
+		{
+			name: "error publisher not found",
+			configureMock: func(mockRepo *mocks.MockPublisherRepository) {
+				mockRepo.EXPECT().
+					GetByID(gomock.Any(), publisherID).
+					Return(nil, publisher.ErrNotFound)
+			},
+			expectedError: publisher.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			publisherRepoMock := mocks.NewMockPublisherRepository(ctrl)
+
+			test.configureMock(publisherRepoMock)
+			service := publisher.NewPublisherService(publisherRepoMock, testutils.NewTransactionManager(ctrl), mocks.NewMockOutbox(ctrl), zerolog.Nop())
+
+			result, err := service.GetPublisherBooks(context.Background(), publisherID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
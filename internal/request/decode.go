@@ -0,0 +1,159 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+// MaxBodyBytes is the maximum size accepted for a JSON request body.
+const MaxBodyBytes int64 = 1 << 20
+
+// DecodeError describes why a request body could not be decoded.
+type DecodeError struct {
+	Status  int
+	Message string
+	Errors  []response.ValidationErrorDetail
+}
+
+func (e *DecodeError) Error() string {
+	return e.Message
+}
+
+// DecodeJSON decodes the JSON body of r into dst. The body must be sent with
+// an application/json content type, must not exceed MaxBodyBytes, must only
+// contain fields known by dst and must hold a single JSON value.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	if err := checkContentType(r); err != nil {
+		return err
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(dst); err != nil {
+		return toDecodeError(err)
+	}
+
+	offset := dec.InputOffset()
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return toDecodeError(err)
+		}
+
+		return invalidBody(response.ValidationErrorDetail{
+			Offset:  offset,
+			Message: "Request body must only contain a single JSON value",
+		})
+	}
+
+	return nil
+}
+
+// WriteError writes the response matching an error returned by DecodeJSON.
+func WriteError(w http.ResponseWriter, err error) {
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) {
+		response.Error(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(decodeErr.Errors) == 0 {
+		response.Error(w, decodeErr.Status, decodeErr.Message)
+		return
+	}
+
+	response.JSON(w, decodeErr.Status, response.ValidationErrorResponse{
+		Status:  "error",
+		Message: decodeErr.Message,
+		Errors:  decodeErr.Errors,
+	})
+}
+
+func checkContentType(r *http.Request) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return &DecodeError{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: "Content-Type header must be application/json",
+		}
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return &DecodeError{
+			Status:  http.StatusUnsupportedMediaType,
+			Message: "Content-Type header must be application/json",
+		}
+	}
+
+	return nil
+}
+
+func toDecodeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return invalidBody(response.ValidationErrorDetail{
+			Offset:  syntaxErr.Offset,
+			Message: fmt.Sprintf("Malformed JSON at position %d", syntaxErr.Offset),
+		})
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return invalidBody(response.ValidationErrorDetail{
+			Message: "Malformed JSON, unexpected end of body",
+		})
+
+	case errors.As(err, &typeErr):
+		return invalidBody(response.ValidationErrorDetail{
+			Field:    typeErr.Field,
+			Offset:   typeErr.Offset,
+			Expected: typeErr.Type.String(),
+			Message:  fmt.Sprintf("Invalid value for field %q, expected %s", typeErr.Field, typeErr.Type.String()),
+		})
+
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return invalidBody(response.ValidationErrorDetail{
+			Field:   field,
+			Message: fmt.Sprintf("Unknown field %q", field),
+		})
+
+	case errors.Is(err, io.EOF):
+		return &DecodeError{
+			Status:  http.StatusBadRequest,
+			Message: "Request body must not be empty",
+		}
+
+	case errors.As(err, &maxBytesErr):
+		return &DecodeError{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("Request body must not be larger than %d bytes", maxBytesErr.Limit),
+		}
+
+	default:
+		return invalidBody(response.ValidationErrorDetail{
+			Message: err.Error(),
+		})
+	}
+}
+
+func invalidBody(detail response.ValidationErrorDetail) *DecodeError {
+	return &DecodeError{
+		Status:  http.StatusBadRequest,
+		Message: "Invalid request body",
+		Errors:  []response.ValidationErrorDetail{detail},
+	}
+}
@@ -0,0 +1,177 @@
+package request_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+type payload struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name               string
+		contentType        string
+		body               string
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:               "success decode body",
+			contentType:        "application/json; charset=utf-8",
+			body:               `{"name":"Victor Hugo","count":2}`,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "error missing content type",
+			contentType:        "",
+			body:               `{"name":"Victor Hugo"}`,
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Content-Type header must be application/json",
+			},
+		},
+		{
+			name:               "error wrong content type",
+			contentType:        "text/plain",
+			body:               `{"name":"Victor Hugo"}`,
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Content-Type header must be application/json",
+			},
+		},
+		{
+			name:               "error empty body",
+			contentType:        "application/json",
+			body:               "",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Request body must not be empty",
+			},
+		},
+		{
+			name:               "error malformed json",
+			contentType:        "application/json",
+			body:               `{"name": "Victor Hugo",}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Offset:  24,
+					Message: "Malformed JSON at position 24",
+				}},
+			},
+		},
+		{
+			name:               "error truncated json",
+			contentType:        "application/json",
+			body:               `{"name": "Victor`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Message: "Malformed JSON, unexpected end of body",
+				}},
+			},
+		},
+		{
+			name:               "error wrong type",
+			contentType:        "application/json",
+			body:               `{"name": "Victor Hugo", "count": "two"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Field:    "count",
+					Offset:   38,
+					Expected: "int",
+					Message:  `Invalid value for field "count", expected int`,
+				}},
+			},
+		},
+		{
+			name:               "error unknown field",
+			contentType:        "application/json",
+			body:               `{"name": "Victor Hugo", "age": 83}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "age",
+					Message: `Unknown field "age"`,
+				}},
+			},
+		},
+		{
+			name:               "error trailing data",
+			contentType:        "application/json",
+			body:               `{"name": "Victor Hugo"} {"name": "Emile Zola"}`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Invalid request body",
+				Errors: []response.ValidationErrorDetail{{
+					Offset:  23,
+					Message: "Request body must only contain a single JSON value",
+				}},
+			},
+		},
+		{
+			name:               "error body too large",
+			contentType:        "application/json",
+			body:               `{"name": "` + strings.Repeat("a", int(request.MaxBodyBytes)) + `"}`,
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Request body must not be larger than 1048576 bytes",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			w := httptest.NewRecorder()
+
+			var dst payload
+			err := request.DecodeJSON(w, req, &dst)
+
+			if test.expectedResponse == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, payload{Name: "Victor Hugo", Count: 2}, dst)
+				return
+			}
+
+			require.Error(t, err)
+			request.WriteError(w, err)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
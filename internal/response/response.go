@@ -16,8 +16,10 @@ type ErrorResponse struct {
 }
 
 type ValidationErrorDetail struct {
-	Field   string `json:"field" example:"email"`
-	Message string `json:"message" example:"Email is required"`
+	Field    string `json:"field" example:"email"`
+	Offset   int64  `json:"offset,omitempty" example:"42"`
+	Expected string `json:"expected,omitempty" example:"string"`
+	Message  string `json:"message" example:"Email is required"`
 }
 
 type ValidationErrorResponse struct {
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SubmitReviewRequest is the review of a book by the authenticated member, it
+// waits for moderation once submitted.
+type SubmitReviewRequest struct {
+	BookID string `json:"book_id" validate:"required,uuid_strict"`
+	Rating int    `json:"rating" validate:"required,gte=1,lte=5" example:"4"`
+	Text   string `json:"text,omitempty" validate:"omitempty,trimmed,max=5000"`
+}
+
+// UpdateReviewRequest replaces the rating and the text of a review.
+type UpdateReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,gte=1,lte=5" example:"4"`
+	Text   string `json:"text,omitempty" validate:"omitempty,trimmed,max=5000"`
+}
+
+// RejectReviewRequest tells the member why the review is not published.
+type RejectReviewRequest struct {
+	Reason string `json:"reason" validate:"required,trimmed,max=500"`
+}
+
+// ReviewFilter narrows the listed reviews. Empty fields are ignored.
+type ReviewFilter struct {
+	BookID   string `json:"book_id" validate:"omitempty,uuid_strict"`
+	MemberID string `json:"member_id" validate:"omitempty,uuid_strict"`
+	Status   string `json:"status" validate:"omitempty,oneof=pending approved rejected"`
+}
+
+// NewReviewFilter reads the filter from the query string.
+func NewReviewFilter(query url.Values) ReviewFilter {
+	return ReviewFilter{
+		BookID:   strings.TrimSpace(query.Get("book_id")),
+		MemberID: strings.TrimSpace(query.Get("member_id")),
+		Status:   strings.TrimSpace(query.Get("status")),
+	}
+}
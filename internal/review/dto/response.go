@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type ReviewResponse struct {
+	ID       string `json:"id"`
+	BookID   string `json:"book_id"`
+	MemberID string `json:"member_id"`
+	Rating   int    `json:"rating" example:"4"`
+	Text     string `json:"text,omitempty"`
+	Status   string `json:"status" example:"pending" enums:"pending,approved,rejected"`
+	// Reason explains the rejection of the review.
+	Reason      string     `json:"reason,omitempty"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func ToReviewResponse(review *entity.Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:          review.ID.String(),
+		BookID:      review.BookID.String(),
+		MemberID:    review.MemberID.String(),
+		Rating:      review.Rating,
+		Text:        review.Text,
+		Status:      review.Status,
+		Reason:      review.Reason,
+		ModeratedAt: review.ModeratedAt,
+		CreatedAt:   review.CreatedAt,
+		UpdatedAt:   review.UpdatedAt,
+	}
+}
+
+func ToReviewsResponse(reviews []*entity.Review) []ReviewResponse {
+	responses := make([]ReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = *ToReviewResponse(review)
+	}
+	return responses
+}
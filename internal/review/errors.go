@@ -0,0 +1,13 @@
+package review
+
+import "errors"
+
+var (
+	ErrNotFound        = errors.New("review not found")
+	ErrInvalidBookID   = errors.New("invalid book ID")
+	ErrDuplicate       = errors.New("member already reviewed the book")
+	ErrAlreadyApproved = errors.New("review is already approved")
+	ErrAlreadyRejected = errors.New("review is already rejected")
+	ErrNotAuthor       = errors.New("review belongs to another member")
+	ErrNotMember       = errors.New("access token does not identify a member")
+)
@@ -0,0 +1,433 @@
+package review
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type ReviewSuccessResponse struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Review retrieved successfully"`
+	Review  *dto.ReviewResponse `json:"review"`
+}
+
+type ReviewsSuccessResponse struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Reviews retrieved successfully"`
+	Reviews []dto.ReviewResponse `json:"reviews"`
+}
+
+type ReviewHandler struct {
+	service   ReviewService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewReviewHandler(service ReviewService, validator *internalValidator.Validator, logger zerolog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *ReviewHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Get("/", h.GetReviews)
+	r.Get("/{review_id}", h.GetReviewByID)
+
+	// the members review under their own identity
+	r.Group(func(r chi.Router) {
+		r.Use(auth.Require)
+
+		r.Post("/", h.SubmitReview)
+		r.Put("/{review_id}", h.UpdateReview)
+		r.Delete("/{review_id}", h.DeleteReview)
+	})
+
+	// the moderation is left to the librarians
+	r.Group(func(r chi.Router) {
+		r.Use(auth.RequireRole(auth.RoleLibrarian))
+
+		r.Post("/{review_id}/approve", h.ApproveReview)
+		r.Post("/{review_id}/reject", h.RejectReview)
+	})
+
+	return r
+}
+
+// SubmitReview godoc
+//
+//	@Summary		Submit a review
+//	@Description	Review a book as the authenticated member, whose ID is the subject of the access token, with a rating of 1 to 5 stars and an optional text. A member reviews a book at most once, the review waits for moderation before it counts in the rating of the book.
+//	@Tags			reviews
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			review			body		dto.SubmitReviewRequest	true	"Review data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	ReviewSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/reviews [post]
+func (h *ReviewHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	memberID, err := callerMemberID(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	var req dto.SubmitReviewRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	review, err := h.service.SubmitReview(r.Context(), &req, memberID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, ReviewSuccessResponse{
+		Status:  "success",
+		Message: "Review submitted successfully",
+		Review:  dto.ToReviewResponse(review),
+	})
+}
+
+// GetReviews godoc
+//
+//	@Summary		Get reviews
+//	@Description	Get the reviews matching the filters, the most recent first. The status filter is only honoured for the librarians and for the members listing their own reviews, the other callers get the approved reviews.
+//	@Tags			reviews
+//	@Produce		json
+//	@Param			book_id			query		string	false	"Only the reviews of this book"
+//	@Param			member_id		query		string	false	"Only the reviews of this member"
+//	@Param			status			query		string	false	"Only the reviews with this status"	Enums(pending, approved, rejected)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	ReviewsSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/reviews [get]
+func (h *ReviewHandler) GetReviews(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewReviewFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	// the reviews waiting for moderation or rejected are only shown to the
+	// librarians and to their authors
+	if err := auth.ActFor(r.Context(), filter.MemberID); err != nil {
+		filter.Status = entity.ReviewStatusApproved
+	}
+
+	reviews, err := h.service.GetReviews(r.Context(), filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReviewsSuccessResponse{
+		Status:  "success",
+		Message: "Reviews retrieved successfully",
+		Reviews: dto.ToReviewsResponse(reviews),
+	})
+}
+
+// GetReviewByID godoc
+//
+//	@Summary		Get review by id
+//	@Description	Get a single review by its ID. A review which is not approved is only found by the librarians and by its author.
+//	@Tags			reviews
+//	@Produce		json
+//	@Param			review_id	path		string	true	"Review ID"
+//	@Success		200			{object}	ReviewSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/reviews/{review_id} [get]
+func (h *ReviewHandler) GetReviewByID(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	review, err := h.service.GetReviewByID(r.Context(), reviewID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if review.Status != entity.ReviewStatusApproved {
+		if err := auth.ActFor(r.Context(), review.MemberID.String()); err != nil {
+			h.handleError(w, ErrNotFound)
+			return
+		}
+	}
+
+	response.JSON(w, http.StatusOK, ReviewSuccessResponse{
+		Status:  "success",
+		Message: "Review retrieved successfully",
+		Review:  dto.ToReviewResponse(review),
+	})
+}
+
+// UpdateReview godoc
+//
+//	@Summary		Update a review
+//	@Description	Replace the rating and the text of a review of the authenticated member, it waits for moderation again
+//	@Tags			reviews
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			review_id		path		string					true	"Review ID"
+//	@Param			review			body		dto.UpdateReviewRequest	true	"Review data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	ReviewSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/reviews/{review_id} [put]
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	memberID, err := callerMemberID(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	var req dto.UpdateReviewRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	review, err := h.service.UpdateReview(r.Context(), &req, reviewID, memberID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReviewSuccessResponse{
+		Status:  "success",
+		Message: "Review updated successfully",
+		Review:  dto.ToReviewResponse(review),
+	})
+}
+
+// DeleteReview godoc
+//
+//	@Summary		Delete a review
+//	@Description	Delete a review of the authenticated member by its ID, the rating of the book no longer counts it
+//	@Tags			reviews
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			review_id	path		string	true	"Review ID"
+//	@Success		200			{object}	response.SuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/reviews/{review_id} [delete]
+func (h *ReviewHandler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	memberID, err := callerMemberID(r)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	if err := h.service.DeleteReview(r.Context(), reviewID, memberID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Review deleted successfully")
+}
+
+// ApproveReview godoc
+//
+//	@Summary		Approve a review
+//	@Description	Publish a pending or rejected review, its rating counts in the rating of the book
+//	@Tags			reviews
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			review_id	path		string	true	"Review ID"
+//	@Success		200			{object}	ReviewSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		409			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/reviews/{review_id}/approve [post]
+func (h *ReviewHandler) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	review, err := h.service.ApproveReview(r.Context(), reviewID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReviewSuccessResponse{
+		Status:  "success",
+		Message: "Review approved successfully",
+		Review:  dto.ToReviewResponse(review),
+	})
+}
+
+// RejectReview godoc
+//
+//	@Summary		Reject a review
+//	@Description	Withdraw a pending or approved review with the reason given to the member
+//	@Tags			reviews
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			review_id		path		string					true	"Review ID"
+//	@Param			rejection		body		dto.RejectReviewRequest	true	"Rejection data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	ReviewSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/reviews/{review_id}/reject [post]
+func (h *ReviewHandler) RejectReview(w http.ResponseWriter, r *http.Request) {
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.RejectReviewRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	review, err := h.service.RejectReview(r.Context(), &req, reviewID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, ReviewSuccessResponse{
+		Status:  "success",
+		Message: "Review rejected successfully",
+		Review:  dto.ToReviewResponse(review),
+	})
+}
+
+// callerMemberID returns the ID of the authenticated member, the subject of
+// its access token.
+func callerMemberID(r *http.Request) (uuid.UUID, error) {
+	identity, ok := auth.FromContext(r.Context())
+	if !ok {
+		return uuid.Nil, ErrNotMember
+	}
+
+	memberID, err := uuid.Parse(identity.Subject)
+	if err != nil {
+		return uuid.Nil, ErrNotMember
+	}
+
+	return memberID, nil
+}
+
+func (h *ReviewHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Review not found")
+	case errors.Is(err, book.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Book not found")
+	case errors.Is(err, member.ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Member not found")
+	case errors.Is(err, ErrInvalidBookID):
+		response.Error(w, http.StatusBadRequest, "invalid book ID")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Member has already reviewed this book")
+	case errors.Is(err, ErrAlreadyApproved):
+		response.Error(w, http.StatusConflict, "Review is already approved")
+	case errors.Is(err, ErrAlreadyRejected):
+		response.Error(w, http.StatusConflict, "Review is already rejected")
+	case errors.Is(err, ErrNotAuthor):
+		response.Error(w, http.StatusForbidden, "Review belongs to another member")
+	case errors.Is(err, ErrNotMember):
+		response.Error(w, http.StatusForbidden, "Access token does not identify a member")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
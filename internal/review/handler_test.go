@@ -0,0 +1,586 @@
+package review_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/auth"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/review"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestReviewHandler_SubmitReview(t *testing.T) {
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	reviewer := &auth.Identity{Subject: memberID.String()}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		requestBody        interface{}
+		configureMock      func(*mocks.MockReviewService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success submit review",
+			identity: reviewer,
+			requestBody: dto.SubmitReviewRequest{
+				BookID: bookID.String(),
+				Rating: 4,
+				Text:   "Heartbreaking.",
+			},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				input := &dto.SubmitReviewRequest{
+					BookID: bookID.String(),
+					Rating: 4,
+					Text:   "Heartbreaking.",
+				}
+
+				mockService.EXPECT().
+					SubmitReview(gomock.Any(), input, memberID).
+					Return(&entity.Review{
+						ID:        reviewID,
+						BookID:    bookID,
+						MemberID:  memberID,
+						Rating:    4,
+						Text:      "Heartbreaking.",
+						Status:    entity.ReviewStatusPending,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: &review.ReviewSuccessResponse{
+				Status:  "success",
+				Message: "Review submitted successfully",
+				Review: &dto.ReviewResponse{
+					ID:        reviewID.String(),
+					BookID:    bookID.String(),
+					MemberID:  memberID.String(),
+					Rating:    4,
+					Text:      "Heartbreaking.",
+					Status:    entity.ReviewStatusPending,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				},
+			},
+		},
+		{
+			name:               "error anonymous caller",
+			requestBody:        dto.SubmitReviewRequest{BookID: bookID.String(), Rating: 4},
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Unauthorized",
+			},
+		},
+		{
+			name:               "error caller is not a member",
+			identity:           &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			requestBody:        dto.SubmitReviewRequest{BookID: bookID.String(), Rating: 4},
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Access token does not identify a member",
+			},
+		},
+		{
+			name:     "error validation fails rating out of range",
+			identity: reviewer,
+			requestBody: dto.SubmitReviewRequest{
+				BookID: bookID.String(),
+				Rating: 6,
+			},
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "rating",
+					Message: "rating must be less than or equal to 5",
+				}},
+			},
+		},
+		{
+			name:     "error duplicate",
+			identity: reviewer,
+			requestBody: dto.SubmitReviewRequest{
+				BookID: bookID.String(),
+				Rating: 2,
+			},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					SubmitReview(gomock.Any(), gomock.Any(), memberID).
+					Return(nil, review.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: &response.ErrorResponse{
+				Status:  "error",
+				Message: "Member has already reviewed this book",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockReviewService(ctrl)
+			test.configureMock(mockService)
+
+			handler := review.NewReviewHandler(mockService, validator.New(), zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/reviews", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/reviews", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestReviewHandler_GetReviews(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		query              string
+		configureMock      func(*mocks.MockReviewService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:     "success filters on the status",
+			identity: librarian,
+			query:    "book_id=" + bookID.String() + "&status=pending",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviews(gomock.Any(), dto.ReviewFilter{BookID: bookID.String(), Status: entity.ReviewStatusPending}).
+					Return([]*entity.Review{{ID: reviewID, BookID: bookID, MemberID: memberID, Rating: 3, Status: entity.ReviewStatusPending}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &review.ReviewsSuccessResponse{
+				Status:  "success",
+				Message: "Reviews retrieved successfully",
+				Reviews: []dto.ReviewResponse{{
+					ID:       reviewID.String(),
+					BookID:   bookID.String(),
+					MemberID: memberID.String(),
+					Rating:   3,
+					Status:   entity.ReviewStatusPending,
+				}},
+			},
+		},
+		{
+			name:     "success member filters their own reviews on the status",
+			identity: &auth.Identity{Subject: memberID.String()},
+			query:    "member_id=" + memberID.String() + "&status=rejected",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviews(gomock.Any(), dto.ReviewFilter{MemberID: memberID.String(), Status: entity.ReviewStatusRejected}).
+					Return([]*entity.Review{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &review.ReviewsSuccessResponse{
+				Status:  "success",
+				Message: "Reviews retrieved successfully",
+				Reviews: []dto.ReviewResponse{},
+			},
+		},
+		{
+			name:     "success member gets the approved reviews of the others",
+			identity: &auth.Identity{Subject: memberID.String()},
+			query:    "book_id=" + bookID.String() + "&status=pending",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviews(gomock.Any(), dto.ReviewFilter{BookID: bookID.String(), Status: entity.ReviewStatusApproved}).
+					Return([]*entity.Review{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &review.ReviewsSuccessResponse{
+				Status:  "success",
+				Message: "Reviews retrieved successfully",
+				Reviews: []dto.ReviewResponse{},
+			},
+		},
+		{
+			name:  "success anonymous caller gets the approved reviews",
+			query: "member_id=" + memberID.String(),
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviews(gomock.Any(), dto.ReviewFilter{MemberID: memberID.String(), Status: entity.ReviewStatusApproved}).
+					Return([]*entity.Review{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &review.ReviewsSuccessResponse{
+				Status:  "success",
+				Message: "Reviews retrieved successfully",
+				Reviews: []dto.ReviewResponse{},
+			},
+		},
+		{
+			name:               "error invalid status",
+			query:              "status=hidden",
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "status",
+					Message: "status must be one of [pending approved rejected]",
+				}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockReviewService(ctrl)
+			test.configureMock(mockService)
+
+			handler := review.NewReviewHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/reviews?"+test.query, nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/reviews", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestReviewHandler_GetReviewByID(t *testing.T) {
+	pending := &entity.Review{ID: reviewID, BookID: bookID, MemberID: memberID, Rating: 2, Status: entity.ReviewStatusPending}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		configureMock      func(*mocks.MockReviewService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name: "success approved review for an anonymous caller",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviewByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, MemberID: memberID, Rating: 5, Status: entity.ReviewStatusApproved}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review retrieved successfully",
+		},
+		{
+			name:     "success pending review for its author",
+			identity: &auth.Identity{Subject: memberID.String()},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviewByID(gomock.Any(), reviewID).
+					Return(pending, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review retrieved successfully",
+		},
+		{
+			name:     "success pending review for a librarian",
+			identity: &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviewByID(gomock.Any(), reviewID).
+					Return(pending, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review retrieved successfully",
+		},
+		{
+			name:     "error pending review of another member",
+			identity: &auth.Identity{Subject: "5d8e1f3a-7b2c-4e6d-9a1f-3c5e7b9d1f2a"},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviewByID(gomock.Any(), reviewID).
+					Return(pending, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Review not found",
+		},
+		{
+			name: "error pending review for an anonymous caller",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					GetReviewByID(gomock.Any(), reviewID).
+					Return(pending, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Review not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockReviewService(ctrl)
+			test.configureMock(mockService)
+
+			handler := review.NewReviewHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/reviews/"+reviewID.String(), nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/reviews", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
+
+func TestReviewHandler_Moderation(t *testing.T) {
+	librarian := &auth.Identity{Subject: "staff-1", Roles: []string{auth.RoleLibrarian}}
+
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		action             string
+		requestBody        string
+		configureMock      func(*mocks.MockReviewService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:     "success approve",
+			identity: librarian,
+			action:   "approve",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					ApproveReview(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, Status: entity.ReviewStatusApproved}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review approved successfully",
+		},
+		{
+			name:               "error member moderating",
+			identity:           &auth.Identity{Subject: memberID.String()},
+			action:             "approve",
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    "Forbidden",
+		},
+		{
+			name:     "error already approved",
+			identity: librarian,
+			action:   "approve",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					ApproveReview(gomock.Any(), reviewID).
+					Return(nil, review.ErrAlreadyApproved)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Review is already approved",
+		},
+		{
+			name:        "success reject",
+			identity:    librarian,
+			action:      "reject",
+			requestBody: `{"reason":"Spoilers"}`,
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					RejectReview(gomock.Any(), &dto.RejectReviewRequest{Reason: "Spoilers"}, reviewID).
+					Return(&entity.Review{ID: reviewID, Status: entity.ReviewStatusRejected, Reason: "Spoilers"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review rejected successfully",
+		},
+		{
+			name:               "error reject without reason",
+			identity:           librarian,
+			action:             "reject",
+			requestBody:        `{}`,
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Validation failed",
+		},
+		{
+			name:     "error review not found",
+			identity: librarian,
+			action:   "approve",
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					ApproveReview(gomock.Any(), reviewID).
+					Return(nil, review.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Review not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockReviewService(ctrl)
+			test.configureMock(mockService)
+
+			handler := review.NewReviewHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, "/reviews/"+reviewID.String()+"/"+test.action, bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/reviews", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
+
+func TestReviewHandler_DeleteReview(t *testing.T) {
+	tests := []struct {
+		name               string
+		identity           *auth.Identity
+		configureMock      func(*mocks.MockReviewService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:     "success delete own review",
+			identity: &auth.Identity{Subject: memberID.String()},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					DeleteReview(gomock.Any(), reviewID, memberID).
+					Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    "Review deleted successfully",
+		},
+		{
+			name:     "error review of another member",
+			identity: &auth.Identity{Subject: memberID.String()},
+			configureMock: func(mockService *mocks.MockReviewService) {
+				mockService.EXPECT().
+					DeleteReview(gomock.Any(), reviewID, memberID).
+					Return(review.ErrNotAuthor)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    "Review belongs to another member",
+		},
+		{
+			name:               "error anonymous caller",
+			configureMock:      func(mockService *mocks.MockReviewService) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    "Unauthorized",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockReviewService(ctrl)
+			test.configureMock(mockService)
+
+			handler := review.NewReviewHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodDelete, "/reviews/"+reviewID.String(), nil)
+			if test.identity != nil {
+				req = req.WithContext(auth.NewContext(req.Context(), test.identity))
+			}
+
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/reviews", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_review_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/review ReviewRepository
+type ReviewRepository interface {
+	// Create returns ErrDuplicate when the member already reviewed the book.
+	Create(ctx context.Context, newReview *entity.Review) (*entity.Review, error)
+	GetAll(ctx context.Context, filter dto.ReviewFilter) ([]*entity.Review, error)
+	GetByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error)
+	LockByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error)
+	Update(ctx context.Context, review *entity.Review) (*entity.Review, error)
+	Delete(ctx context.Context, reviewID uuid.UUID) error
+	// RefreshRating recomputes the rating of the book from its approved
+	// reviews, the average is rounded to two decimals.
+	RefreshRating(ctx context.Context, bookID uuid.UUID) error
+}
+
+type reviewRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewReviewRepository(db *gorm.DB, logger zerolog.Logger) ReviewRepository {
+	return &reviewRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *reviewRepository) Create(ctx context.Context, newReview *entity.Review) (*entity.Review, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newReview).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newReview, nil
+}
+
+// GetAll returns the filtered reviews, the most recent first.
+func (r *reviewRepository) GetAll(ctx context.Context, filter dto.ReviewFilter) ([]*entity.Review, error) {
+	var reviews []*entity.Review
+
+	query := transaction.DB(ctx, r.db)
+
+	if filter.BookID != "" {
+		query = query.Where("book_id = ?", filter.BookID)
+	}
+
+	if filter.MemberID != "" {
+		query = query.Where("member_id = ?", filter.MemberID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Order("created_at DESC, id").Find(&reviews).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	return r.first(transaction.DB(ctx, r.db), reviewID)
+}
+
+// LockByID reads the review and locks its row until the end of the
+// transaction carried by ctx.
+func (r *reviewRepository) LockByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	return r.first(transaction.DB(ctx, r.db).Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), reviewID)
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *entity.Review) (*entity.Review, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(review).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, reviewID uuid.UUID) error {
+	if err := transaction.DB(ctx, r.db).Where("id = ?", reviewID).Delete(&entity.Review{}).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) RefreshRating(ctx context.Context, bookID uuid.UUID) error {
+	var summary struct {
+		Count int64
+		Total int64
+	}
+
+	err := transaction.DB(ctx, r.db).
+		Model(&entity.Review{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(rating), 0) AS total").
+		Where("book_id = ? AND status = ?", bookID, entity.ReviewStatusApproved).
+		Scan(&summary).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	average := 0.0
+	if summary.Count > 0 {
+		average = math.Round(float64(summary.Total)/float64(summary.Count)*100) / 100
+	}
+
+	// the columns are updated alone, the rating is not an edit of the book
+	err = transaction.DB(ctx, r.db).
+		Model(&entity.Book{}).
+		Where("id = ?", bookID).
+		UpdateColumns(map[string]any{"rating_average": average, "rating_count": summary.Count}).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+func (r *reviewRepository) first(query *gorm.DB, reviewID uuid.UUID) (*entity.Review, error) {
+	var review *entity.Review
+
+	if err := query.First(&review, "id = ?", reviewID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return review, nil
+}
@@ -0,0 +1,234 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/review"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var reviewColumns = []string{"id", "book_id", "member_id", "rating", "text", "status", "reason", "moderated_at", "created_at", "updated_at"}
+
+func TestReviewRepository_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Review
+		configureMock    func(sqlmock.Sqlmock, *entity.Review)
+		expectedError    error
+		expectedResponse *entity.Review
+	}{
+		{
+			name:  "success create review",
+			input: &entity.Review{BookID: bookID, MemberID: memberID, Rating: 4, Status: entity.ReviewStatusPending},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Review) {
+				mock.ExpectExec(`INSERT INTO .reviews.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.BookID,
+						input.MemberID,
+						input.Rating,
+						input.Text,
+						input.Status,
+						input.Reason,
+						input.ModeratedAt,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Review{BookID: bookID, MemberID: memberID, Rating: 4},
+		},
+		{
+			name:  "error member already reviewed the book",
+			input: &entity.Review{BookID: bookID, MemberID: memberID, Rating: 2, Status: entity.ReviewStatusPending},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Review) {
+				mock.ExpectExec(`INSERT INTO .reviews.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: review.ErrDuplicate,
+		},
+		{
+			name:  "error database connection failed",
+			input: &entity.Review{BookID: bookID, MemberID: memberID, Rating: 4, Status: entity.ReviewStatusPending},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Review) {
+				mock.ExpectExec(`INSERT INTO .reviews.`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := review.NewReviewRepository(db, zerolog.Nop())
+
+			newReview, err := repo.Create(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newReview)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.BookID, newReview.BookID)
+				assert.Equal(t, test.expectedResponse.MemberID, newReview.MemberID)
+				assert.Equal(t, test.expectedResponse.Rating, newReview.Rating)
+				assert.NotEqual(t, uuid.Nil, newReview.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReviewRepository_GetAll(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          dto.ReviewFilter
+		configureMock   func(sqlmock.Sqlmock)
+		expectedError   error
+		expectedRatings []int
+	}{
+		{
+			name:   "success the most recent first",
+			filter: dto.ReviewFilter{BookID: bookID.String()},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(reviewColumns).
+					AddRow(uuid.New(), bookID, memberID, 3, "", entity.ReviewStatusApproved, "", nil, now, now).
+					AddRow(uuid.New(), bookID, memberID, 1, "", entity.ReviewStatusPending, "", nil, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .reviews. WHERE book_id = \? ORDER BY created_at DESC, id`).
+					WithArgs(bookID.String()).
+					WillReturnRows(rows)
+			},
+			expectedRatings: []int{3, 1},
+		},
+		{
+			name:   "success by member and status",
+			filter: dto.ReviewFilter{MemberID: memberID.String(), Status: entity.ReviewStatusApproved},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				rows := sqlmock.NewRows(reviewColumns).
+					AddRow(uuid.New(), bookID, memberID, 5, "", entity.ReviewStatusApproved, "", now, now, now)
+
+				mock.ExpectQuery(`SELECT \* FROM .reviews. WHERE member_id = \? AND status = \? ORDER BY created_at DESC, id`).
+					WithArgs(memberID.String(), entity.ReviewStatusApproved).
+					WillReturnRows(rows)
+			},
+			expectedRatings: []int{5},
+		},
+		{
+			name:   "error database connection failed",
+			filter: dto.ReviewFilter{},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .reviews. ORDER BY created_at DESC, id`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := review.NewReviewRepository(db, zerolog.Nop())
+
+			reviews, err := repo.GetAll(context.Background(), test.filter)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, reviews)
+			} else {
+				require.NoError(t, err)
+
+				ratings := make([]int, len(reviews))
+				for i, r := range reviews {
+					ratings[i] = r.Rating
+				}
+				assert.Equal(t, test.expectedRatings, ratings)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReviewRepository_RefreshRating(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "success average of the approved reviews",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) AS count, COALESCE\(SUM\(rating\), 0\) AS total FROM .reviews. WHERE book_id = \? AND status = \?`).
+					WithArgs(bookID, entity.ReviewStatusApproved).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "total"}).AddRow(2, 9))
+
+				// the updated_at column is left alone
+				mock.ExpectExec("UPDATE .books. SET .rating_average.=\\?,.rating_count.=\\? WHERE id = \\?$").
+					WithArgs(4.5, int64(2), bookID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "success no approved review",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) AS count, COALESCE\(SUM\(rating\), 0\) AS total FROM .reviews. WHERE book_id = \? AND status = \?`).
+					WithArgs(bookID, entity.ReviewStatusApproved).
+					WillReturnRows(sqlmock.NewRows([]string{"count", "total"}).AddRow(0, 0))
+
+				mock.ExpectExec("UPDATE .books. SET .rating_average.=\\?,.rating_count.=\\? WHERE id = \\?$").
+					WithArgs(0.0, int64(0), bookID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT COUNT\(\*\) AS count, COALESCE\(SUM\(rating\), 0\) AS total FROM .reviews. WHERE book_id = \? AND status = \?`).
+					WithArgs(bookID, entity.ReviewStatusApproved).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := review.NewReviewRepository(db, zerolog.Nop())
+
+			err := repo.RefreshRating(context.Background(), bookID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
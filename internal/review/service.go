@@ -0,0 +1,225 @@
+package review
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/member"
+	"go-boilerplate-rest-api-chi/internal/outbox"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_review_service.go -package=mocks go-boilerplate-rest-api-chi/internal/review ReviewService
+type ReviewService interface {
+	// SubmitReview records the review of the member, pending moderation.
+	SubmitReview(ctx context.Context, req *dto.SubmitReviewRequest, memberID uuid.UUID) (*entity.Review, error)
+	GetReviews(ctx context.Context, filter dto.ReviewFilter) ([]*entity.Review, error)
+	GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error)
+	// UpdateReview replaces the rating and the text of the review of the
+	// member, which waits for moderation again.
+	UpdateReview(ctx context.Context, req *dto.UpdateReviewRequest, reviewID, memberID uuid.UUID) (*entity.Review, error)
+	// DeleteReview deletes the review of the member.
+	DeleteReview(ctx context.Context, reviewID, memberID uuid.UUID) error
+	// ApproveReview publishes a pending or rejected review, its rating counts
+	// in the rating of the book.
+	ApproveReview(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error)
+	// RejectReview withdraws a pending or approved review with the reason.
+	RejectReview(ctx context.Context, req *dto.RejectReviewRequest, reviewID uuid.UUID) (*entity.Review, error)
+}
+
+type reviewService struct {
+	repository       ReviewRepository
+	bookRepository   book.BookRepository
+	memberRepository member.MemberRepository
+	transactions     transaction.Manager
+	outbox           outbox.Outbox
+	logger           zerolog.Logger
+}
+
+func NewReviewService(repository ReviewRepository, bookRepository book.BookRepository, memberRepository member.MemberRepository, transactions transaction.Manager, outbox outbox.Outbox, logger zerolog.Logger) ReviewService {
+	return &reviewService{
+		repository:       repository,
+		bookRepository:   bookRepository,
+		memberRepository: memberRepository,
+		transactions:     transactions,
+		outbox:           outbox,
+		logger:           logger,
+	}
+}
+
+func (s *reviewService) SubmitReview(ctx context.Context, req *dto.SubmitReviewRequest, memberID uuid.UUID) (*entity.Review, error) {
+	bookID, err := uuid.Parse(req.BookID)
+	if err != nil {
+		return nil, ErrInvalidBookID
+	}
+
+	var review *entity.Review
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.memberRepository.LockByID(ctx, memberID); err != nil {
+			return err
+		}
+
+		if _, err := s.bookRepository.LockByID(ctx, bookID); err != nil {
+			return err
+		}
+
+		review, err = s.repository.Create(ctx, &entity.Review{
+			BookID:   bookID,
+			MemberID: memberID,
+			Rating:   req.Rating,
+			Text:     req.Text,
+			Status:   entity.ReviewStatusPending,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.outbox.Record(ctx, event.NewReviewSubmitted(review))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (s *reviewService) GetReviews(ctx context.Context, filter dto.ReviewFilter) ([]*entity.Review, error) {
+	return s.repository.GetAll(ctx, filter)
+}
+
+func (s *reviewService) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	return s.repository.GetByID(ctx, reviewID)
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, req *dto.UpdateReviewRequest, reviewID, memberID uuid.UUID) (*entity.Review, error) {
+	return s.change(ctx, reviewID, func(review *entity.Review) (event.Event, error) {
+		if review.MemberID != memberID {
+			return event.Event{}, ErrNotAuthor
+		}
+
+		review.Rating = req.Rating
+		review.Text = req.Text
+		review.Status = entity.ReviewStatusPending
+		review.Reason = ""
+		review.ModeratedAt = nil
+
+		return event.NewReviewUpdated(review), nil
+	})
+}
+
+func (s *reviewService) DeleteReview(ctx context.Context, reviewID, memberID uuid.UUID) error {
+	review, err := s.repository.GetByID(ctx, reviewID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.bookRepository.LockByID(ctx, review.BookID); err != nil {
+			return err
+		}
+
+		locked, err := s.repository.LockByID(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+
+		if locked.MemberID != memberID {
+			return ErrNotAuthor
+		}
+
+		if err := s.repository.Delete(ctx, reviewID); err != nil {
+			return err
+		}
+
+		if locked.Status == entity.ReviewStatusApproved {
+			if err := s.repository.RefreshRating(ctx, locked.BookID); err != nil {
+				return err
+			}
+		}
+
+		return s.outbox.Record(ctx, event.NewReviewDeleted(locked))
+	})
+}
+
+func (s *reviewService) ApproveReview(ctx context.Context, reviewID uuid.UUID) (*entity.Review, error) {
+	return s.change(ctx, reviewID, func(review *entity.Review) (event.Event, error) {
+		if review.Status == entity.ReviewStatusApproved {
+			return event.Event{}, ErrAlreadyApproved
+		}
+
+		now := time.Now().UTC()
+		review.Status = entity.ReviewStatusApproved
+		review.Reason = ""
+		review.ModeratedAt = &now
+
+		return event.NewReviewApproved(review), nil
+	})
+}
+
+func (s *reviewService) RejectReview(ctx context.Context, req *dto.RejectReviewRequest, reviewID uuid.UUID) (*entity.Review, error) {
+	return s.change(ctx, reviewID, func(review *entity.Review) (event.Event, error) {
+		if review.Status == entity.ReviewStatusRejected {
+			return event.Event{}, ErrAlreadyRejected
+		}
+
+		now := time.Now().UTC()
+		review.Status = entity.ReviewStatusRejected
+		review.Reason = req.Reason
+		review.ModeratedAt = &now
+
+		return event.NewReviewRejected(review), nil
+	})
+}
+
+// change applies fn to the locked review and saves it. The book stays locked
+// until the commit, its rating is recomputed when the review was or becomes
+// approved.
+func (s *reviewService) change(ctx context.Context, reviewID uuid.UUID, fn func(review *entity.Review) (event.Event, error)) (*entity.Review, error) {
+	review, err := s.repository.GetByID(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.bookRepository.LockByID(ctx, review.BookID); err != nil {
+			return err
+		}
+
+		locked, err := s.repository.LockByID(ctx, reviewID)
+		if err != nil {
+			return err
+		}
+
+		wasApproved := locked.Status == entity.ReviewStatusApproved
+
+		changed, err := fn(locked)
+		if err != nil {
+			return err
+		}
+
+		if review, err = s.repository.Update(ctx, locked); err != nil {
+			return err
+		}
+
+		if wasApproved || review.Status == entity.ReviewStatusApproved {
+			if err := s.repository.RefreshRating(ctx, review.BookID); err != nil {
+				return err
+			}
+		}
+
+		return s.outbox.Record(ctx, changed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
@@ -0,0 +1,477 @@
+package review_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/review"
+	"go-boilerplate-rest-api-chi/internal/review/dto"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	bookID   = uuid.MustParse("d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f")
+	memberID = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	reviewID = uuid.MustParse("3f6b2c1d-8e4a-4f7b-9c2d-1a0e5b6c7d8e")
+)
+
+func TestReviewService_SubmitReview(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.SubmitReviewRequest
+		configureMock    func(*mocks.MockReviewRepository, *mocks.MockBookRepository, *mocks.MockMemberRepository, *mocks.MockOutbox)
+		expectedResponse *entity.Review
+		expectedError    error
+	}{
+		{
+			name:  "success pending moderation",
+			input: &dto.SubmitReviewRequest{BookID: bookID.String(), Rating: 4, Text: "Heartbreaking."},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) {
+						r.ID = reviewID
+						return r, nil
+					})
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ReviewSubmitted, events[0].Type)
+						assert.Equal(t, reviewID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.Review{
+				ID:       reviewID,
+				BookID:   bookID,
+				MemberID: memberID,
+				Rating:   4,
+				Text:     "Heartbreaking.",
+				Status:   entity.ReviewStatusPending,
+			},
+		},
+		{
+			name:  "error book not found",
+			input: &dto.SubmitReviewRequest{BookID: bookID.String(), Rating: 4, Text: "Heartbreaking."},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(nil, book.ErrNotFound)
+			},
+			expectedError: book.ErrNotFound,
+		},
+		{
+			name:  "error already reviewed",
+			input: &dto.SubmitReviewRequest{BookID: bookID.String(), Rating: 4, Text: "Heartbreaking."},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockMemberRepo *mocks.MockMemberRepository, mockOutbox *mocks.MockOutbox) {
+				mockMemberRepo.EXPECT().LockByID(gomock.Any(), memberID).Return(&entity.Member{ID: memberID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, review.ErrDuplicate)
+			},
+			expectedError: review.ErrDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			reviewRepoMock := mocks.NewMockReviewRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			memberRepoMock := mocks.NewMockMemberRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(reviewRepoMock, bookRepoMock, memberRepoMock, outboxMock)
+			service := review.NewReviewService(reviewRepoMock, bookRepoMock, memberRepoMock, testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			result, err := service.SubmitReview(context.Background(), test.input, memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestReviewService_UpdateReview(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.UpdateReviewRequest
+		configureMock    func(*mocks.MockReviewRepository, *mocks.MockBookRepository, *mocks.MockOutbox)
+		expectedResponse *entity.Review
+		expectedError    error
+	}{
+		{
+			name:  "success review waits for moderation again",
+			input: &dto.UpdateReviewRequest{Rating: 2},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, MemberID: memberID, Rating: 5, Status: entity.ReviewStatusApproved}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) { return r, nil })
+
+				// the approved review no longer counts until approved again
+				mockRepo.EXPECT().
+					RefreshRating(gomock.Any(), bookID).
+					Return(nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ReviewUpdated, events[0].Type)
+						assert.Equal(t, reviewID, events[0].AggregateID)
+						return nil
+					})
+			},
+			expectedResponse: &entity.Review{ID: reviewID, BookID: bookID, MemberID: memberID, Rating: 2, Status: entity.ReviewStatusPending},
+		},
+		{
+			name:  "error review of another member",
+			input: &dto.UpdateReviewRequest{Rating: 1},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, MemberID: uuid.New(), Rating: 5, Status: entity.ReviewStatusApproved}, nil)
+			},
+			expectedError: review.ErrNotAuthor,
+		},
+		{
+			name:  "error review not found",
+			input: &dto.UpdateReviewRequest{Rating: 1},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(nil, review.ErrNotFound)
+			},
+			expectedError: review.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			reviewRepoMock := mocks.NewMockReviewRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(reviewRepoMock, bookRepoMock, outboxMock)
+			service := review.NewReviewService(reviewRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			result, err := service.UpdateReview(context.Background(), test.input, reviewID, memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestReviewService_ApproveReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockReviewRepository, *mocks.MockBookRepository, *mocks.MockOutbox)
+		expectedError error
+	}{
+		{
+			name: "success pending review",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusPending}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) { return r, nil })
+
+				mockRepo.EXPECT().
+					RefreshRating(gomock.Any(), bookID).
+					Return(nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ReviewApproved, events[0].Type)
+						assert.Equal(t, reviewID, events[0].AggregateID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "success rejected review",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusRejected, Reason: "Spoilers"}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) { return r, nil })
+
+				mockRepo.EXPECT().
+					RefreshRating(gomock.Any(), bookID).
+					Return(nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "error already approved",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusApproved}, nil)
+			},
+			expectedError: review.ErrAlreadyApproved,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			reviewRepoMock := mocks.NewMockReviewRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(reviewRepoMock, bookRepoMock, outboxMock)
+			service := review.NewReviewService(reviewRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			result, err := service.ApproveReview(context.Background(), reviewID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.ReviewStatusApproved, result.Status)
+			assert.Empty(t, result.Reason)
+			assert.NotNil(t, result.ModeratedAt)
+		})
+	}
+}
+
+func TestReviewService_RejectReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *dto.RejectReviewRequest
+		configureMock func(*mocks.MockReviewRepository, *mocks.MockBookRepository, *mocks.MockOutbox)
+		expectedError error
+	}{
+		{
+			name:  "success pending review",
+			input: &dto.RejectReviewRequest{Reason: "Spoilers"},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusPending}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) { return r, nil })
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ReviewRejected, events[0].Type)
+						assert.Equal(t, reviewID, events[0].AggregateID)
+						return nil
+					})
+			},
+		},
+		{
+			name:  "success approved review",
+			input: &dto.RejectReviewRequest{Reason: "Spoilers"},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusApproved}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, r *entity.Review) (*entity.Review, error) { return r, nil })
+
+				// the approved review no longer counts
+				mockRepo.EXPECT().
+					RefreshRating(gomock.Any(), bookID).
+					Return(nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name:  "error already rejected",
+			input: &dto.RejectReviewRequest{Reason: "Spoilers"},
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, Rating: 4, Status: entity.ReviewStatusRejected}, nil)
+			},
+			expectedError: review.ErrAlreadyRejected,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			reviewRepoMock := mocks.NewMockReviewRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(reviewRepoMock, bookRepoMock, outboxMock)
+			service := review.NewReviewService(reviewRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			result, err := service.RejectReview(context.Background(), test.input, reviewID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.ReviewStatusRejected, result.Status)
+			assert.Equal(t, "Spoilers", result.Reason)
+		})
+	}
+}
+
+func TestReviewService_DeleteReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockReviewRepository, *mocks.MockBookRepository, *mocks.MockOutbox)
+		expectedError error
+	}{
+		{
+			name: "success approved review",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, MemberID: memberID, Status: entity.ReviewStatusApproved}, nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), reviewID).
+					Return(nil)
+
+				mockRepo.EXPECT().
+					RefreshRating(gomock.Any(), bookID).
+					Return(nil)
+
+				mockOutbox.EXPECT().
+					Record(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, events ...event.Event) error {
+						assert.Len(t, events, 1)
+						assert.Equal(t, event.ReviewDeleted, events[0].Type)
+						assert.Equal(t, reviewID, events[0].AggregateID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "error review of another member",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(&entity.Review{ID: reviewID, BookID: bookID}, nil)
+				mockBookRepo.EXPECT().LockByID(gomock.Any(), bookID).Return(&entity.Book{ID: bookID}, nil)
+
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), reviewID).
+					Return(&entity.Review{ID: reviewID, BookID: bookID, MemberID: uuid.New(), Status: entity.ReviewStatusApproved}, nil)
+			},
+			expectedError: review.ErrNotAuthor,
+		},
+		{
+			name: "error review not found",
+			configureMock: func(mockRepo *mocks.MockReviewRepository, mockBookRepo *mocks.MockBookRepository, mockOutbox *mocks.MockOutbox) {
+				mockRepo.EXPECT().GetByID(gomock.Any(), reviewID).Return(nil, review.ErrNotFound)
+			},
+			expectedError: review.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			reviewRepoMock := mocks.NewMockReviewRepository(ctrl)
+			bookRepoMock := mocks.NewMockBookRepository(ctrl)
+			outboxMock := mocks.NewMockOutbox(ctrl)
+
+			test.configureMock(reviewRepoMock, bookRepoMock, outboxMock)
+			service := review.NewReviewService(reviewRepoMock, bookRepoMock, mocks.NewMockMemberRepository(ctrl), testutils.NewTransactionManager(ctrl), outboxMock, zerolog.Nop())
+
+			err := service.DeleteReview(context.Background(), reviewID, memberID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/author/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+	libraryv1 "go-boilerplate-rest-api-chi/pkg/pb/library/v1"
+)
+
+type AuthorServer struct {
+	libraryv1.UnimplementedAuthorServiceServer
+
+	service   author.AuthorService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewAuthorServer(service author.AuthorService, validator *internalValidator.Validator, logger zerolog.Logger) *AuthorServer {
+	return &AuthorServer{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (s *AuthorServer) CreateAuthor(ctx context.Context, in *libraryv1.CreateAuthorRequest) (*libraryv1.CreateAuthorResponse, error) {
+	req := dto.CreateAuthorRequest{
+		Name: in.GetName(),
+	}
+
+	if err := s.validator.Struct(&req); err != nil {
+		return nil, invalidArgument(s.validator.FormatErrors(err, acceptLanguage(ctx)))
+	}
+
+	created, err := s.service.CreateAuthor(ctx, &req)
+	if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.CreateAuthorResponse{Author: toAuthor(created)}, nil
+}
+
+func (s *AuthorServer) GetAuthor(ctx context.Context, in *libraryv1.GetAuthorRequest) (*libraryv1.GetAuthorResponse, error) {
+	authorID, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, errInvalidUUID
+	}
+
+	found, err := s.service.GetAuthorByID(ctx, authorID)
+	if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.GetAuthorResponse{Author: toAuthor(found)}, nil
+}
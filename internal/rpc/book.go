@@ -0,0 +1,143 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+	libraryv1 "go-boilerplate-rest-api-chi/pkg/pb/library/v1"
+)
+
+type BookServer struct {
+	libraryv1.UnimplementedBookServiceServer
+
+	service   book.BookService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewBookServer(service book.BookService, validator *internalValidator.Validator, logger zerolog.Logger) *BookServer {
+	return &BookServer{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (s *BookServer) CreateBook(ctx context.Context, in *libraryv1.CreateBookRequest) (*libraryv1.CreateBookResponse, error) {
+	// a missing author is reported by the validation
+	var authorID uuid.UUID
+	if in.GetAuthorId() != "" {
+		var err error
+		if authorID, err = uuid.Parse(in.GetAuthorId()); err != nil {
+			return nil, errInvalidUUID
+		}
+	}
+
+	req := dto.CreateBookRequest{
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		AuthorID:    authorID,
+	}
+
+	if err := s.validator.Struct(&req); err != nil {
+		return nil, invalidArgument(s.validator.FormatErrors(err, acceptLanguage(ctx)))
+	}
+
+	created, err := s.service.CreateBook(ctx, &req)
+	if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.CreateBookResponse{Book: toBook(created)}, nil
+}
+
+func (s *BookServer) GetBook(ctx context.Context, in *libraryv1.GetBookRequest) (*libraryv1.GetBookResponse, error) {
+	bookID, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, errInvalidUUID
+	}
+
+	found, err := s.service.GetBookByID(ctx, bookID)
+	if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.GetBookResponse{Book: toBook(found)}, nil
+}
+
+func (s *BookServer) ListBooks(ctx context.Context, in *libraryv1.ListBooksRequest) (*libraryv1.ListBooksResponse, error) {
+	filter := dto.BookFilter{
+		AuthorID: strings.TrimSpace(in.GetAuthorId()),
+		Title:    strings.TrimSpace(in.GetTitle()),
+	}
+
+	if err := s.validator.Struct(&filter); err != nil {
+		return nil, invalidArgument(s.validator.FormatErrors(err, acceptLanguage(ctx)))
+	}
+
+	if in.GetPageSize() < 0 {
+		return nil, errInvalidPageSize
+	}
+
+	// an unset page size takes the default, a larger one is cut to the maximum
+	page := pagination.Page{
+		Limit:  pagination.DefaultLimit,
+		Cursor: strings.TrimSpace(in.GetPageToken()),
+	}
+	if in.GetPageSize() > 0 {
+		page.Limit = min(int(in.GetPageSize()), pagination.MaxLimit)
+	}
+
+	books, next, err := s.service.GetAllBooks(ctx, filter, page)
+	if errors.Is(err, book.ErrNotFound) {
+		books = []*entity.Book{}
+	} else if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.ListBooksResponse{Books: toBooks(books), NextPageToken: next}, nil
+}
+
+func (s *BookServer) UpdateBook(ctx context.Context, in *libraryv1.UpdateBookRequest) (*libraryv1.UpdateBookResponse, error) {
+	bookID, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, errInvalidUUID
+	}
+
+	req := dto.UpdateBookRequest{
+		Description: in.GetDescription(),
+	}
+
+	if err := s.validator.Struct(&req); err != nil {
+		return nil, invalidArgument(s.validator.FormatErrors(err, acceptLanguage(ctx)))
+	}
+
+	updated, err := s.service.UpdateBook(ctx, &req, bookID)
+	if err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.UpdateBookResponse{Book: toBook(updated)}, nil
+}
+
+func (s *BookServer) DeleteBook(ctx context.Context, in *libraryv1.DeleteBookRequest) (*libraryv1.DeleteBookResponse, error) {
+	bookID, err := uuid.Parse(in.GetId())
+	if err != nil {
+		return nil, errInvalidUUID
+	}
+
+	if err := s.service.DeleteBook(ctx, bookID); err != nil {
+		return nil, toStatus(err, s.logger)
+	}
+
+	return &libraryv1.DeleteBookResponse{}, nil
+}
@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+	libraryv1 "go-boilerplate-rest-api-chi/pkg/pb/library/v1"
+)
+
+func toAuthor(author *entity.Author) *libraryv1.Author {
+	return &libraryv1.Author{
+		Id:   author.ID.String(),
+		Name: author.Name,
+	}
+}
+
+func toBook(book *entity.Book) *libraryv1.Book {
+	pb := &libraryv1.Book{
+		Id:          book.ID.String(),
+		Title:       book.Title,
+		Description: book.Description,
+	}
+
+	if book.Author != nil {
+		pb.Author = toAuthor(book.Author)
+	}
+
+	return pb
+}
+
+func toBooks(books []*entity.Book) []*libraryv1.Book {
+	pbs := make([]*libraryv1.Book, len(books))
+	for i, book := range books {
+		pbs[i] = toBook(book)
+	}
+	return pbs
+}
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+var (
+	errInvalidUUID     = status.Error(codes.InvalidArgument, "Invalid uuid")
+	errInvalidPageSize = status.Error(codes.InvalidArgument, "Page size must not be negative")
+)
+
+// toStatus converts an error of the services to a gRPC status, unexpected
+// errors are logged and hidden behind INTERNAL.
+func toStatus(err error, logger zerolog.Logger) error {
+	switch {
+	case errors.Is(err, book.ErrNotFound):
+		return status.Error(codes.NotFound, "Book not found")
+	case errors.Is(err, book.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, "Book with this name already exists")
+	case errors.Is(err, book.ErrHasLoans):
+		return status.Error(codes.FailedPrecondition, "Book has loans and cannot be deleted")
+	case errors.Is(err, author.ErrNotFound):
+		return status.Error(codes.NotFound, "Author not found")
+	case errors.Is(err, author.ErrDuplicate):
+		return status.Error(codes.AlreadyExists, "Author with this identifier already exists")
+	case errors.Is(err, pagination.ErrInvalidCursor):
+		return status.Error(codes.InvalidArgument, "Invalid page token")
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "Request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "Deadline exceeded")
+	default:
+		logger.Error().Err(err).Msg("unexpected error")
+		return status.Error(codes.Internal, "Internal server error")
+	}
+}
+
+// invalidArgument lists the validation errors in a BadRequest detail, as the
+// REST API lists them in its response.
+func invalidArgument(validationErrors []response.ValidationErrorDetail) error {
+	badRequest := &errdetails.BadRequest{}
+
+	for _, ve := range validationErrors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       ve.Field,
+			Description: ve.Message,
+		})
+	}
+
+	st, err := status.New(codes.InvalidArgument, "Validation failed").WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Validation failed")
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+	libraryv1 "go-boilerplate-rest-api-chi/pkg/pb/library/v1"
+)
+
+// NewServer builds the gRPC server of the book and author services, along
+// with the health and reflection services. The health server reports every
+// service as serving until it is shut down.
+func NewServer(bookService book.BookService, authorService author.AuthorService, validator *internalValidator.Validator, logger zerolog.Logger) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		recoverer(logger),
+		requestLogger(logger),
+	))
+
+	libraryv1.RegisterBookServiceServer(server, NewBookServer(bookService, validator, logger))
+	libraryv1.RegisterAuthorServiceServer(server, NewAuthorServer(authorService, validator, logger))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(libraryv1.BookService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(libraryv1.AuthorService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return server, healthServer
+}
+
+// Handler serves the gRPC requests with server and every other request with
+// next. The gRPC requests are only recognized over HTTP/2, the HTTP server
+// must accept unencrypted HTTP/2 when there is no TLS.
+func Handler(server *grpc.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			server.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recoverer turns a panic of a handler into an INTERNAL error.
+func recoverer(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error().
+					Interface("panic", r).
+					Str("method", info.FullMethod).
+					Bytes("stack", debug.Stack()).
+					Msg("panic while handling rpc")
+				err = status.Error(codes.Internal, "Internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func requestLogger(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		logger.Info().
+			Str("method", info.FullMethod).
+			Str("code", status.Code(err).String()).
+			Dur("duration", time.Since(start)).
+			Msg("rpc handled")
+
+		return resp, err
+	}
+}
+
+// acceptLanguage reads the language of the validation messages from the
+// accept-language metadata.
+func acceptLanguage(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, "accept-language"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
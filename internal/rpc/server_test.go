@@ -0,0 +1,396 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"go-boilerplate-rest-api-chi/internal/author"
+	"go-boilerplate-rest-api-chi/internal/book"
+	"go-boilerplate-rest-api-chi/internal/book/dto"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/pagination"
+	"go-boilerplate-rest-api-chi/internal/rpc"
+	"go-boilerplate-rest-api-chi/internal/validator"
+	libraryv1 "go-boilerplate-rest-api-chi/pkg/pb/library/v1"
+)
+
+var (
+	authorID = uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626")
+	bookID   = uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0")
+)
+
+// newClient serves the services over an in-memory connection.
+func newClient(t *testing.T, bookService book.BookService, authorService author.AuthorService) *grpc.ClientConn {
+	t.Helper()
+
+	v := validator.New()
+	require.NoError(t, author.RegisterValidations(v))
+	require.NoError(t, book.RegisterValidations(v))
+
+	server, _ := rpc.NewServer(bookService, authorService, v, zerolog.Nop())
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func TestBookServer(t *testing.T) {
+	tests := []struct {
+		name             string
+		call             func(context.Context, libraryv1.BookServiceClient) (proto.Message, error)
+		configureMock    func(*mocks.MockBookService)
+		expectedCode     codes.Code
+		expectedResponse proto.Message
+		expectedFields   []string
+	}{
+		{
+			name: "success create book",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.CreateBook(ctx, &libraryv1.CreateBookRequest{
+					Title:       "Les Misérables",
+					Description: "A novel",
+					AuthorId:    authorID.String(),
+				})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					CreateBook(gomock.Any(), &dto.CreateBookRequest{
+						Title:       "Les Misérables",
+						Description: "A novel",
+						AuthorID:    authorID,
+					}).
+					DoAndReturn(func(_ context.Context, req *dto.CreateBookRequest) (*entity.Book, error) {
+						created := book.NewBook(req, authorID)
+						created.ID = bookID
+						created.Author = &entity.Author{ID: authorID, Name: "Victor Hugo"}
+						return created, nil
+					})
+			},
+			expectedCode: codes.OK,
+			expectedResponse: &libraryv1.CreateBookResponse{Book: &libraryv1.Book{
+				Id:          bookID.String(),
+				Title:       "Les Misérables",
+				Description: "A novel",
+				Author:      &libraryv1.Author{Id: authorID.String(), Name: "Victor Hugo"},
+			}},
+		},
+		{
+			name: "error create book validation fails",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.CreateBook(ctx, &libraryv1.CreateBookRequest{Description: "A novel"})
+			},
+			configureMock:  func(mockService *mocks.MockBookService) {},
+			expectedCode:   codes.InvalidArgument,
+			expectedFields: []string{"title", "author_id"},
+		},
+		{
+			name: "error create book invalid author id",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.CreateBook(ctx, &libraryv1.CreateBookRequest{Title: "Title", Description: "A novel", AuthorId: "invalid"})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {},
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "error create book duplicate",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.CreateBook(ctx, &libraryv1.CreateBookRequest{Title: "Title", Description: "A novel", AuthorId: authorID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					CreateBook(gomock.Any(), gomock.Any()).
+					Return(nil, book.ErrDuplicate)
+			},
+			expectedCode: codes.AlreadyExists,
+		},
+		{
+			name: "error create book unknown author",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.CreateBook(ctx, &libraryv1.CreateBookRequest{Title: "Title", Description: "A novel", AuthorId: authorID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					CreateBook(gomock.Any(), gomock.Any()).
+					Return(nil, author.ErrNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "error get book not found",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.GetBook(ctx, &libraryv1.GetBookRequest{Id: bookID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					GetBookByID(gomock.Any(), bookID).
+					Return(nil, book.ErrNotFound)
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "error get book invalid uuid",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.GetBook(ctx, &libraryv1.GetBookRequest{Id: "invalid"})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {},
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "error get book internal error is hidden",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.GetBook(ctx, &libraryv1.GetBookRequest{Id: bookID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					GetBookByID(gomock.Any(), bookID).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedCode: codes.Internal,
+		},
+		{
+			name: "success list books empty",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.ListBooks(ctx, &libraryv1.ListBooksRequest{AuthorId: authorID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					GetAllBooks(gomock.Any(), dto.BookFilter{AuthorID: authorID.String()}, pagination.Page{Limit: pagination.DefaultLimit}).
+					Return(nil, "", book.ErrNotFound)
+			},
+			expectedCode:     codes.OK,
+			expectedResponse: &libraryv1.ListBooksResponse{},
+		},
+		{
+			name: "success list books page cut to the maximum",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.ListBooks(ctx, &libraryv1.ListBooksRequest{PageSize: 500, PageToken: "eyJvIjoidGl0bGUsIGlkIn0"})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					GetAllBooks(gomock.Any(), dto.BookFilter{}, pagination.Page{Limit: pagination.MaxLimit, Cursor: "eyJvIjoidGl0bGUsIGlkIn0"}).
+					Return([]*entity.Book{{ID: bookID, Title: "Les Misérables"}}, "eyJvIjoidGl0bGUsIGlkIiwidiI6W119", nil)
+			},
+			expectedCode: codes.OK,
+			expectedResponse: &libraryv1.ListBooksResponse{
+				Books:         []*libraryv1.Book{{Id: bookID.String(), Title: "Les Misérables"}},
+				NextPageToken: "eyJvIjoidGl0bGUsIGlkIiwidiI6W119",
+			},
+		},
+		{
+			name: "error list books negative page size",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.ListBooks(ctx, &libraryv1.ListBooksRequest{PageSize: -1})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {},
+			expectedCode:  codes.InvalidArgument,
+		},
+		{
+			name: "error list books invalid page token",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.ListBooks(ctx, &libraryv1.ListBooksRequest{PageToken: "invalid"})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					GetAllBooks(gomock.Any(), dto.BookFilter{}, pagination.Page{Limit: pagination.DefaultLimit, Cursor: "invalid"}).
+					Return(nil, "", pagination.ErrInvalidCursor)
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "error update book without description",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.UpdateBook(ctx, &libraryv1.UpdateBookRequest{Id: bookID.String()})
+			},
+			configureMock:  func(mockService *mocks.MockBookService) {},
+			expectedCode:   codes.InvalidArgument,
+			expectedFields: []string{"description"},
+		},
+		{
+			name: "success update book",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.UpdateBook(ctx, &libraryv1.UpdateBookRequest{Id: bookID.String(), Description: "A new novel"})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					UpdateBook(gomock.Any(), &dto.UpdateBookRequest{Description: "A new novel"}, bookID).
+					Return(&entity.Book{ID: bookID, Title: "Les Misérables", Description: "A new novel"}, nil)
+			},
+			expectedCode: codes.OK,
+			expectedResponse: &libraryv1.UpdateBookResponse{Book: &libraryv1.Book{
+				Id:          bookID.String(),
+				Title:       "Les Misérables",
+				Description: "A new novel",
+			}},
+		},
+		{
+			name: "success delete book",
+			call: func(ctx context.Context, client libraryv1.BookServiceClient) (proto.Message, error) {
+				return client.DeleteBook(ctx, &libraryv1.DeleteBookRequest{Id: bookID.String()})
+			},
+			configureMock: func(mockService *mocks.MockBookService) {
+				mockService.EXPECT().
+					DeleteBook(gomock.Any(), bookID).
+					Return(nil)
+			},
+			expectedCode:     codes.OK,
+			expectedResponse: &libraryv1.DeleteBookResponse{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockBookService(ctrl)
+			test.configureMock(mockService)
+
+			conn := newClient(t, mockService, mocks.NewMockAuthorService(ctrl))
+
+			resp, err := test.call(context.Background(), libraryv1.NewBookServiceClient(conn))
+
+			st := status.Convert(err)
+			require.Equal(t, test.expectedCode, st.Code(), st.Message())
+
+			if test.expectedResponse != nil {
+				assert.True(t, proto.Equal(test.expectedResponse, resp), "unexpected response %v", resp)
+			}
+
+			if test.expectedFields != nil {
+				require.Len(t, st.Details(), 1)
+				badRequest := st.Details()[0].(*errdetails.BadRequest)
+
+				var fields []string
+				for _, violation := range badRequest.GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+				assert.Equal(t, test.expectedFields, fields)
+			}
+		})
+	}
+}
+
+func TestAuthorServer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockService := mocks.NewMockAuthorService(ctrl)
+	conn := newClient(t, mocks.NewMockBookService(ctrl), mockService)
+	client := libraryv1.NewAuthorServiceClient(conn)
+
+	t.Run("success get author", func(t *testing.T) {
+		mockService.EXPECT().
+			GetAuthorByID(gomock.Any(), authorID).
+			Return(&entity.Author{ID: authorID, Name: "Victor Hugo"}, nil)
+
+		resp, err := client.GetAuthor(context.Background(), &libraryv1.GetAuthorRequest{Id: authorID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, "Victor Hugo", resp.GetAuthor().GetName())
+	})
+
+	t.Run("error create author validation message translated", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "accept-language", "fr")
+
+		_, err := client.CreateAuthor(ctx, &libraryv1.CreateAuthorRequest{})
+
+		st := status.Convert(err)
+		require.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+
+		violations := st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()
+		require.Len(t, violations, 1)
+		assert.Equal(t, "name", violations[0].GetField())
+		assert.Equal(t, "name est obligatoire", violations[0].GetDescription())
+	})
+
+	t.Run("error create author duplicate", func(t *testing.T) {
+		mockService.EXPECT().
+			CreateAuthor(gomock.Any(), gomock.Any()).
+			Return(nil, author.ErrDuplicate)
+
+		_, err := client.CreateAuthor(context.Background(), &libraryv1.CreateAuthorRequest{Name: "Victor Hugo"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+}
+
+func TestServer_Health(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	conn := newClient(t, mocks.NewMockBookService(ctrl), mocks.NewMockAuthorService(ctrl))
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", libraryv1.BookService_ServiceDesc.ServiceName, libraryv1.AuthorService_ServiceDesc.ServiceName} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+}
+
+func TestHandler_SharedPort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockService := mocks.NewMockAuthorService(ctrl)
+	mockService.EXPECT().
+		GetAuthorByID(gomock.Any(), authorID).
+		Return(&entity.Author{ID: authorID, Name: "Victor Hugo"}, nil)
+
+	server, _ := rpc.NewServer(mocks.NewMockBookService(ctrl), mockService, validator.New(), zerolog.Nop())
+
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("rest"))
+	})
+
+	srv := httptest.NewUnstartedServer(rpc.Handler(server, rest))
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	conn, err := grpc.NewClient(strings.TrimPrefix(srv.URL, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	resp, err := libraryv1.NewAuthorServiceClient(conn).GetAuthor(context.Background(), &libraryv1.GetAuthorRequest{Id: authorID.String()})
+	require.NoError(t, err)
+	assert.Equal(t, "Victor Hugo", resp.GetAuthor().GetName())
+
+	httpResp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+}
@@ -0,0 +1,8 @@
+package search
+
+import "errors"
+
+var (
+	ErrEmptyQuery   = errors.New("search query is empty")
+	ErrInvalidLimit = errors.New("invalid search limit")
+)
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/response"
+)
+
+type SearchResultResponse struct {
+	Type  string  `json:"type" example:"book"`
+	ID    string  `json:"id"`
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
+}
+
+type SearchSuccessResponse struct {
+	Status  string                 `json:"status" example:"success"`
+	Message string                 `json:"message" example:"Search completed successfully"`
+	Results []SearchResultResponse `json:"results"`
+}
+
+type SearchHandler struct {
+	service SearchService
+	logger  zerolog.Logger
+}
+
+func NewSearchHandler(service SearchService, logger zerolog.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *SearchHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Get("/", h.Search)
+
+	return r
+}
+
+// Search godoc
+//
+//	@Summary		Search books and authors
+//	@Description	Full-text search across book titles, descriptions and author names, ranked by relevance. Query terms match by prefix and tolerate typos.
+//	@Tags			search
+//	@Produce		json
+//	@Param			q		query		string	true	"Search query"
+//	@Param			limit	query		int		false	"Maximum number of results (default 20, max 100)"
+//	@Success		200		{object}	SearchSuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/search [get]
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if rawLimit := r.URL.Query().Get("limit"); rawLimit != "" {
+		var err error
+		if limit, err = strconv.Atoi(rawLimit); err != nil {
+			h.handleError(w, ErrInvalidLimit)
+			return
+		}
+	}
+
+	hits, err := h.service.Search(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	results := make([]SearchResultResponse, len(hits))
+	for i, hit := range hits {
+		results[i] = SearchResultResponse{
+			Type:  string(hit.Type),
+			ID:    hit.ID.String(),
+			Title: hit.Title,
+			Score: hit.Score,
+		}
+	}
+
+	response.JSON(w, http.StatusOK, SearchSuccessResponse{
+		Status:  "success",
+		Message: "Search completed successfully",
+		Results: results,
+	})
+}
+
+func (h *SearchHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrEmptyQuery):
+		response.Error(w, http.StatusBadRequest, "Search query must not be empty")
+	case errors.Is(err, ErrInvalidLimit):
+		response.Error(w, http.StatusBadRequest, fmt.Sprintf("Limit must be between 1 and %d", MaxLimit))
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
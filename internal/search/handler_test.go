@@ -0,0 +1,136 @@
+package search_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/search"
+)
+
+func TestSearchHandler_Search(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		configureMock      func(*mocks.MockSearchService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name: "success search",
+			url:  "/search?q=hugo&limit=5",
+			configureMock: func(mockService *mocks.MockSearchService) {
+				mockService.EXPECT().
+					Search(gomock.Any(), "hugo", 5).
+					Return([]search.Hit{{
+						Type:  search.DocumentAuthor,
+						ID:    uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+						Title: "Victor Hugo",
+						Score: 1.5,
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: search.SearchSuccessResponse{
+				Status:  "success",
+				Message: "Search completed successfully",
+				Results: []search.SearchResultResponse{{
+					Type:  "author",
+					ID:    "eb21d07a-7ab3-40db-bfd3-448093bc5626",
+					Title: "Victor Hugo",
+					Score: 1.5,
+				}},
+			},
+		},
+		{
+			name: "success no result",
+			url:  "/search?q=proust",
+			configureMock: func(mockService *mocks.MockSearchService) {
+				mockService.EXPECT().
+					Search(gomock.Any(), "proust", 0).
+					Return(nil, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: search.SearchSuccessResponse{
+				Status:  "success",
+				Message: "Search completed successfully",
+				Results: []search.SearchResultResponse{},
+			},
+		},
+		{
+			name:               "error limit is not a number",
+			url:                "/search?q=hugo&limit=ten",
+			configureMock:      func(mockService *mocks.MockSearchService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Limit must be between 1 and 100",
+			},
+		},
+		{
+			name: "error empty query",
+			url:  "/search",
+			configureMock: func(mockService *mocks.MockSearchService) {
+				mockService.EXPECT().
+					Search(gomock.Any(), "", 0).
+					Return(nil, search.ErrEmptyQuery)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Search query must not be empty",
+			},
+		},
+		{
+			name: "error service internal error",
+			url:  "/search?q=hugo",
+			configureMock: func(mockService *mocks.MockSearchService) {
+				mockService.EXPECT().
+					Search(gomock.Any(), "hugo", 0).
+					Return(nil, errors.New("database connection failed"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Internal server error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockSearchService(ctrl)
+			test.configureMock(mockService)
+
+			handler := search.NewSearchHandler(mockService, zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/search", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type DocumentType string
+
+const (
+	DocumentBook   DocumentType = "book"
+	DocumentAuthor DocumentType = "author"
+)
+
+const (
+	DriverMySQL  = "mysql"
+	DriverMemory = "memory"
+)
+
+// Hit is a document matching a search query. Title holds the book title or
+// the author name.
+type Hit struct {
+	Type  DocumentType
+	ID    uuid.UUID
+	Title string
+	Score float64
+}
+
+//go:generate mockgen -destination=../mocks/mock_search_index.go -package=mocks go-boilerplate-rest-api-chi/internal/search Index
+type Index interface {
+	IndexBook(ctx context.Context, book *entity.Book) error
+	IndexAuthor(ctx context.Context, author *entity.Author) error
+	RemoveBook(ctx context.Context, bookID uuid.UUID) error
+	RemoveAuthor(ctx context.Context, authorID uuid.UUID) error
+	Search(ctx context.Context, query string, limit int) ([]Hit, error)
+}
+
+// NewIndex creates the index matching the configured driver. The mysql driver
+// relies on FULLTEXT indexes, the memory driver keeps an inverted index in
+// process and works with any database, SQLite included.
+func NewIndex(ctx context.Context, cfg config.SearchConfig, db *gorm.DB, logger zerolog.Logger) (Index, error) {
+	switch cfg.Driver {
+	case DriverMySQL:
+		if err := MigrateMySQL(db); err != nil {
+			logger.Error().Err(err).Msg("failed to migrate search index")
+			return nil, err
+		}
+		return NewMySQLIndex(db, logger), nil
+	case DriverMemory:
+		index := NewMemoryIndex()
+		if err := Load(ctx, index, db); err != nil {
+			logger.Error().Err(err).Msg("failed to load search index")
+			return nil, err
+		}
+		return index, nil
+	default:
+		return nil, fmt.Errorf("unknown search driver %q", cfg.Driver)
+	}
+}
+
+// Load indexes every book and author stored in the database.
+func Load(ctx context.Context, index Index, db *gorm.DB) error {
+	var books []*entity.Book
+	result := db.WithContext(ctx).Preload("Author").FindInBatches(&books, 500, func(_ *gorm.DB, _ int) error {
+		for _, book := range books {
+			if err := index.IndexBook(ctx, book); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	var authors []*entity.Author
+	return db.WithContext(ctx).Preload("Aliases").FindInBatches(&authors, 500, func(_ *gorm.DB, _ int) error {
+		for _, author := range authors {
+			if err := index.IndexAuthor(ctx, author); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
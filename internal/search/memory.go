@@ -0,0 +1,240 @@
+package search
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+// Weights of the indexed fields and of the kind of match between a query
+// term and an indexed term.
+const (
+	weightTitle       = 3.0
+	weightAuthorName  = 2.0
+	weightDescription = 1.0
+
+	weightExactMatch  = 1.0
+	weightPrefixMatch = 0.7
+	weightFuzzyMatch  = 0.5
+
+	maxPrefixExpansions = 50
+)
+
+type documentKey struct {
+	Type DocumentType
+	ID   uuid.UUID
+}
+
+type document struct {
+	title string
+	terms map[string]float64
+}
+
+type memoryIndex struct {
+	mu        sync.RWMutex
+	documents map[documentKey]*document
+	postings  map[string]map[documentKey]float64
+	terms     []string
+}
+
+// NewMemoryIndex creates an empty in-process inverted index.
+func NewMemoryIndex() Index {
+	return &memoryIndex{
+		documents: make(map[documentKey]*document),
+		postings:  make(map[string]map[documentKey]float64),
+	}
+}
+
+func (i *memoryIndex) IndexBook(_ context.Context, book *entity.Book) error {
+	terms := make(map[string]float64)
+	addTerms(terms, book.Title, weightTitle)
+	addTerms(terms, book.Description, weightDescription)
+	if book.Author != nil {
+		addTerms(terms, book.Author.Name, weightAuthorName)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.put(documentKey{Type: DocumentBook, ID: book.ID}, &document{title: book.Title, terms: terms})
+	return nil
+}
+
+// IndexAuthor indexes the author under its name and its aliases, a hit
+// always shows the name.
+func (i *memoryIndex) IndexAuthor(_ context.Context, author *entity.Author) error {
+	terms := make(map[string]float64)
+	addTerms(terms, author.Name, weightTitle)
+	for _, alias := range author.Aliases {
+		addTerms(terms, alias.Name, weightAuthorName)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.put(documentKey{Type: DocumentAuthor, ID: author.ID}, &document{title: author.Name, terms: terms})
+	return nil
+}
+
+func (i *memoryIndex) RemoveBook(_ context.Context, bookID uuid.UUID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(documentKey{Type: DocumentBook, ID: bookID})
+	return nil
+}
+
+func (i *memoryIndex) RemoveAuthor(_ context.Context, authorID uuid.UUID) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(documentKey{Type: DocumentAuthor, ID: authorID})
+	return nil
+}
+
+// Search scores documents with a TF-IDF like formula. Each query term matches
+// indexed terms exactly, by prefix or within maxEdits typos, and documents
+// matching only part of the query are penalized proportionally.
+func (i *memoryIndex) Search(_ context.Context, query string, limit int) ([]Hit, error) {
+	tokens := uniqueTokens(query)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	total := float64(len(i.documents))
+	scores := make(map[documentKey]float64)
+	matches := make(map[documentKey]int)
+
+	for _, token := range tokens {
+		best := make(map[documentKey]float64)
+
+		for term, matchWeight := range i.expand(token) {
+			postings := i.postings[term]
+			idf := math.Log(1 + total/float64(len(postings)))
+
+			for key, fieldWeight := range postings {
+				best[key] = max(best[key], matchWeight*fieldWeight*idf)
+			}
+		}
+
+		for key, score := range best {
+			scores[key] += score
+			matches[key]++
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for key, score := range scores {
+		hits = append(hits, Hit{
+			Type:  key.Type,
+			ID:    key.ID,
+			Title: i.documents[key].title,
+			Score: score * float64(matches[key]) / float64(len(tokens)),
+		})
+	}
+
+	sortHits(hits)
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// expand returns the indexed terms matching a query term with the weight of
+// the match.
+func (i *memoryIndex) expand(token string) map[string]float64 {
+	expansions := make(map[string]float64)
+
+	if _, ok := i.postings[token]; ok {
+		expansions[token] = weightExactMatch
+	}
+
+	start := sort.SearchStrings(i.terms, token)
+	for j := start; j < len(i.terms) && j-start < maxPrefixExpansions && strings.HasPrefix(i.terms[j], token); j++ {
+		if i.terms[j] != token {
+			expansions[i.terms[j]] = weightPrefixMatch
+		}
+	}
+
+	edits := maxEdits(token)
+	if edits == 0 {
+		return expansions
+	}
+
+	for _, term := range i.terms {
+		if _, ok := expansions[term]; ok {
+			continue
+		}
+
+		if distance := levenshtein(token, term, edits); distance <= edits {
+			expansions[term] = weightFuzzyMatch / float64(distance)
+		}
+	}
+
+	return expansions
+}
+
+func (i *memoryIndex) put(key documentKey, doc *document) {
+	i.remove(key)
+
+	i.documents[key] = doc
+	for term, weight := range doc.terms {
+		postings, ok := i.postings[term]
+		if !ok {
+			postings = make(map[documentKey]float64)
+			i.postings[term] = postings
+
+			pos := sort.SearchStrings(i.terms, term)
+			i.terms = append(i.terms, "")
+			copy(i.terms[pos+1:], i.terms[pos:])
+			i.terms[pos] = term
+		}
+		postings[key] = weight
+	}
+}
+
+func (i *memoryIndex) remove(key documentKey) {
+	doc, ok := i.documents[key]
+	if !ok {
+		return
+	}
+
+	delete(i.documents, key)
+	for term := range doc.terms {
+		postings := i.postings[term]
+		delete(postings, key)
+
+		if len(postings) == 0 {
+			delete(i.postings, term)
+
+			pos := sort.SearchStrings(i.terms, term)
+			i.terms = append(i.terms[:pos], i.terms[pos+1:]...)
+		}
+	}
+}
+
+func addTerms(terms map[string]float64, text string, weight float64) {
+	for _, token := range tokenize(text) {
+		terms[token] += weight
+	}
+}
+
+func sortHits(hits []Hit) {
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].Title < hits[b].Title
+	})
+}
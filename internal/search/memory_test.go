@@ -0,0 +1,213 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/search"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var (
+	hugo = &entity.Author{
+		ID:   uuid.MustParse("eb21d07a-7ab3-40db-bfd3-448093bc5626"),
+		Name: "Victor Hugo",
+	}
+	zola = &entity.Author{
+		ID:   uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51"),
+		Name: "Émile Zola",
+	}
+	miserables = &entity.Book{
+		ID:          uuid.MustParse("a1b2c3d4-e5f6-7890-1234-56789abcdef0"),
+		Title:       "Les Misérables",
+		Description: "Les Misérables raconte la vie de Jean Valjean.",
+		AuthorID:    &hugo.ID,
+		Author:      hugo,
+	}
+	notreDame = &entity.Book{
+		ID:          uuid.MustParse("b1c2d3e4-f5a6-7890-1234-56789abcdef1"),
+		Title:       "Notre-Dame de Paris",
+		Description: "Quasimodo, sonneur de cloches de Notre-Dame.",
+		AuthorID:    &hugo.ID,
+		Author:      hugo,
+	}
+	germinal = &entity.Book{
+		ID:          uuid.MustParse("c1d2e3f4-a5b6-7890-1234-56789abcdef2"),
+		Title:       "Germinal",
+		Description: "La grève des mineurs du Nord, à Paris on l'ignore.",
+		AuthorID:    &zola.ID,
+		Author:      zola,
+	}
+)
+
+func newMemoryIndex(t *testing.T) search.Index {
+	t.Helper()
+
+	index := search.NewMemoryIndex()
+	for _, book := range []*entity.Book{miserables, notreDame, germinal} {
+		require.NoError(t, index.IndexBook(context.Background(), book))
+	}
+	for _, author := range []*entity.Author{hugo, zola} {
+		require.NoError(t, index.IndexAuthor(context.Background(), author))
+	}
+
+	return index
+}
+
+func TestMemoryIndex_Search(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		limit       int
+		expectedIDs []uuid.UUID
+	}{
+		{
+			name:        "exact match ignoring case and accents",
+			query:       "MISERABLES",
+			expectedIDs: []uuid.UUID{miserables.ID},
+		},
+		{
+			name:        "prefix match",
+			query:       "germ",
+			expectedIDs: []uuid.UUID{germinal.ID},
+		},
+		{
+			name:        "typo tolerance",
+			query:       "quasimdo",
+			expectedIDs: []uuid.UUID{notreDame.ID},
+		},
+		{
+			name:        "author name matches author and books",
+			query:       "hugo",
+			expectedIDs: []uuid.UUID{hugo.ID, miserables.ID, notreDame.ID},
+		},
+		{
+			name:        "title ranks above description",
+			query:       "paris",
+			expectedIDs: []uuid.UUID{notreDame.ID, germinal.ID},
+		},
+		{
+			name:        "documents matching every term rank first",
+			query:       "zola paris",
+			limit:       1,
+			expectedIDs: []uuid.UUID{germinal.ID},
+		},
+		{
+			name:        "limit",
+			query:       "hugo",
+			limit:       1,
+			expectedIDs: []uuid.UUID{hugo.ID},
+		},
+		{
+			name:        "no match",
+			query:       "proust",
+			expectedIDs: []uuid.UUID{},
+		},
+		{
+			name:        "no token",
+			query:       " - ",
+			expectedIDs: []uuid.UUID{},
+		},
+	}
+
+	index := newMemoryIndex(t)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hits, err := index.Search(context.Background(), test.query, test.limit)
+			assert.NoError(t, err)
+
+			ids := make([]uuid.UUID, 0, len(hits))
+			for _, hit := range hits {
+				ids = append(ids, hit.ID)
+			}
+
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
+
+func TestMemoryIndex_Remove(t *testing.T) {
+	t.Run("removed book is not found anymore", func(t *testing.T) {
+		index := newMemoryIndex(t)
+
+		require.NoError(t, index.RemoveBook(context.Background(), germinal.ID))
+
+		hits, err := index.Search(context.Background(), "germinal", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+	})
+
+	t.Run("reindexed book replaces previous terms", func(t *testing.T) {
+		index := newMemoryIndex(t)
+
+		renamed := *germinal
+		renamed.Title = "L'Assommoir"
+		require.NoError(t, index.IndexBook(context.Background(), &renamed))
+
+		hits, err := index.Search(context.Background(), "germinal", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, hits)
+
+		hits, err = index.Search(context.Background(), "assommoir", 0)
+		assert.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, "L'Assommoir", hits[0].Title)
+	})
+
+	t.Run("removed author", func(t *testing.T) {
+		index := newMemoryIndex(t)
+
+		require.NoError(t, index.RemoveAuthor(context.Background(), zola.ID))
+
+		hits, err := index.Search(context.Background(), "emile", 0)
+		assert.NoError(t, err)
+		require.Len(t, hits, 1)
+		assert.Equal(t, search.DocumentBook, hits[0].Type)
+	})
+}
+
+func TestMemoryIndex_Aliases(t *testing.T) {
+	index := newMemoryIndex(t)
+
+	withAlias := *hugo
+	withAlias.Aliases = []*entity.AuthorAlias{{Name: "Olympio"}}
+	require.NoError(t, index.IndexAuthor(context.Background(), &withAlias))
+
+	hits, err := index.Search(context.Background(), "olympio", 0)
+	assert.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, hugo.ID, hits[0].ID)
+	assert.Equal(t, "Victor Hugo", hits[0].Title)
+
+	// reindexed without its alias, the author no longer matches it
+	require.NoError(t, index.IndexAuthor(context.Background(), hugo))
+
+	hits, err = index.Search(context.Background(), "olympio", 0)
+	assert.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("index books and authors from sqlite", func(t *testing.T) {
+		db := testutils.NewGormSQLite(t, &entity.Author{}, &entity.AuthorAlias{}, &entity.Book{})
+
+		author := &entity.Author{Name: "Victor Hugo"}
+		require.NoError(t, db.Create(author).Error)
+		book := &entity.Book{Title: "Les Misérables", Description: "Jean Valjean", AuthorID: &author.ID}
+		require.NoError(t, db.Create(book).Error)
+
+		index := search.NewMemoryIndex()
+		require.NoError(t, search.Load(context.Background(), index, db))
+
+		hits, err := index.Search(context.Background(), "hugo", 0)
+		assert.NoError(t, err)
+		require.Len(t, hits, 2)
+		assert.ElementsMatch(t, []uuid.UUID{author.ID, book.ID}, []uuid.UUID{hits[0].ID, hits[1].ID})
+	})
+}
@@ -0,0 +1,254 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+// maxTermLength matches the size of the search_terms.term column.
+const maxTermLength = 64
+
+// searchTerm is the vocabulary of the indexed documents. MySQL FULLTEXT
+// indexes have no typo tolerance, query terms missing from the vocabulary are
+// replaced by their closest known terms before querying the indexes.
+type searchTerm struct {
+	Term string `gorm:"primaryKey;size:64"`
+}
+
+func (searchTerm) TableName() string {
+	return "search_terms"
+}
+
+type fulltextIndex struct {
+	name    string
+	model   any
+	table   string
+	columns string
+}
+
+var fulltextIndexes = []fulltextIndex{
+	{name: "idx_books_title_fulltext", model: &entity.Book{}, table: "books", columns: "title"},
+	{name: "idx_books_fulltext", model: &entity.Book{}, table: "books", columns: "title, description"},
+	{name: "idx_authors_fulltext", model: &entity.Author{}, table: "authors", columns: "name"},
+	{name: "idx_author_aliases_fulltext", model: &entity.AuthorAlias{}, table: "author_aliases", columns: "name"},
+}
+
+type mysqlIndex struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewMySQLIndex(db *gorm.DB, logger zerolog.Logger) Index {
+	return &mysqlIndex{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// MigrateMySQL creates the FULLTEXT indexes and the vocabulary table when they
+// are missing.
+func MigrateMySQL(db *gorm.DB) error {
+	if err := db.AutoMigrate(&searchTerm{}); err != nil {
+		return err
+	}
+
+	for _, index := range fulltextIndexes {
+		if db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+
+		if err := db.Exec("CREATE FULLTEXT INDEX " + index.name + " ON " + index.table + " (" + index.columns + ")").Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *mysqlIndex) IndexBook(ctx context.Context, book *entity.Book) error {
+	text := book.Title + " " + book.Description
+	if book.Author != nil {
+		text += " " + book.Author.Name
+	}
+
+	return i.addTerms(ctx, text)
+}
+
+func (i *mysqlIndex) IndexAuthor(ctx context.Context, author *entity.Author) error {
+	text := author.Name
+	for _, alias := range author.Aliases {
+		text += " " + alias.Name
+	}
+
+	return i.addTerms(ctx, text)
+}
+
+// RemoveBook is a no-op, FULLTEXT indexes follow the table. Terms are kept in
+// the vocabulary, they only widen typo corrections.
+func (i *mysqlIndex) RemoveBook(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+// RemoveAuthor is a no-op, see RemoveBook.
+func (i *mysqlIndex) RemoveAuthor(_ context.Context, _ uuid.UUID) error {
+	return nil
+}
+
+type mysqlHit struct {
+	ID    uuid.UUID
+	Title string
+	Score float64
+}
+
+func (i *mysqlIndex) Search(ctx context.Context, query string, limit int) ([]Hit, error) {
+	tokens := uniqueTokens(query)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	against, err := i.booleanQuery(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []mysqlHit
+	if err := i.db.WithContext(ctx).Raw(`
+		SELECT books.id, books.title,
+			2 * MATCH (books.title) AGAINST (@q IN BOOLEAN MODE)
+			+ MATCH (books.title, books.description) AGAINST (@q IN BOOLEAN MODE)
+			+ IFNULL(MATCH (authors.name) AGAINST (@q IN BOOLEAN MODE), 0) AS score
+		FROM books
+		LEFT JOIN authors ON authors.id = books.author_id
+		WHERE MATCH (books.title, books.description) AGAINST (@q IN BOOLEAN MODE)
+			OR MATCH (authors.name) AGAINST (@q IN BOOLEAN MODE)
+		ORDER BY score DESC
+		LIMIT @limit`,
+		map[string]any{"q": against, "limit": limit},
+	).Scan(&books).Error; err != nil {
+		i.logger.Error().Err(err).Msg("failed to search books")
+		return nil, err
+	}
+
+	// authors match by name or by alias, the best alias of an author counts
+	var authors []mysqlHit
+	if err := i.db.WithContext(ctx).Raw(`
+		SELECT authors.id, authors.name AS title,
+			3 * MATCH (authors.name) AGAINST (@q IN BOOLEAN MODE)
+			+ 2 * IFNULL(aliases.score, 0) AS score
+		FROM authors
+		LEFT JOIN (
+			SELECT author_id, MAX(MATCH (name) AGAINST (@q IN BOOLEAN MODE)) AS score
+			FROM author_aliases
+			WHERE MATCH (name) AGAINST (@q IN BOOLEAN MODE)
+			GROUP BY author_id
+		) aliases ON aliases.author_id = authors.id
+		WHERE MATCH (authors.name) AGAINST (@q IN BOOLEAN MODE)
+			OR aliases.author_id IS NOT NULL
+		ORDER BY score DESC
+		LIMIT @limit`,
+		map[string]any{"q": against, "limit": limit},
+	).Scan(&authors).Error; err != nil {
+		i.logger.Error().Err(err).Msg("failed to search authors")
+		return nil, err
+	}
+
+	hits := make([]Hit, 0, len(books)+len(authors))
+	for _, book := range books {
+		hits = append(hits, Hit{Type: DocumentBook, ID: book.ID, Title: book.Title, Score: book.Score})
+	}
+	for _, author := range authors {
+		hits = append(hits, Hit{Type: DocumentAuthor, ID: author.ID, Title: author.Title, Score: author.Score})
+	}
+
+	sortHits(hits)
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// booleanQuery builds the AGAINST clause. Each query term becomes a group of
+// optional words made of the term as a prefix and its typo corrections, so
+// that documents matching more groups rank higher.
+func (i *mysqlIndex) booleanQuery(ctx context.Context, tokens []string) (string, error) {
+	groups := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		words := []string{token + "*"}
+
+		corrections, err := i.corrections(ctx, token)
+		if err != nil {
+			return "", err
+		}
+		words = append(words, corrections...)
+
+		groups = append(groups, "("+strings.Join(words, " ")+")")
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+// corrections returns the vocabulary terms within maxEdits typos of a token
+// missing from the vocabulary. Candidates share the first letter of the token
+// to keep the lookup on the primary key.
+func (i *mysqlIndex) corrections(ctx context.Context, token string) ([]string, error) {
+	edits := maxEdits(token)
+	if edits == 0 {
+		return nil, nil
+	}
+
+	length := len([]rune(token))
+	first := string([]rune(token)[0])
+
+	var candidates []string
+	if err := i.db.WithContext(ctx).Model(&searchTerm{}).
+		Where("term LIKE ? AND CHAR_LENGTH(term) BETWEEN ? AND ?", first+"%", length-edits, length+edits).
+		Pluck("term", &candidates).Error; err != nil {
+		i.logger.Error().Err(err).Msg("failed to load search terms")
+		return nil, err
+	}
+
+	var corrections []string
+	for _, candidate := range candidates {
+		if candidate == token {
+			return nil, nil
+		}
+
+		if levenshtein(token, candidate, edits) <= edits {
+			corrections = append(corrections, candidate)
+		}
+	}
+
+	return corrections, nil
+}
+
+func (i *mysqlIndex) addTerms(ctx context.Context, text string) error {
+	tokens := uniqueTokens(text)
+
+	terms := make([]searchTerm, 0, len(tokens))
+	for _, token := range tokens {
+		if len(token) <= maxTermLength {
+			terms = append(terms, searchTerm{Term: token})
+		}
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+
+	if err := i.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&terms).Error; err != nil {
+		i.logger.Error().Err(err).Msg("failed to index search terms")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/search"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func newMySQLIndex(t *testing.T) (search.Index, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock := testutils.NewGormMySQL(t)
+
+	return search.NewMySQLIndex(db, zerolog.Nop()), mock
+}
+
+func TestMySQLIndex_IndexBook(t *testing.T) {
+	t.Run("success add terms to vocabulary", func(t *testing.T) {
+		index, mock := newMySQLIndex(t)
+
+		mock.ExpectExec("INSERT INTO `search_terms` \\(`term`\\) VALUES \\(\\?\\),\\(\\?\\),\\(\\?\\) ON DUPLICATE KEY UPDATE `term`=`term`").
+			WithArgs("germinal", "zola", "emile").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		err := index.IndexBook(context.Background(), &entity.Book{
+			Title:       "Germinal",
+			Description: "Zola",
+			Author:      &entity.Author{Name: "Émile Zola"},
+		})
+
+		assert.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIndex_IndexAuthor(t *testing.T) {
+	t.Run("success add the aliases to vocabulary", func(t *testing.T) {
+		index, mock := newMySQLIndex(t)
+
+		mock.ExpectExec("INSERT INTO `search_terms` \\(`term`\\) VALUES \\(\\?\\),\\(\\?\\),\\(\\?\\),\\(\\?\\) ON DUPLICATE KEY UPDATE `term`=`term`").
+			WithArgs("stephen", "king", "richard", "bachman").
+			WillReturnResult(sqlmock.NewResult(0, 4))
+
+		err := index.IndexAuthor(context.Background(), &entity.Author{
+			Name:    "Stephen King",
+			Aliases: []*entity.AuthorAlias{{Name: "Richard Bachman"}},
+		})
+
+		assert.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestMySQLIndex_Search(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedHits  []search.Hit
+	}{
+		{
+			name:  "success search with typo correction",
+			query: "Miserbles",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .term. FROM .search_terms. WHERE term LIKE \? AND CHAR_LENGTH\(term\) BETWEEN \? AND \?`).
+					WithArgs("m%", 7, 11).
+					WillReturnRows(sqlmock.NewRows([]string{"term"}).AddRow("miserables").AddRow("mineurs"))
+
+				mock.ExpectQuery(`SELECT books.id, books.title`).
+					WithArgs(
+						"(miserbles* miserables)", "(miserbles* miserables)", "(miserbles* miserables)",
+						"(miserbles* miserables)", "(miserbles* miserables)", 10,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "score"}).
+						AddRow(miserables.ID, "Les Misérables", 4.2))
+
+				mock.ExpectQuery(`SELECT authors.id, authors.name AS title`).
+					WithArgs(
+						"(miserbles* miserables)", "(miserbles* miserables)", "(miserbles* miserables)",
+						"(miserbles* miserables)", 10,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "score"}))
+			},
+			expectedHits: []search.Hit{
+				{Type: search.DocumentBook, ID: miserables.ID, Title: "Les Misérables", Score: 4.2},
+			},
+		},
+		{
+			name:  "success merge books and authors by score",
+			query: "hugo",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .term. FROM .search_terms.`).
+					WithArgs("h%", 3, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"term"}).AddRow("hugo"))
+
+				mock.ExpectQuery(`SELECT books.id, books.title`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "score"}).
+						AddRow(miserables.ID, "Les Misérables", 1.1))
+
+				mock.ExpectQuery(`SELECT authors.id, authors.name AS title`).
+					WithArgs("(hugo*)", "(hugo*)", "(hugo*)", "(hugo*)", 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "score"}).
+						AddRow(hugo.ID, "Victor Hugo", 3.3))
+			},
+			expectedHits: []search.Hit{
+				{Type: search.DocumentAuthor, ID: hugo.ID, Title: "Victor Hugo", Score: 3.3},
+				{Type: search.DocumentBook, ID: miserables.ID, Title: "Les Misérables", Score: 1.1},
+			},
+		},
+		{
+			name:  "error database connection failed",
+			query: "zola",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT .term. FROM .search_terms.`).
+					WillReturnRows(sqlmock.NewRows([]string{"term"}))
+
+				mock.ExpectQuery(`SELECT books.id, books.title`).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			index, mock := newMySQLIndex(t)
+			test.configureMock(mock)
+
+			hits, err := index.Search(context.Background(), test.query, 10)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedHits, hits)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
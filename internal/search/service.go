@@ -0,0 +1,46 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+//go:generate mockgen -destination=../mocks/mock_search_service.go -package=mocks go-boilerplate-rest-api-chi/internal/search SearchService
+type SearchService interface {
+	Search(ctx context.Context, query string, limit int) ([]Hit, error)
+}
+
+type searchService struct {
+	index  Index
+	logger zerolog.Logger
+}
+
+func NewSearchService(index Index, logger zerolog.Logger) SearchService {
+	return &searchService{
+		index:  index,
+		logger: logger,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, query string, limit int) ([]Hit, error) {
+	if len(tokenize(strings.TrimSpace(query))) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	if limit < 0 || limit > MaxLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	return s.index.Search(ctx, query, limit)
+}
@@ -0,0 +1,9 @@
+package dto
+
+type CreateSeriesRequest struct {
+	Name string `json:"name" validate:"required,trimmed,max=255"`
+}
+
+type UpdateSeriesRequest struct {
+	Name string `json:"name" validate:"required,trimmed,max=255"`
+}
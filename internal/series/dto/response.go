@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type SeriesResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Books is the reading order of the series, it is only returned for a
+	// single series.
+	Books []SeriesBookResponse `json:"books,omitempty"`
+}
+
+// SeriesBookResponse is a book of a series with its volume.
+type SeriesBookResponse struct {
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Volume float64 `json:"volume" example:"2.5"`
+}
+
+func ToSeriesResponse(series *entity.Series) *SeriesResponse {
+	response := &SeriesResponse{
+		ID:   series.ID.String(),
+		Name: series.Name,
+	}
+
+	if len(series.Books) > 0 {
+		response.Books = make([]SeriesBookResponse, len(series.Books))
+		for i, book := range series.Books {
+			response.Books[i] = *ToSeriesBookResponse(book)
+		}
+	}
+
+	return response
+}
+
+func ToSeriesListResponse(seriesList []*entity.Series) []SeriesResponse {
+	responses := make([]SeriesResponse, len(seriesList))
+	for i, series := range seriesList {
+		responses[i] = *ToSeriesResponse(series)
+	}
+	return responses
+}
+
+func ToSeriesBookResponse(book *entity.Book) *SeriesBookResponse {
+	response := &SeriesBookResponse{
+		ID:    book.ID.String(),
+		Title: book.Title,
+	}
+
+	if book.SeriesVolume != nil {
+		response.Volume = *book.SeriesVolume
+	}
+
+	return response
+}
@@ -0,0 +1,8 @@
+package series
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("series not found")
+	ErrDuplicate = errors.New("series already exists")
+)
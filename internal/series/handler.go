@@ -0,0 +1,243 @@
+package series
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/series/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type SeriesSuccessResponse struct {
+	Status  string              `json:"status" example:"success"`
+	Message string              `json:"message" example:"Series retrieved successfully"`
+	Series  *dto.SeriesResponse `json:"series"`
+}
+
+type SeriesListSuccessResponse struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Series retrieved successfully"`
+	Series  []dto.SeriesResponse `json:"series"`
+}
+
+type SeriesHandler struct {
+	service   SeriesService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewSeriesHandler(service SeriesService, validator *internalValidator.Validator, logger zerolog.Logger) *SeriesHandler {
+	return &SeriesHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *SeriesHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.CreateSeries)
+	r.Get("/", h.GetAllSeries)
+	r.Get("/{series_id}", h.GetSeriesByID)
+	r.Put("/{series_id}", h.UpdateSeries)
+	r.Delete("/{series_id}", h.DeleteSeries)
+
+	return r
+}
+
+// CreateSeries godoc
+//
+//	@Summary		Create a series
+//	@Description	Create a series, books join it with their volume number
+//	@Tags			series
+//	@Accept			json
+//	@Produce		json
+//	@Param			series			body		dto.CreateSeriesRequest	true	"Series data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	SeriesSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/series [post]
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSeriesRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	series, err := h.service.CreateSeries(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, SeriesSuccessResponse{
+		Status:  "success",
+		Message: "Series created successfully",
+		Series:  dto.ToSeriesResponse(series),
+	})
+}
+
+// GetAllSeries godoc
+//
+//	@Summary		Get all series
+//	@Description	Get every series ordered by name, without their books
+//	@Tags			series
+//	@Produce		json
+//	@Success		200	{object}	SeriesListSuccessResponse
+//	@Failure		500	{object}	response.ErrorResponse
+//	@Router			/series [get]
+func (h *SeriesHandler) GetAllSeries(w http.ResponseWriter, r *http.Request) {
+	seriesList, err := h.service.GetAllSeries(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, SeriesListSuccessResponse{
+		Status:  "success",
+		Message: "Series retrieved successfully",
+		Series:  dto.ToSeriesListResponse(seriesList),
+	})
+}
+
+// GetSeriesByID godoc
+//
+//	@Summary		Get series by id
+//	@Description	Get a single series by its ID, with its books ordered by volume
+//	@Tags			series
+//	@Produce		json
+//	@Param			series_id	path		string	true	"Series ID"
+//	@Success		200			{object}	SeriesSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/series/{series_id} [get]
+func (h *SeriesHandler) GetSeriesByID(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := uuid.Parse(chi.URLParam(r, "series_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	series, err := h.service.GetSeriesByID(r.Context(), seriesID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, SeriesSuccessResponse{
+		Status:  "success",
+		Message: "Series retrieved successfully",
+		Series:  dto.ToSeriesResponse(series),
+	})
+}
+
+// UpdateSeries godoc
+//
+//	@Summary		Update a series
+//	@Description	Rename a series
+//	@Tags			series
+//	@Accept			json
+//	@Produce		json
+//	@Param			series_id		path		string					true	"Series ID"
+//	@Param			series			body		dto.UpdateSeriesRequest	true	"Series data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	SeriesSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/series/{series_id} [put]
+func (h *SeriesHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := uuid.Parse(chi.URLParam(r, "series_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.UpdateSeriesRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	series, err := h.service.UpdateSeries(r.Context(), &req, seriesID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, SeriesSuccessResponse{
+		Status:  "success",
+		Message: "Series updated successfully",
+		Series:  dto.ToSeriesResponse(series),
+	})
+}
+
+// DeleteSeries godoc
+//
+//	@Summary		Delete a series
+//	@Description	Delete a series, its books are kept outside of any series
+//	@Tags			series
+//	@Produce		json
+//	@Param			series_id	path		string	true	"Series ID"
+//	@Success		200			{object}	response.SuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/series/{series_id} [delete]
+func (h *SeriesHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := uuid.Parse(chi.URLParam(r, "series_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.DeleteSeries(r.Context(), seriesID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Series deleted successfully")
+}
+
+func (h *SeriesHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Series not found")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Series with this name already exists")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,188 @@
+package series_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/series"
+	"go-boilerplate-rest-api-chi/internal/series/dto"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestSeriesHandler_GetSeriesByID(t *testing.T) {
+	first := uuid.MustParse("0f1e2d3c-4b5a-4968-8776-a5b4c3d2e1f0")
+	between := uuid.MustParse("1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d")
+	one, twoAndAHalf := 1.0, 2.5
+
+	tests := []struct {
+		name               string
+		idInUrlParam       string
+		configureMock      func(*mocks.MockSeriesService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:         "success get series with its volumes in order",
+			idInUrlParam: seriesID.String(),
+			configureMock: func(mockService *mocks.MockSeriesService) {
+				mockService.EXPECT().
+					GetSeriesByID(gomock.Any(), seriesID).
+					Return(&entity.Series{
+						ID:   seriesID,
+						Name: "Discworld",
+						Books: []*entity.Book{
+							{ID: first, Title: "The Colour of Magic", SeriesVolume: &one},
+							{ID: between, Title: "Troll Bridge", SeriesVolume: &twoAndAHalf},
+						},
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: &series.SeriesSuccessResponse{
+				Status:  "success",
+				Message: "Series retrieved successfully",
+				Series: &dto.SeriesResponse{
+					ID:   seriesID.String(),
+					Name: "Discworld",
+					Books: []dto.SeriesBookResponse{
+						{ID: first.String(), Title: "The Colour of Magic", Volume: 1},
+						{ID: between.String(), Title: "Troll Bridge", Volume: 2.5},
+					},
+				},
+			},
+		},
+		{
+			name:               "error invalid uuid",
+			idInUrlParam:       "invalid-uuid",
+			configureMock:      func(mockService *mocks.MockSeriesService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockSeriesService(ctrl)
+			test.configureMock(mockService)
+
+			handler := series.NewSeriesHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, "/series/"+test.idInUrlParam, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/series", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestSeriesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		requestBody        string
+		configureMock      func(*mocks.MockSeriesService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:               "error create without name",
+			method:             http.MethodPost,
+			path:               "/series",
+			requestBody:        `{}`,
+			configureMock:      func(mockService *mocks.MockSeriesService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Validation failed",
+		},
+		{
+			name:        "error duplicate",
+			method:      http.MethodPost,
+			path:        "/series",
+			requestBody: `{"name":"Discworld"}`,
+			configureMock: func(mockService *mocks.MockSeriesService) {
+				mockService.EXPECT().
+					CreateSeries(gomock.Any(), &dto.CreateSeriesRequest{Name: "Discworld"}).
+					Return(nil, series.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Series with this name already exists",
+		},
+		{
+			name:   "error series not found",
+			method: http.MethodGet,
+			path:   "/series/" + seriesID.String(),
+			configureMock: func(mockService *mocks.MockSeriesService) {
+				mockService.EXPECT().
+					GetSeriesByID(gomock.Any(), seriesID).
+					Return(nil, series.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Series not found",
+		},
+		{
+			name:               "error invalid uuid",
+			method:             http.MethodDelete,
+			path:               "/series/not-a-uuid",
+			configureMock:      func(mockService *mocks.MockSeriesService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Invalid uuid",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockSeriesService(ctrl)
+			test.configureMock(mockService)
+
+			handler := series.NewSeriesHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/series", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
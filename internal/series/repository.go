@@ -0,0 +1,163 @@
+package series
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_series_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/series SeriesRepository
+type SeriesRepository interface {
+	Create(ctx context.Context, newSeries *entity.Series) (*entity.Series, error)
+	GetAll(ctx context.Context) ([]*entity.Series, error)
+	// GetByID returns the series with its books in reading order.
+	GetByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error)
+	// GetByIDs returns the series with the id, the title and the volume of
+	// their books in reading order. Unknown ids are skipped.
+	GetByIDs(ctx context.Context, seriesIDs []uuid.UUID) ([]*entity.Series, error)
+	LockByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error)
+	Update(ctx context.Context, series *entity.Series) (*entity.Series, error)
+	// Delete removes the series, its books are kept outside of any series. It
+	// must run in a transaction.
+	Delete(ctx context.Context, seriesID uuid.UUID) error
+}
+
+type seriesRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewSeriesRepository(db *gorm.DB, logger zerolog.Logger) SeriesRepository {
+	return &seriesRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *seriesRepository) Create(ctx context.Context, newSeries *entity.Series) (*entity.Series, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Create(newSeries).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newSeries, nil
+}
+
+func (r *seriesRepository) GetAll(ctx context.Context) ([]*entity.Series, error) {
+	var seriesList []*entity.Series
+
+	if err := transaction.DB(ctx, r.db).Order("name").Find(&seriesList).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return seriesList, nil
+}
+
+func (r *seriesRepository) GetByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error) {
+	var series *entity.Series
+
+	err := transaction.DB(ctx, r.db).
+		Preload("Books", func(db *gorm.DB) *gorm.DB { return db.Order("series_volume") }).
+		First(&series, "id = ?", seriesID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) GetByIDs(ctx context.Context, seriesIDs []uuid.UUID) ([]*entity.Series, error) {
+	var seriesList []*entity.Series
+
+	if len(seriesIDs) == 0 {
+		return seriesList, nil
+	}
+
+	err := transaction.DB(ctx, r.db).
+		Preload("Books", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "title", "series_id", "series_volume").Order("series_volume")
+		}).
+		Find(&seriesList, "id IN ?", seriesIDs).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return seriesList, nil
+}
+
+// LockByID reads the series and locks its row until the end of the
+// transaction carried by ctx, the volumes of the series cannot change
+// meanwhile.
+func (r *seriesRepository) LockByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error) {
+	var series *entity.Series
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&series, "id = ?", seriesID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *entity.Series) (*entity.Series, error) {
+	if err := transaction.DB(ctx, r.db).Omit(clause.Associations).Save(series).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) Delete(ctx context.Context, seriesID uuid.UUID) error {
+	db := transaction.DB(ctx, r.db)
+
+	// the volumes mean nothing outside of the series
+	err := db.Model(&entity.Book{}).
+		Where("series_id = ?", seriesID).
+		UpdateColumns(map[string]any{"series_id": nil, "series_volume": nil}).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	result := db.Delete(&entity.Series{}, "id = ?", seriesID)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Msg("database error")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,267 @@
+package series_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/series"
+	testutils "go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestSeriesRepository_Create(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *entity.Series
+		configureMock    func(sqlmock.Sqlmock, *entity.Series)
+		expectedError    error
+		expectedResponse *entity.Series
+	}{
+		{
+			name:  "success create series",
+			input: &entity.Series{Name: "Discworld"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Series) {
+				mock.ExpectExec(`INSERT INTO .series.`).
+					WithArgs(
+						sqlmock.AnyArg(), // ID généré
+						input.Name,
+						sqlmock.AnyArg(), // created_at
+						sqlmock.AnyArg(), // updated_at
+					).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expectedResponse: &entity.Series{Name: "Discworld"},
+		},
+		{
+			name:  "error duplicate series",
+			input: &entity.Series{Name: "Discworld"},
+			configureMock: func(mock sqlmock.Sqlmock, input *entity.Series) {
+				mock.ExpectExec(`INSERT INTO .series.`).
+					WillReturnError(gorm.ErrDuplicatedKey)
+			},
+			expectedError: series.ErrDuplicate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock, test.input)
+
+			repo := series.NewSeriesRepository(db, zerolog.Nop())
+
+			newSeries, err := repo.Create(context.Background(), test.input)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, newSeries)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse.Name, newSeries.Name)
+				assert.NotEqual(t, uuid.Nil, newSeries.ID)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeriesRepository_GetByID(t *testing.T) {
+	tests := []struct {
+		name           string
+		configureMock  func(sqlmock.Sqlmock)
+		expectedError  error
+		expectedTitles []string
+	}{
+		{
+			name: "success with the books in reading order",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .series. WHERE id = \? ORDER BY .series.\..id. LIMIT \?`).
+					WithArgs(seriesID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+						AddRow(seriesID, "Discworld", now, now))
+
+				mock.ExpectQuery(`SELECT \* FROM .books. WHERE .books.\..series_id. = \? ORDER BY series_volume`).
+					WithArgs(seriesID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "series_id", "series_volume"}).
+						AddRow(uuid.New(), "The Colour of Magic", seriesID, 1.0).
+						AddRow(uuid.New(), "The Light Fantastic", seriesID, 2.0).
+						AddRow(uuid.New(), "Troll Bridge", seriesID, 2.5))
+			},
+			expectedTitles: []string{"The Colour of Magic", "The Light Fantastic", "Troll Bridge"},
+		},
+		{
+			name: "error series not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .series. WHERE id = \? ORDER BY .series.\..id. LIMIT \?`).
+					WithArgs(seriesID, 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+			expectedError: series.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := series.NewSeriesRepository(db, zerolog.Nop())
+
+			got, err := repo.GetByID(context.Background(), seriesID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+
+				titles := make([]string, len(got.Books))
+				for i, book := range got.Books {
+					titles[i] = book.Title
+				}
+				assert.Equal(t, test.expectedTitles, titles)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeriesRepository_GetByIDs(t *testing.T) {
+	tests := []struct {
+		name          string
+		seriesIDs     []uuid.UUID
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+		expectedCount int
+	}{
+		{
+			name:      "success reads the volumes only",
+			seriesIDs: []uuid.UUID{seriesID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				now := time.Now()
+
+				mock.ExpectQuery(`SELECT \* FROM .series. WHERE id IN \(\?\)`).
+					WithArgs(seriesID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+						AddRow(seriesID, "Discworld", now, now))
+
+				mock.ExpectQuery(`SELECT .id.,.title.,.series_id.,.series_volume. FROM .books. WHERE .books.\..series_id. = \? ORDER BY series_volume`).
+					WithArgs(seriesID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "title", "series_id", "series_volume"}).
+						AddRow(uuid.New(), "The Colour of Magic", seriesID, 1.0))
+			},
+			expectedCount: 1,
+		},
+		{
+			name:          "success no series",
+			seriesIDs:     []uuid.UUID{},
+			configureMock: func(mock sqlmock.Sqlmock) {},
+		},
+		{
+			name:      "error database connection failed",
+			seriesIDs: []uuid.UUID{seriesID},
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM .series. WHERE id IN \(\?\)`).
+					WithArgs(seriesID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := series.NewSeriesRepository(db, zerolog.Nop())
+
+			got, err := repo.GetByIDs(context.Background(), test.seriesIDs)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, got)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, got, test.expectedCount)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSeriesRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(sqlmock.Sqlmock)
+		expectedError error
+	}{
+		{
+			name: "success detach the books and delete the series",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				// the books are kept out of the series
+				mock.ExpectExec("UPDATE .books. SET .series_id.=\\?,.series_volume.=\\? WHERE series_id = \\?$").
+					WithArgs(nil, nil, seriesID).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+
+				mock.ExpectExec(`DELETE FROM .series. WHERE id = \?`).
+					WithArgs(seriesID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "error series not found",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE .books.`).
+					WithArgs(nil, nil, seriesID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectExec(`DELETE FROM .series. WHERE id = \?`).
+					WithArgs(seriesID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedError: series.ErrNotFound,
+		},
+		{
+			name: "error database connection failed",
+			configureMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE .books.`).
+					WithArgs(nil, nil, seriesID).
+					WillReturnError(gorm.ErrInvalidDB)
+			},
+			expectedError: gorm.ErrInvalidDB,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock := testutils.NewGormMySQL(t)
+			test.configureMock(mock)
+
+			repo := series.NewSeriesRepository(db, zerolog.Nop())
+
+			err := repo.Delete(context.Background(), seriesID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package series
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/series/dto"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_series_service.go -package=mocks go-boilerplate-rest-api-chi/internal/series SeriesService
+type SeriesService interface {
+	CreateSeries(ctx context.Context, req *dto.CreateSeriesRequest) (*entity.Series, error)
+	GetAllSeries(ctx context.Context) ([]*entity.Series, error)
+	// GetSeriesByID returns the series with its books in reading order.
+	GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error)
+	UpdateSeries(ctx context.Context, req *dto.UpdateSeriesRequest, seriesID uuid.UUID) (*entity.Series, error)
+	// DeleteSeries removes the series, its books are kept outside of any
+	// series.
+	DeleteSeries(ctx context.Context, seriesID uuid.UUID) error
+}
+
+type seriesService struct {
+	repository   SeriesRepository
+	transactions transaction.Manager
+	logger       zerolog.Logger
+}
+
+func NewSeriesService(repository SeriesRepository, transactions transaction.Manager, logger zerolog.Logger) SeriesService {
+	return &seriesService{
+		repository:   repository,
+		transactions: transactions,
+		logger:       logger,
+	}
+}
+
+func (s *seriesService) CreateSeries(ctx context.Context, req *dto.CreateSeriesRequest) (*entity.Series, error) {
+	return s.repository.Create(ctx, &entity.Series{Name: req.Name})
+}
+
+func (s *seriesService) GetAllSeries(ctx context.Context) ([]*entity.Series, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *seriesService) GetSeriesByID(ctx context.Context, seriesID uuid.UUID) (*entity.Series, error) {
+	return s.repository.GetByID(ctx, seriesID)
+}
+
+func (s *seriesService) UpdateSeries(ctx context.Context, req *dto.UpdateSeriesRequest, seriesID uuid.UUID) (*entity.Series, error) {
+	var series *entity.Series
+
+	err := s.transactions.Do(ctx, func(ctx context.Context) error {
+		var err error
+		series, err = s.repository.LockByID(ctx, seriesID)
+		if err != nil {
+			return err
+		}
+
+		series.Name = req.Name
+
+		series, err = s.repository.Update(ctx, series)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (s *seriesService) DeleteSeries(ctx context.Context, seriesID uuid.UUID) error {
+	return s.transactions.Do(ctx, func(ctx context.Context) error {
+		if _, err := s.repository.LockByID(ctx, seriesID); err != nil {
+			return err
+		}
+
+		return s.repository.Delete(ctx, seriesID)
+	})
+}
@@ -0,0 +1,142 @@
+package series_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/series"
+	"go-boilerplate-rest-api-chi/internal/series/dto"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+var seriesID = uuid.MustParse("2b4d6f8a-1c3e-4a5b-8d7f-9e1a3c5b7d9f")
+
+func TestSeriesService_UpdateSeries(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            *dto.UpdateSeriesRequest
+		configureMock    func(*mocks.MockSeriesRepository)
+		expectedResponse *entity.Series
+		expectedError    error
+	}{
+		{
+			name:  "success update series",
+			input: &dto.UpdateSeriesRequest{Name: "The Discworld"},
+			configureMock: func(mockRepo *mocks.MockSeriesRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), seriesID).
+					Return(&entity.Series{ID: seriesID, Name: "Discworld"}, nil)
+
+				mockRepo.EXPECT().
+					Update(gomock.Any(), &entity.Series{ID: seriesID, Name: "The Discworld"}).
+					DoAndReturn(func(_ context.Context, s *entity.Series) (*entity.Series, error) { return s, nil })
+			},
+			expectedResponse: &entity.Series{ID: seriesID, Name: "The Discworld"},
+		},
+		{
+			name:  "error series not found",
+			input: &dto.UpdateSeriesRequest{Name: "The Discworld"},
+			configureMock: func(mockRepo *mocks.MockSeriesRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), seriesID).
+					Return(nil, series.ErrNotFound)
+			},
+			expectedError: series.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			seriesRepoMock := mocks.NewMockSeriesRepository(ctrl)
+
+			test.configureMock(seriesRepoMock)
+			service := series.NewSeriesService(seriesRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			result, err := service.UpdateSeries(context.Background(), test.input, seriesID)
+
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestSeriesService_DeleteSeries(t *testing.T) {
+	tests := []struct {
+		name          string
+		configureMock func(*mocks.MockSeriesRepository)
+		expectedError error
+	}{
+		{
+			name: "success delete series",
+			configureMock: func(mockRepo *mocks.MockSeriesRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), seriesID).
+					Return(&entity.Series{ID: seriesID}, nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), seriesID).
+					Return(nil)
+			},
+		},
+		{
+			name: "error series not found",
+			configureMock: func(mockRepo *mocks.MockSeriesRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), seriesID).
+					Return(nil, series.ErrNotFound)
+			},
+			expectedError: series.ErrNotFound,
+		},
+		{
+			name: "error database error",
+			configureMock: func(mockRepo *mocks.MockSeriesRepository) {
+				mockRepo.EXPECT().
+					LockByID(gomock.Any(), seriesID).
+					Return(&entity.Series{ID: seriesID}, nil)
+
+				mockRepo.EXPECT().
+					Delete(gomock.Any(), seriesID).
+					Return(errors.New("database connection failed"))
+			},
+			expectedError: errors.New("database connection failed"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+			seriesRepoMock := mocks.NewMockSeriesRepository(ctrl)
+
+			test.configureMock(seriesRepoMock)
+			service := series.NewSeriesService(seriesRepoMock, testutils.NewTransactionManager(ctrl), zerolog.Nop())
+
+			err := service.DeleteSeries(context.Background(), seriesID)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectedError.Error(), err.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
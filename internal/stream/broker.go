@@ -0,0 +1,196 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+)
+
+// clientBuffer is the number of messages a client may lag behind before it is
+// dropped.
+const clientBuffer = 64
+
+// Message is an event numbered in the order the broker received it. The IDs
+// only hold for the lifetime of the broker.
+type Message struct {
+	ID   uint64
+	Type event.Type
+	// Data is the event serialized as JSON, on a single line.
+	Data []byte
+
+	eventID uuid.UUID
+}
+
+// Subscription receives the messages of the subscribed types. C is closed
+// when the client lagged too far behind or the broker closed, the client is
+// expected to reconnect and resume from the last message it received.
+type Subscription struct {
+	C <-chan Message
+
+	c      chan Message
+	types  []event.Type
+	broker *Broker
+}
+
+// Close unsubscribes, it may be called more than once.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+
+	s.broker.drop(s)
+}
+
+func (s *Subscription) accepts(t event.Type) bool {
+	return len(s.types) == 0 || slices.Contains(s.types, t)
+}
+
+// Broker fans the published events out to the stream clients, and keeps the
+// last ones so that a client reconnecting shortly after does not miss any.
+//
+// Only the events relayed by this instance reach its clients.
+type Broker struct {
+	mu         sync.Mutex
+	buffer     []Message
+	bufferSize int
+	seen       map[uuid.UUID]struct{}
+	lastID     uint64
+	clients    map[*Subscription]struct{}
+	maxClients int
+	closed     bool
+}
+
+func NewBroker(cfg config.StreamConfig) *Broker {
+	return &Broker{
+		buffer:     make([]Message, 0, cfg.BufferSize),
+		bufferSize: cfg.BufferSize,
+		seen:       make(map[uuid.UUID]struct{}, cfg.BufferSize),
+		clients:    make(map[*Subscription]struct{}),
+		maxClients: cfg.MaxClients,
+	}
+}
+
+// Publish numbers e and sends it to the clients subscribed to its type. It is
+// meant to be subscribed to the outbox bus, an event still in the buffer is
+// not sent twice. The stream is anonymous, the events which are not public
+// are dropped.
+func (b *Broker) Publish(_ context.Context, e event.Event) error {
+	if !slices.Contains(event.PublicTypes, e.Type) {
+		return nil
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.seen[e.ID]; ok || b.closed {
+		return nil
+	}
+
+	b.lastID++
+	msg := Message{ID: b.lastID, Type: e.Type, Data: data, eventID: e.ID}
+
+	if len(b.buffer) == b.bufferSize {
+		delete(b.seen, b.buffer[0].eventID)
+		b.buffer = append(b.buffer[:0], b.buffer[1:]...)
+	}
+	b.buffer = append(b.buffer, msg)
+	b.seen[e.ID] = struct{}{}
+
+	for client := range b.clients {
+		if !client.accepts(msg.Type) {
+			continue
+		}
+
+		select {
+		case client.c <- msg:
+		default:
+			// the client resumes from the buffer once reconnected
+			b.drop(client)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a client to the given types, every type when empty. The
+// messages following lastEventID, if set, are returned to be sent before the
+// ones received on the subscription. resumed is false when they are no longer
+// buffered, or lastEventID was sent by another broker, the client missed
+// events.
+func (b *Broker) Subscribe(lastEventID string, types []event.Type) (sub *Subscription, replay []Message, resumed bool, err error) {
+	var after uint64
+	if lastEventID != "" {
+		if after, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			return nil, nil, false, ErrInvalidEventID
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, false, ErrClosed
+	}
+
+	if len(b.clients) >= b.maxClients {
+		return nil, nil, false, ErrTooManyClients
+	}
+
+	c := make(chan Message, clientBuffer)
+	sub = &Subscription{C: c, c: c, types: types, broker: b}
+	b.clients[sub] = struct{}{}
+
+	if lastEventID == "" {
+		return sub, nil, true, nil
+	}
+
+	oldest := b.lastID + 1
+	if len(b.buffer) > 0 {
+		oldest = b.buffer[0].ID
+	}
+
+	if after > b.lastID || after+1 < oldest {
+		return sub, nil, false, nil
+	}
+
+	for _, msg := range b.buffer {
+		if msg.ID > after && sub.accepts(msg.Type) {
+			replay = append(replay, msg)
+		}
+	}
+
+	return sub, replay, true, nil
+}
+
+// Close ends every subscription and refuses new ones, so that the streams do
+// not hold the server shutdown.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for client := range b.clients {
+		b.drop(client)
+	}
+}
+
+// drop must be called with mu held.
+func (b *Broker) drop(client *Subscription) {
+	if _, ok := b.clients[client]; !ok {
+		return
+	}
+
+	delete(b.clients, client)
+	close(client.c)
+}
@@ -0,0 +1,196 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/stream"
+)
+
+func newBroker(bufferSize, maxClients int) *stream.Broker {
+	return stream.NewBroker(config.StreamConfig{
+		BufferSize: bufferSize,
+		MaxClients: maxClients,
+	})
+}
+
+func publish(t *testing.T, broker *stream.Broker, types ...event.Type) []event.Event {
+	t.Helper()
+
+	events := make([]event.Event, len(types))
+	for i, eventType := range types {
+		events[i] = event.New(eventType, event.AggregateBook, uuid.New(), nil)
+		require.NoError(t, broker.Publish(context.Background(), events[i]))
+	}
+
+	return events
+}
+
+func ids(messages []stream.Message) []uint64 {
+	var ids []uint64
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+	}
+	return ids
+}
+
+// drain returns the messages already received on sub.
+func drain(sub *stream.Subscription) []stream.Message {
+	var messages []stream.Message
+	for {
+		select {
+		case msg, ok := <-sub.C:
+			if !ok {
+				return messages
+			}
+			messages = append(messages, msg)
+		default:
+			return messages
+		}
+	}
+}
+
+func TestBroker_Publish(t *testing.T) {
+	broker := newBroker(10, 10)
+
+	all, _, _, err := broker.Subscribe("", nil)
+	require.NoError(t, err)
+	books, _, _, err := broker.Subscribe("", []event.Type{event.BookCreated})
+	require.NoError(t, err)
+
+	events := publish(t, broker, event.BookCreated, event.AuthorCreated)
+	// the relay may publish an event again
+	require.NoError(t, broker.Publish(context.Background(), events[0]))
+
+	received := drain(all)
+	assert.Equal(t, []uint64{1, 2}, ids(received))
+	assert.Equal(t, event.AuthorCreated, received[1].Type)
+
+	var e event.Event
+	require.NoError(t, json.Unmarshal(received[0].Data, &e))
+	assert.Equal(t, events[0].ID, e.ID)
+
+	assert.Equal(t, []uint64{1}, ids(drain(books)))
+}
+
+func TestBroker_Subscribe(t *testing.T) {
+	broker := newBroker(3, 10)
+	publish(t, broker, event.BookCreated, event.BookUpdated, event.AuthorCreated, event.BookDeleted, event.BookUpdated)
+	// the buffer holds the messages 3 to 5
+
+	tests := []struct {
+		name            string
+		lastEventID     string
+		types           []event.Type
+		expectedReplay  []uint64
+		expectedResumed bool
+		expectedErr     error
+	}{
+		{
+			name:            "no last event ID",
+			expectedResumed: true,
+		},
+		{
+			name:            "resume from the buffer",
+			lastEventID:     "2",
+			expectedReplay:  []uint64{3, 4, 5},
+			expectedResumed: true,
+		},
+		{
+			name:            "resume filtered",
+			lastEventID:     "3",
+			types:           []event.Type{event.BookUpdated},
+			expectedReplay:  []uint64{5},
+			expectedResumed: true,
+		},
+		{
+			name:            "up to date",
+			lastEventID:     "5",
+			expectedResumed: true,
+		},
+		{
+			name:        "missed events out of the buffer",
+			lastEventID: "1",
+		},
+		{
+			name:        "event ID of another broker",
+			lastEventID: "42",
+		},
+		{
+			name:        "invalid event ID",
+			lastEventID: "abc",
+			expectedErr: stream.ErrInvalidEventID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sub, replay, resumed, err := broker.Subscribe(test.lastEventID, test.types)
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			defer sub.Close()
+
+			assert.Equal(t, test.expectedReplay, ids(replay))
+			assert.Equal(t, test.expectedResumed, resumed)
+		})
+	}
+}
+
+func TestBroker_DropsSlowClient(t *testing.T) {
+	broker := newBroker(1000, 10)
+
+	sub, _, _, err := broker.Subscribe("", nil)
+	require.NoError(t, err)
+
+	types := make([]event.Type, 100)
+	for i := range types {
+		types[i] = event.BookUpdated
+	}
+	publish(t, broker, types...)
+
+	received := drain(sub)
+	assert.Less(t, len(received), 100)
+
+	_, ok := <-sub.C
+	assert.False(t, ok, "the subscription must be closed")
+
+	// the client resumes where it was dropped
+	_, replay, resumed, err := broker.Subscribe("64", nil)
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	assert.Len(t, replay, 36)
+}
+
+func TestBroker_Limits(t *testing.T) {
+	broker := newBroker(10, 1)
+
+	sub, _, _, err := broker.Subscribe("", nil)
+	require.NoError(t, err)
+
+	_, _, _, err = broker.Subscribe("", nil)
+	assert.ErrorIs(t, err, stream.ErrTooManyClients)
+
+	sub.Close()
+	sub.Close()
+
+	sub, _, _, err = broker.Subscribe("", nil)
+	require.NoError(t, err)
+
+	broker.Close()
+
+	_, ok := <-sub.C
+	assert.False(t, ok, "the subscription must be closed")
+
+	_, _, _, err = broker.Subscribe("", nil)
+	assert.ErrorIs(t, err, stream.ErrClosed)
+}
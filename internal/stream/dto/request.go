@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// StreamFilter narrows the streamed events to the given types, every type
+// when empty.
+type StreamFilter struct {
+	Types []string `json:"types" validate:"dive,event_type"`
+}
+
+// NewStreamFilter reads the comma separated types from the query string.
+func NewStreamFilter(query url.Values) StreamFilter {
+	var filter StreamFilter
+
+	for _, value := range query["types"] {
+		for t := range strings.SplitSeq(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				filter.Types = append(filter.Types, t)
+			}
+		}
+	}
+
+	return filter
+}
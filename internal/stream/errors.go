@@ -0,0 +1,9 @@
+package stream
+
+import "errors"
+
+var (
+	ErrInvalidEventID = errors.New("invalid last event ID")
+	ErrTooManyClients = errors.New("too many stream clients")
+	ErrClosed         = errors.New("stream broker closed")
+)
@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/stream/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+const (
+	// ResetEvent tells the client that it missed events, it should reload
+	// its state.
+	ResetEvent = "stream.reset"
+	// retryDelay is the reconnection delay advised to the clients.
+	retryDelay = 3 * time.Second
+)
+
+type StreamHandler struct {
+	broker    *Broker
+	heartbeat time.Duration
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewStreamHandler(broker *Broker, heartbeat time.Duration, validator *internalValidator.Validator, logger zerolog.Logger) *StreamHandler {
+	return &StreamHandler{
+		broker:    broker,
+		heartbeat: heartbeat,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *StreamHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Get("/stream", h.StreamEvents)
+
+	return r
+}
+
+// StreamEvents godoc
+//
+//	@Summary		Stream the change events
+//	@Description	Push the book and author change events as Server-Sent Events. A client reconnecting with the ID of the last event it received gets the events it missed while they are buffered, or a stream.reset event when they are not. A comment is sent as heartbeat.
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			types			query		string	false	"Comma separated event types to receive, every type when empty"
+//	@Param			last_event_id	query		string	false	"ID of the last event received, for clients unable to set the Last-Event-ID header"
+//	@Param			Last-Event-ID	header		string	false	"ID of the last event received"
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{string}	string	"Stream of events"
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		503				{object}	response.ErrorResponse
+//	@Router			/events/stream [get]
+func (h *StreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	filter := dto.NewStreamFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	types := make([]event.Type, len(filter.Types))
+	for i, t := range filter.Types {
+		types[i] = event.Type(t)
+	}
+
+	sub, replay, resumed, err := h.broker.Subscribe(lastEventID, types)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+	defer sub.Close()
+
+	controller := http.NewResponseController(w)
+
+	// the stream outlives any write timeout of the server
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Warn().Err(err).Msg("failed to clear the write deadline")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Connection", "keep-alive")
+	// disables the buffering of nginx
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", retryDelay.Milliseconds())
+
+	if !resumed {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", ResetEvent)
+	}
+
+	for _, msg := range replay {
+		writeMessage(w, msg)
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		if err := controller.Flush(); err != nil {
+			h.logger.Debug().Err(err).Msg("event stream closed")
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			writeMessage(w, msg)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+	}
+}
+
+func writeMessage(w io.Writer, msg Message) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", msg.ID, msg.Type, msg.Data)
+}
+
+func (h *StreamHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidEventID):
+		response.Error(w, http.StatusBadRequest, "Invalid last event ID")
+	case errors.Is(err, ErrTooManyClients), errors.Is(err, ErrClosed):
+		w.Header().Set("Retry-After", "5")
+		response.Error(w, http.StatusServiceUnavailable, "Event stream unavailable, retry later")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
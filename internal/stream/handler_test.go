@@ -0,0 +1,249 @@
+package stream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/stream"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func newStreamServer(t *testing.T, broker *stream.Broker) *httptest.Server {
+	t.Helper()
+
+	v := validator.New()
+	require.NoError(t, stream.RegisterValidations(v))
+
+	r := chi.NewRouter()
+	r.Mount("/events", stream.NewStreamHandler(broker, 10*time.Millisecond, v, zerolog.Nop()).Routes())
+
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// openStream connects to the stream and returns its lines.
+func openStream(t *testing.T, url, lastEventID string) (*http.Response, <-chan string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resp, lines
+}
+
+// nextFrame returns the next frame of the stream, skipping the heartbeats
+// unless keepHeartbeats is set.
+func nextFrame(t *testing.T, lines <-chan string, keepHeartbeats bool) []string {
+	t.Helper()
+
+	var frame []string
+	for {
+		select {
+		case line, ok := <-lines:
+			require.True(t, ok, "stream closed")
+
+			if line != "" {
+				frame = append(frame, line)
+				continue
+			}
+
+			if !keepHeartbeats && len(frame) == 1 && strings.HasPrefix(frame[0], ":") {
+				frame = nil
+				continue
+			}
+			return frame
+		case <-time.After(5 * time.Second):
+			t.Fatal("no frame received")
+		}
+	}
+}
+
+func TestStreamHandler_StreamEvents(t *testing.T) {
+	broker := newBroker(10, 10)
+	srv := newStreamServer(t, broker)
+
+	resp, lines := openStream(t, srv.URL+"/events/stream?types=book.created,author.created", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	assert.Equal(t, []string{"retry: 3000"}, nextFrame(t, lines, false))
+	assert.Equal(t, []string{": heartbeat"}, nextFrame(t, lines, true))
+
+	events := publish(t, broker, event.BookUpdated, event.BookCreated)
+
+	frame := nextFrame(t, lines, false)
+	require.Len(t, frame, 3)
+	assert.Equal(t, "id: 2", frame[0])
+	assert.Equal(t, "event: book.created", frame[1])
+
+	var e event.Event
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame[2], "data: ")), &e))
+	assert.Equal(t, events[1].ID, e.ID)
+
+	t.Run("resume", func(t *testing.T) {
+		_, lines := openStream(t, srv.URL+"/events/stream", "1")
+
+		assert.Equal(t, []string{"retry: 3000"}, nextFrame(t, lines, false))
+		assert.Equal(t, "id: 2", nextFrame(t, lines, false)[0])
+	})
+
+	t.Run("missed events", func(t *testing.T) {
+		_, lines := openStream(t, srv.URL+"/events/stream", "42")
+
+		assert.Equal(t, []string{"retry: 3000"}, nextFrame(t, lines, false))
+		assert.Equal(t, []string{"event: stream.reset", "data: {}"}, nextFrame(t, lines, false))
+	})
+
+	t.Run("closed on shutdown", func(t *testing.T) {
+		broker.Close()
+
+		for range lines {
+		}
+	})
+}
+
+func TestStreamHandler_StreamEvents_MemberEvents(t *testing.T) {
+	broker := newBroker(10, 10)
+	srv := newStreamServer(t, broker)
+
+	_, lines := openStream(t, srv.URL+"/events/stream", "")
+	assert.Equal(t, []string{"retry: 3000"}, nextFrame(t, lines, false))
+
+	// the loan carries the member, it is not streamed to anonymous clients
+	events := publish(t, broker, event.LoanCreated, event.BookCreated)
+
+	frame := nextFrame(t, lines, false)
+	require.Len(t, frame, 3)
+	assert.Equal(t, "id: 1", frame[0])
+	assert.Equal(t, "event: book.created", frame[1])
+
+	var e event.Event
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(frame[2], "data: ")), &e))
+	assert.Equal(t, events[1].ID, e.ID)
+}
+
+func TestStreamHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		lastEventID        string
+		connected          int
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:               "error unknown event type",
+			url:                "/events/stream?types=book.created,book.archived",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "types[1]",
+					Message: "types[1] must be a public event type",
+				}},
+			},
+		},
+		{
+			name:               "error member event type",
+			url:                "/events/stream?types=loan.created",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{{
+					Field:   "types[0]",
+					Message: "types[0] must be a public event type",
+				}},
+			},
+		},
+		{
+			name:               "error invalid last event ID",
+			url:                "/events/stream",
+			lastEventID:        "abc",
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid last event ID",
+			},
+		},
+		{
+			name:               "error too many clients",
+			url:                "/events/stream",
+			connected:          1,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Event stream unavailable, retry later",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			broker := newBroker(10, 1)
+			for range test.connected {
+				_, _, _, err := broker.Subscribe("", nil)
+				require.NoError(t, err)
+			}
+
+			v := validator.New()
+			require.NoError(t, stream.RegisterValidations(v))
+
+			r := chi.NewRouter()
+			r.Mount("/events", stream.NewStreamHandler(broker, time.Second, v, zerolog.Nop()).Routes())
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			req.Header.Set("Last-Event-ID", test.lastEventID)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
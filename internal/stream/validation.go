@@ -0,0 +1,26 @@
+package stream
+
+import (
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+
+	"go-boilerplate-rest-api-chi/internal/event"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+// RegisterValidations registers the validation rules of the stream module.
+func RegisterValidations(v *internalValidator.Validator) error {
+	return v.RegisterRules(internalValidator.Rule{
+		Tag:  "event_type",
+		Func: isEventType,
+		Messages: map[string]string{
+			"en": "{0} must be a public event type",
+			"fr": "{0} doit être un type d'événement public",
+		},
+	})
+}
+
+func isEventType(fl validator.FieldLevel) bool {
+	return slices.Contains(event.PublicTypes, event.Type(fl.Field().String()))
+}
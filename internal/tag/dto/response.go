@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type TagResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name" example:"award winner"`
+}
+
+func ToTagResponse(tag *entity.Tag) *TagResponse {
+	return &TagResponse{
+		ID:   tag.ID.String(),
+		Name: tag.Name,
+	}
+}
+
+func ToTagsResponse(tags []*entity.Tag) []TagResponse {
+	responses := make([]TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = *ToTagResponse(tag)
+	}
+	return responses
+}
@@ -0,0 +1,8 @@
+package tag
+
+import "errors"
+
+var (
+	ErrNotFound  = errors.New("tag not found")
+	ErrDuplicate = errors.New("tag already exists")
+)
@@ -0,0 +1,158 @@
+package tag
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/tag/dto"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+type TagSuccessResponse struct {
+	Status  string           `json:"status" example:"success"`
+	Message string           `json:"message" example:"Tag created successfully"`
+	Tag     *dto.TagResponse `json:"tag"`
+}
+
+type TagsSuccessResponse struct {
+	Status  string            `json:"status" example:"success"`
+	Message string            `json:"message" example:"Tags retrieved successfully"`
+	Tags    []dto.TagResponse `json:"tags"`
+}
+
+type TagHandler struct {
+	service   TagService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewTagHandler(service TagService, validator *internalValidator.Validator, logger zerolog.Logger) *TagHandler {
+	return &TagHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *TagHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.CreateTag)
+	r.Get("/", h.GetAllTags)
+	r.Delete("/{tag_id}", h.DeleteTag)
+
+	return r
+}
+
+// CreateTag godoc
+//
+//	@Summary		Create a tag
+//	@Description	Create a free-form tag, its name is stored in lowercase. Tags are also created on the fly when assigned to a book.
+//	@Tags			tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag				body		dto.CreateTagRequest	true	"Tag data"
+//	@Param			Accept-Language	header		string					false	"Language of the validation messages (en, fr)"
+//	@Param			Idempotency-Key	header		string					false	"Unique key making retries of this request safe, the first response is replayed"
+//	@Success		201				{object}	TagSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		409				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/tags [post]
+func (h *TagHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateTagRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	tag, err := h.service.CreateTag(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, TagSuccessResponse{
+		Status:  "success",
+		Message: "Tag created successfully",
+		Tag:     dto.ToTagResponse(tag),
+	})
+}
+
+// GetAllTags godoc
+//
+//	@Summary		Get all tags
+//	@Description	Get every tag ordered by name
+//	@Tags			tags
+//	@Produce		json
+//	@Success		200	{object}	TagsSuccessResponse
+//	@Failure		500	{object}	response.ErrorResponse
+//	@Router			/tags [get]
+func (h *TagHandler) GetAllTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.GetAllTags(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, TagsSuccessResponse{
+		Status:  "success",
+		Message: "Tags retrieved successfully",
+		Tags:    dto.ToTagsResponse(tags),
+	})
+}
+
+// DeleteTag godoc
+//
+//	@Summary		Delete a tag
+//	@Description	Delete a tag, it is removed from every book
+//	@Tags			tags
+//	@Produce		json
+//	@Param			tag_id	path		string	true	"Tag ID"
+//	@Success		200		{object}	response.SuccessResponse
+//	@Failure		400		{object}	response.ErrorResponse
+//	@Failure		404		{object}	response.ErrorResponse
+//	@Failure		500		{object}	response.ErrorResponse
+//	@Router			/tags/{tag_id} [delete]
+func (h *TagHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	tagID, err := uuid.Parse(chi.URLParam(r, "tag_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.DeleteTag(r.Context(), tagID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Tag deleted successfully")
+}
+
+func (h *TagHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Tag not found")
+	case errors.Is(err, ErrDuplicate):
+		response.Error(w, http.StatusConflict, "Tag with this name already exists")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
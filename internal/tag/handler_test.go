@@ -0,0 +1,113 @@
+package tag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/tag"
+	"go-boilerplate-rest-api-chi/internal/tag/dto"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+var tagID = uuid.MustParse("9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a")
+
+func TestTagHandler(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		requestBody        string
+		configureMock      func(*mocks.MockTagService)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "success create tag",
+			method:      http.MethodPost,
+			path:        "/tags",
+			requestBody: `{"name":"Paris"}`,
+			configureMock: func(mockService *mocks.MockTagService) {
+				mockService.EXPECT().
+					CreateTag(gomock.Any(), &dto.CreateTagRequest{Name: "Paris"}).
+					Return(&entity.Tag{ID: tagID, Name: "paris"}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    "Tag created successfully",
+		},
+		{
+			name:               "error create without name",
+			method:             http.MethodPost,
+			path:               "/tags",
+			requestBody:        `{}`,
+			configureMock:      func(mockService *mocks.MockTagService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "Validation failed",
+		},
+		{
+			name:        "error duplicate",
+			method:      http.MethodPost,
+			path:        "/tags",
+			requestBody: `{"name":"paris"}`,
+			configureMock: func(mockService *mocks.MockTagService) {
+				mockService.EXPECT().
+					CreateTag(gomock.Any(), gomock.Any()).
+					Return(nil, tag.ErrDuplicate)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "Tag with this name already exists",
+		},
+		{
+			name:   "error delete unknown tag",
+			method: http.MethodDelete,
+			path:   "/tags/" + tagID.String(),
+			configureMock: func(mockService *mocks.MockTagService) {
+				mockService.EXPECT().
+					DeleteTag(gomock.Any(), tagID).
+					Return(tag.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    "Tag not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockTagService(ctrl)
+			test.configureMock(mockService)
+
+			handler := tag.NewTagHandler(mockService, validator.New(), zerolog.Nop())
+
+			req := httptest.NewRequest(test.method, test.path, bytes.NewBufferString(test.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/tags", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			var got struct {
+				Message string `json:"message"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, test.expectedMessage, got.Message)
+		})
+	}
+}
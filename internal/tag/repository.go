@@ -0,0 +1,103 @@
+package tag
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+//go:generate mockgen -destination=../mocks/mock_tag_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/tag TagRepository
+type TagRepository interface {
+	Create(ctx context.Context, newTag *entity.Tag) (*entity.Tag, error)
+	GetAll(ctx context.Context) ([]*entity.Tag, error)
+	// FindOrCreate returns the tags of the given normalized names ordered by
+	// name, the missing ones are created.
+	FindOrCreate(ctx context.Context, names []string) ([]*entity.Tag, error)
+	// Delete removes the tag from every book, it returns ErrNotFound when
+	// there is no such tag.
+	Delete(ctx context.Context, tagID uuid.UUID) error
+}
+
+type tagRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewTagRepository(db *gorm.DB, logger zerolog.Logger) TagRepository {
+	return &tagRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *tagRepository) Create(ctx context.Context, newTag *entity.Tag) (*entity.Tag, error) {
+	if err := transaction.DB(ctx, r.db).Create(newTag).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrDuplicate
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return newTag, nil
+}
+
+func (r *tagRepository) GetAll(ctx context.Context) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+
+	if err := transaction.DB(ctx, r.db).Order("name").Find(&tags).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepository) FindOrCreate(ctx context.Context, names []string) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+
+	if len(names) == 0 {
+		return tags, nil
+	}
+
+	newTags := make([]*entity.Tag, len(names))
+	for i, name := range names {
+		newTags[i] = &entity.Tag{Name: name}
+	}
+
+	// the tags created concurrently or beforehand are left as they are, and
+	// read back with their own ID
+	if err := transaction.DB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	if err := transaction.DB(ctx, r.db).Order("name").Find(&tags, "name IN ?", names).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, tagID uuid.UUID) error {
+	result := transaction.DB(ctx, r.db).Delete(&entity.Tag{}, "id = ?", tagID)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Msg("database error")
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
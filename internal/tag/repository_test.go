@@ -0,0 +1,53 @@
+package tag_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/tag"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+)
+
+func TestTagRepository_FindOrCreate(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Tag{})
+	repo := tag.NewTagRepository(db, zerolog.Nop())
+	ctx := context.Background()
+
+	existing, err := repo.Create(ctx, &entity.Tag{Name: "paris"})
+	require.NoError(t, err)
+
+	tags, err := repo.FindOrCreate(ctx, []string{"paris", "classic"})
+	require.NoError(t, err)
+
+	require.Len(t, tags, 2)
+	assert.Equal(t, "classic", tags[0].Name)
+	assert.Equal(t, existing.ID, tags[1].ID)
+
+	var count int64
+	require.NoError(t, db.Model(&entity.Tag{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestTagRepository_Create(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Tag{})
+	repo := tag.NewTagRepository(db, zerolog.Nop())
+
+	_, err := repo.Create(context.Background(), &entity.Tag{Name: "paris"})
+	require.NoError(t, err)
+
+	_, err = repo.Create(context.Background(), &entity.Tag{Name: "paris"})
+	assert.ErrorIs(t, err, tag.ErrDuplicate)
+}
+
+func TestTagRepository_Delete(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Tag{})
+	repo := tag.NewTagRepository(db, zerolog.Nop())
+
+	assert.ErrorIs(t, repo.Delete(context.Background(), uuid.New()), tag.ErrNotFound)
+}
@@ -0,0 +1,62 @@
+package tag
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/tag/dto"
+)
+
+//go:generate mockgen -destination=../mocks/mock_tag_service.go -package=mocks go-boilerplate-rest-api-chi/internal/tag TagService
+type TagService interface {
+	CreateTag(ctx context.Context, req *dto.CreateTagRequest) (*entity.Tag, error)
+	GetAllTags(ctx context.Context) ([]*entity.Tag, error)
+	DeleteTag(ctx context.Context, tagID uuid.UUID) error
+}
+
+type tagService struct {
+	repository TagRepository
+	logger     zerolog.Logger
+}
+
+func NewTagService(repository TagRepository, logger zerolog.Logger) TagService {
+	return &tagService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *tagService) CreateTag(ctx context.Context, req *dto.CreateTagRequest) (*entity.Tag, error) {
+	return s.repository.Create(ctx, &entity.Tag{Name: Normalize(req.Name)})
+}
+
+func (s *tagService) GetAllTags(ctx context.Context) ([]*entity.Tag, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, tagID uuid.UUID) error {
+	return s.repository.Delete(ctx, tagID)
+}
+
+// Normalize returns the stored form of a tag name, tags differing only by
+// their case are the same tag.
+func Normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeAll normalizes the names and drops the duplicates, the order is
+// kept.
+func NormalizeAll(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = Normalize(name); !slices.Contains(normalized, name) {
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,36 @@
+package testutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewGormSQLite opens an in-memory SQLite database migrated with the given
+// models.
+func NewGormSQLite(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	sqlDB, err := gormDB.DB()
+	require.NoError(t, err)
+
+	// every connection to ":memory:" opens a new database
+	sqlDB.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	require.NoError(t, gormDB.AutoMigrate(models...))
+
+	return gormDB
+}
@@ -0,0 +1,24 @@
+package testutils
+
+import (
+	"context"
+
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+// NewTransactionManager returns a transaction manager running the
+// transactions inline.
+func NewTransactionManager(ctrl *gomock.Controller) *mocks.MockManager {
+	manager := mocks.NewMockManager(ctrl)
+	manager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error, _ ...transaction.Option) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return manager
+}
@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMaxAttempts is the number of times a transaction runs before a
+	// deadlock is returned to the caller.
+	DefaultMaxAttempts = 3
+	// baseBackoff is the delay before the first retry, doubled on each retry.
+	baseBackoff = 20 * time.Millisecond
+)
+
+// MySQL errors worth retrying the whole transaction for.
+const (
+	errLockWaitTimeout = 1205
+	errDeadlock        = 1213
+)
+
+type ctxKey struct{}
+
+// state is the transaction carried by a context.
+type state struct {
+	tx    *gorm.DB
+	depth int
+}
+
+type options struct {
+	retry bool
+}
+
+// Option configures a single call to Manager.Do.
+type Option func(*options)
+
+// WithoutRetry runs the transaction once, for functions that cannot run
+// twice, such as the ones consuming a stream.
+func WithoutRetry() Option {
+	return func(o *options) {
+		o.retry = false
+	}
+}
+
+//go:generate mockgen -destination=../mocks/mock_transaction_manager.go -package=mocks go-boilerplate-rest-api-chi/internal/transaction Manager
+type Manager interface {
+	// Do runs fn in a transaction, committed when fn returns nil and rolled
+	// back otherwise. The context given to fn carries the transaction, the
+	// repositories pick it up through DB. When ctx already carries a
+	// transaction, fn runs in a savepoint of it.
+	//
+	// A top level transaction failing on a deadlock is retried, fn must only
+	// have side effects through the transaction.
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error
+}
+
+type manager struct {
+	db          *gorm.DB
+	maxAttempts int
+	logger      zerolog.Logger
+}
+
+func NewManager(db *gorm.DB, logger zerolog.Logger) Manager {
+	return &manager{
+		db:          db,
+		maxAttempts: DefaultMaxAttempts,
+		logger:      logger,
+	}
+}
+
+// DB returns the transaction carried by ctx, or db when there is none, bound
+// to ctx.
+func DB(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if s, ok := ctx.Value(ctxKey{}).(*state); ok {
+		return s.tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+func (m *manager) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...Option) error {
+	if s, ok := ctx.Value(ctxKey{}).(*state); ok {
+		return m.savepoint(ctx, s, fn)
+	}
+
+	o := options{retry: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return fn(context.WithValue(ctx, ctxKey{}, &state{tx: tx}))
+		})
+		if err == nil || !o.retry || attempt >= m.maxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		delay := baseBackoff << (attempt - 1)
+		delay += rand.N(delay)
+		m.logger.Warn().Err(err).Int("attempt", attempt).Dur("retry_in", delay).Msg("transaction failed, retrying")
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// savepoint runs fn in a savepoint of the transaction s. Savepoints are named
+// after their depth so that nested ones never shadow their parent.
+func (m *manager) savepoint(ctx context.Context, s *state, fn func(ctx context.Context) error) (err error) {
+	nested := &state{tx: s.tx, depth: s.depth + 1}
+	name := fmt.Sprintf("sp_%d", nested.depth)
+
+	if err := s.tx.SavePoint(name).Error; err != nil {
+		return err
+	}
+
+	panicked := true
+	defer func() {
+		if panicked || err != nil {
+			if rollbackErr := s.tx.RollbackTo(name).Error; rollbackErr != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, ctxKey{}, nested))
+	panicked = false
+
+	return err
+}
+
+// IsRetryable reports whether err aborted a transaction that may succeed when
+// run again.
+func IsRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == errDeadlock || mysqlErr.Number == errLockWaitTimeout
+	}
+
+	return false
+}
@@ -0,0 +1,234 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+var errFailed = errors.New("failed")
+
+func createAuthor(ctx context.Context, db *gorm.DB, name string) error {
+	return transaction.DB(ctx, db).Create(&entity.Author{Name: name}).Error
+}
+
+func authorNames(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+
+	var names []string
+	require.NoError(t, db.Model(&entity.Author{}).Order("name").Pluck("name", &names).Error)
+
+	return names
+}
+
+func TestManager_Do(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []transaction.Option
+		fn               func(db *gorm.DB, tm transaction.Manager, attempt int) func(ctx context.Context) error
+		expectedError    error
+		expectedAttempts int
+		expectedNames    []string
+	}{
+		{
+			name: "success commit",
+			fn: func(db *gorm.DB, _ transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+						return err
+					}
+					return createAuthor(ctx, db, "Émile Zola")
+				}
+			},
+			expectedAttempts: 1,
+			expectedNames:    []string{"Victor Hugo", "Émile Zola"},
+		},
+		{
+			name: "error rollback",
+			fn: func(db *gorm.DB, _ transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+						return err
+					}
+					return errFailed
+				}
+			},
+			expectedError:    errFailed,
+			expectedAttempts: 1,
+		},
+		{
+			name: "success failed savepoint keeps the transaction",
+			fn: func(db *gorm.DB, tm transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+						return err
+					}
+
+					err := tm.Do(ctx, func(ctx context.Context) error {
+						if err := createAuthor(ctx, db, "Émile Zola"); err != nil {
+							return err
+						}
+						return errFailed
+					})
+					if !errors.Is(err, errFailed) {
+						return err
+					}
+
+					return tm.Do(ctx, func(ctx context.Context) error {
+						return createAuthor(ctx, db, "Jules Verne")
+					})
+				}
+			},
+			expectedAttempts: 1,
+			expectedNames:    []string{"Jules Verne", "Victor Hugo"},
+		},
+		{
+			name: "success nested savepoints",
+			fn: func(db *gorm.DB, tm transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return tm.Do(ctx, func(ctx context.Context) error {
+						if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+							return err
+						}
+
+						_ = tm.Do(ctx, func(ctx context.Context) error {
+							if err := createAuthor(ctx, db, "Émile Zola"); err != nil {
+								return err
+							}
+							return errFailed
+						})
+
+						return nil
+					})
+				}
+			},
+			expectedAttempts: 1,
+			expectedNames:    []string{"Victor Hugo"},
+		},
+		{
+			name: "error failed savepoint aborts the transaction",
+			fn: func(db *gorm.DB, tm transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+						return err
+					}
+
+					return tm.Do(ctx, func(ctx context.Context) error {
+						return errFailed
+					})
+				}
+			},
+			expectedError:    errFailed,
+			expectedAttempts: 1,
+		},
+		{
+			name: "success retry on deadlock",
+			fn: func(db *gorm.DB, _ transaction.Manager, attempt int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+						return err
+					}
+					if attempt == 1 {
+						return &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+					}
+					return nil
+				}
+			},
+			expectedAttempts: 2,
+			expectedNames:    []string{"Victor Hugo"},
+		},
+		{
+			name: "error deadlock after every attempt",
+			fn: func(_ *gorm.DB, _ transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+				}
+			},
+			expectedError:    &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"},
+			expectedAttempts: transaction.DefaultMaxAttempts,
+		},
+		{
+			name: "error deadlock without retry",
+			opts: []transaction.Option{transaction.WithoutRetry()},
+			fn: func(_ *gorm.DB, _ transaction.Manager, _ int) func(ctx context.Context) error {
+				return func(ctx context.Context) error {
+					return &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+				}
+			},
+			expectedError:    &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := testutils.NewGormSQLite(t, &entity.Author{})
+			tm := transaction.NewManager(db, zerolog.Nop())
+
+			attempts := 0
+			err := tm.Do(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return test.fn(db, tm, attempts)(ctx)
+			}, test.opts...)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, test.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, test.expectedAttempts, attempts)
+			assert.ElementsMatch(t, test.expectedNames, authorNames(t, db))
+		})
+	}
+}
+
+func TestManager_DoPanic(t *testing.T) {
+	db := testutils.NewGormSQLite(t, &entity.Author{})
+	tm := transaction.NewManager(db, zerolog.Nop())
+
+	assert.Panics(t, func() {
+		_ = tm.Do(context.Background(), func(ctx context.Context) error {
+			if err := createAuthor(ctx, db, "Victor Hugo"); err != nil {
+				return err
+			}
+
+			return tm.Do(ctx, func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+	})
+
+	assert.Empty(t, authorNames(t, db))
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "deadlock", err: &mysql.MySQLError{Number: 1213}, expected: true},
+		{name: "lock wait timeout", err: &mysql.MySQLError{Number: 1205}, expected: true},
+		{name: "wrapped deadlock", err: errors.Join(errFailed, &mysql.MySQLError{Number: 1213}), expected: true},
+		{name: "duplicate entry", err: &mysql.MySQLError{Number: 1062}, expected: false},
+		{name: "other error", err: errFailed, expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, transaction.IsRetryable(test.err))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package validator
+
+// catalog holds the validation messages of each supported locale, keyed by
+// validation tag. Tags missing from the catalog fall back on the default
+// go-playground translations of the locale.
+//
+// To support a new language, add its locale to supportedLocales and its
+// messages here.
+var catalog = map[string]map[string]string{
+	"en": {
+		"required": "{0} is required",
+		"email":    "{0} must be a valid email address",
+		"min":      "{0} must be at least {1} characters",
+		"max":      "{0} must be at most {1} characters",
+		"url":      "{0} must be a valid URL",
+		"alpha":    "{0} must contain only letters",
+		"alphanum": "{0} must contain only letters and numbers",
+		"numeric":  "{0} must be a number",
+		"len":      "{0} must be exactly {1} characters",
+		"gt":       "{0} must be greater than {1}",
+		"gte":      "{0} must be greater than or equal to {1}",
+		"lt":       "{0} must be less than {1}",
+		"lte":      "{0} must be less than or equal to {1}",
+
+		"uuid_strict": "{0} must be a lowercase UUID",
+		"isbn":        "{0} must be a valid ISBN-10 or ISBN-13",
+		"bcp47":       "{0} must be a valid BCP 47 language tag",
+		"iso_date":    "{0} must be a date formatted as YYYY-MM-DD",
+		"not_future":  "{0} must not be in the future",
+		"trimmed":     "{0} must not start or end with spaces",
+	},
+	"fr": {
+		"required": "{0} est obligatoire",
+		"email":    "{0} doit être une adresse email valide",
+		"min":      "{0} doit contenir au moins {1} caractères",
+		"max":      "{0} doit contenir au plus {1} caractères",
+		"url":      "{0} doit être une URL valide",
+		"alpha":    "{0} ne doit contenir que des lettres",
+		"alphanum": "{0} ne doit contenir que des lettres et des chiffres",
+		"numeric":  "{0} doit être un nombre",
+		"len":      "{0} doit contenir exactement {1} caractères",
+		"gt":       "{0} doit être supérieur à {1}",
+		"gte":      "{0} doit être supérieur ou égal à {1}",
+		"lt":       "{0} doit être inférieur à {1}",
+		"lte":      "{0} doit être inférieur ou égal à {1}",
+
+		"uuid_strict": "{0} doit être un UUID en minuscules",
+		"isbn":        "{0} doit être un ISBN-10 ou ISBN-13 valide",
+		"bcp47":       "{0} doit être une étiquette de langue BCP 47 valide",
+		"iso_date":    "{0} doit être une date au format AAAA-MM-JJ",
+		"not_future":  "{0} ne doit pas être dans le futur",
+		"trimmed":     "{0} ne doit pas commencer ou finir par des espaces",
+	},
+}
@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/text/language"
+)
+
+// DateLayout is the ISO 8601 calendar date layout accepted by the iso_date
+// tag.
+const DateLayout = "2006-01-02"
+
+// Rule is a custom validation tag. Messages are keyed by locale and follow the
+// same placeholders as RegisterMessages.
+type Rule struct {
+	Tag      string
+	Func     validator.Func
+	Messages map[string]string
+}
+
+// StructRule is a cross-field validation run on every value of the given
+// types. Errors must be reported with StructLevel.ReportError using a tag
+// whose messages are registered through a Rule or RegisterMessages.
+type StructRule struct {
+	Func  validator.StructLevelFunc
+	Types []any
+}
+
+var uuidStrictRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[1-8][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// defaultRules are available to every module. Their messages live in the
+// catalog.
+var defaultRules = []Rule{
+	{Tag: "uuid_strict", Func: isUUIDStrict},
+	{Tag: "isbn", Func: isISBN},
+	{Tag: "bcp47", Func: isBCP47},
+	{Tag: "iso_date", Func: isISODate},
+	{Tag: "not_future", Func: isNotFuture},
+	{Tag: "trimmed", Func: isTrimmed},
+}
+
+// RegisterRules adds custom validation tags, overriding any existing tag with
+// the same name.
+func (v *Validator) RegisterRules(rules ...Rule) error {
+	for _, rule := range rules {
+		if rule.Func != nil {
+			if err := v.validate.RegisterValidation(rule.Tag, rule.Func); err != nil {
+				return err
+			}
+		}
+
+		for locale, message := range rule.Messages {
+			if err := v.RegisterMessages(locale, map[string]string{rule.Tag: message}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegisterStructRules adds cross-field validations.
+func (v *Validator) RegisterStructRules(rules ...StructRule) {
+	for _, rule := range rules {
+		v.validate.RegisterStructValidation(rule.Func, rule.Types...)
+	}
+}
+
+// NormalizeISBN strips the separators of an ISBN and upper cases its check
+// digit.
+func NormalizeISBN(isbn string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+}
+
+// isUUIDStrict only accepts lowercase, hyphenated, non nil RFC 4122 UUIDs.
+func isUUIDStrict(fl validator.FieldLevel) bool {
+	return uuidStrictRegex.MatchString(fl.Field().String())
+}
+
+// isISBN accepts ISBN-10 and ISBN-13 with a valid check digit, optionally
+// separated by hyphens or spaces.
+func isISBN(fl validator.FieldLevel) bool {
+	isbn := NormalizeISBN(fl.Field().String())
+
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i, c := range isbn {
+			var digit int
+			switch {
+			case c >= '0' && c <= '9':
+				digit = int(c - '0')
+			case c == 'X' && i == 9:
+				digit = 10
+			default:
+				return false
+			}
+			sum += digit * (10 - i)
+		}
+		return sum%11 == 0
+
+	case 13:
+		sum := 0
+		for i, c := range isbn {
+			if c < '0' || c > '9' {
+				return false
+			}
+			digit := int(c - '0')
+			if i%2 == 1 {
+				digit *= 3
+			}
+			sum += digit
+		}
+		return sum%10 == 0
+
+	default:
+		return false
+	}
+}
+
+// isBCP47 accepts well-formed BCP 47 language tags made of known subtags.
+func isBCP47(fl validator.FieldLevel) bool {
+	_, err := language.Parse(fl.Field().String())
+	return err == nil
+}
+
+// isISODate accepts calendar dates formatted as YYYY-MM-DD.
+func isISODate(fl validator.FieldLevel) bool {
+	_, err := time.Parse(DateLayout, fl.Field().String())
+	return err == nil
+}
+
+// isNotFuture rejects dates after today. It supports time.Time values and
+// YYYY-MM-DD strings, other strings are left to the iso_date tag. Both are
+// compared on their UTC calendar date.
+func isNotFuture(fl validator.FieldLevel) bool {
+	today := utcDate(time.Now())
+
+	switch value := fl.Field().Interface().(type) {
+	case time.Time:
+		return !utcDate(value).After(today)
+	case string:
+		date, err := time.Parse(DateLayout, value)
+		if err != nil {
+			return true
+		}
+		return !date.After(today)
+	default:
+		return false
+	}
+}
+
+// utcDate returns midnight UTC of the UTC calendar date of t.
+func utcDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// isTrimmed rejects strings with leading or trailing white space.
+func isTrimmed(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return strings.TrimSpace(value) == value
+}
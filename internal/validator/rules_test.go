@@ -0,0 +1,131 @@
+package validator_test
+
+import (
+	"testing"
+	"time"
+
+	govalidator "github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+)
+
+func TestDefaultRules(t *testing.T) {
+	now := time.Now().UTC()
+	tomorrow := now.AddDate(0, 0, 1)
+	// the last minute of the UTC day is already tomorrow in UTC+14
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 0, 0, time.UTC).In(time.FixedZone("UTC+14", 14*60*60))
+
+	tests := []struct {
+		name  string
+		tag   string
+		value any
+		valid bool
+	}{
+		{name: "uuid strict valid", tag: "uuid_strict", value: "eb21d07a-7ab3-40db-bfd3-448093bc5626", valid: true},
+		{name: "uuid strict uppercase", tag: "uuid_strict", value: "EB21D07A-7AB3-40DB-BFD3-448093BC5626", valid: false},
+		{name: "uuid strict without hyphens", tag: "uuid_strict", value: "eb21d07a7ab340dbbfd3448093bc5626", valid: false},
+		{name: "uuid strict nil uuid", tag: "uuid_strict", value: "00000000-0000-0000-0000-000000000000", valid: false},
+		{name: "isbn 13 with hyphens", tag: "isbn", value: "978-2-07-040850-4", valid: true},
+		{name: "isbn 10 with check digit x", tag: "isbn", value: "0-8044-2957-X", valid: true},
+		{name: "isbn 13 wrong check digit", tag: "isbn", value: "9782070408505", valid: false},
+		{name: "isbn wrong length", tag: "isbn", value: "12345", valid: false},
+		{name: "bcp47 language", tag: "bcp47", value: "fr", valid: true},
+		{name: "bcp47 language and region", tag: "bcp47", value: "pt-BR", valid: true},
+		{name: "bcp47 unknown language", tag: "bcp47", value: "xx-YY", valid: false},
+		{name: "iso date", tag: "iso_date", value: "1862-04-03", valid: true},
+		{name: "iso date wrong layout", tag: "iso_date", value: "03/04/1862", valid: false},
+		{name: "not future past date", tag: "not_future", value: "1862-04-03", valid: true},
+		{name: "not future tomorrow", tag: "not_future", value: tomorrow.Format(validator.DateLayout), valid: false},
+		{name: "not future today", tag: "not_future", value: now.Format(validator.DateLayout), valid: true},
+		{name: "not future time", tag: "not_future", value: tomorrow, valid: false},
+		{name: "not future time later today", tag: "not_future", value: endOfToday, valid: true},
+		{name: "trimmed", tag: "trimmed", value: "Les Misérables", valid: true},
+		{name: "trimmed leading space", tag: "trimmed", value: " Les Misérables", valid: false},
+		{name: "trimmed trailing new line", tag: "trimmed", value: "Les Misérables\n", valid: false},
+	}
+
+	v := validator.New()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.Var(test.value, test.tag)
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+type period struct {
+	Start string `json:"start" validate:"required,iso_date"`
+	End   string `json:"end" validate:"required,iso_date"`
+}
+
+func TestRegisterRules(t *testing.T) {
+	t.Run("custom tag", func(t *testing.T) {
+		v := validator.New()
+
+		err := v.RegisterRules(validator.Rule{
+			Tag: "even",
+			Func: func(fl govalidator.FieldLevel) bool {
+				return fl.Field().Int()%2 == 0
+			},
+			Messages: map[string]string{
+				"en": "{0} must be even",
+				"fr": "{0} doit être pair",
+			},
+		})
+		assert.NoError(t, err)
+
+		type sample struct {
+			Count int `json:"count" validate:"even"`
+		}
+
+		err = v.Struct(&sample{Count: 3})
+		assert.Equal(t, []response.ValidationErrorDetail{
+			{Field: "count", Message: "count doit être pair"},
+		}, v.FormatErrors(err, "fr"))
+	})
+
+	t.Run("struct rule", func(t *testing.T) {
+		v := validator.New()
+
+		err := v.RegisterRules(validator.Rule{
+			Tag:      "after_start",
+			Messages: map[string]string{"en": "{0} must be after start"},
+		})
+		assert.NoError(t, err)
+
+		v.RegisterStructRules(validator.StructRule{
+			Func: func(sl govalidator.StructLevel) {
+				p := sl.Current().Interface().(period)
+				if p.End <= p.Start {
+					sl.ReportError(p.End, "end", "End", "after_start", "")
+				}
+			},
+			Types: []any{period{}},
+		})
+
+		assert.NoError(t, v.Struct(&period{Start: "1862-04-03", End: "1862-06-30"}))
+
+		err = v.Struct(&period{Start: "1862-06-30", End: "1862-04-03"})
+		assert.Equal(t, []response.ValidationErrorDetail{
+			{Field: "end", Message: "end must be after start"},
+		}, v.FormatErrors(err, "en"))
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		v := validator.New()
+
+		err := v.RegisterRules(validator.Rule{
+			Tag:      "whatever",
+			Messages: map[string]string{"xx": "{0}"},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -2,36 +2,120 @@ package validator
 
 import (
 	"errors"
-	"fmt"
+	"reflect"
+	"strings"
 
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/fr"
+	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	frTranslations "github.com/go-playground/validator/v10/translations/fr"
+	"golang.org/x/text/language"
 
 	"go-boilerplate-rest-api-chi/internal/response"
 )
 
+// defaultLocale is used when the Accept-Language header does not match any
+// supported locale.
+const defaultLocale = "en"
+
+type supportedLocale struct {
+	translator          locales.Translator
+	tag                 language.Tag
+	defaultTranslations func(*validator.Validate, ut.Translator) error
+}
+
+// supportedLocales lists the languages validation messages can be rendered
+// in. The first entry is the fallback locale.
+var supportedLocales = []supportedLocale{
+	{translator: en.New(), tag: language.English, defaultTranslations: enTranslations.RegisterDefaultTranslations},
+	{translator: fr.New(), tag: language.French, defaultTranslations: frTranslations.RegisterDefaultTranslations},
+}
+
 type Validator struct {
 	validate *validator.Validate
+	uni      *ut.UniversalTranslator
+	matcher  language.Matcher
 }
 
 func New() *Validator {
-	return &Validator{
-		validate: validator.New(),
+	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+
+	translators := make([]locales.Translator, 0, len(supportedLocales))
+	tags := make([]language.Tag, 0, len(supportedLocales))
+	for _, l := range supportedLocales {
+		translators = append(translators, l.translator)
+		tags = append(tags, l.tag)
+	}
+
+	v := &Validator{
+		validate: validate,
+		uni:      ut.New(translators[0], translators...),
+		matcher:  language.NewMatcher(tags),
+	}
+
+	for _, l := range supportedLocales {
+		trans, _ := v.uni.GetTranslator(l.translator.Locale())
+		if err := l.defaultTranslations(validate, trans); err != nil {
+			panic(err)
+		}
 	}
+
+	if err := v.RegisterRules(defaultRules...); err != nil {
+		panic(err)
+	}
+
+	for locale, messages := range catalog {
+		if err := v.RegisterMessages(locale, messages); err != nil {
+			panic(err)
+		}
+	}
+
+	return v
 }
 
 func (v *Validator) Struct(s any) error {
 	return v.validate.Struct(s)
 }
 
-func (v *Validator) FormatErrors(err error) []response.ValidationErrorDetail {
+func (v *Validator) Var(field any, tag string) error {
+	return v.validate.Var(field, tag)
+}
+
+// RegisterMessages adds or overrides the messages of a supported locale.
+// Messages are keyed by validation tag, "{0}" is replaced by the field name
+// and "{1}" by the tag parameter.
+func (v *Validator) RegisterMessages(locale string, messages map[string]string) error {
+	trans, found := v.uni.FindTranslator(locale)
+	if !found {
+		return errors.New("unsupported locale " + locale)
+	}
+
+	for tag, message := range messages {
+		if err := v.validate.RegisterTranslation(tag, trans, registerMessage(tag, message), translateMessage(tag)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FormatErrors converts validation errors into response details translated
+// in the best locale matching the given Accept-Language header value.
+func (v *Validator) FormatErrors(err error, acceptLanguage string) []response.ValidationErrorDetail {
 	var validationErrors []response.ValidationErrorDetail
 
+	trans := v.translator(acceptLanguage)
+
 	var ve validator.ValidationErrors
 	if errors.As(err, &ve) {
 		for _, fe := range ve {
 			validationErrors = append(validationErrors, response.ValidationErrorDetail{
 				Field:   fe.Field(),
-				Message: getErrorMessage(fe),
+				Message: fe.Translate(trans),
 			})
 		}
 	}
@@ -39,37 +123,49 @@ func (v *Validator) FormatErrors(err error) []response.ValidationErrorDetail {
 	return validationErrors
 }
 
-func getErrorMessage(fe validator.FieldError) string {
-	field := fe.Field()
-
-	switch fe.Tag() {
-	case "required":
-		return fmt.Sprintf("%s is required", field)
-	case "email":
-		return fmt.Sprintf("%s must be a valid email address", field)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", field, fe.Param())
-	case "max":
-		return fmt.Sprintf("%s must be at most %s characters", field, fe.Param())
-	case "url":
-		return fmt.Sprintf("%s must be a valid URL", field)
-	case "alpha":
-		return fmt.Sprintf("%s must contain only letters", field)
-	case "alphanum":
-		return fmt.Sprintf("%s must contain only letters and numbers", field)
-	case "numeric":
-		return fmt.Sprintf("%s must be a number", field)
-	case "len":
-		return fmt.Sprintf("%s must be exactly %s characters", field, fe.Param())
-	case "gt":
-		return fmt.Sprintf("%s must be greater than %s", field, fe.Param())
-	case "gte":
-		return fmt.Sprintf("%s must be greater than or equal to %s", field, fe.Param())
-	case "lt":
-		return fmt.Sprintf("%s must be less than %s", field, fe.Param())
-	case "lte":
-		return fmt.Sprintf("%s must be less than or equal to %s", field, fe.Param())
+func (v *Validator) translator(acceptLanguage string) ut.Translator {
+	locale := defaultLocale
+
+	if acceptLanguage != "" {
+		tag, _ := language.MatchStrings(v.matcher, acceptLanguage)
+		base, _ := tag.Base()
+		locale = base.String()
+	}
+
+	trans, found := v.uni.FindTranslator(locale)
+	if !found {
+		trans, _ = v.uni.GetTranslator(defaultLocale)
+	}
+
+	return trans
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
 	default:
-		return fmt.Sprintf("%s is invalid", field)
+		return name
+	}
+}
+
+func registerMessage(tag, message string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+}
+
+func translateMessage(tag string) validator.TranslationFunc {
+	return func(trans ut.Translator, fe validator.FieldError) string {
+		message, err := trans.T(tag, fe.Field(), fe.Param())
+		if err != nil {
+			return fe.Error()
+		}
+
+		return message
 	}
 }
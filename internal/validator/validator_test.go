@@ -3,6 +3,9 @@ package validator_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"go-boilerplate-rest-api-chi/internal/response"
 	"go-boilerplate-rest-api-chi/internal/validator"
 )
 
@@ -14,3 +17,85 @@ func TestNew(t *testing.T) {
 		}
 	})
 }
+
+type sample struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email_address" validate:"omitempty,email"`
+	Count int    `json:"count" validate:"gte=1"`
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		input          sample
+		expected       []response.ValidationErrorDetail
+	}{
+		{
+			name:           "english by default",
+			acceptLanguage: "",
+			input:          sample{Email: "not-an-email", Count: 1},
+			expected: []response.ValidationErrorDetail{
+				{Field: "name", Message: "name is required"},
+				{Field: "email_address", Message: "email_address must be a valid email address"},
+			},
+		},
+		{
+			name:           "french from accept-language",
+			acceptLanguage: "fr-FR,fr;q=0.9,en;q=0.8",
+			input:          sample{Count: 0},
+			expected: []response.ValidationErrorDetail{
+				{Field: "name", Message: "name est obligatoire"},
+				{Field: "count", Message: "count doit être supérieur ou égal à 1"},
+			},
+		},
+		{
+			name:           "english preferred over french",
+			acceptLanguage: "en-GB,fr;q=0.5",
+			input:          sample{Name: "Victor Hugo"},
+			expected: []response.ValidationErrorDetail{
+				{Field: "count", Message: "count must be greater than or equal to 1"},
+			},
+		},
+		{
+			name:           "unsupported language falls back to english",
+			acceptLanguage: "de-DE",
+			input:          sample{Name: "Victor Hugo"},
+			expected: []response.ValidationErrorDetail{
+				{Field: "count", Message: "count must be greater than or equal to 1"},
+			},
+		},
+	}
+
+	v := validator.New()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.Struct(&test.input)
+			assert.Error(t, err)
+
+			assert.Equal(t, test.expected, v.FormatErrors(err, test.acceptLanguage))
+		})
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	t.Run("override message", func(t *testing.T) {
+		v := validator.New()
+
+		err := v.RegisterMessages("fr", map[string]string{"required": "{0} doit être renseigné"})
+		assert.NoError(t, err)
+
+		err = v.Struct(&sample{Count: 1})
+		assert.Equal(t, []response.ValidationErrorDetail{
+			{Field: "name", Message: "name doit être renseigné"},
+		}, v.FormatErrors(err, "fr"))
+	})
+
+	t.Run("unsupported locale", func(t *testing.T) {
+		v := validator.New()
+
+		err := v.RegisterMessages("xx", map[string]string{"required": "{0}"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+const (
+	// HeaderID holds the delivery ID, the same on every attempt so that
+	// receivers can drop duplicates.
+	HeaderID        = "X-Webhook-Id"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature holds the hex encoded HMAC-SHA256 of the timestamp and
+	// the body joined by a dot, keyed by the subscription secret and prefixed
+	// by "sha256=".
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxRetryDelay caps the delay between two attempts of a delivery.
+const maxRetryDelay = time.Hour
+
+// Dispatcher posts the pending deliveries to their subscription. A failed
+// attempt is retried with an exponential backoff, the delivery is dead after
+// MaxAttempts and only retried on demand.
+type Dispatcher struct {
+	repository   WebhookRepository
+	client       *http.Client
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	retryBase    time.Duration
+	logger       zerolog.Logger
+	now          func() time.Time
+}
+
+func NewDispatcher(repository WebhookRepository, cfg config.WebhookConfig, logger zerolog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repository: repository,
+		client: &http.Client{
+			Transport: newTransport(cfg),
+			Timeout:   cfg.Timeout,
+			// a redirect is a failure, the receiver must be configured with
+			// its final URL
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		maxAttempts:  cfg.MaxAttempts,
+		retryBase:    cfg.RetryBase,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// newTransport returns the transport of the deliveries, refusing to connect to
+// the internal network unless cfg allows it.
+func newTransport(cfg config.WebhookConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.AllowPrivateTargets {
+		return transport
+	}
+
+	// the receivers are reached directly, through a proxy only the address of
+	// the proxy could be checked
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}).DialContext
+
+	return transport
+}
+
+// Run dispatches the due deliveries until ctx is done, polling every poll
+// interval while none are due.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := d.DispatchPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.logger.Error().Err(err).Msg("failed to dispatch webhooks")
+		}
+
+		// a full batch means more deliveries are probably due
+		if err == nil && processed == d.batchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending makes a single pass over the due deliveries and returns the
+// number of deliveries it read.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.GetDueDeliveries(ctx, d.now(), d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		if err := d.Deliver(ctx, delivery); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+// Deliver makes an attempt of a due delivery, loaded with its subscription,
+// and records its outcome. The delivery is left untouched when another attempt
+// is running. Only failing to record the outcome is an error.
+func (d *Dispatcher) Deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	now := d.now()
+
+	// the claim outlives the attempt, so that a concurrent dispatcher does not
+	// post the delivery meanwhile
+	claimed, err := d.repository.ClaimDelivery(ctx, delivery, now, now.Add(d.client.Timeout+d.pollInterval))
+	if err != nil || !claimed {
+		return err
+	}
+
+	statusCode, cause := d.post(ctx, delivery, now)
+	if cause != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	logger := d.logger.With().
+		Str("delivery_id", delivery.ID.String()).
+		Str("subscription_id", delivery.SubscriptionID.String()).
+		Int("attempts", delivery.Attempts).
+		Logger()
+
+	switch {
+	case cause == nil:
+		delivery.Status = entity.DeliveryStatusDelivered
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = entity.DeliveryStatusDead
+		delivery.LastError = cause.Error()
+		logger.Error().Err(cause).Msg("giving up webhook delivery")
+	default:
+		delivery.NextAttemptAt = now.Add(retryDelay(d.retryBase, delivery.Attempts))
+		delivery.LastError = cause.Error()
+		logger.Warn().Err(cause).Msg("failed to deliver webhook")
+	}
+
+	return d.repository.SaveDelivery(ctx, delivery)
+}
+
+// post sends the delivery and returns the status code of the response, if
+// any. Any response but a 2xx is a failure.
+func (d *Dispatcher) post(ctx context.Context, delivery *entity.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Body)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-boilerplate-rest-api-chi-webhooks")
+	req.Header.Set(HeaderID, delivery.ID.String())
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(delivery.Subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the value of the HeaderSignature header for body sent at
+// timestamp, in Unix seconds. Receivers should also reject old timestamps to
+// prevent replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay doubles the delay on every attempt, up to maxRetryDelay.
+func retryDelay(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
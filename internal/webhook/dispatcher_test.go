@@ -0,0 +1,318 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/test-utils"
+	"go-boilerplate-rest-api-chi/internal/webhook"
+	"go-boilerplate-rest-api-chi/internal/webhook/dto"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver is a local webhook endpoint answering with status, and keeping the
+// requests it received.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	t.Helper()
+
+	rec := &receiver{status: status}
+	rec.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		rec.requests = append(rec.requests, r)
+		rec.bodies = append(rec.bodies, body)
+		w.WriteHeader(rec.status)
+	}))
+	t.Cleanup(rec.Close)
+
+	return rec
+}
+
+func (r *receiver) setStatus(status int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status = status
+}
+
+func (r *receiver) received() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.requests)
+}
+
+type fixture struct {
+	service    webhook.WebhookService
+	dispatcher *webhook.Dispatcher
+}
+
+func newFixture(t *testing.T, maxAttempts int) fixture {
+	t.Helper()
+
+	return newFixtureWithConfig(t, config.WebhookConfig{
+		PollInterval: time.Millisecond,
+		BatchSize:    10,
+		MaxAttempts:  maxAttempts,
+		RetryBase:    time.Millisecond,
+		Timeout:      time.Second,
+		// the receivers listen on the loopback
+		AllowPrivateTargets: true,
+	})
+}
+
+func newFixtureWithConfig(t *testing.T, cfg config.WebhookConfig) fixture {
+	t.Helper()
+
+	db := testutils.NewGormSQLite(t, &entity.WebhookSubscription{}, &entity.WebhookDelivery{})
+	repository := webhook.NewWebhookRepository(db, zerolog.Nop())
+	dispatcher := webhook.NewDispatcher(repository, cfg, zerolog.Nop())
+
+	return fixture{
+		service:    webhook.NewWebhookService(repository, dispatcher, zerolog.Nop()),
+		dispatcher: dispatcher,
+	}
+}
+
+func (f fixture) subscribe(t *testing.T, url string, eventTypes ...string) *entity.WebhookSubscription {
+	t.Helper()
+
+	subscription, err := f.service.CreateWebhook(context.Background(), &dto.CreateWebhookRequest{
+		URL:        url,
+		EventTypes: eventTypes,
+		Secret:     secret,
+	})
+	require.NoError(t, err)
+
+	return subscription
+}
+
+// dispatchUntil dispatches the due deliveries until done reports true.
+func (f fixture) dispatchUntil(t *testing.T, done func() bool) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		_, err := f.dispatcher.DispatchPending(context.Background())
+		require.NoError(t, err)
+
+		return done()
+	}, 5*time.Second, 2*time.Millisecond)
+}
+
+func (f fixture) deliveries(t *testing.T, subscription *entity.WebhookSubscription, status string) []*entity.WebhookDelivery {
+	t.Helper()
+
+	deliveries, err := f.service.GetDeliveries(context.Background(), subscription.ID, dto.DeliveryFilter{Status: status})
+	require.NoError(t, err)
+
+	return deliveries
+}
+
+func TestDispatcher_SignedDelivery(t *testing.T) {
+	f := newFixture(t, 3)
+	rec := newReceiver(t, http.StatusNoContent)
+	subscription := f.subscribe(t, rec.URL, "book.created")
+
+	e := event.New(event.BookCreated, event.AggregateBook, uuid.New(), event.BookPayload{Title: "Dune"})
+	require.NoError(t, f.service.HandleEvent(context.Background(), e))
+
+	f.dispatchUntil(t, func() bool { return rec.received() == 1 })
+
+	req, body := rec.requests[0], rec.bodies[0]
+	delivery := f.deliveries(t, subscription, "")[0]
+
+	assert.Equal(t, delivery.ID.String(), req.Header.Get(webhook.HeaderID))
+	assert.Equal(t, "book.created", req.Header.Get(webhook.HeaderEvent))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	timestamp := req.Header.Get(webhook.HeaderTimestamp)
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(sentAt, 0), time.Minute)
+	assert.Equal(t, webhook.Sign(secret, timestamp, body), req.Header.Get(webhook.HeaderSignature))
+	assert.NotEqual(t, webhook.Sign("another secret", timestamp, body), req.Header.Get(webhook.HeaderSignature))
+
+	var received event.Event
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, e.ID, received.ID)
+	assert.Equal(t, e.Type, received.Type)
+
+	assert.Equal(t, entity.DeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.LastStatusCode)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestDispatcher_RetriesThenDeadLetter(t *testing.T) {
+	f := newFixture(t, 3)
+	rec := newReceiver(t, http.StatusServiceUnavailable)
+	subscription := f.subscribe(t, rec.URL, "*")
+
+	require.NoError(t, f.service.HandleEvent(context.Background(), event.New(event.BookDeleted, event.AggregateBook, uuid.New(), nil)))
+
+	f.dispatchUntil(t, func() bool { return len(f.deliveries(t, subscription, entity.DeliveryStatusDead)) == 1 })
+
+	delivery := f.deliveries(t, subscription, entity.DeliveryStatusDead)[0]
+	assert.Equal(t, 3, rec.received())
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.LastStatusCode)
+	assert.Equal(t, "receiver responded with status 503", delivery.LastError)
+	assert.Nil(t, delivery.DeliveredAt)
+
+	// a dead delivery stays dead
+	processed, err := f.dispatcher.DispatchPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, processed)
+
+	// until retried on demand
+	rec.setStatus(http.StatusOK)
+	_, err = f.service.RetryDelivery(context.Background(), subscription.ID, delivery.ID)
+	require.NoError(t, err)
+
+	_, err = f.service.RetryDelivery(context.Background(), subscription.ID, delivery.ID)
+	assert.ErrorIs(t, err, webhook.ErrDeliveryNotDead)
+
+	f.dispatchUntil(t, func() bool { return len(f.deliveries(t, subscription, entity.DeliveryStatusDelivered)) == 1 })
+	assert.Equal(t, 4, rec.received())
+}
+
+func TestDispatcher_RefusesInternalTargets(t *testing.T) {
+	f := newFixtureWithConfig(t, config.WebhookConfig{
+		PollInterval: time.Millisecond,
+		BatchSize:    10,
+		MaxAttempts:  3,
+		RetryBase:    time.Millisecond,
+		Timeout:      time.Second,
+	})
+	rec := newReceiver(t, http.StatusOK)
+	subscription := f.subscribe(t, rec.URL, "*")
+
+	delivery, err := f.service.SendTestEvent(context.Background(), subscription.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+	assert.Zero(t, delivery.LastStatusCode)
+	assert.Contains(t, delivery.LastError, webhook.ErrTargetNotAllowed.Error())
+	assert.Zero(t, rec.received())
+}
+
+func TestWebhookService_HandleEvent(t *testing.T) {
+	f := newFixture(t, 3)
+	rec := newReceiver(t, http.StatusOK)
+	ctx := context.Background()
+
+	all := f.subscribe(t, rec.URL, "*")
+	created := f.subscribe(t, rec.URL, "book.created", "author.created")
+	deleted := f.subscribe(t, rec.URL, "book.deleted")
+	disabled := f.subscribe(t, rec.URL, "*")
+
+	active := false
+	_, err := f.service.UpdateWebhook(ctx, &dto.UpdateWebhookRequest{Active: &active}, disabled.ID)
+	require.NoError(t, err)
+
+	e := event.New(event.BookCreated, event.AggregateBook, uuid.New(), nil)
+	require.NoError(t, f.service.HandleEvent(ctx, e))
+	// the bus publishes an event again when a subscriber fails
+	require.NoError(t, f.service.HandleEvent(ctx, e))
+
+	assert.Len(t, f.deliveries(t, all, ""), 1)
+	assert.Len(t, f.deliveries(t, created, ""), 1)
+	assert.Empty(t, f.deliveries(t, deleted, ""))
+	assert.Empty(t, f.deliveries(t, disabled, ""))
+
+	f.dispatchUntil(t, func() bool { return rec.received() == 2 })
+}
+
+func TestWebhookService_SendTestEvent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivered", func(t *testing.T) {
+		f := newFixture(t, 3)
+		rec := newReceiver(t, http.StatusOK)
+		subscription := f.subscribe(t, rec.URL, "book.deleted")
+
+		delivery, err := f.service.SendTestEvent(ctx, subscription.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.DeliveryStatusDelivered, delivery.Status)
+		assert.Equal(t, string(webhook.TestEventType), delivery.EventType)
+		require.Equal(t, 1, rec.received())
+		assert.Equal(t, "webhook.test", rec.requests[0].Header.Get(webhook.HeaderEvent))
+		assert.Len(t, f.deliveries(t, subscription, entity.DeliveryStatusDelivered), 1)
+	})
+
+	t.Run("failed attempt is retried", func(t *testing.T) {
+		f := newFixture(t, 3)
+		rec := newReceiver(t, http.StatusInternalServerError)
+		subscription := f.subscribe(t, rec.URL, "book.deleted")
+
+		delivery, err := f.service.SendTestEvent(ctx, subscription.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.DeliveryStatusPending, delivery.Status)
+		assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+
+		rec.setStatus(http.StatusOK)
+		f.dispatchUntil(t, func() bool { return len(f.deliveries(t, subscription, entity.DeliveryStatusDelivered)) == 1 })
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		f := newFixture(t, 3)
+
+		_, err := f.service.SendTestEvent(ctx, uuid.New())
+		assert.ErrorIs(t, err, webhook.ErrNotFound)
+	})
+}
+
+func TestWebhookService_CreateAndDelete(t *testing.T) {
+	f := newFixture(t, 3)
+	rec := newReceiver(t, http.StatusOK)
+	ctx := context.Background()
+
+	subscription, err := f.service.CreateWebhook(ctx, &dto.CreateWebhookRequest{
+		URL:        rec.URL,
+		EventTypes: []string{"book.updated", "book.created", "book.updated"},
+	})
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^whsec_[0-9a-f]{48}$`, subscription.Secret)
+	assert.Equal(t, []string{"book.created", "book.updated"}, subscription.EventTypes)
+	assert.True(t, subscription.Active)
+
+	_, err = f.service.SendTestEvent(ctx, subscription.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, f.service.DeleteWebhook(ctx, subscription.ID))
+
+	_, err = f.service.GetDeliveries(ctx, subscription.ID, dto.DeliveryFilter{})
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+	assert.ErrorIs(t, f.service.DeleteWebhook(ctx, subscription.ID), webhook.ErrNotFound)
+}
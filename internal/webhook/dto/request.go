@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"net/url"
+	"strings"
+)
+
+// CreateWebhookRequest subscribes URL to the given event types, "*" matching
+// every event. A secret is generated when none is provided.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,webhook_url,webhook_host"`
+	EventTypes []string `json:"event_types" validate:"required,webhook_events,dive,webhook_event"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=128,trimmed"`
+}
+
+// UpdateWebhookRequest only updates the provided fields.
+type UpdateWebhookRequest struct {
+	URL        *string   `json:"url,omitempty" validate:"omitnil,webhook_url,webhook_host"`
+	EventTypes *[]string `json:"event_types,omitempty" validate:"omitnil,webhook_events,dive,webhook_event"`
+	Secret     *string   `json:"secret,omitempty" validate:"omitnil,min=16,max=128,trimmed"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+// DeliveryFilter narrows the deliveries of a subscription to a status.
+type DeliveryFilter struct {
+	Status string `json:"status" validate:"omitempty,oneof=pending delivered dead"`
+}
+
+// NewDeliveryFilter reads the filter from the query string.
+func NewDeliveryFilter(query url.Values) DeliveryFilter {
+	return DeliveryFilter{
+		Status: strings.TrimSpace(query.Get("status")),
+	}
+}
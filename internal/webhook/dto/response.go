@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"time"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+)
+
+type WebhookResponse struct {
+	ID         string    `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// WebhookCreatedResponse is only returned on creation, the secret cannot be
+// read afterwards.
+type WebhookCreatedResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type DeliveryResponse struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status" example:"pending" enums:"pending,delivered,dead"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func ToWebhookResponse(subscription *entity.WebhookSubscription) *WebhookResponse {
+	return &WebhookResponse{
+		ID:         subscription.ID.String(),
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		Active:     subscription.Active,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+func ToWebhooksResponse(subscriptions []*entity.WebhookSubscription) []WebhookResponse {
+	responses := make([]WebhookResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		responses[i] = *ToWebhookResponse(subscription)
+	}
+	return responses
+}
+
+func ToWebhookCreatedResponse(subscription *entity.WebhookSubscription) *WebhookCreatedResponse {
+	return &WebhookCreatedResponse{
+		WebhookResponse: *ToWebhookResponse(subscription),
+		Secret:          subscription.Secret,
+	}
+}
+
+func ToDeliveryResponse(delivery *entity.WebhookDelivery) *DeliveryResponse {
+	resp := &DeliveryResponse{
+		ID:             delivery.ID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	// the next attempt is only meaningful while the delivery is pending
+	if delivery.Status == entity.DeliveryStatusPending {
+		resp.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return resp
+}
+
+func ToDeliveriesResponse(deliveries []*entity.WebhookDelivery) []DeliveryResponse {
+	responses := make([]DeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responses[i] = *ToDeliveryResponse(delivery)
+	}
+	return responses
+}
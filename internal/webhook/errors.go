@@ -0,0 +1,10 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("webhook not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryNotDead  = errors.New("webhook delivery is not dead")
+	ErrTargetNotAllowed = errors.New("webhook target address is not allowed")
+)
@@ -0,0 +1,399 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/request"
+	"go-boilerplate-rest-api-chi/internal/response"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+	"go-boilerplate-rest-api-chi/internal/webhook/dto"
+)
+
+type WebhookSuccessResponse struct {
+	Status  string               `json:"status" example:"success"`
+	Message string               `json:"message" example:"Webhook retrieved successfully"`
+	Webhook *dto.WebhookResponse `json:"webhook"`
+}
+
+type WebhookCreatedSuccessResponse struct {
+	Status  string                      `json:"status" example:"success"`
+	Message string                      `json:"message" example:"Webhook created successfully"`
+	Webhook *dto.WebhookCreatedResponse `json:"webhook"`
+}
+
+type WebhooksSuccessResponse struct {
+	Status   string                `json:"status" example:"success"`
+	Message  string                `json:"message" example:"Webhooks retrieved successfully"`
+	Webhooks []dto.WebhookResponse `json:"webhooks"`
+}
+
+type DeliverySuccessResponse struct {
+	Status   string                `json:"status" example:"success"`
+	Message  string                `json:"message" example:"Test event sent"`
+	Delivery *dto.DeliveryResponse `json:"delivery"`
+}
+
+type DeliveriesSuccessResponse struct {
+	Status     string                 `json:"status" example:"success"`
+	Message    string                 `json:"message" example:"Deliveries retrieved successfully"`
+	Deliveries []dto.DeliveryResponse `json:"deliveries"`
+}
+
+type WebhookHandler struct {
+	service   WebhookService
+	validator *internalValidator.Validator
+	logger    zerolog.Logger
+}
+
+func NewWebhookHandler(service WebhookService, validator *internalValidator.Validator, logger zerolog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service:   service,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+func (h *WebhookHandler) Routes() http.Handler {
+	r := chi.NewRouter()
+
+	// routes
+	r.Post("/", h.CreateWebhook)
+	r.Get("/", h.GetAllWebhooks)
+	r.Get("/{webhook_id}", h.GetWebhookByID)
+	r.Put("/{webhook_id}", h.UpdateWebhook)
+	r.Delete("/{webhook_id}", h.DeleteWebhook)
+	r.Post("/{webhook_id}/test", h.SendTestEvent)
+	r.Get("/{webhook_id}/deliveries", h.GetDeliveries)
+	r.Post("/{webhook_id}/deliveries/{delivery_id}/retry", h.RetryDelivery)
+
+	return r
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		Create a webhook
+//	@Description	Subscribe a URL to events. The deliveries are signed with the secret, generated when not provided and only returned here. URLs targeting a loopback, private or link-local address are rejected.
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook			body		dto.CreateWebhookRequest	true	"Webhook data"
+//	@Param			Accept-Language	header		string						false	"Language of the validation messages (en, fr)"
+//	@Success		201				{object}	WebhookCreatedSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWebhookRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	subscription, err := h.service.CreateWebhook(r.Context(), &req)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, WebhookCreatedSuccessResponse{
+		Status:  "success",
+		Message: "Webhook created successfully",
+		Webhook: dto.ToWebhookCreatedResponse(subscription),
+	})
+}
+
+// GetAllWebhooks godoc
+//
+//	@Summary		Get all webhooks
+//	@Description	Get a list of all webhooks
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Success		200	{object}	WebhooksSuccessResponse
+//	@Failure		401	{object}	response.ErrorResponse
+//	@Failure		403	{object}	response.ErrorResponse
+//	@Failure		500	{object}	response.ErrorResponse
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) GetAllWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.service.GetAllWebhooks(r.Context())
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, WebhooksSuccessResponse{
+		Status:   "success",
+		Message:  "Webhooks retrieved successfully",
+		Webhooks: dto.ToWebhooksResponse(subscriptions),
+	})
+}
+
+// GetWebhookByID godoc
+//
+//	@Summary		Get webhook by id
+//	@Description	Get a single webhook by its ID
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"
+//	@Success		200			{object}	WebhookSuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id} [get]
+func (h *WebhookHandler) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	subscription, err := h.service.GetWebhookByID(r.Context(), webhookID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, WebhookSuccessResponse{
+		Status:  "success",
+		Message: "Webhook retrieved successfully",
+		Webhook: dto.ToWebhookResponse(subscription),
+	})
+}
+
+// UpdateWebhook godoc
+//
+//	@Summary		Update a webhook
+//	@Description	Update a webhook with the provided data, a disabled webhook keeps its pending deliveries until enabled again
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook_id		path		string						true	"Webhook ID"
+//	@Param			webhook			body		dto.UpdateWebhookRequest	true	"Webhook data"
+//	@Param			Accept-Language	header		string						false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	WebhookSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		413				{object}	response.ErrorResponse
+//	@Failure		415				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		request.WriteError(w, err)
+		return
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	subscription, err := h.service.UpdateWebhook(r.Context(), &req, webhookID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, WebhookSuccessResponse{
+		Status:  "success",
+		Message: "Webhook updated successfully",
+		Webhook: dto.ToWebhookResponse(subscription),
+	})
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Delete a webhook and its delivery log
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"
+//	@Success		200			{object}	response.SuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	if err := h.service.DeleteWebhook(r.Context(), webhookID); err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.Success(w, "Webhook deleted successfully")
+}
+
+// SendTestEvent godoc
+//
+//	@Summary		Send a test event
+//	@Description	Deliver a webhook.test event to the webhook right away, even when disabled, and return the outcome of the attempt
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"
+//	@Success		200			{object}	DeliverySuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id}/test [post]
+func (h *WebhookHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	delivery, err := h.service.SendTestEvent(r.Context(), webhookID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, DeliverySuccessResponse{
+		Status:   "success",
+		Message:  "Test event sent",
+		Delivery: dto.ToDeliveryResponse(delivery),
+	})
+}
+
+// GetDeliveries godoc
+//
+//	@Summary		Get the deliveries of a webhook
+//	@Description	Get the latest deliveries of a webhook, optionally filtered by status
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			webhook_id		path		string	true	"Webhook ID"
+//	@Param			status			query		string	false	"Only deliveries with this status"	Enums(pending, delivered, dead)
+//	@Param			Accept-Language	header		string	false	"Language of the validation messages (en, fr)"
+//	@Success		200				{object}	DeliveriesSuccessResponse
+//	@Failure		400				{object}	response.ValidationErrorResponse
+//	@Failure		401				{object}	response.ErrorResponse
+//	@Failure		403				{object}	response.ErrorResponse
+//	@Failure		404				{object}	response.ErrorResponse
+//	@Failure		500				{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	filter := dto.NewDeliveryFilter(r.URL.Query())
+	if err := h.validator.Struct(&filter); err != nil {
+		validationErrors := h.validator.FormatErrors(err, r.Header.Get("Accept-Language"))
+		response.ValidationError(w, validationErrors)
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), webhookID, filter)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, DeliveriesSuccessResponse{
+		Status:     "success",
+		Message:    "Deliveries retrieved successfully",
+		Deliveries: dto.ToDeliveriesResponse(deliveries),
+	})
+}
+
+// RetryDelivery godoc
+//
+//	@Summary		Retry a dead delivery
+//	@Description	Schedule a dead delivery again, with a fresh number of attempts
+//	@Tags			webhooks
+//	@Security		ApiKeyAuth
+//	@Produce		json
+//	@Param			webhook_id	path		string	true	"Webhook ID"
+//	@Param			delivery_id	path		string	true	"Delivery ID"
+//	@Success		200			{object}	DeliverySuccessResponse
+//	@Failure		400			{object}	response.ErrorResponse
+//	@Failure		401			{object}	response.ErrorResponse
+//	@Failure		403			{object}	response.ErrorResponse
+//	@Failure		404			{object}	response.ErrorResponse
+//	@Failure		409			{object}	response.ErrorResponse
+//	@Failure		500			{object}	response.ErrorResponse
+//	@Router			/webhooks/{webhook_id}/deliveries/{delivery_id}/retry [post]
+func (h *WebhookHandler) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookID, err := uuid.Parse(chi.URLParam(r, "webhook_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	deliveryID, err := uuid.Parse(chi.URLParam(r, "delivery_id"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "Invalid uuid")
+		return
+	}
+
+	delivery, err := h.service.RetryDelivery(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		h.handleError(w, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, DeliverySuccessResponse{
+		Status:   "success",
+		Message:  "Delivery scheduled",
+		Delivery: dto.ToDeliveryResponse(delivery),
+	})
+}
+
+func (h *WebhookHandler) handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		response.Error(w, http.StatusNotFound, "Webhook not found")
+	case errors.Is(err, ErrDeliveryNotFound):
+		response.Error(w, http.StatusNotFound, "Delivery not found")
+	case errors.Is(err, ErrDeliveryNotDead):
+		response.Error(w, http.StatusConflict, "Only dead deliveries can be retried")
+	default:
+		h.logger.Error().Err(err).Msg("unexpected error")
+		response.Error(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,467 @@
+package webhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/mocks"
+	"go-boilerplate-rest-api-chi/internal/response"
+	"go-boilerplate-rest-api-chi/internal/validator"
+	"go-boilerplate-rest-api-chi/internal/webhook"
+	"go-boilerplate-rest-api-chi/internal/webhook/dto"
+)
+
+var (
+	webhookID  = uuid.MustParse("aeca0955-bae4-47e9-9f85-6818dc68ca51")
+	deliveryID = uuid.MustParse("0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b")
+	createdAt  = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func TestWebhookHandler_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        interface{}
+		configureMock      func(*mocks.MockWebhookService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name: "success create webhook",
+			requestBody: dto.CreateWebhookRequest{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{"book.created", "*"},
+			},
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				input := &dto.CreateWebhookRequest{
+					URL:        "https://example.com/hooks",
+					EventTypes: []string{"book.created", "*"},
+				}
+
+				mockService.EXPECT().
+					CreateWebhook(gomock.Any(), input).
+					Return(&entity.WebhookSubscription{
+						ID:         webhookID,
+						URL:        "https://example.com/hooks",
+						EventTypes: []string{"*", "book.created"},
+						Secret:     "whsec_generated",
+						Active:     true,
+						CreatedAt:  createdAt,
+						UpdatedAt:  createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: webhook.WebhookCreatedSuccessResponse{
+				Status:  "success",
+				Message: "Webhook created successfully",
+				Webhook: &dto.WebhookCreatedResponse{
+					WebhookResponse: dto.WebhookResponse{
+						ID:         "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+						URL:        "https://example.com/hooks",
+						EventTypes: []string{"*", "book.created"},
+						Active:     true,
+						CreatedAt:  createdAt,
+						UpdatedAt:  createdAt,
+					},
+					Secret: "whsec_generated",
+				},
+			},
+		},
+		{
+			name: "error validation fails",
+			requestBody: dto.CreateWebhookRequest{
+				URL:        "ftp://example.com/hooks",
+				EventTypes: []string{"book.archived"},
+				Secret:     "short",
+			},
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "url", Message: "url must be an absolute http or https URL"},
+					{Field: "event_types[0]", Message: "event_types[0] must be a known event type or *"},
+					{Field: "secret", Message: "secret must be at least 16 characters"},
+				},
+			},
+		},
+		{
+			name: "error validation fails internal target",
+			requestBody: dto.CreateWebhookRequest{
+				URL:        "http://169.254.169.254/latest/meta-data",
+				EventTypes: []string{"*"},
+			},
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "url", Message: "url must not target a loopback, private or link-local address"},
+				},
+			},
+		},
+		{
+			name: "error validation fails localhost target",
+			requestBody: dto.CreateWebhookRequest{
+				URL:        "http://localhost:9000",
+				EventTypes: []string{"*"},
+			},
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "url", Message: "url must not target a loopback, private or link-local address"},
+				},
+			},
+		},
+		{
+			name: "error validation fails no event type",
+			requestBody: dto.CreateWebhookRequest{
+				URL:        "https://example.com/hooks",
+				EventTypes: []string{},
+			},
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "event_types", Message: "event_types must contain at least one event type"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockWebhookService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, webhook.RegisterValidations(v, config.WebhookConfig{}))
+			handler := webhook.NewWebhookHandler(mockService, v, zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/webhooks", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		configureMock      func(*mocks.MockWebhookService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name: "success get dead deliveries",
+			url:  "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries?status=dead",
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				mockService.EXPECT().
+					GetDeliveries(gomock.Any(), webhookID, dto.DeliveryFilter{Status: "dead"}).
+					Return([]*entity.WebhookDelivery{{
+						ID:             deliveryID,
+						SubscriptionID: webhookID,
+						EventID:        webhookID,
+						EventType:      "book.created",
+						Status:         entity.DeliveryStatusDead,
+						Attempts:       8,
+						NextAttemptAt:  createdAt,
+						LastAttemptAt:  &createdAt,
+						LastStatusCode: http.StatusServiceUnavailable,
+						LastError:      "receiver responded with status 503",
+						CreatedAt:      createdAt,
+					}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: webhook.DeliveriesSuccessResponse{
+				Status:  "success",
+				Message: "Deliveries retrieved successfully",
+				Deliveries: []dto.DeliveryResponse{{
+					ID:             "0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b",
+					EventID:        "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					EventType:      "book.created",
+					Status:         "dead",
+					Attempts:       8,
+					LastAttemptAt:  &createdAt,
+					LastStatusCode: http.StatusServiceUnavailable,
+					LastError:      "receiver responded with status 503",
+					CreatedAt:      createdAt,
+				}},
+			},
+		},
+		{
+			name:               "error invalid status",
+			url:                "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries?status=lost",
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "status", Message: "status must be one of [pending delivered dead]"},
+				},
+			},
+		},
+		{
+			name: "error webhook not found",
+			url:  "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries",
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				mockService.EXPECT().
+					GetDeliveries(gomock.Any(), webhookID, dto.DeliveryFilter{}).
+					Return(nil, webhook.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Webhook not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockWebhookService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, webhook.RegisterValidations(v, config.WebhookConfig{}))
+			handler := webhook.NewWebhookHandler(mockService, v, zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/webhooks", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestWebhookHandler_RetryDelivery(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		configureMock      func(*mocks.MockWebhookService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name: "error delivery not dead",
+			url:  "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries/0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b/retry",
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				mockService.EXPECT().
+					RetryDelivery(gomock.Any(), webhookID, deliveryID).
+					Return(nil, webhook.ErrDeliveryNotDead)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Only dead deliveries can be retried",
+			},
+		},
+		{
+			name: "error delivery not found",
+			url:  "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries/0b5fd8a4-8f4e-4b8e-9d6a-3c7f2e1d0a9b/retry",
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				mockService.EXPECT().
+					RetryDelivery(gomock.Any(), webhookID, deliveryID).
+					Return(nil, webhook.ErrDeliveryNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Delivery not found",
+			},
+		},
+		{
+			name:               "error invalid delivery id",
+			url:                "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51/deliveries/abc/retry",
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Invalid uuid",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockWebhookService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, webhook.RegisterValidations(v, config.WebhookConfig{}))
+			handler := webhook.NewWebhookHandler(mockService, v, zerolog.Nop())
+
+			req := httptest.NewRequest(http.MethodPost, test.url, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/webhooks", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
+
+func TestWebhookHandler_UpdateWebhook(t *testing.T) {
+	tests := []struct {
+		name               string
+		requestBody        interface{}
+		configureMock      func(*mocks.MockWebhookService)
+		expectedStatusCode int
+		expectedResponse   interface{}
+	}{
+		{
+			name:        "success disable webhook",
+			requestBody: map[string]any{"active": false},
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				active := false
+
+				mockService.EXPECT().
+					UpdateWebhook(gomock.Any(), &dto.UpdateWebhookRequest{Active: &active}, webhookID).
+					Return(&entity.WebhookSubscription{
+						ID:         webhookID,
+						URL:        "https://example.com/hooks",
+						EventTypes: []string{"*"},
+						Secret:     "whsec_generated",
+						CreatedAt:  createdAt,
+						UpdatedAt:  createdAt,
+					}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: webhook.WebhookSuccessResponse{
+				Status:  "success",
+				Message: "Webhook updated successfully",
+				Webhook: &dto.WebhookResponse{
+					ID:         "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+					URL:        "https://example.com/hooks",
+					EventTypes: []string{"*"},
+					CreatedAt:  createdAt,
+					UpdatedAt:  createdAt,
+				},
+			},
+		},
+		{
+			name:               "error validation fails",
+			requestBody:        map[string]any{"url": "/relative", "event_types": []string{}},
+			configureMock:      func(mockService *mocks.MockWebhookService) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: response.ValidationErrorResponse{
+				Status:  "error",
+				Message: "Validation failed",
+				Errors: []response.ValidationErrorDetail{
+					{Field: "url", Message: "url must be an absolute http or https URL"},
+					{Field: "event_types", Message: "event_types must contain at least one event type"},
+				},
+			},
+		},
+		{
+			name:        "error webhook not found",
+			requestBody: map[string]any{"event_types": []string{"book.deleted"}},
+			configureMock: func(mockService *mocks.MockWebhookService) {
+				mockService.EXPECT().
+					UpdateWebhook(gomock.Any(), &dto.UpdateWebhookRequest{EventTypes: &[]string{"book.deleted"}}, webhookID).
+					Return(nil, webhook.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse: response.ErrorResponse{
+				Status:  "error",
+				Message: "Webhook not found",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			t.Cleanup(ctrl.Finish)
+
+			mockService := mocks.NewMockWebhookService(ctrl)
+			test.configureMock(mockService)
+
+			v := validator.New()
+			require.NoError(t, webhook.RegisterValidations(v, config.WebhookConfig{}))
+			handler := webhook.NewWebhookHandler(mockService, v, zerolog.Nop())
+
+			b, err := json.Marshal(test.requestBody)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/webhooks/aeca0955-bae4-47e9-9f85-6818dc68ca51", bytes.NewBuffer(b))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Mount("/webhooks", handler.Routes())
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, test.expectedStatusCode, w.Code)
+
+			expectedJSON, err := json.Marshal(test.expectedResponse)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expectedJSON), w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/transaction"
+)
+
+// deliveriesLimit bounds the number of deliveries listed for a subscription.
+const deliveriesLimit = 100
+
+//go:generate mockgen -destination=../mocks/mock_webhook_repository.go -package=mocks go-boilerplate-rest-api-chi/internal/webhook WebhookRepository
+type WebhookRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	GetAll(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	GetActive(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	GetByID(ctx context.Context, subscriptionID uuid.UUID) (*entity.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error)
+	Delete(ctx context.Context, subscriptionID uuid.UUID) error
+
+	// EnqueueDeliveries ignores the deliveries of an event already enqueued
+	// for their subscription.
+	EnqueueDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string) ([]*entity.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error)
+	// GetDueDeliveries returns the pending deliveries of active subscriptions
+	// due at now, with their subscription.
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	// ClaimDelivery postpones the next attempt of a delivery due at now to
+	// until, and reports whether it did. It fails to when another attempt
+	// claimed or recorded the delivery meanwhile.
+	ClaimDelivery(ctx context.Context, delivery *entity.WebhookDelivery, now, until time.Time) (bool, error)
+	SaveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db     *gorm.DB
+	logger zerolog.Logger
+}
+
+func NewWebhookRepository(db *gorm.DB, logger zerolog.Logger) WebhookRepository {
+	return &webhookRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if err := transaction.DB(ctx, r.db).Create(subscription).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *webhookRepository) GetAll(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+
+	if err := transaction.DB(ctx, r.db).Order("created_at").Find(&subscriptions).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) GetActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+
+	if err := transaction.DB(ctx, r.db).Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) GetByID(ctx context.Context, subscriptionID uuid.UUID) (*entity.WebhookSubscription, error) {
+	var subscription *entity.WebhookSubscription
+
+	if err := transaction.DB(ctx, r.db).First(&subscription, "id = ?", subscriptionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, error) {
+	if err := transaction.DB(ctx, r.db).Save(subscription).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// Delete removes the subscription and its delivery log.
+func (r *webhookRepository) Delete(ctx context.Context, subscriptionID uuid.UUID) error {
+	err := transaction.DB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscriptionID).Delete(&entity.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&entity.WebhookSubscription{}, "id = ?", subscriptionID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		r.logger.Error().Err(err).Msg("database error")
+	}
+
+	return err
+}
+
+func (r *webhookRepository) EnqueueDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	err := transaction.DB(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
+
+func (r *webhookRepository) GetDeliveries(ctx context.Context, subscriptionID uuid.UUID, status string) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+
+	query := transaction.DB(ctx, r.db).Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Order("created_at DESC").Limit(deliveriesLimit).Find(&deliveries).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	var delivery *entity.WebhookDelivery
+
+	err := transaction.DB(ctx, r.db).
+		Preload("Subscription").
+		First(&delivery, "id = ? AND subscription_id = ?", deliveryID, subscriptionID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeliveryNotFound
+		}
+
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (r *webhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+
+	db := transaction.DB(ctx, r.db)
+	active := db.Model(&entity.WebhookSubscription{}).Select("id").Where("active = ?", true)
+
+	err := db.
+		Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", entity.DeliveryStatusPending, now).
+		Where("subscription_id IN (?)", active).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, delivery *entity.WebhookDelivery, now, until time.Time) (bool, error) {
+	result := transaction.DB(ctx, r.db).
+		Model(&entity.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND attempts = ? AND next_attempt_at <= ?", delivery.ID, entity.DeliveryStatusPending, delivery.Attempts, now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		r.logger.Error().Err(result.Error).Msg("database error")
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	delivery.NextAttemptAt = until
+	return true, nil
+}
+
+func (r *webhookRepository) SaveDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := transaction.DB(ctx, r.db).Omit("Subscription").Save(delivery).Error; err != nil {
+		r.logger.Error().Err(err).Msg("database error")
+		return err
+	}
+
+	return nil
+}
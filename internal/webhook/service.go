@@ -0,0 +1,233 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"go-boilerplate-rest-api-chi/internal/entity"
+	"go-boilerplate-rest-api-chi/internal/event"
+	"go-boilerplate-rest-api-chi/internal/webhook/dto"
+)
+
+const (
+	// AllEvents subscribes to every event type.
+	AllEvents = "*"
+	// TestEventType is the type of the event sent on demand to check a
+	// subscription.
+	TestEventType event.Type = "webhook.test"
+	// AggregateWebhook is the aggregate type of the test events.
+	AggregateWebhook = "webhook"
+)
+
+// TestPayload is the payload of the test events.
+type TestPayload struct {
+	WebhookID string `json:"webhook_id"`
+	Message   string `json:"message"`
+}
+
+//go:generate mockgen -destination=../mocks/mock_webhook_service.go -package=mocks go-boilerplate-rest-api-chi/internal/webhook WebhookService
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*entity.WebhookSubscription, error)
+	GetAllWebhooks(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (*entity.WebhookSubscription, error)
+	UpdateWebhook(ctx context.Context, req *dto.UpdateWebhookRequest, webhookID uuid.UUID) (*entity.WebhookSubscription, error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error
+	// SendTestEvent delivers a test event right away. A failed delivery is
+	// retried like any other.
+	SendTestEvent(ctx context.Context, webhookID uuid.UUID) (*entity.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID uuid.UUID, filter dto.DeliveryFilter) ([]*entity.WebhookDelivery, error)
+	// RetryDelivery schedules a dead delivery again, with a fresh number of
+	// attempts.
+	RetryDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error)
+	// HandleEvent enqueues a delivery of e for every active subscription to
+	// its type. It is meant to be subscribed to the outbox bus, handling the
+	// same event twice enqueues its deliveries once.
+	HandleEvent(ctx context.Context, e event.Event) error
+}
+
+type webhookService struct {
+	repository WebhookRepository
+	dispatcher *Dispatcher
+	logger     zerolog.Logger
+}
+
+func NewWebhookService(repository WebhookRepository, dispatcher *Dispatcher, logger zerolog.Logger) WebhookService {
+	return &webhookService{
+		repository: repository,
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+func (s *webhookService) CreateWebhook(ctx context.Context, req *dto.CreateWebhookRequest) (*entity.WebhookSubscription, error) {
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repository.Create(ctx, &entity.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: slices.Compact(slices.Sorted(slices.Values(req.EventTypes))),
+		Secret:     secret,
+		Active:     true,
+	})
+}
+
+func (s *webhookService) GetAllWebhooks(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return s.repository.GetAll(ctx)
+}
+
+func (s *webhookService) GetWebhookByID(ctx context.Context, webhookID uuid.UUID) (*entity.WebhookSubscription, error) {
+	return s.repository.GetByID(ctx, webhookID)
+}
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, req *dto.UpdateWebhookRequest, webhookID uuid.UUID) (*entity.WebhookSubscription, error) {
+	subscription, err := s.repository.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		subscription.URL = *req.URL
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = slices.Compact(slices.Sorted(slices.Values(*req.EventTypes)))
+	}
+	if req.Secret != nil {
+		subscription.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+
+	return s.repository.Update(ctx, subscription)
+}
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	return s.repository.Delete(ctx, webhookID)
+}
+
+func (s *webhookService) SendTestEvent(ctx context.Context, webhookID uuid.UUID) (*entity.WebhookDelivery, error) {
+	subscription, err := s.repository.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	e := event.New(TestEventType, AggregateWebhook, subscription.ID, TestPayload{
+		WebhookID: subscription.ID.String(),
+		Message:   "This is a test event",
+	})
+
+	deliveries, err := s.newDeliveries(e, []*entity.WebhookSubscription{subscription})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.EnqueueDeliveries(ctx, deliveries); err != nil {
+		return nil, err
+	}
+
+	delivery := deliveries[0]
+	delivery.Subscription = subscription
+
+	if err := s.dispatcher.Deliver(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, webhookID uuid.UUID, filter dto.DeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	if _, err := s.repository.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetDeliveries(ctx, webhookID, filter.Status)
+}
+
+func (s *webhookService) RetryDelivery(ctx context.Context, webhookID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := s.repository.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status != entity.DeliveryStatusDead {
+		return nil, ErrDeliveryNotDead
+	}
+
+	delivery.Status = entity.DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	if err := s.repository.SaveDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+func (s *webhookService) HandleEvent(ctx context.Context, e event.Event) error {
+	subscriptions, err := s.repository.GetActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscriptions = slices.DeleteFunc(subscriptions, func(subscription *entity.WebhookSubscription) bool {
+		return !slices.Contains(subscription.EventTypes, AllEvents) && !slices.Contains(subscription.EventTypes, string(e.Type))
+	})
+
+	deliveries, err := s.newDeliveries(e, subscriptions)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.EnqueueDeliveries(ctx, deliveries)
+}
+
+// newDeliveries returns the pending deliveries of e to the subscriptions, due
+// right away.
+func (s *webhookService) newDeliveries(e event.Event, subscriptions []*entity.WebhookSubscription) ([]*entity.WebhookDelivery, error) {
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveries := make([]*entity.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &entity.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        e.ID,
+			EventType:      string(e.Type),
+			Body:           string(body),
+			Status:         entity.DeliveryStatusPending,
+			NextAttemptAt:  now,
+		}
+	}
+
+	return deliveries, nil
+}
+
+// newSecret generates a random signing secret.
+func newSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
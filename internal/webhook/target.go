@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// blockedPrefixes are the internal ranges not covered by the netip
+// predicates: "this network" and the shared address space, where some clouds
+// serve their metadata.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// publicAddr reports whether addr may be the target of a webhook, the
+// loopback, private, link-local and multicast addresses belong to the
+// internal network.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// publicHost reports whether the host of a webhook URL may be targeted. Host
+// names are only resolved when dialing, here the addresses and the local names
+// are rejected.
+func publicHost(host string) bool {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return publicAddr(addr)
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	return host != "localhost" && !strings.HasSuffix(host, ".localhost")
+}
+
+// dialControl refuses to connect to the internal addresses. It runs once the
+// host is resolved, so that a name resolving to an internal address after its
+// validation is not reached either.
+func dialControl(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	if !publicAddr(addrPort.Addr()) {
+		return ErrTargetNotAllowed
+	}
+
+	return nil
+}
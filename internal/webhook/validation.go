@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"net/url"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+
+	"go-boilerplate-rest-api-chi/internal/config"
+	"go-boilerplate-rest-api-chi/internal/event"
+	internalValidator "go-boilerplate-rest-api-chi/internal/validator"
+)
+
+// RegisterValidations registers the validation rules of the webhook module.
+// The URLs targeting the internal network are rejected unless cfg allows them.
+func RegisterValidations(v *internalValidator.Validator, cfg config.WebhookConfig) error {
+	return v.RegisterRules(
+		internalValidator.Rule{
+			Tag:  "webhook_url",
+			Func: isWebhookURL,
+			Messages: map[string]string{
+				"en": "{0} must be an absolute http or https URL",
+				"fr": "{0} doit être une URL http ou https absolue",
+			},
+		},
+		internalValidator.Rule{
+			Tag: "webhook_host",
+			Func: func(fl validator.FieldLevel) bool {
+				return cfg.AllowPrivateTargets || isPublicWebhookURL(fl)
+			},
+			Messages: map[string]string{
+				"en": "{0} must not target a loopback, private or link-local address",
+				"fr": "{0} ne doit pas viser une adresse de bouclage, privée ou lien-local",
+			},
+		},
+		internalValidator.Rule{
+			Tag:  "webhook_events",
+			Func: hasEventTypes,
+			Messages: map[string]string{
+				"en": "{0} must contain at least one event type",
+				"fr": "{0} doit contenir au moins un type d'événement",
+			},
+		},
+		internalValidator.Rule{
+			Tag:  "webhook_event",
+			Func: isWebhookEvent,
+			Messages: map[string]string{
+				"en": "{0} must be a known event type or *",
+				"fr": "{0} doit être un type d'événement connu ou *",
+			},
+		},
+	)
+}
+
+func isWebhookURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" && u.User == nil
+}
+
+func isPublicWebhookURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		// reported by webhook_url
+		return true
+	}
+
+	return publicHost(u.Hostname())
+}
+
+func hasEventTypes(fl validator.FieldLevel) bool {
+	return fl.Field().Len() > 0
+}
+
+func isWebhookEvent(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+
+	return value == AllEvents || slices.Contains(event.Types, event.Type(value))
+}
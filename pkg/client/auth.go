@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Authenticator adds the credentials to every request, retries included.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerToken authenticates the requests with a static access token.
+func BearerToken(token string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BearerTokenSource authenticates the requests with the token returned by
+// source, called before every attempt so that it can refresh expired tokens.
+func BearerTokenSource(source func(ctx context.Context) (string, error)) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		token, err := source(req.Context())
+		if err != nil {
+			return err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
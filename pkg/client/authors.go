@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Author mirrors the author of the API responses.
+type Author struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Biography   string        `json:"biography,omitempty"`
+	BirthDate   string        `json:"birth_date,omitempty"`
+	DeathDate   string        `json:"death_date,omitempty"`
+	Nationality string        `json:"nationality,omitempty"`
+	ISNI        string        `json:"isni,omitempty"`
+	VIAFID      string        `json:"viaf_id,omitempty"`
+	WikidataID  string        `json:"wikidata_id,omitempty"`
+	Aliases     []AuthorAlias `json:"aliases,omitempty"`
+}
+
+// AuthorAlias is a pen name or a variant spelling of the author name.
+type AuthorAlias struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type CreateAuthorRequest struct {
+	Name      string `json:"name"`
+	Biography string `json:"biography,omitempty"`
+	// BirthDate and DeathDate are YYYY-MM-DD dates.
+	BirthDate string `json:"birth_date,omitempty"`
+	DeathDate string `json:"death_date,omitempty"`
+	// Nationality is an ISO 3166-1 alpha-2 country code.
+	Nationality string `json:"nationality,omitempty"`
+	ISNI        string `json:"isni,omitempty"`
+	VIAFID      string `json:"viaf_id,omitempty"`
+	WikidataID  string `json:"wikidata_id,omitempty"`
+}
+
+type authorResponse struct {
+	Author *Author `json:"author"`
+}
+
+type authorsResponse struct {
+	Authors    []Author `json:"authors"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+// AuthorService calls the /authors endpoints.
+type AuthorService struct {
+	client *Client
+}
+
+// Create creates an author. The request is sent with an Idempotency-Key so
+// that its retries, when RetryPolicy.RetryCreations allows them, cannot
+// create the author twice.
+func (s *AuthorService) Create(ctx context.Context, req CreateAuthorRequest) (*Author, error) {
+	var resp authorResponse
+
+	err := s.client.do(ctx, request{
+		method:         http.MethodPost,
+		path:           []string{"authors"},
+		body:           req,
+		idempotencyKey: newIdempotencyKey(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Author, nil
+}
+
+func (s *AuthorService) Get(ctx context.Context, id string) (*Author, error) {
+	var resp authorResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"authors", id},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Author, nil
+}
+
+// FindByName returns the authors named so or having such an alias. Several
+// authors may share a name.
+func (s *AuthorService) FindByName(ctx context.Context, name string) ([]Author, error) {
+	var resp authorsResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"authors"},
+		query:  url.Values{"name": {name}},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Authors, nil
+}
+
+// List returns a page of the authors ordered by name and the cursor of the
+// next page, empty on the last page.
+func (s *AuthorService) List(ctx context.Context, opts ListOptions) ([]Author, string, error) {
+	var resp authorsResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"authors"},
+		query:  opts.apply(url.Values{}),
+	}, &resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Authors, resp.NextCursor, nil
+}
+
+// All iterates over every author ordered by name, page by page. The iteration
+// stops at the first error.
+func (s *AuthorService) All(ctx context.Context) iter.Seq2[*Author, error] {
+	return paginate(ctx, s.List)
+}
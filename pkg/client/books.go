@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// Book mirrors the book of the API responses.
+type Book struct {
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Author *Author `json:"author,omitempty"`
+	Copies *Copies `json:"copies,omitempty"`
+}
+
+// Copies tells how many copies of a book the library owns and how many of
+// them can be checked out right now.
+type Copies struct {
+	Total     int64 `json:"total"`
+	Available int64 `json:"available"`
+}
+
+// ExportedBook mirrors a record of the export, every field is always present.
+type ExportedBook struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AuthorID    string `json:"author_id"`
+	Author      string `json:"author"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type CreateBookRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	AuthorID    string `json:"author_id"`
+}
+
+// UpdateBookRequest replaces the description of the book.
+type UpdateBookRequest struct {
+	Description string `json:"description"`
+}
+
+// BookFilter narrows the listed books. Empty fields are ignored.
+type BookFilter struct {
+	AuthorID string
+	// Title matches the books whose title contains it.
+	Title string
+}
+
+func (f BookFilter) query() url.Values {
+	query := url.Values{}
+
+	if f.AuthorID != "" {
+		query.Set("author_id", f.AuthorID)
+	}
+
+	if f.Title != "" {
+		query.Set("title", f.Title)
+	}
+
+	return query
+}
+
+type bookResponse struct {
+	Book *Book `json:"book"`
+}
+
+type booksResponse struct {
+	Books      []Book `json:"books"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// BookService calls the /books endpoints.
+type BookService struct {
+	client *Client
+}
+
+// Create creates a book. The request is sent with an Idempotency-Key so that
+// its retries, when RetryPolicy.RetryCreations allows them, cannot create the
+// book twice.
+func (s *BookService) Create(ctx context.Context, req CreateBookRequest) (*Book, error) {
+	var resp bookResponse
+
+	err := s.client.do(ctx, request{
+		method:         http.MethodPost,
+		path:           []string{"books"},
+		body:           req,
+		idempotencyKey: newIdempotencyKey(),
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Book, nil
+}
+
+func (s *BookService) Get(ctx context.Context, id string) (*Book, error) {
+	var resp bookResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"books", id},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Book, nil
+}
+
+// List returns a page of the books matching filter, none when the API
+// answers that no book was found, and the cursor of the next page, empty on
+// the last page.
+func (s *BookService) List(ctx context.Context, filter BookFilter, opts ListOptions) ([]Book, string, error) {
+	var resp booksResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"books"},
+		query:  opts.apply(filter.query()),
+	}, &resp)
+	if IsNotFound(err) {
+		return []Book{}, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return resp.Books, resp.NextCursor, nil
+}
+
+func (s *BookService) Update(ctx context.Context, id string, req UpdateBookRequest) (*Book, error) {
+	var resp bookResponse
+
+	err := s.client.do(ctx, request{
+		method: http.MethodPut,
+		path:   []string{"books", id},
+		body:   req,
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Book, nil
+}
+
+func (s *BookService) Delete(ctx context.Context, id string) error {
+	return s.client.do(ctx, request{
+		method: http.MethodDelete,
+		path:   []string{"books", id},
+	}, nil)
+}
+
+// Export formats.
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportJSON   = "json"
+)
+
+// Export streams the books matching filter in the given format. The caller
+// must close the returned reader.
+func (s *BookService) Export(ctx context.Context, filter BookFilter, format string) (io.ReadCloser, error) {
+	query := filter.query()
+	query.Set("format", format)
+
+	resp, err := s.client.send(ctx, request{
+		method: http.MethodGet,
+		path:   []string{"books", "export"},
+		query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// All iterates over every book matching filter, page by page, so that large
+// catalogs are not held in memory. The iteration stops at the first error.
+//
+//	for book, err := range c.Books.All(ctx, client.BookFilter{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (s *BookService) All(ctx context.Context, filter BookFilter) iter.Seq2[*Book, error] {
+	return paginate(ctx, func(ctx context.Context, opts ListOptions) ([]Book, string, error) {
+		return s.List(ctx, filter, opts)
+	})
+}
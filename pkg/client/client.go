@@ -0,0 +1,283 @@
+// Package client is a Go client of the REST API.
+//
+//	c, err := client.New("http://localhost:8080/api", client.WithAuth(client.BearerToken(token)))
+//	if err != nil {
+//		return err
+//	}
+//
+//	book, err := c.Books.Get(ctx, bookID)
+//	if client.IsNotFound(err) {
+//		...
+//	}
+//
+// Failed requests are retried according to the RetryPolicy. The creations
+// carry an Idempotency-Key so that a retried creation is applied once, they
+// are only retried when the policy allows it with RetryCreations.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	// DefaultUserAgent is sent unless WithUserAgent is given.
+	DefaultUserAgent = "go-boilerplate-rest-api-chi-client"
+	// maxErrorBodySize bounds the error responses read.
+	maxErrorBodySize = 1 << 20
+)
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	auth       Authenticator
+	retry      RetryPolicy
+	userAgent  string
+	language   string
+
+	Books   *BookService
+	Authors *AuthorService
+	Import  *ImportService
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces the default client, which times out after 30
+// seconds.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAuth authenticates every request.
+func WithAuth(auth Authenticator) Option {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLanguage sets the Accept-Language of the requests, the language of the
+// validation messages.
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
+}
+
+// New returns a client of the API served at baseURL, such as
+// http://localhost:8080/api.
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base url %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+		userAgent:  DefaultUserAgent,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Books = &BookService{client: c}
+	c.Authors = &AuthorService{client: c}
+	c.Import = &ImportService{client: c}
+
+	return c, nil
+}
+
+// request describes a call to the API.
+type request struct {
+	method string
+	path   []string
+	query  url.Values
+	body   any
+	// stream is sent as is with contentType instead of the JSON of body. It
+	// cannot be replayed, so the request is not retried.
+	stream      io.Reader
+	contentType string
+	// accept lists the error statuses whose body is a regular response.
+	accept []int
+	// idempotencyKey makes a non idempotent request safe to retry, when the
+	// server honours it.
+	idempotencyKey string
+}
+
+// do sends req and decodes the JSON response into out, when not nil.
+func (c *Client) do(ctx context.Context, req request, out any) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// send sends req, retrying it when allowed, and returns the successful
+// response. The caller must close its body.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	// a POST carrying a key is only safe to retry when the server honours the
+	// key, which the caller vouches for with RetryCreations
+	retryAllowed := req.stream == nil &&
+		(req.method != http.MethodPost || (req.idempotencyKey != "" && c.retry.RetryCreations))
+	attempts := max(c.retry.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		httpReq, err := c.newRequest(ctx, req, body)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(httpReq)
+		if err == nil && (resp.StatusCode < http.StatusBadRequest || slices.Contains(req.accept, resp.StatusCode)) {
+			return resp, nil
+		}
+
+		if err == nil && (!retryable(resp.StatusCode) || !retryAllowed || attempt == attempts) {
+			defer resp.Body.Close()
+			return nil, decodeError(resp)
+		}
+
+		if err != nil && (ctx.Err() != nil || !retryAllowed || attempt == attempts) {
+			return nil, err
+		}
+
+		wait := c.retry.delay(attempt, resp)
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, req request, body []byte) (*http.Request, error) {
+	u := c.baseURL.JoinPath(req.path...)
+	if len(req.query) > 0 {
+		u.RawQuery = req.query.Encode()
+	}
+
+	var reader io.Reader
+	contentType := "application/json"
+
+	switch {
+	case req.stream != nil:
+		reader = req.stream
+		contentType = req.contentType
+	case body != nil:
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+
+	if reader != nil {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	if c.language != "" {
+		httpReq.Header.Set("Accept-Language", c.language)
+	}
+
+	if req.idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", req.idempotencyKey)
+	}
+
+	if c.auth != nil {
+		if err := c.auth.Authenticate(httpReq); err != nil {
+			return nil, fmt.Errorf("authenticate request: %w", err)
+		}
+	}
+
+	return httpReq, nil
+}
+
+// decodeError reads an error response, a ValidationError when it lists the
+// invalid fields and an APIError otherwise.
+func decodeError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+
+	var validationErr ValidationError
+	if err := json.Unmarshal(body, &validationErr); err != nil || validationErr.Message == "" {
+		// not an error of the API, such as a proxy error page
+		return &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+	}
+
+	validationErr.StatusCode = resp.StatusCode
+
+	if validationErr.Errors != nil {
+		return &validationErr
+	}
+
+	return &validationErr.APIError
+}
+
+// newIdempotencyKey returns a random key for a creation.
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,461 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-boilerplate-rest-api-chi/pkg/client"
+)
+
+const bookID = "d2bd6cc6-5e57-4a4e-8c46-9f3a4e0b3d2f"
+
+func newClient(t *testing.T, handler http.HandlerFunc, opts ...client.Option) *client.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]client.Option{
+		client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	}, opts...)
+
+	c, err := client.New(server.URL+"/api", opts...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	fmt.Fprint(w, body)
+}
+
+func TestNew(t *testing.T) {
+	_, err := client.New("localhost:8080")
+	assert.Error(t, err)
+}
+
+func TestBookService_Get(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedBook  *client.Book
+		expectedError error
+	}{
+		{
+			name:       "success",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","message":"Book retrieved successfully","book":{"id":"` + bookID + `","title":"A Game of Thrones","author":{"id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","name":"George R.R. Martin"}}}`,
+			expectedBook: &client.Book{
+				ID:     bookID,
+				Title:  "A Game of Thrones",
+				Author: &client.Author{ID: "aeca0955-bae4-47e9-9f85-6818dc68ca51", Name: "George R.R. Martin"},
+			},
+		},
+		{
+			name:          "not found",
+			statusCode:    http.StatusNotFound,
+			body:          `{"status":"error","message":"Book not found"}`,
+			expectedError: &client.APIError{StatusCode: http.StatusNotFound, Status: "error", Message: "Book not found"},
+		},
+		{
+			name:          "not an api error",
+			statusCode:    http.StatusBadGateway,
+			body:          `<html>Bad Gateway</html>`,
+			expectedError: &client.APIError{StatusCode: http.StatusBadGateway, Message: "Bad Gateway"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "/api/books/"+bookID, r.URL.Path)
+				writeJSON(w, tt.statusCode, tt.body)
+			})
+
+			book, err := c.Books.Get(context.Background(), bookID)
+
+			assert.Equal(t, tt.expectedBook, book)
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBookService_Create(t *testing.T) {
+	t.Run("success retry with auth and idempotency key", func(t *testing.T) {
+		var keys []string
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			assert.Equal(t, "fr", r.Header.Get("Accept-Language"))
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+			var req client.CreateBookRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "A Game of Thrones", req.Title)
+
+			if len(keys) == 1 {
+				writeJSON(w, http.StatusServiceUnavailable, `{"status":"error","message":"Service unavailable"}`)
+				return
+			}
+
+			writeJSON(w, http.StatusCreated, `{"status":"success","message":"Book created successfully","book":{"id":"`+bookID+`","title":"A Game of Thrones"}}`)
+		},
+			client.WithAuth(client.BearerToken("secret")),
+			client.WithLanguage("fr"),
+			client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryCreations: true}),
+		)
+
+		book, err := c.Books.Create(context.Background(), client.CreateBookRequest{
+			Title:       "A Game of Thrones",
+			Description: "Winter is coming",
+			AuthorID:    "aeca0955-bae4-47e9-9f85-6818dc68ca51",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, &client.Book{ID: bookID, Title: "A Game of Thrones"}, book)
+		require.Len(t, keys, 2)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1], "a retry must reuse the idempotency key")
+	})
+
+	t.Run("error not retried unless the policy allows it", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+			writeJSON(w, http.StatusServiceUnavailable, `{"status":"error","message":"Service unavailable"}`)
+		})
+
+		_, err := c.Books.Create(context.Background(), client.CreateBookRequest{Title: "A Game of Thrones"})
+
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusBadRequest, `{"status":"error","message":"Validation failed","errors":[{"field":"title","message":"title is required"}]}`)
+		})
+
+		_, err := c.Books.Create(context.Background(), client.CreateBookRequest{})
+
+		var validationErr *client.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []client.ValidationErrorDetail{{Field: "title", Message: "title is required"}}, validationErr.Errors)
+
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+}
+
+func TestBookService_List(t *testing.T) {
+	t.Run("filter", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "cursor=abc&limit=10&title=thrones", r.URL.RawQuery)
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Books retrieved successfully","books":[{"id":"`+bookID+`","title":"A Game of Thrones"}],"next_cursor":"def"}`)
+		})
+
+		books, next, err := c.Books.List(context.Background(), client.BookFilter{Title: "thrones"}, client.ListOptions{Limit: 10, Cursor: "abc"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []client.Book{{ID: bookID, Title: "A Game of Thrones"}}, books)
+		assert.Equal(t, "def", next)
+	})
+
+	t.Run("no book found", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusNotFound, `{"status":"error","message":"No books found"}`)
+		})
+
+		books, next, err := c.Books.List(context.Background(), client.BookFilter{}, client.ListOptions{})
+
+		require.NoError(t, err)
+		assert.Empty(t, books)
+		assert.Empty(t, next)
+	})
+}
+
+func TestBookService_Delete(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		writeJSON(w, http.StatusConflict, `{"status":"error","message":"Book is borrowed"}`)
+	})
+
+	err := c.Books.Delete(context.Background(), bookID)
+
+	assert.True(t, client.IsConflict(err))
+	assert.False(t, client.IsNotFound(err))
+}
+
+func TestBookService_All(t *testing.T) {
+	t.Run("pages through the books", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/books", r.URL.Path)
+			assert.Equal(t, "100", r.URL.Query().Get("limit"))
+			assert.Equal(t, "Song", r.URL.Query().Get("title"))
+
+			switch r.URL.Query().Get("cursor") {
+			case "":
+				writeJSON(w, http.StatusOK, `{"status":"success","books":[{"id":"1","title":"A Game of Thrones"},{"id":"2","title":"A Clash of Kings"}],"next_cursor":"2"}`)
+			case "2":
+				writeJSON(w, http.StatusOK, `{"status":"success","books":[{"id":"3","title":"A Storm of Swords"}]}`)
+			default:
+				t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+			}
+		})
+
+		var titles []string
+		for book, err := range c.Books.All(context.Background(), client.BookFilter{Title: "Song"}) {
+			require.NoError(t, err)
+			titles = append(titles, book.Title)
+		}
+
+		assert.Equal(t, []string{"A Game of Thrones", "A Clash of Kings", "A Storm of Swords"}, titles)
+	})
+
+	t.Run("stops when the caller breaks", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusOK, `{"status":"success","books":[{"id":"1","title":"A Game of Thrones"},{"id":"2","title":"A Clash of Kings"}],"next_cursor":"2"}`)
+		})
+
+		for _, err := range c.Books.All(context.Background(), client.BookFilter{}) {
+			require.NoError(t, err)
+			break
+		}
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusBadRequest, `{"status":"error","message":"Invalid cursor"}`)
+		})
+
+		var errs []error
+		for book, err := range c.Books.All(context.Background(), client.BookFilter{}) {
+			assert.Nil(t, book)
+			errs = append(errs, err)
+		}
+
+		require.Len(t, errs, 1)
+
+		var apiErr *client.APIError
+		require.ErrorAs(t, errs[0], &apiErr)
+		assert.Equal(t, "Invalid cursor", apiErr.Message)
+	})
+}
+
+func TestAuthorService_Create(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/authors", r.URL.Path)
+		assert.NotEmpty(t, r.Header.Get("Idempotency-Key"))
+		writeJSON(w, http.StatusCreated, `{"status":"success","message":"Author created successfully","author":{"id":"aeca0955-bae4-47e9-9f85-6818dc68ca51","name":"George R.R. Martin"}}`)
+	})
+
+	author, err := c.Authors.Create(context.Background(), client.CreateAuthorRequest{Name: "George R.R. Martin"})
+
+	require.NoError(t, err)
+	assert.Equal(t, &client.Author{ID: "aeca0955-bae4-47e9-9f85-6818dc68ca51", Name: "George R.R. Martin"}, author)
+}
+
+func TestAuthorService_FindByName(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/authors", r.URL.Path)
+		assert.Equal(t, "Richard Bachman", r.URL.Query().Get("name"))
+		writeJSON(w, http.StatusOK, `{"status":"success","message":"Authors retrieved successfully","authors":[{"id":"1","name":"Stephen King","aliases":[{"id":"2","name":"Richard Bachman","kind":"pen_name"}]}]}`)
+	})
+
+	authors, err := c.Authors.FindByName(context.Background(), "Richard Bachman")
+
+	require.NoError(t, err)
+	require.Len(t, authors, 1)
+	assert.Equal(t, "Stephen King", authors[0].Name)
+	assert.Equal(t, []client.AuthorAlias{{ID: "2", Name: "Richard Bachman", Kind: "pen_name"}}, authors[0].Aliases)
+}
+
+func TestAuthorService_All(t *testing.T) {
+	c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/authors", r.URL.Path)
+
+		if r.URL.Query().Get("cursor") == "" {
+			writeJSON(w, http.StatusOK, `{"status":"success","authors":[{"id":"1","name":"Alexandre Dumas"}],"next_cursor":"1"}`)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, `{"status":"success","authors":[{"id":"2","name":"Victor Hugo"}]}`)
+	})
+
+	var names []string
+	for author, err := range c.Authors.All(context.Background()) {
+		require.NoError(t, err)
+		names = append(names, author.Name)
+	}
+
+	assert.Equal(t, []string{"Alexandre Dumas", "Victor Hugo"}, names)
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("honours retry after", func(t *testing.T) {
+		var calls atomic.Int32
+		var first time.Time
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				first = time.Now()
+				w.Header().Set("Retry-After", "1")
+				writeJSON(w, http.StatusTooManyRequests, `{"status":"error","message":"Too many requests"}`)
+				return
+			}
+
+			assert.GreaterOrEqual(t, time.Since(first), time.Second)
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Author retrieved successfully","author":{"id":"1","name":"George R.R. Martin"}}`)
+		})
+
+		_, err := c.Authors.Get(context.Background(), "1")
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusInternalServerError, `{"status":"error","message":"Internal server error"}`)
+		})
+
+		_, err := c.Authors.Get(context.Background(), "1")
+
+		var apiErr *client.APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusBadRequest, `{"status":"error","message":"Invalid uuid"}`)
+		})
+
+		_, err := c.Authors.Get(context.Background(), "1")
+
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("stops waiting when the context is canceled", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			writeJSON(w, http.StatusServiceUnavailable, `{"status":"error","message":"Service unavailable"}`)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.Authors.Get(ctx, "1")
+
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("refreshes the token on every attempt", func(t *testing.T) {
+		var tokens atomic.Int32
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			n := calls.Add(1)
+			assert.Equal(t, fmt.Sprintf("Bearer token-%d", n), r.Header.Get("Authorization"))
+
+			if n == 1 {
+				writeJSON(w, http.StatusBadGateway, `{"status":"error","message":"Bad gateway"}`)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, `{"status":"success","message":"Author retrieved successfully","author":{"id":"1","name":"George R.R. Martin"}}`)
+		}, client.WithAuth(client.BearerTokenSource(func(ctx context.Context) (string, error) {
+			return fmt.Sprintf("token-%d", tokens.Add(1)), nil
+		})))
+
+		_, err := c.Authors.Get(context.Background(), "1")
+
+		require.NoError(t, err)
+	})
+}
+
+func TestImportService_Books(t *testing.T) {
+	t.Run("rolled back", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			assert.Equal(t, "/api/import", r.URL.Path)
+			assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+			assert.Equal(t, "all_or_nothing", r.URL.Query().Get("mode"))
+			writeJSON(w, http.StatusUnprocessableEntity, `{"status":"error","message":"Import rolled back, some rows are invalid","report":{"mode":"all_or_nothing","committed":false,"total":1,"failed":1,"rows":[{"line":2,"status":"failed","errors":[{"field":"title","message":"title is required"}]}]}}`)
+		})
+
+		report, err := c.Import.Books(context.Background(), strings.NewReader("title,description,author\n,Winter,George\n"), client.ImportOptions{
+			Format: client.ImportCSV,
+			Mode:   client.ImportAllOrNothing,
+		})
+
+		require.NoError(t, err)
+		assert.False(t, report.Committed)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, "title", report.Rows[0].Errors[0].Field)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("not retried", func(t *testing.T) {
+		var calls atomic.Int32
+
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, http.StatusServiceUnavailable, `{"status":"error","message":"Service unavailable"}`)
+		})
+
+		_, err := c.Import.Books(context.Background(), strings.NewReader("{}\n"), client.ImportOptions{Format: client.ImportNDJSON})
+
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		c := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected request")
+		})
+
+		_, err := c.Import.Books(context.Background(), strings.NewReader(""), client.ImportOptions{Format: "xml"})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is an error response of the API, its body mirrors
+// response.ErrorResponse.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// ValidationError is a rejected request body or query, its body mirrors
+// response.ValidationErrorResponse. It unwraps to its APIError.
+type ValidationError struct {
+	APIError
+	Errors []ValidationErrorDetail `json:"errors"`
+}
+
+// ValidationErrorDetail mirrors response.ValidationErrorDetail.
+type ValidationErrorDetail struct {
+	Field    string `json:"field"`
+	Offset   int64  `json:"offset,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return e.APIError.Error()
+	}
+
+	return fmt.Sprintf("%s (%s: %s)", e.APIError.Error(), e.Errors[0].Field, e.Errors[0].Message)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return &e.APIError
+}
+
+// IsNotFound reports whether err is a 404 response.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 response, such as a duplicate.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
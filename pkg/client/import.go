@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Import formats.
+const (
+	ImportCSV    = "csv"
+	ImportNDJSON = "ndjson"
+)
+
+// Import modes.
+const (
+	ImportBestEffort   = "best_effort"
+	ImportAllOrNothing = "all_or_nothing"
+)
+
+var importContentTypes = map[string]string{
+	ImportCSV:    "text/csv",
+	ImportNDJSON: "application/x-ndjson",
+}
+
+type ImportOptions struct {
+	// Format is ImportCSV or ImportNDJSON.
+	Format string
+	// Mode is ImportBestEffort, the default, or ImportAllOrNothing.
+	Mode string
+}
+
+// ImportReport mirrors the report of the import endpoint.
+type ImportReport struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Line          int                     `json:"line"`
+	Status        string                  `json:"status"`
+	BookID        string                  `json:"book_id,omitempty"`
+	AuthorID      string                  `json:"author_id,omitempty"`
+	AuthorCreated bool                    `json:"author_created,omitempty"`
+	Errors        []ValidationErrorDetail `json:"errors,omitempty"`
+}
+
+type importResponse struct {
+	Report *ImportReport `json:"report"`
+}
+
+// ImportService calls the /import endpoint.
+type ImportService struct {
+	client *Client
+}
+
+// Books imports the books read from body. A rolled back import is not an
+// error, its report is returned with Committed set to false. The body is
+// streamed, so the request is never retried.
+func (s *ImportService) Books(ctx context.Context, body io.Reader, opts ImportOptions) (*ImportReport, error) {
+	contentType, ok := importContentTypes[opts.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported import format %q", opts.Format)
+	}
+
+	query := url.Values{}
+	if opts.Mode != "" {
+		query.Set("mode", opts.Mode)
+	}
+
+	var resp importResponse
+
+	err := s.client.do(ctx, request{
+		method:      http.MethodPost,
+		path:        []string{"import"},
+		query:       query,
+		stream:      body,
+		contentType: contentType,
+		accept:      []int{http.StatusUnprocessableEntity},
+	}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Report, nil
+}
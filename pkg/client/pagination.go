@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// allPageSize is the size of the pages requested by the iterators, the
+// largest accepted by the API.
+const allPageSize = 100
+
+// ListOptions selects a page of a list. The zero value selects the first page
+// of the default size of the API.
+type ListOptions struct {
+	// Limit is the maximum number of items of the page, at most 100.
+	Limit int
+	// Cursor is the next cursor returned with the previous page.
+	Cursor string
+}
+
+func (o ListOptions) apply(query url.Values) url.Values {
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+
+	return query
+}
+
+// paginate iterates over the items of the pages returned by list, from the
+// first page to the one without a next cursor. The iteration stops at the
+// first error.
+func paginate[T any](ctx context.Context, list func(ctx context.Context, opts ListOptions) ([]T, string, error)) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		opts := ListOptions{Limit: allPageSize}
+
+		for {
+			items, next, err := list(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range items {
+				if !yield(&items[i], nil) {
+					return
+				}
+			}
+
+			if next == "" {
+				return
+			}
+			opts.Cursor = next
+		}
+	}
+}
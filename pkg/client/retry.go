@@ -0,0 +1,81 @@
+package client
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy tells how the failed requests are retried. A request is retried
+// on network errors and on 429 and 5xx responses, waiting for the delay of
+// the Retry-After header when the response has one and for an exponential
+// backoff with jitter otherwise.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts including the first one, 1
+	// disables the retries.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, it doubles with every
+	// retry up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryCreations lets the creations be retried as well. They always
+	// carry an Idempotency-Key, yet a retry is applied once only when the
+	// server honours it: the API does for the authenticated callers and for
+	// the anonymous ones calling from the same address, a proxy or another
+	// server may not. Set it once the server is known to honour the keys.
+	RetryCreations bool
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  200 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// retryable reports whether a response with this status may succeed later.
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// delay returns the wait before the given retry, starting from 1. The delay
+// asked by the server wins over the backoff.
+func (p RetryPolicy) delay(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+
+	backoff := p.MinBackoff
+	for i := 1; i < retry && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, p.MaxBackoff)
+
+	if backoff <= 0 {
+		return 0
+	}
+
+	// half fixed, half random so that the clients do not retry in sync
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retryAfter parses a Retry-After header, either a number of seconds or an
+// HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: library/v1/author.proto
+
+package libraryv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_library_v1_author_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_author_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_library_v1_author_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Author) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorRequest) Reset() {
+	*x = CreateAuthorRequest{}
+	mi := &file_library_v1_author_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorRequest) ProtoMessage() {}
+
+func (x *CreateAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_author_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_author_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAuthorRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *Author                `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuthorResponse) Reset() {
+	*x = CreateAuthorResponse{}
+	mi := &file_library_v1_author_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuthorResponse) ProtoMessage() {}
+
+func (x *CreateAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_author_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuthorResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_author_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateAuthorResponse) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+type GetAuthorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorRequest) Reset() {
+	*x = GetAuthorRequest{}
+	mi := &file_library_v1_author_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorRequest) ProtoMessage() {}
+
+func (x *GetAuthorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_author_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorRequest) Descriptor() ([]byte, []int) {
+	return file_library_v1_author_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAuthorRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAuthorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Author        *Author                `protobuf:"bytes,1,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorResponse) Reset() {
+	*x = GetAuthorResponse{}
+	mi := &file_library_v1_author_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorResponse) ProtoMessage() {}
+
+func (x *GetAuthorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_library_v1_author_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorResponse.ProtoReflect.Descriptor instead.
+func (*GetAuthorResponse) Descriptor() ([]byte, []int) {
+	return file_library_v1_author_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAuthorResponse) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+var File_library_v1_author_proto protoreflect.FileDescriptor
+
+const file_library_v1_author_proto_rawDesc = "" +
+	"\n" +
+	"\x17library/v1/author.proto\x12\n" +
+	"library.v1\",\n" +
+	"\x06Author\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\")\n" +
+	"\x13CreateAuthorRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"B\n" +
+	"\x14CreateAuthorResponse\x12*\n" +
+	"\x06author\x18\x01 \x01(\v2\x12.library.v1.AuthorR\x06author\"\"\n" +
+	"\x10GetAuthorRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x11GetAuthorResponse\x12*\n" +
+	"\x06author\x18\x01 \x01(\v2\x12.library.v1.AuthorR\x06author2\xac\x01\n" +
+	"\rAuthorService\x12Q\n" +
+	"\fCreateAuthor\x12\x1f.library.v1.CreateAuthorRequest\x1a .library.v1.CreateAuthorResponse\x12H\n" +
+	"\tGetAuthor\x12\x1c.library.v1.GetAuthorRequest\x1a\x1d.library.v1.GetAuthorResponseB9Z7go-boilerplate-rest-api-chi/pkg/pb/library/v1;libraryv1b\x06proto3"
+
+var (
+	file_library_v1_author_proto_rawDescOnce sync.Once
+	file_library_v1_author_proto_rawDescData []byte
+)
+
+func file_library_v1_author_proto_rawDescGZIP() []byte {
+	file_library_v1_author_proto_rawDescOnce.Do(func() {
+		file_library_v1_author_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_library_v1_author_proto_rawDesc), len(file_library_v1_author_proto_rawDesc)))
+	})
+	return file_library_v1_author_proto_rawDescData
+}
+
+var file_library_v1_author_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_library_v1_author_proto_goTypes = []any{
+	(*Author)(nil),               // 0: library.v1.Author
+	(*CreateAuthorRequest)(nil),  // 1: library.v1.CreateAuthorRequest
+	(*CreateAuthorResponse)(nil), // 2: library.v1.CreateAuthorResponse
+	(*GetAuthorRequest)(nil),     // 3: library.v1.GetAuthorRequest
+	(*GetAuthorResponse)(nil),    // 4: library.v1.GetAuthorResponse
+}
+var file_library_v1_author_proto_depIdxs = []int32{
+	0, // 0: library.v1.CreateAuthorResponse.author:type_name -> library.v1.Author
+	0, // 1: library.v1.GetAuthorResponse.author:type_name -> library.v1.Author
+	1, // 2: library.v1.AuthorService.CreateAuthor:input_type -> library.v1.CreateAuthorRequest
+	3, // 3: library.v1.AuthorService.GetAuthor:input_type -> library.v1.GetAuthorRequest
+	2, // 4: library.v1.AuthorService.CreateAuthor:output_type -> library.v1.CreateAuthorResponse
+	4, // 5: library.v1.AuthorService.GetAuthor:output_type -> library.v1.GetAuthorResponse
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_library_v1_author_proto_init() }
+func file_library_v1_author_proto_init() {
+	if File_library_v1_author_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_library_v1_author_proto_rawDesc), len(file_library_v1_author_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_library_v1_author_proto_goTypes,
+		DependencyIndexes: file_library_v1_author_proto_depIdxs,
+		MessageInfos:      file_library_v1_author_proto_msgTypes,
+	}.Build()
+	File_library_v1_author_proto = out.File
+	file_library_v1_author_proto_goTypes = nil
+	file_library_v1_author_proto_depIdxs = nil
+}